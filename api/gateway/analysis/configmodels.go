@@ -41,6 +41,15 @@ type Config struct {
 	Tags                      []string          `yaml:"Tags"`
 	Tests                     []Test            `yaml:"Tests"`
 	DedupPeriodMinutes        int               `yaml:"DedupPeriodMinutes"`
+	AlertSettings             *AlertSettings    `yaml:"AlertSettings"`
+}
+
+// AlertSettings defines how the events matching a rule are grouped into alerts and when the alerts are sent.
+type AlertSettings struct {
+	DedupFields                  []string `yaml:"DedupFields"`
+	Threshold                    int64    `yaml:"Threshold"`
+	RenotifyEventCount           int64    `yaml:"RenotifyEventCount"`
+	RenotifyOnSeverityEscalation bool     `yaml:"RenotifyOnSeverityEscalation"`
 }
 
 // Test is a unit test definition when parsing policies in a bulk upload.
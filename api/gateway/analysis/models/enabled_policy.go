@@ -33,6 +33,9 @@ import (
 // swagger:model EnabledPolicy
 type EnabledPolicy struct {
 
+	// alert settings
+	AlertSettings *AlertSettings `json:"alertSettings,omitempty"`
+
 	// body
 	Body Body `json:"body,omitempty"`
 
@@ -59,6 +62,10 @@ type EnabledPolicy struct {
 func (m *EnabledPolicy) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAlertSettings(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateBody(formats); err != nil {
 		res = append(res, err)
 	}
@@ -93,6 +100,24 @@ func (m *EnabledPolicy) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EnabledPolicy) validateAlertSettings(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AlertSettings) { // not required
+		return nil
+	}
+
+	if m.AlertSettings != nil {
+		if err := m.AlertSettings.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("alertSettings")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *EnabledPolicy) validateBody(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Body) { // not required
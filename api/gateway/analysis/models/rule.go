@@ -34,6 +34,9 @@ import (
 // swagger:model Rule
 type Rule struct {
 
+	// alert settings
+	AlertSettings *AlertSettings `json:"alertSettings,omitempty"`
+
 	// body
 	// Required: true
 	Body Body `json:"body"`
@@ -109,6 +112,10 @@ type Rule struct {
 func (m *Rule) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAlertSettings(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateBody(formats); err != nil {
 		res = append(res, err)
 	}
@@ -183,6 +190,24 @@ func (m *Rule) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rule) validateAlertSettings(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AlertSettings) { // not required
+		return nil
+	}
+
+	if m.AlertSettings != nil {
+		if err := m.AlertSettings.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("alertSettings")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Rule) validateBody(formats strfmt.Registry) error {
 
 	if err := m.Body.Validate(formats); err != nil {
@@ -33,6 +33,9 @@ import (
 // swagger:model UpdateRule
 type UpdateRule struct {
 
+	// alert settings
+	AlertSettings *AlertSettings `json:"alertSettings,omitempty"`
+
 	// body
 	// Required: true
 	Body Body `json:"body"`
@@ -82,6 +85,10 @@ type UpdateRule struct {
 func (m *UpdateRule) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAlertSettings(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateBody(formats); err != nil {
 		res = append(res, err)
 	}
@@ -140,6 +147,24 @@ func (m *UpdateRule) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateRule) validateAlertSettings(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AlertSettings) { // not required
+		return nil
+	}
+
+	if m.AlertSettings != nil {
+		if err := m.AlertSettings.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("alertSettings")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *UpdateRule) validateBody(formats strfmt.Registry) error {
 
 	if err := m.Body.Validate(formats); err != nil {
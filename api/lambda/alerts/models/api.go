@@ -20,10 +20,32 @@ package models
 
 import "time"
 
+// The triage status of an alert, new alerts are open
+const (
+	AlertStatusOpen          = "OPEN"
+	AlertStatusTriaging      = "TRIAGING"
+	AlertStatusClosed        = "CLOSED"
+	AlertStatusFalsePositive = "FALSE_POSITIVE"
+)
+
+// The orders in which alerts can be listed
+const (
+	SortByCreationTime = "creationTime"
+	SortByUpdateTime   = "updateTime"
+	SortBySeverity     = "severity"
+
+	SortAscending  = "ascending"
+	SortDescending = "descending"
+)
+
+// Severities lists the alert severities from the least to the most severe
+var Severities = []string{"INFO", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
 // LambdaInput is the request structure for the alerts-api Lambda function.
 type LambdaInput struct {
-	GetAlert   *GetAlertInput   `json:"getAlert"`
-	ListAlerts *ListAlertsInput `json:"listAlerts"`
+	GetAlert          *GetAlertInput          `json:"getAlert"`
+	ListAlerts        *ListAlertsInput        `json:"listAlerts"`
+	UpdateAlertStatus *UpdateAlertStatusInput `json:"updateAlertStatus"`
 }
 
 // GetAlertInput retrieves details for a single alert.
@@ -52,28 +74,47 @@ type GetAlertInput struct {
 // }
 type GetAlertOutput = Alert
 
-// ListAlertsInput lists the alerts in reverse-chronological order (newest to oldest)
+// ListAlertsInput lists the alerts matching the filters, newest to oldest by default
 // If "ruleId" is not set, we return all the alerts for the organization
-// If the "exclusiveStartKey" is not set, we return alerts starting from the most recent one. If it is set,
+// If other filters are set, only the alerts matching all of them are returned
+// If "sortBy" is set, alerts are sorted by creation time (default), last update time or severity and then last update time
+// If the "exclusiveStartKey" is not set, we return alerts starting from the first one. If it is set,
 // the output will return alerts starting from the "exclusiveStartKey" exclusive.
+// The "exclusiveStartKey" must be used with the same filters and sort order as the request that returned it.
 //
 //
 // {
 //     "listAlerts": {
 //         "ruleId": "My.Rule",
+//         "severity": ["HIGH", "CRITICAL"],
+//         "createdAtAfter": "2020-04-01T00:00:00Z",
+//         "titleContains": "root login",
+//         "sortBy": "updateTime",
 //         "pageSize": 25
 //     }
 // }
 type ListAlertsInput struct {
-	RuleID            *string `json:"ruleId,omitempty"`
-	PageSize          *int    `json:"pageSize,omitempty"  validate:"omitempty,min=1,max=50"`
-	ExclusiveStartKey *string `json:"exclusiveStartKey,omitempty"`
+	RuleID            *string    `json:"ruleId,omitempty"`
+	Status            *string    `json:"status,omitempty" validate:"omitempty,oneof=OPEN TRIAGING CLOSED FALSE_POSITIVE"`
+	AssigneeID        *string    `json:"assigneeId,omitempty" validate:"omitempty,uuid4"`
+	Severity          []*string  `json:"severity,omitempty" validate:"omitempty,dive,oneof=INFO LOW MEDIUM HIGH CRITICAL"`
+	CreatedAtAfter    *time.Time `json:"createdAtAfter,omitempty"`
+	CreatedAtBefore   *time.Time `json:"createdAtBefore,omitempty"`
+	UpdatedAtAfter    *time.Time `json:"updatedAtAfter,omitempty"`
+	UpdatedAtBefore   *time.Time `json:"updatedAtBefore,omitempty"`
+	LogType           *string    `json:"logType,omitempty" validate:"omitempty,min=1"`
+	TitleContains     *string    `json:"titleContains,omitempty" validate:"omitempty,min=1"`
+	EventCountMin     *int       `json:"eventCountMin,omitempty" validate:"omitempty,min=0"`
+	EventCountMax     *int       `json:"eventCountMax,omitempty" validate:"omitempty,min=0"`
+	SortBy            *string    `json:"sortBy,omitempty" validate:"omitempty,oneof=creationTime updateTime severity"`
+	SortDir           *string    `json:"sortDir,omitempty" validate:"omitempty,oneof=ascending descending"`
+	PageSize          *int       `json:"pageSize,omitempty"  validate:"omitempty,min=1,max=50"`
+	ExclusiveStartKey *string    `json:"exclusiveStartKey,omitempty"`
 }
 
 // ListAlertsOutput is the returned alert list.
 type ListAlertsOutput struct {
-	// Alerts is a list of alerts sorted by timestamp descending.
-	// Alerts with the same timestamp are returned in ascending order of alert ID.
+	// Alerts is a list of alerts in the requested order, by default sorted by creation time descending.
 	Alerts []*AlertSummary `json:"alertSummaries"`
 	// LastEvaluatedKey contains the last evaluated alert Id.
 	// If it is populated it means there are more alerts available
@@ -81,6 +122,30 @@ type ListAlertsOutput struct {
 	LastEvaluatedKey *string `json:"lastEvaluatedKey,omitempty"`
 }
 
+// UpdateAlertStatusInput changes the triage status of an alert.
+//
+// The assignee must be a Panther user, "assigneeId" and "resolutionNotes" are left unchanged if not set
+// and are removed if set to an empty string. Every update is recorded in the status history of the alert.
+//
+// {
+//     "updateAlertStatus": {
+//         "alertId": "8c1b7f1a597d0480354e66c3a6266ccc",
+//         "status": "CLOSED",
+//         "resolutionNotes": "Expected activity of the deployment pipeline",
+//         "userId": "f6cfaf0a-4a4c-4ec0-a9f4-5d1b8c2e4e1f"
+//     }
+// }
+type UpdateAlertStatusInput struct {
+	AlertID         *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Status          *string `json:"status" validate:"required,oneof=OPEN TRIAGING CLOSED FALSE_POSITIVE"`
+	AssigneeID      *string `json:"assigneeId,omitempty" validate:"omitempty,uuid4"`
+	ResolutionNotes *string `json:"resolutionNotes,omitempty" validate:"omitempty,max=10000"`
+	UserID          *string `json:"userId" validate:"required,uuid4"`
+}
+
+// UpdateAlertStatusOutput is the updated alert
+type UpdateAlertStatusOutput = AlertSummary
+
 // AlertSummary contains summary information for an alert
 type AlertSummary struct {
 	AlertID       *string    `json:"alertId"`
@@ -90,6 +155,10 @@ type AlertSummary struct {
 	UpdateTime    *time.Time `json:"updateTime"`
 	EventsMatched *int       `json:"eventsMatched"`
 	Severity      *string    `json:"severity"`
+	Title         *string    `json:"title,omitempty"`
+	LogTypes      []*string  `json:"logTypes"`
+	Status        *string    `json:"status"`
+	AssigneeID    *string    `json:"assigneeId,omitempty"`
 }
 
 // Alert contains the details of an alert
@@ -102,4 +171,18 @@ type Alert struct {
 	EventsMatched          *int       `json:"eventsMatched"`
 	Events                 []*string  `json:"events"`
 	EventsLastEvaluatedKey *string    `json:"eventsLastEvaluatedKey,omitempty"`
+	Status                 *string    `json:"status"`
+	AssigneeID             *string    `json:"assigneeId,omitempty"`
+	ResolutionNotes        *string    `json:"resolutionNotes,omitempty"`
+	// StatusHistory is the audit trail of the status updates, oldest first
+	StatusHistory []*AlertStatusChange `json:"statusHistory"`
+}
+
+// AlertStatusChange records who updated the status of an alert and what changed
+type AlertStatusChange struct {
+	Status          *string    `json:"status"`
+	AssigneeID      *string    `json:"assigneeId,omitempty"`      // set if the assignee changed, empty if removed
+	ResolutionNotes *string    `json:"resolutionNotes,omitempty"` // set if the notes changed, empty if removed
+	UserID          *string    `json:"userId"`
+	Time            *time.Time `json:"time"`
 }
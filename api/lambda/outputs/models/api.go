@@ -18,15 +18,18 @@ package models
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
+import "time"
+
 // LambdaInput is the invocation event expected by the Lambda function.
 //
 // Exactly one action must be specified.
 type LambdaInput struct {
-	AddOutput    *AddOutputInput    `json:"addOutput"`
-	UpdateOutput *UpdateOutputInput `json:"updateOutput"`
-	GetOutput    *GetOutputInput    `json:"getOutput"`
-	DeleteOutput *DeleteOutputInput `json:"deleteOutput"`
-	GetOutputs   *GetOutputsInput   `json:"getOutputs"`
+	AddOutput     *AddOutputInput     `json:"addOutput"`
+	UpdateOutput  *UpdateOutputInput  `json:"updateOutput"`
+	GetOutput     *GetOutputInput     `json:"getOutput"`
+	DeleteOutput  *DeleteOutputInput  `json:"deleteOutput"`
+	GetOutputs    *GetOutputsInput    `json:"getOutputs"`
+	GetDeliveries *GetDeliveriesInput `json:"getDeliveries"`
 }
 
 // AddOutputInput adds a new encrypted alert output to DynamoDB.
@@ -125,6 +128,85 @@ type GetOutputsInput struct {
 // }
 type GetOutputsOutput = []*AlertOutput
 
+// GetDeliveriesInput fetches the delivery attempts of alerts to an output, most recent first
+//
+// Example:
+// {
+//     "getDeliveries": {
+//         "outputId": "7d1c5854-f3ea-491c-8a52-0aa0d58cb456",
+//         "pageSize": 25
+//     }
+// }
+type GetDeliveriesInput struct {
+	OutputID          *string `json:"outputId" validate:"required,uuid4"`
+	PageSize          *int64  `json:"pageSize" validate:"omitempty,min=1,max=50"`
+	ExclusiveStartKey *string `json:"exclusiveStartKey" validate:"omitempty,min=1"`
+}
+
+// GetDeliveriesOutput returns a page of delivery attempts to an output
+//
+// Example:
+// {
+//     "deliveries": [
+//         {
+//             "outputId": "7d1c5854-f3ea-491c-8a52-0aa0d58cb456",
+//             "deliveryId": "2020-05-20T10:00:00.000000000Z:f2a3c6b1e8c5d7e4a9b0c1d2e3f4a5b6:1",
+//             "alertId": "f2a3c6b1e8c5d7e4a9b0c1d2e3f4a5b6",
+//             "policyId": "AWS.CloudTrail.RootActivity",
+//             "attempt": 1,
+//             "attemptedAt": "2020-05-20T10:00:00Z",
+//             "status": "RETRYING",
+//             "statusCode": 503,
+//             "error": "request failed: 503 Service Unavailable: "
+//         }
+//     ],
+//     "lastEvaluatedKey": "2020-05-20T10:00:00.000000000Z:f2a3c6b1e8c5d7e4a9b0c1d2e3f4a5b6:1"
+// }
+type GetDeliveriesOutput struct {
+	Deliveries       []*AlertDelivery `json:"deliveries"`
+	LastEvaluatedKey *string          `json:"lastEvaluatedKey,omitempty"`
+}
+
+const (
+	// DeliverySucceeded is the status of a successful delivery attempt
+	DeliverySucceeded = "SUCCESS"
+	// DeliveryRetrying is the status of a failed delivery attempt that will be retried
+	DeliveryRetrying = "RETRYING"
+	// DeliveryFailed is the status of the last delivery attempt of an alert that could not be delivered
+	DeliveryFailed = "FAILED"
+)
+
+// AlertDelivery is the record of an attempt to deliver an alert to an output
+type AlertDelivery struct {
+
+	// OutputID is the output the alert was sent to (table partition key)
+	OutputID *string `json:"outputId"`
+
+	// DeliveryID identifies uniquely an attempt and sorts the attempts by time (table sort key)
+	DeliveryID *string `json:"deliveryId"`
+
+	// AlertID is the alert that was delivered, it is empty for policy failures
+	AlertID *string `json:"alertId,omitempty"`
+
+	// PolicyID is the rule or policy that triggered the alert
+	PolicyID *string `json:"policyId"`
+
+	// Attempt is the number of the delivery attempt of the alert to the output, starting at 1
+	Attempt int `json:"attempt"`
+
+	// AttemptedAt is the time of the delivery attempt
+	AttemptedAt *time.Time `json:"attemptedAt"`
+
+	// Status is the result of the attempt: SUCCESS, RETRYING or FAILED
+	Status *string `json:"status"`
+
+	// StatusCode is the HTTP status code returned by the output, if any
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Error is the reason the delivery attempt failed
+	Error *string `json:"error,omitempty"`
+}
+
 // AlertOutput contains the information for alert output configuration
 type AlertOutput struct {
 
@@ -185,6 +267,9 @@ type OutputConfig struct {
 
 	// AsanaConfig contains the configuration for Asana alert output
 	Asana *AsanaConfig `json:"asana,omitempty"`
+
+	// CustomWebhook contains the configuration for a custom webhook alert output
+	CustomWebhook *CustomWebhookConfig `json:"customWebhook,omitempty"`
 }
 
 // SlackConfig defines options for each Slack output.
@@ -239,6 +324,22 @@ type AsanaConfig struct {
 	ProjectGids         []*string `json:"projectGids" validate:"required,min=1,dive,required"`
 }
 
+// CustomWebhookConfig defines options for each custom webhook output
+type CustomWebhookConfig struct {
+	WebhookURL *string          `json:"webhookURL" validate:"required,url"`
+	Headers    []*WebhookHeader `json:"headers,omitempty" validate:"omitempty,dive,required"`
+	// BodyTemplate is a Go template rendered with the alert, the alert is sent as JSON if it is not set
+	BodyTemplate *string `json:"bodyTemplate,omitempty" validate:"omitempty,min=1,goTemplate"`
+	// SigningSecret is the key used to sign the requests with HMAC-SHA256, the requests are not signed if it is not set
+	SigningSecret *string `json:"signingSecret,omitempty" validate:"omitempty,min=1"`
+}
+
+// WebhookHeader is an HTTP header added to the requests of a custom webhook output
+type WebhookHeader struct {
+	Name  *string `json:"name" validate:"required,min=1"`
+	Value *string `json:"value" validate:"required"`
+}
+
 // DefaultOutputs is the structure holding the information about default outputs for severity
 type DefaultOutputs struct {
 	Severity  *string   `json:"severity"`
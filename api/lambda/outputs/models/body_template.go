@@ -0,0 +1,49 @@
+package models
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"text/template"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// bodyTemplateFuncs are the functions available to the body templates of custom webhooks, in addition to the
+// fields of the alert
+var bodyTemplateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {{json .Title}} renders a quoted and escaped JSON string
+	"json": func(value interface{}) (string, error) {
+		return jsoniter.MarshalToString(value)
+	},
+	// the alert functions are bound by the alert delivery before rendering the template
+	"alertTitle": unboundAlertFunc,
+	"alertURL":   unboundAlertFunc,
+}
+
+func unboundAlertFunc(interface{}) (string, error) {
+	return "", errors.New("alert function is not bound")
+}
+
+// ParseBodyTemplate parses the body template of a custom webhook.
+//
+// The alert functions (alertTitle and alertURL) must be bound with Funcs before the template is executed.
+func ParseBodyTemplate(text string) (*template.Template, error) {
+	return template.New("body").Funcs(bodyTemplateFuncs).Parse(text)
+}
@@ -0,0 +1,46 @@
+package models
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBodyTemplate(t *testing.T) {
+	_, err := ParseBodyTemplate(`{{json .Title}} {{alertTitle .}} {{alertURL .}}`)
+	assert.NoError(t, err)
+
+	_, err = ParseBodyTemplate(`{{unknownFunction .Title}}`)
+	assert.Error(t, err)
+
+	_, err = ParseBodyTemplate(`{{.Title`)
+	assert.Error(t, err)
+}
+
+func TestBodyTemplateUnboundAlertFunc(t *testing.T) {
+	bodyTemplate, err := ParseBodyTemplate(`{{json .Title}} {{alertTitle .}}`)
+	require.NoError(t, err)
+
+	var body bytes.Buffer
+	assert.Error(t, bodyTemplate.Execute(&body, struct{ Title string }{Title: "title"}))
+}
@@ -32,9 +32,18 @@ type LambdaInput struct {
 
 	UpdateIntegrationLastScanEnd   *UpdateIntegrationLastScanEndInput   `json:"updateIntegrationLastScanEnd"`
 	UpdateIntegrationLastScanStart *UpdateIntegrationLastScanStartInput `json:"updateIntegrationLastScanStart"`
+	UpdateIntegrationLastPull      *UpdateIntegrationLastPullInput      `json:"updateIntegrationLastPull"`
 	UpdateIntegrationSettings      *UpdateIntegrationSettingsInput      `json:"updateIntegrationSettings"`
 
 	DeleteIntegration *DeleteIntegrationInput `json:"deleteIntegration"`
+
+	PutCustomLogSchema    *PutCustomLogSchemaInput    `json:"putCustomLogSchema"`
+	ListCustomLogSchemas  *ListCustomLogSchemasInput  `json:"listCustomLogSchemas"`
+	DeleteCustomLogSchema *DeleteCustomLogSchemaInput `json:"deleteCustomLogSchema"`
+
+	PutThreatIntelFeed    *PutThreatIntelFeedInput    `json:"putThreatIntelFeed"`
+	ListThreatIntelFeeds  *ListThreatIntelFeedsInput  `json:"listThreatIntelFeeds"`
+	DeleteThreatIntelFeed *DeleteThreatIntelFeedInput `json:"deleteThreatIntelFeed"`
 }
 
 //
@@ -44,7 +53,7 @@ type LambdaInput struct {
 // CheckIntegrationInput is used to check the health of a potential configuration.
 type CheckIntegrationInput struct {
 	AWSAccountID     *string `genericapi:"redact" json:"awsAccountId" validate:"required,len=12,numeric"`
-	IntegrationType  *string `json:"integrationType" validate:"required,oneof=aws-scan aws-s3"`
+	IntegrationType  *string `json:"integrationType" validate:"required,oneof=aws-scan aws-s3 aws-cloudwatch-logs saas-api"`
 	IntegrationLabel *string `json:"integrationLabel" validate:"required,integrationLabel"`
 
 	// Checks for cloudsec integrations
@@ -52,9 +61,15 @@ type CheckIntegrationInput struct {
 	EnableRemediation *bool `json:"enableRemediation"`
 
 	// Checks for log analysis integrations
-	S3Bucket *string `json:"s3Bucket,omitempty"`
-	S3Prefix *string `json:"s3Prefix,omitempty"`
-	KmsKey   *string `json:"kmsKey,omitempty"`
+	S3Bucket     *string `json:"s3Bucket,omitempty"`
+	S3Prefix     *string `json:"s3Prefix,omitempty"`
+	KmsKey       *string `json:"kmsKey,omitempty"`
+	LogGroupName *string `json:"logGroupName,omitempty"`
+
+	// Checks for SaaS integrations
+	SaaSProvider *string `json:"saasProvider,omitempty"`
+	SaaSEndpoint *string `json:"saasEndpoint,omitempty"`
+	SaaSAPIToken *string `genericapi:"redact" json:"saasApiToken,omitempty"`
 }
 
 //
@@ -71,7 +86,7 @@ type PutIntegrationInput struct {
 type PutIntegrationSettings struct {
 	AWSAccountID       *string   `genericapi:"redact" json:"awsAccountId" validate:"required,len=12,numeric"`
 	IntegrationLabel   *string   `json:"integrationLabel,omitempty" validate:"required,integrationLabel"`
-	IntegrationType    *string   `json:"integrationType" validate:"required,oneof=aws-scan aws-s3"`
+	IntegrationType    *string   `json:"integrationType" validate:"required,oneof=aws-scan aws-s3 aws-cloudwatch-logs saas-api"`
 	CWEEnabled         *bool     `json:"cweEnabled,omitempty"`
 	RemediationEnabled *bool     `json:"remediationEnabled,omitempty"`
 	ScanIntervalMins   *int      `json:"scanIntervalMins,omitempty" validate:"omitempty,oneof=60 180 360 720 1440"`
@@ -79,6 +94,10 @@ type PutIntegrationSettings struct {
 	S3Bucket           *string   `json:"s3Bucket,omitempty"`
 	S3Prefix           *string   `json:"s3Prefix,omitempty" validate:"omitempty,min=1"`
 	KmsKey             *string   `json:"kmsKey,omitempty" validate:"omitempty,kmsKeyArn"`
+	LogGroupName       *string   `json:"logGroupName,omitempty" validate:"omitempty,logGroupName"`
+	SaaSProvider       *string   `json:"saasProvider,omitempty" validate:"omitempty,oneof=okta"`
+	SaaSEndpoint       *string   `json:"saasEndpoint,omitempty" validate:"omitempty,url"`
+	SaaSAPIToken       *string   `genericapi:"redact" json:"saasApiToken,omitempty" validate:"omitempty,min=1"`
 	LogTypes           []*string `json:"logTypes,omitempty" validate:"omitempty,min=1"`
 }
 
@@ -88,7 +107,7 @@ type PutIntegrationSettings struct {
 
 // ListIntegrationsInput allows filtering by the IntegrationType or Enabled fields
 type ListIntegrationsInput struct {
-	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3"`
+	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-cloudwatch-logs saas-api"`
 }
 
 //
@@ -98,7 +117,7 @@ type ListIntegrationsInput struct {
 // GetIntegrationTemplateInput allows specification of what resources should be enabled/disabled in the template
 type GetIntegrationTemplateInput struct {
 	AWSAccountID       *string `genericapi:"redact" json:"awsAccountId" validate:"required,len=12,numeric"`
-	IntegrationType    *string `json:"integrationType" validate:"oneof=aws-scan aws-s3"`
+	IntegrationType    *string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-cloudwatch-logs saas-api"`
 	IntegrationLabel   *string `json:"integrationLabel" validate:"required,integrationLabel"`
 	RemediationEnabled *bool   `json:"remediationEnabled,omitempty"`
 	CWEEnabled         *bool   `json:"cweEnabled,omitempty"`
@@ -136,6 +155,14 @@ type UpdateIntegrationLastScanEndInput struct {
 	ScanStatus           *string    `json:"scanStatus" validate:"required,oneof=ok error scanning"`
 }
 
+// UpdateIntegrationLastPullInput is used to update pull information after pulling the events of a SaaS integration.
+type UpdateIntegrationLastPullInput struct {
+	IntegrationID        *string    `json:"integrationId" validate:"required,uuid4"`
+	LastPullTime         *time.Time `json:"lastPullTime" validate:"required"`
+	LastPullErrorMessage *string    `json:"lastPullErrorMessage"`
+	PullCursor           *string    `json:"pullCursor"`
+}
+
 // UpdateIntegrationSettingsInput is used to update integration settings.
 type UpdateIntegrationSettingsInput struct {
 	IntegrationID      *string   `json:"integrationId" validate:"required,uuid4"`
@@ -146,5 +173,46 @@ type UpdateIntegrationSettingsInput struct {
 	S3Bucket           *string   `json:"s3Bucket,omitempty" validate:"omitempty,min=1"`
 	S3Prefix           *string   `json:"s3Prefix,omitempty" validate:"omitempty,min=1"`
 	KmsKey             *string   `json:"kmsKey,omitempty" validate:"omitempty,kmsKeyArn"`
+	LogGroupName       *string   `json:"logGroupName,omitempty" validate:"omitempty,logGroupName"`
+	SaaSEndpoint       *string   `json:"saasEndpoint,omitempty" validate:"omitempty,url"`
+	SaaSAPIToken       *string   `genericapi:"redact" json:"saasApiToken,omitempty" validate:"omitempty,min=1"`
 	LogTypes           []*string `json:"logTypes,omitempty" validate:"omitempty,min=1"`
 }
+
+//
+// CustomLogSchemas: Used by the UI to manage user defined log types and by log analysis to parse them
+//
+
+// PutCustomLogSchemaInput is used to add a custom log schema or append fields to an existing one.
+type PutCustomLogSchemaInput struct {
+	CustomLogSchemaSettings
+	UserID *string `json:"userId" validate:"required,uuid4"`
+}
+
+// ListCustomLogSchemasInput is used to list all custom log schemas.
+type ListCustomLogSchemasInput struct {
+}
+
+// DeleteCustomLogSchemaInput is used to delete a custom log schema.
+type DeleteCustomLogSchemaInput struct {
+	LogType *string `json:"logType" validate:"required,customLogType"`
+}
+
+//
+// ThreatIntelFeeds: Used to manage the indicator sets log analysis matches events against
+//
+
+// PutThreatIntelFeedInput is used to add a threat intelligence feed or replace the indicators of an existing one.
+type PutThreatIntelFeedInput struct {
+	ThreatIntelFeedSettings
+	UserID *string `json:"userId" validate:"required,uuid4"`
+}
+
+// ListThreatIntelFeedsInput is used to list all threat intelligence feeds (without their indicators).
+type ListThreatIntelFeedsInput struct {
+}
+
+// DeleteThreatIntelFeedInput is used to delete a threat intelligence feed.
+type DeleteThreatIntelFeedInput struct {
+	FeedName *string `json:"feedName" validate:"required,threatIntelFeedName"`
+}
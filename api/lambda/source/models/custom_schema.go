@@ -0,0 +1,221 @@
+package models
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	// CustomLogTypePrefix is the prefix all user defined log types must have to avoid collisions with built-in log types
+	CustomLogTypePrefix = "Custom."
+
+	// CustomLogFormatJSON is the format of custom logs with one JSON object per line
+	CustomLogFormatJSON = "json"
+	// CustomLogFormatCSV is the format of custom logs with one CSV record per line
+	CustomLogFormatCSV = "csv"
+
+	CustomFieldTypeString    = "string"
+	CustomFieldTypeBigInt    = "bigint"
+	CustomFieldTypeDouble    = "double"
+	CustomFieldTypeBoolean   = "boolean"
+	CustomFieldTypeTimestamp = "timestamp"
+	CustomFieldTypeJSON      = "json"
+
+	CustomFieldIndicatorIP            = "ip"
+	CustomFieldIndicatorDomain        = "domain"
+	CustomFieldIndicatorSHA1          = "sha1"
+	CustomFieldIndicatorMD5           = "md5"
+	CustomFieldIndicatorSHA256        = "sha256"
+	CustomFieldIndicatorAWSARN        = "aws_arn"
+	CustomFieldIndicatorAWSAccountID  = "aws_account_id"
+	CustomFieldIndicatorAWSInstanceID = "aws_instance_id"
+	CustomFieldIndicatorUsername      = "username"
+
+	// CustomLogFramingLine is the default framing of custom logs, each line is a record
+	CustomLogFramingLine = "line"
+	// CustomLogFramingJSON frames records as a stream of JSON values that can span multiple lines
+	CustomLogFramingJSON = "json"
+	// CustomLogFramingRegex frames records starting with a line matching the record start pattern
+	CustomLogFramingRegex = "regex"
+
+	CustomTimeFormatRFC3339 = "rfc3339"
+	CustomTimeFormatUnix    = "unix"
+	CustomTimeFormatUnixMs  = "unix_ms"
+)
+
+// CustomLogSchema is the dynamodb item corresponding to the PutCustomLogSchema route.
+type CustomLogSchema struct {
+	CustomLogSchemaSettings
+	LastModified   *time.Time `json:"lastModified"`
+	LastModifiedBy *string    `json:"lastModifiedBy"`
+}
+
+// CustomLogSchemaSettings describe a user defined log type.
+//
+// NOTE: Fields can only be appended to an existing schema, since the Glue table columns are derived from them.
+type CustomLogSchemaSettings struct {
+	LogType     *string `json:"logType" validate:"required,customLogType"`
+	Description *string `json:"description" validate:"required,min=1"`
+	Format      *string `json:"format" validate:"required,oneof=json csv"`
+	// Delimiter of CSV logs, defaults to ','
+	Delimiter *string `json:"delimiter,omitempty" validate:"omitempty,len=1"`
+	// EventTimeField is the name of the timestamp field used as p_event_time, if not set the parse time is used
+	EventTimeField *string           `json:"eventTimeField,omitempty" validate:"omitempty,customFieldName"`
+	Fields         []*CustomLogField `json:"fields" validate:"required,min=1,dive,required"`
+	// Framing of the records in the log files: line (default), json or regex
+	Framing *string `json:"framing,omitempty" validate:"omitempty,oneof=line json regex"`
+	// RecordStartPattern is the regular expression matching the first line of each record when using regex framing
+	RecordStartPattern *string `json:"recordStartPattern,omitempty" validate:"omitempty,min=1"`
+	// Timezone is the IANA name of the zone of timestamps without one (e.g. America/New_York), defaults to UTC
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,min=1"`
+}
+
+// CustomLogField is a column of a custom log type.
+//
+// For CSV logs the fields are in the same order as the columns of each record.
+type CustomLogField struct {
+	Name        *string `json:"name" validate:"required,customFieldName"`
+	Type        *string `json:"type" validate:"required,oneof=string bigint double boolean timestamp json"`
+	Description *string `json:"description" validate:"required,min=1"`
+	Required    *bool   `json:"required,omitempty"`
+	// TimeFormat of timestamp fields: rfc3339 (default), unix, unix_ms or a Go time layout
+	TimeFormat *string `json:"timeFormat,omitempty" validate:"omitempty,min=1"`
+	// TimeFormats are more formats tried in order if the value does not match TimeFormat.
+	// Formats without a year (e.g. "Jan _2 15:04:05") are assumed to be from the last 12 months.
+	TimeFormats []*string `json:"timeFormats,omitempty" validate:"omitempty,dive,required,min=1"`
+	// Indicators are the p_any fields the value of the field is added to
+	Indicators []*string `json:"indicators,omitempty" validate:"omitempty,dive,required,oneof=ip domain sha1 md5 sha256 aws_arn aws_account_id aws_instance_id username"`
+}
+
+// Validate checks the parts of a schema the struct tags cannot (e.g. the fields referenced by other settings),
+// so that a parser can always be built for a stored schema.
+//
+// NOTE: This is shared by the source API and the log processor, which both need to accept the same schemas.
+func (s *CustomLogSchemaSettings) Validate() error {
+	logType := aws.StringValue(s.LogType)
+	if !strings.HasPrefix(logType, CustomLogTypePrefix) {
+		return fmt.Errorf("log type %q must start with %q", logType, CustomLogTypePrefix)
+	}
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("log type %q has no fields", logType)
+	}
+
+	format := aws.StringValue(s.Format)
+	switch format {
+	case CustomLogFormatJSON:
+	case CustomLogFormatCSV:
+		if delimiter := aws.StringValue(s.Delimiter); delimiter != "" {
+			if len(delimiter) != 1 || delimiter == "\n" || delimiter == "\r" || delimiter == `"` {
+				return fmt.Errorf("invalid csv delimiter %q", delimiter)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported log format %q", format)
+	}
+
+	switch framing := aws.StringValue(s.Framing); framing {
+	case "", CustomLogFramingLine:
+	case CustomLogFramingJSON:
+		if format != CustomLogFormatJSON {
+			return fmt.Errorf("json framing requires the %q format", CustomLogFormatJSON)
+		}
+	case CustomLogFramingRegex:
+		pattern := aws.StringValue(s.RecordStartPattern)
+		if pattern == "" {
+			return errors.New("regex framing requires a record start pattern")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid record start pattern %q: %v", pattern, err)
+		}
+	default:
+		return fmt.Errorf("unsupported framing %q", framing)
+	}
+
+	if timezone := aws.StringValue(s.Timezone); timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %v", timezone, err)
+		}
+	}
+
+	names := make(map[string]struct{}, len(s.Fields))
+	eventTimeFound := false
+	for i, field := range s.Fields {
+		name := aws.StringValue(field.Name)
+		if err := field.validate(i); err != nil {
+			return err
+		}
+		// Glue column names are case insensitive
+		if _, exists := names[strings.ToLower(name)]; exists {
+			return fmt.Errorf("duplicate field %q", name)
+		}
+		names[strings.ToLower(name)] = struct{}{}
+
+		if name == aws.StringValue(s.EventTimeField) {
+			if aws.StringValue(field.Type) != CustomFieldTypeTimestamp {
+				return fmt.Errorf("event time field %q is not a timestamp", name)
+			}
+			eventTimeFound = true
+		}
+	}
+	if s.EventTimeField != nil && !eventTimeFound {
+		return fmt.Errorf("event time field %q does not exist", *s.EventTimeField)
+	}
+	return nil
+}
+
+func (f *CustomLogField) validate(index int) error {
+	name := aws.StringValue(f.Name)
+	fieldType := aws.StringValue(f.Type)
+	if name == "" {
+		return fmt.Errorf("field %d has no name", index)
+	}
+	if strings.HasPrefix(strings.ToLower(name), reservedFieldPrefix) {
+		return fmt.Errorf("field %q uses the reserved prefix %q", name, reservedFieldPrefix)
+	}
+	switch fieldType {
+	case CustomFieldTypeString, CustomFieldTypeBigInt, CustomFieldTypeDouble, CustomFieldTypeBoolean,
+		CustomFieldTypeTimestamp, CustomFieldTypeJSON:
+	default:
+		return fmt.Errorf("field %q has unsupported type %q", name, fieldType)
+	}
+	if fieldType != CustomFieldTypeTimestamp && (f.TimeFormat != nil || len(f.TimeFormats) > 0) {
+		return fmt.Errorf("field %q has a time format but is not a timestamp", name)
+	}
+	for _, indicator := range aws.StringValueSlice(f.Indicators) {
+		if fieldType != CustomFieldTypeString {
+			return fmt.Errorf("field %q has indicators but is not a string", name)
+		}
+		switch indicator {
+		case CustomFieldIndicatorIP, CustomFieldIndicatorDomain,
+			CustomFieldIndicatorSHA1, CustomFieldIndicatorMD5, CustomFieldIndicatorSHA256,
+			CustomFieldIndicatorAWSARN, CustomFieldIndicatorAWSAccountID, CustomFieldIndicatorAWSInstanceID,
+			CustomFieldIndicatorUsername:
+		default:
+			return fmt.Errorf("field %q has unsupported indicator %q", name, indicator)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package models
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func testCustomLogSchema() *CustomLogSchemaSettings {
+	return &CustomLogSchemaSettings{
+		LogType:        aws.String("Custom.Test"),
+		Description:    aws.String("A custom log type"),
+		Format:         aws.String(CustomLogFormatJSON),
+		EventTimeField: aws.String("time"),
+		Fields: []*CustomLogField{
+			{
+				Name:        aws.String("time"),
+				Type:        aws.String(CustomFieldTypeTimestamp),
+				Description: aws.String("The time"),
+			},
+			{
+				Name:        aws.String("ip"),
+				Type:        aws.String(CustomFieldTypeString),
+				Description: aws.String("The ip"),
+				Indicators:  aws.StringSlice([]string{CustomFieldIndicatorIP}),
+			},
+		},
+	}
+}
+
+func TestCustomLogSchemaValidate(t *testing.T) {
+	require.NoError(t, testCustomLogSchema().Validate())
+}
+
+func TestCustomLogSchemaValidateFails(t *testing.T) {
+	schema := testCustomLogSchema()
+	schema.EventTimeField = aws.String("ip")
+	require.EqualError(t, schema.Validate(), `event time field "ip" is not a timestamp`)
+
+	schema = testCustomLogSchema()
+	schema.Fields[1].Name = aws.String("TIME")
+	require.EqualError(t, schema.Validate(), `duplicate field "TIME"`)
+
+	schema = testCustomLogSchema()
+	schema.Fields[1].Name = aws.String("p_ip")
+	require.EqualError(t, schema.Validate(), `field "p_ip" uses the reserved prefix "p_"`)
+
+	schema = testCustomLogSchema()
+	schema.Framing = aws.String(CustomLogFramingRegex)
+	require.EqualError(t, schema.Validate(), "regex framing requires a record start pattern")
+
+	schema = testCustomLogSchema()
+	schema.Timezone = aws.String("Mars/Olympus")
+	require.Error(t, schema.Validate())
+}
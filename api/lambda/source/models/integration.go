@@ -25,6 +25,7 @@ type SourceIntegration struct {
 	*SourceIntegrationMetadata
 	*SourceIntegrationStatus
 	*SourceIntegrationScanInformation
+	*SourceIntegrationPullInformation
 }
 
 // SourceIntegrationMetadata is general settings and metadata for an integration.
@@ -41,6 +42,9 @@ type SourceIntegrationMetadata struct {
 	S3Bucket           *string    `json:"s3Bucket,omitempty"`
 	S3Prefix           *string    `json:"s3Prefix,omitempty"`
 	KmsKey             *string    `json:"kmsKey,omitempty"`
+	LogGroupName       *string    `json:"logGroupName,omitempty"`
+	SaaSProvider       *string    `json:"saasProvider,omitempty"`
+	SaaSEndpoint       *string    `json:"saasEndpoint,omitempty"`
 	LogTypes           []*string  `json:"logTypes,omitempty"`
 	LogProcessingRole  *string    `json:"logProcessingRole,omitempty"`
 	StackName          *string    `json:"stackName,omitempty"`
@@ -59,6 +63,13 @@ type SourceIntegrationScanInformation struct {
 	LastScanStartTime    *time.Time `json:"lastScanStartTime"`
 }
 
+// SourceIntegrationPullInformation is detail about the last pull of a SaaS integration.
+type SourceIntegrationPullInformation struct {
+	LastPullTime         *time.Time `json:"lastPullTime,omitempty"`
+	LastPullErrorMessage *string    `json:"lastPullErrorMessage,omitempty"`
+	PullCursor           *string    `json:"pullCursor,omitempty"`
+}
+
 type SourceIntegrationHealth struct {
 	AWSAccountID    string `json:"awsAccountId"`
 	IntegrationType string `json:"integrationType"`
@@ -72,6 +83,10 @@ type SourceIntegrationHealth struct {
 	ProcessingRoleStatus SourceIntegrationItemStatus `json:"processingRoleStatus"`
 	S3BucketStatus       SourceIntegrationItemStatus `json:"s3BucketStatus"`
 	KMSKeyStatus         SourceIntegrationItemStatus `json:"kmsKeyStatus"`
+	LogGroupStatus       SourceIntegrationItemStatus `json:"logGroupStatus"`
+
+	// Checks for SaaS integrations
+	SaaSAPIStatus SourceIntegrationItemStatus `json:"saasApiStatus"`
 }
 
 type SourceIntegrationItemStatus struct {
@@ -0,0 +1,89 @@
+package models
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// ThreatIntelFeedPrefix is the prefix of the S3 keys of the threat intelligence feeds
+	ThreatIntelFeedPrefix = "threat_intel/"
+
+	ThreatIntelIndicatorIP     = "ip"
+	ThreatIntelIndicatorDomain = "domain"
+	ThreatIntelIndicatorSHA1   = "sha1"
+	ThreatIntelIndicatorMD5    = "md5"
+	ThreatIntelIndicatorSHA256 = "sha256"
+)
+
+// ThreatIntelFeed is the S3 object corresponding to the PutThreatIntelFeed route.
+type ThreatIntelFeed struct {
+	ThreatIntelFeedSettings
+	LastModified   *time.Time `json:"lastModified"`
+	LastModifiedBy *string    `json:"lastModifiedBy"`
+}
+
+// ThreatIntelFeedSettings describe a named set of indicators of compromise (e.g. bad ips, domains or file hashes).
+type ThreatIntelFeedSettings struct {
+	FeedName    *string                 `json:"feedName" validate:"required,threatIntelFeedName"`
+	Description *string                 `json:"description,omitempty"`
+	Indicators  []*ThreatIntelIndicator `json:"indicators" validate:"required,min=1,dive,required"`
+}
+
+// ThreatIntelIndicator is matched against the p_any field of its type (e.g. p_any_ip_addresses for ip indicators).
+type ThreatIntelIndicator struct {
+	Type  *string `json:"type" validate:"required,oneof=ip domain sha1 md5 sha256"`
+	Value *string `json:"value" validate:"required,min=1"`
+}
+
+// ThreatIntelFeedSummary is a feed without its indicators, returned by the ListThreatIntelFeeds route.
+type ThreatIntelFeedSummary struct {
+	FeedName       *string    `json:"feedName"`
+	Description    *string    `json:"description,omitempty"`
+	IndicatorCount *int       `json:"indicatorCount"`
+	LastModified   *time.Time `json:"lastModified"`
+	LastModifiedBy *string    `json:"lastModifiedBy"`
+}
+
+// ThreatIntelFeedKey returns the S3 key of a threat intelligence feed
+func ThreatIntelFeedKey(feedName string) string {
+	return ThreatIntelFeedPrefix + feedName + ".json"
+}
+
+// NormalizeThreatIntelIndicator returns the form of an indicator used for matching, empty if the value is not valid.
+//
+// Both the stored indicators and the values of the events are normalized, so that e.g. hashes match regardless of case.
+func NormalizeThreatIntelIndicator(indicatorType, value string) string {
+	value = strings.TrimSpace(value)
+	switch indicatorType {
+	case ThreatIntelIndicatorIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	case ThreatIntelIndicatorDomain:
+		return strings.TrimSuffix(strings.ToLower(value), ".")
+	default: // hashes
+		return strings.ToLower(value)
+	}
+}
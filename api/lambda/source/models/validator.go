@@ -28,10 +28,19 @@ import (
 
 const (
 	integrationLabelMaxLength = 32
+	customLogTypeMaxLength    = 64
+	customFieldNameMaxLength  = 128
+	logGroupNameMaxLength     = 512
+	threatIntelFeedMaxLength  = 64
+	reservedFieldPrefix       = "p_"
 )
 
 var (
 	integrationLabelValidatorRegex = regexp.MustCompile("^[0-9a-zA-Z- ]+$")
+	customLogTypeValidatorRegex    = regexp.MustCompile(`^Custom\.[0-9a-zA-Z]+(\.[0-9a-zA-Z]+)*$`)
+	customFieldNameValidatorRegex  = regexp.MustCompile("^[a-zA-Z_][0-9a-zA-Z_]*$")
+	logGroupNameValidatorRegex     = regexp.MustCompile(`^[0-9a-zA-Z_\-/.#]+$`)
+	threatIntelFeedValidatorRegex  = regexp.MustCompile("^[0-9a-zA-Z_-]+$")
 )
 
 // Validator builds a custom struct validator.
@@ -43,6 +52,18 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("kmsKeyArn", validateKmsKeyArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("customLogType", validateCustomLogType); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("customFieldName", validateCustomFieldName); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("logGroupName", validateLogGroupName); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("threatIntelFeedName", validateThreatIntelFeedName); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -66,3 +87,42 @@ func validateKmsKeyArn(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+func validateCustomLogType(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len(value) > customLogTypeMaxLength {
+		return false
+	}
+	return customLogTypeValidatorRegex.MatchString(value)
+}
+
+// Field names become Glue columns, fields prefixed with 'p_' are reserved for Panther
+func validateCustomFieldName(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len(value) > customFieldNameMaxLength || strings.HasPrefix(strings.ToLower(value), reservedFieldPrefix) {
+		return false
+	}
+	return customFieldNameValidatorRegex.MatchString(value)
+}
+
+func validateLogGroupName(fl validator.FieldLevel) bool {
+	return IsValidLogGroupName(fl.Field().String())
+}
+
+// IsValidLogGroupName returns true if name is a valid CloudWatch Logs log group name, see
+// https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_CreateLogGroup.html
+func IsValidLogGroupName(name string) bool {
+	if len(name) > logGroupNameMaxLength {
+		return false
+	}
+	return logGroupNameValidatorRegex.MatchString(name)
+}
+
+// Feed names are part of the S3 key of the feed and are copied to the matched events
+func validateThreatIntelFeedName(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len(value) > threatIntelFeedMaxLength {
+		return false
+	}
+	return threatIntelFeedValidatorRegex.MatchString(value)
+}
@@ -19,6 +19,7 @@ package models
  */
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -81,3 +82,68 @@ func TestValidateKmsKey(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestValidateCustomLogSchema(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	schema := &CustomLogSchemaSettings{
+		LogType:     aws.String("Custom.MyApp.Access"),
+		Description: aws.String("My application access logs"),
+		Format:      aws.String(CustomLogFormatJSON),
+		Fields: []*CustomLogField{
+			{
+				Name:        aws.String("client_ip"),
+				Type:        aws.String(CustomFieldTypeString),
+				Description: aws.String("The client IP"),
+				Indicators:  aws.StringSlice([]string{CustomFieldIndicatorIP}),
+			},
+		},
+	}
+	require.NoError(t, validator.Struct(schema))
+
+	schema.LogType = aws.String("AWS.CloudTrail")
+	require.Error(t, validator.Struct(schema))
+
+	schema.LogType = aws.String("Custom.MyApp")
+	schema.Fields[0].Name = aws.String("p_event_time")
+	require.Error(t, validator.Struct(schema))
+
+	schema.Fields[0].Name = aws.String("client ip")
+	require.Error(t, validator.Struct(schema))
+}
+
+func TestValidateLogGroupName(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := &PutIntegrationInput{
+		PutIntegrationSettings: PutIntegrationSettings{
+			AWSAccountID:     aws.String("123456789012"),
+			IntegrationLabel: aws.String("Test12- "),
+			IntegrationType:  aws.String(IntegrationTypeAWSCloudWatchLogs),
+			UserID:           aws.String("cb7663c7-80ed-420b-a287-ed7dc50a0bf7"),
+			LogGroupName:     aws.String("/aws/lambda/my-function"),
+		},
+	}
+	require.NoError(t, validator.Struct(input))
+
+	input.LogGroupName = aws.String("/aws/lambda/my function")
+	errorMsg := "Key: 'PutIntegrationInput.PutIntegrationSettings.LogGroupName' " +
+		"Error:Field validation for 'LogGroupName' failed on the 'logGroupName' tag"
+	require.EqualError(t, validator.Struct(input), errorMsg)
+}
+
+func TestIsValidLogGroupName(t *testing.T) {
+	require.True(t, IsValidLogGroupName("/aws/lambda/my-function"))
+	require.True(t, IsValidLogGroupName("API-Gateway-Execution-Logs_a1b2c3/prod#1"))
+	require.False(t, IsValidLogGroupName(""))
+	require.False(t, IsValidLogGroupName("my:group"))
+	require.False(t, IsValidLogGroupName(strings.Repeat("a", logGroupNameMaxLength+1)))
+}
+
+func TestValidateThreatIntelFeedName(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	require.NoError(t, validator.Struct(&DeleteThreatIntelFeedInput{FeedName: aws.String("abuse_ch-feodo")}))
+	require.Error(t, validator.Struct(&DeleteThreatIntelFeedInput{FeedName: aws.String("../feed")}))
+	require.Error(t, validator.Struct(&DeleteThreatIntelFeedInput{FeedName: aws.String("")}))
+}
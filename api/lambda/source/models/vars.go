@@ -23,6 +23,17 @@ const (
 	IntegrationTypeAWSScan = "aws-scan"
 	// IntegrationTypeAWS3 is the integration type for importing data from customer S3 buckets.
 	IntegrationTypeAWS3 = "aws-s3"
+	// IntegrationTypeAWSCloudWatchLogs is the integration type for the subscription of a customer log group.
+	IntegrationTypeAWSCloudWatchLogs = "aws-cloudwatch-logs"
+	// IntegrationTypeSaaSAPI is the integration type for pulling audit logs from the API of a SaaS platform.
+	IntegrationTypeSaaSAPI = "saas-api"
+
+	// SaaSProviderOkta pulls the Okta System Log, the only SaaS API with a parser for its events.
+	SaaSProviderOkta = "okta"
+
+	// SaaSAPITokenSecretPrefix is the prefix of the Secrets Manager secrets storing the API tokens of SaaS integrations,
+	// the integration id is appended to it.
+	SaaSAPITokenSecretPrefix = "panther-saas-api-token-"
 
 	// StatusError is the string set in the database when an error occurs in a scan.
 	StatusError = "error"
@@ -60,7 +60,11 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	dataStreams := []*common.DataStream{{Reader: gzipReader, LogType: LOGTYPE}}
+	dataStream := &common.DataStream{Reader: gzipReader, LogType: LOGTYPE}
+	if *LOGTYPE != "" {
+		dataStream.LogTypes = []string{*LOGTYPE}
+	}
+	dataStreams := []*common.DataStream{dataStream}
 
 	if *CPUPROFILE != "" {
 		f, err := os.Create(*CPUPROFILE)
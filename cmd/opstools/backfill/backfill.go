@@ -0,0 +1,372 @@
+package backfill
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	pageSize             = 1000
+	batchSize            = 10 // max entries in an SQS batch
+	batchTimeout         = time.Minute
+	sourceAPIFunction    = "panther-source-api"
+	fakeTopicArnTemplate = "arn:aws:sns:us-east-1:%s:panther-fake-backfill-topic" // account is added for sqs messages
+	progressNotify       = 5000                                                   // log a line every this many to show progress
+)
+
+var (
+	waitFunc = time.Sleep // var so we can set in tests
+)
+
+// Input configures a backfill run
+type Input struct {
+	IntegrationID string
+	Start         time.Time // inclusive, compared against the S3 object's LastModified
+	End           time.Time // exclusive, compared against the S3 object's LastModified
+	QueueName     string
+	FilesPerSec   float64 // if non-zero, limits the rate notifications are sent to the queue
+	Limit         uint64  // if non-zero, limits the number of files sent
+	ProgressFile  string  // if set, progress is recorded here so an interrupted backfill can resume
+}
+
+type Stats struct {
+	NumFiles uint64
+	NumBytes uint64
+}
+
+// Progress is persisted after each batch sent so an interrupted backfill can be resumed.
+// S3 lists keys in lexicographic order, so the last key sent is enough to know where to start again.
+type Progress struct {
+	IntegrationID string    `json:"integrationId"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	LastKey       string    `json:"lastKey"`
+	NumFiles      uint64    `json:"numFiles"`
+	NumBytes      uint64    `json:"numBytes"`
+	Done          bool      `json:"done"`
+}
+
+func Backfill(sess *session.Session, input *Input, stats *Stats) error {
+	integration, err := getIntegration(lambda.New(sess), input.IntegrationID)
+	if err != nil {
+		return err
+	}
+	s3Client, err := newS3Client(sess, integration)
+	if err != nil {
+		return err
+	}
+	return backfill(s3Client, sqs.New(sess), integration, input, stats)
+}
+
+func backfill(s3Client s3iface.S3API, sqsClient sqsiface.SQSAPI, integration *models.SourceIntegration,
+	input *Input, stats *Stats) error {
+
+	if !input.Start.Before(input.End) {
+		return errors.Errorf("start time %s is not before end time %s", input.Start, input.End)
+	}
+
+	progress, err := readProgress(input)
+	if err != nil {
+		return err
+	}
+	if progress.Done {
+		log.Printf("backfill recorded in %s is already complete", input.ProgressFile)
+		return nil
+	}
+	if progress.LastKey != "" {
+		log.Printf("resuming backfill after s3://%s/%s (%d files already sent)",
+			*integration.S3Bucket, progress.LastKey, progress.NumFiles)
+	}
+	stats.NumFiles, stats.NumBytes = progress.NumFiles, progress.NumBytes
+
+	queueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: &input.QueueName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not get queue url for %s", input.QueueName)
+	}
+
+	q := &queuer{
+		sqsClient: sqsClient,
+		queueURL:  queueURL.QueueUrl,
+		// the account id is taken from this arn to assume role for reading in the log processor
+		topicARN: fmt.Sprintf(fakeTopicArnTemplate, *integration.AWSAccountID),
+		input:    input,
+		progress: progress,
+		stats:    stats,
+	}
+	if input.FilesPerSec > 0 {
+		q.batchInterval = time.Duration(float64(batchSize) / input.FilesPerSec * float64(time.Second))
+	}
+
+	limit := input.Limit
+	if limit > 0 {
+		limit += progress.NumFiles // limit applies to this run
+	}
+
+	inputParams := &s3.ListObjectsV2Input{
+		Bucket:  integration.S3Bucket,
+		Prefix:  integration.S3Prefix,
+		MaxKeys: aws.Int64(pageSize),
+	}
+	if progress.LastKey != "" {
+		inputParams.StartAfter = aws.String(progress.LastKey)
+	}
+
+	var sendErr error
+	listErr := s3Client.ListObjectsV2Pages(inputParams, func(page *s3.ListObjectsV2Output, morePages bool) bool {
+		for _, object := range page.Contents {
+			if *object.Size == 0 || !inTimeRange(object.LastModified, input.Start, input.End) {
+				continue
+			}
+			if sendErr = q.add(*integration.S3Bucket, object); sendErr != nil {
+				return false
+			}
+			if limit > 0 && stats.NumFiles+uint64(len(q.entries)) >= limit {
+				return false
+			}
+		}
+		return true // "To stop iterating, return false from the fn function."
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if listErr != nil {
+		return errors.Wrapf(listErr, "failed to list s3://%s/%s", *integration.S3Bucket, aws.StringValue(integration.S3Prefix))
+	}
+
+	// send remaining
+	if err := q.flush(); err != nil {
+		return err
+	}
+
+	if limit == 0 || stats.NumFiles < limit { // listing was not cut short, everything in range was sent
+		q.progress.Done = true
+		return writeProgress(input, q.progress)
+	}
+	return nil
+}
+
+func inTimeRange(lastModified *time.Time, start, end time.Time) bool {
+	if lastModified == nil {
+		return false
+	}
+	return !lastModified.Before(start) && lastModified.Before(end)
+}
+
+// queuer batches S3 notifications to the log processor queue, recording progress after each batch
+type queuer struct {
+	sqsClient     sqsiface.SQSAPI
+	queueURL      *string
+	topicARN      string
+	batchInterval time.Duration
+	lastSend      time.Time
+	input         *Input
+	progress      *Progress
+	stats         *Stats
+	entries       []*sqs.SendMessageBatchRequestEntry
+	lastKey       string
+	numBytes      uint64
+}
+
+func (q *queuer) add(bucket string, object *s3.Object) error {
+	message, err := newNotification(q.topicARN, bucket, *object.Key)
+	if err != nil {
+		return err
+	}
+	q.entries = append(q.entries, &sqs.SendMessageBatchRequestEntry{
+		Id:          aws.String(strconv.Itoa(len(q.entries))),
+		MessageBody: &message,
+	})
+	q.lastKey = *object.Key
+	q.numBytes += (uint64)(*object.Size)
+	if len(q.entries) == batchSize {
+		return q.flush()
+	}
+	return nil
+}
+
+func (q *queuer) flush() error {
+	if len(q.entries) == 0 {
+		return nil
+	}
+
+	// rate limit by spacing out batches
+	if q.batchInterval > 0 && !q.lastSend.IsZero() {
+		if wait := q.batchInterval - time.Since(q.lastSend); wait > 0 {
+			waitFunc(wait)
+		}
+	}
+	q.lastSend = time.Now()
+
+	_, err := sqsbatch.SendMessageBatch(q.sqsClient, batchTimeout, &sqs.SendMessageBatchInput{
+		QueueUrl: q.queueURL,
+		Entries:  q.entries,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to send batch ending with %s", q.lastKey)
+	}
+
+	for range q.entries {
+		q.stats.NumFiles++
+		if q.stats.NumFiles%progressNotify == 0 {
+			log.Printf("sent %d files ...", q.stats.NumFiles)
+		}
+	}
+	q.stats.NumBytes += q.numBytes
+	q.entries = q.entries[:0]
+	q.numBytes = 0
+
+	q.progress.LastKey = q.lastKey
+	q.progress.NumFiles = q.stats.NumFiles
+	q.progress.NumBytes = q.stats.NumBytes
+	return writeProgress(q.input, q.progress)
+}
+
+// newNotification makes a message that looks like an S3 notification delivered over SNS
+func newNotification(topicARN, bucket, key string) (string, error) {
+	s3Notification := &events.S3Event{
+		Records: []events.S3EventRecord{
+			{
+				S3: events.S3Entity{
+					Bucket: events.S3Bucket{
+						Name: bucket,
+					},
+					Object: events.S3Object{
+						Key: key,
+					},
+				},
+			},
+		},
+	}
+	ctnJSON, err := jsoniter.MarshalToString(s3Notification)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal %#v", s3Notification)
+	}
+
+	snsNotification := events.SNSEntity{
+		Type:     "Notification",
+		TopicArn: topicARN, // this is needed by the log processor to get account associated with the S3 object
+		Message:  ctnJSON,
+	}
+	message, err := jsoniter.MarshalToString(snsNotification)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal %#v", snsNotification)
+	}
+	return message, nil
+}
+
+// readProgress loads the progress of a previous run, if there is one
+func readProgress(input *Input) (*Progress, error) {
+	progress := &Progress{
+		IntegrationID: input.IntegrationID,
+		Start:         input.Start,
+		End:           input.End,
+	}
+	if input.ProgressFile == "" {
+		return progress, nil
+	}
+
+	data, err := ioutil.ReadFile(input.ProgressFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return progress, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read progress file %s", input.ProgressFile)
+	}
+
+	var previous Progress
+	if err = jsoniter.Unmarshal(data, &previous); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse progress file %s", input.ProgressFile)
+	}
+	if previous.IntegrationID != progress.IntegrationID ||
+		!previous.Start.Equal(progress.Start) || !previous.End.Equal(progress.End) {
+
+		return nil, errors.Errorf("progress file %s is for integration %s from %s to %s, remove it to start a new backfill",
+			input.ProgressFile, previous.IntegrationID, previous.Start, previous.End)
+	}
+	return &previous, nil
+}
+
+func writeProgress(input *Input, progress *Progress) error {
+	if input.ProgressFile == "" {
+		return nil
+	}
+	data, err := jsoniter.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %#v", progress)
+	}
+	// write then rename so an interrupt never leaves a partial file
+	tmpFile := input.ProgressFile + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write progress file %s", tmpFile)
+	}
+	if err = os.Rename(tmpFile, input.ProgressFile); err != nil {
+		return errors.Wrapf(err, "failed to rename %s to %s", tmpFile, input.ProgressFile)
+	}
+	return nil
+}
+
+func getIntegration(lambdaClient lambdaiface.LambdaAPI, integrationID string) (*models.SourceIntegration, error) {
+	input := &models.LambdaInput{
+		ListIntegrations: &models.ListIntegrationsInput{
+			IntegrationType: aws.String(models.IntegrationTypeAWS3),
+		},
+	}
+	var integrations []*models.SourceIntegration
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunction, input, &integrations); err != nil {
+		return nil, errors.Wrap(err, "failed to list integrations")
+	}
+	for _, integration := range integrations {
+		if aws.StringValue(integration.IntegrationID) == integrationID {
+			if integration.S3Bucket == nil || integration.AWSAccountID == nil {
+				return nil, errors.Errorf("integration %s has no s3 bucket configured", integrationID)
+			}
+			return integration, nil
+		}
+	}
+	return nil, errors.Errorf("no log analysis integration found with id %s", integrationID)
+}
+
+// newS3Client returns a client for the integration's bucket region using the log processing role (if any)
+func newS3Client(sess *session.Session, integration *models.SourceIntegration) (s3iface.S3API, error) {
+	config := aws.NewConfig()
+	if integration.LogProcessingRole != nil {
+		config = config.WithCredentials(stscreds.NewCredentials(sess, *integration.LogProcessingRole))
+	}
+
+	location, err := s3.New(sess, config).GetBucketLocation(&s3.GetBucketLocationInput{
+		Bucket: integration.S3Bucket,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find bucket region for %s", *integration.S3Bucket)
+	}
+	// location is nil for us-east-1
+	region := endpoints.UsEast1RegionID
+	if location.LocationConstraint != nil {
+		region = *location.LocationConstraint
+	}
+	return s3.New(sess, config.WithRegion(region)), nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/cmd/opstools/backfill"
+)
+
+const (
+	banner = "lists s3 objects of a log analysis integration in a time range and posts s3 notifications to log processor queue"
+)
+
+var (
+	REGION      = flag.String("region", "", "The AWS region (optional, defaults to session env vars) where Panther is deployed.")
+	INTEGRATION = flag.String("integration", "", "The id of the log analysis integration to backfill.")
+	START       = flag.String("start", "", "Backfill objects last modified at or after this time (RFC3339, e.g., 2020-01-01T00:00:00Z).")
+	END         = flag.String("end", "", "Backfill objects last modified before this time (RFC3339, optional, defaults to now, required with -progress).")
+	RATE        = flag.Float64("rate", 100, "The maximum number of files per second to send, 0 is unlimited.")
+	LIMIT       = flag.Uint64("limit", 0, "If non-zero, then limit the number of files to this number.")
+	PROGRESS    = flag.String("progress", "", "File to record progress in (optional), rerun with the same file to resume an interrupted backfill.")
+	TOQ         = flag.String("queue", "panther-input-data-notifications-queue", "The name of the log processor queue to send notifications.")
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(),
+		"%s %s\nUsage:\n",
+		filepath.Base(os.Args[0]), banner)
+	flag.PrintDefaults()
+}
+
+func init() {
+	flag.Usage = usage
+}
+
+func main() {
+	flag.Parse()
+
+	input := validateFlags()
+
+	sess, err := session.NewSession()
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	if *REGION != "" { //override
+		sess.Config.Region = REGION
+	}
+
+	startTime := time.Now()
+	log.Printf("sending files for integration %s last modified in [%s, %s) to %s",
+		input.IntegrationID, input.Start.Format(time.RFC3339), input.End.Format(time.RFC3339), *TOQ)
+
+	stats := &backfill.Stats{}
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGTERM, syscall.SIGINT)
+		caught := <-sig // wait for it
+		log.Fatalf("caught %v, sent %d files (%.2fMB) to %s in %v",
+			caught, stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), *TOQ, time.Since(startTime))
+	}()
+
+	err = backfill.Backfill(sess, input, stats)
+	if err != nil {
+		log.Fatal(err)
+	} else {
+		log.Printf("sent %d files (%.2fMB) to %s in %v",
+			stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), *TOQ, time.Since(startTime))
+	}
+}
+
+func validateFlags() *backfill.Input {
+	var err error
+	defer func() {
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			flag.Usage()
+			os.Exit(-2)
+		}
+	}()
+
+	input := &backfill.Input{
+		IntegrationID: *INTEGRATION,
+		End:           time.Now().UTC(),
+		QueueName:     *TOQ,
+		FilesPerSec:   *RATE,
+		Limit:         *LIMIT,
+		ProgressFile:  *PROGRESS,
+	}
+
+	if *INTEGRATION == "" {
+		err = errors.New("-integration not set")
+		return nil
+	}
+	if *START == "" {
+		err = errors.New("-start not set")
+		return nil
+	}
+	if input.Start, err = time.Parse(time.RFC3339, *START); err != nil {
+		err = errors.Wrap(err, "-start is not a valid time")
+		return nil
+	}
+	if *END == "" && *PROGRESS != "" { // defaulting to now would not match on resume
+		err = errors.New("-end must be set when using -progress")
+		return nil
+	}
+	if *END != "" {
+		if input.End, err = time.Parse(time.RFC3339, *END); err != nil {
+			err = errors.Wrap(err, "-end is not a valid time")
+			return nil
+		}
+	}
+	if !input.Start.Before(input.End) {
+		err = errors.New("-start must be before -end")
+		return nil
+	}
+	if *RATE < 0 {
+		err = errors.New("-rate must not be negative")
+		return nil
+	}
+	if *TOQ == "" {
+		err = errors.New("-queue not set")
+		return nil
+	}
+	return input
+}
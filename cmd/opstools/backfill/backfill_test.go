@@ -0,0 +1,220 @@
+package backfill
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+const (
+	testIntegrationID = "45c378a7-2e36-4b12-8e16-2d3c49ff1371"
+	testAccount       = "012345678912"
+	testBucket        = "foo"
+	testQueueName     = "testQueue"
+)
+
+var (
+	testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testIntegration = &models.SourceIntegration{
+		SourceIntegrationMetadata: &models.SourceIntegrationMetadata{
+			AWSAccountID:  aws.String(testAccount),
+			IntegrationID: aws.String(testIntegrationID),
+			S3Bucket:      aws.String(testBucket),
+		},
+	}
+)
+
+func init() {
+	waitFunc = func(time.Duration) {}
+}
+
+func testObject(key string, lastModified time.Time) *s3.Object {
+	return &s3.Object{
+		Key:          aws.String(key),
+		Size:         aws.Int64(1),
+		LastModified: aws.Time(lastModified),
+	}
+}
+
+func testInput() *Input {
+	return &Input{
+		IntegrationID: testIntegrationID,
+		Start:         testStart,
+		End:           testEnd,
+		QueueName:     testQueueName,
+	}
+}
+
+func TestBackfillTimeRange(t *testing.T) {
+	s3Client := &mockS3{}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			testObject("before", testStart.Add(-time.Second)),
+			testObject("start", testStart),
+			testObject("middle", testStart.Add(time.Hour)),
+			testObject("end", testEnd), // end is exclusive
+			{ // empty objects are skipped
+				Key:          aws.String("empty"),
+				Size:         aws.Int64(0),
+				LastModified: aws.Time(testStart),
+			},
+		},
+	}
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Once()
+	sqsClient := &mockSQS{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
+	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	stats := &Stats{}
+	err := backfill(s3Client, sqsClient, testIntegration, testInput(), stats)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(t, uint64(2), stats.NumFiles)
+	sent := sqsClient.Calls[1].Arguments.Get(0).(*sqs.SendMessageBatchInput)
+	require.Len(t, sent.Entries, 2)
+	assert.Contains(t, *sent.Entries[0].MessageBody, testAccount)
+	assert.Contains(t, *sent.Entries[0].MessageBody, "start")
+	assert.Contains(t, *sent.Entries[1].MessageBody, "middle")
+}
+
+func TestBackfillBatchAndRate(t *testing.T) {
+	var contents []*s3.Object
+	for i := 0; i < (2*batchSize)+1; i++ { // 2 full batches and one partial
+		contents = append(contents, testObject("key", testStart))
+	}
+	s3Client := &mockS3{}
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{Contents: contents}, nil).Once()
+	sqsClient := &mockSQS{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
+	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Times(3)
+
+	var waits []time.Duration
+	waitFunc = func(d time.Duration) { waits = append(waits, d) }
+	defer func() { waitFunc = func(time.Duration) {} }()
+
+	input := testInput()
+	input.FilesPerSec = 1 // 10 seconds per batch
+	stats := &Stats{}
+	err := backfill(s3Client, sqsClient, testIntegration, input, stats)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(t, uint64(len(contents)), stats.NumFiles)
+	require.Len(t, waits, 2) // no wait before the first batch
+	for _, wait := range waits {
+		assert.True(t, wait > 9*time.Second && wait <= 10*time.Second)
+	}
+}
+
+func TestBackfillResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backfill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	input := testInput()
+	input.ProgressFile = filepath.Join(dir, "progress.json")
+	input.Limit = 1
+
+	// first run stops at the limit
+	s3Client := &mockS3{}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			testObject("a", testStart),
+			testObject("b", testStart),
+		},
+	}
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Once()
+	sqsClient := &mockSQS{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
+	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	stats := &Stats{}
+	require.NoError(t, backfill(s3Client, sqsClient, testIntegration, input, stats))
+	assert.Equal(t, uint64(1), stats.NumFiles)
+	progress := readProgressFile(t, input.ProgressFile)
+	assert.Equal(t, "a", progress.LastKey)
+	assert.False(t, progress.Done)
+
+	// second run starts after the last key sent and completes
+	input.Limit = 0
+	s3Client = &mockS3{}
+	page = &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			testObject("b", testStart),
+		},
+	}
+	s3Client.On("ListObjectsV2Pages", &s3.ListObjectsV2Input{
+		Bucket:     aws.String(testBucket),
+		MaxKeys:    aws.Int64(pageSize),
+		StartAfter: aws.String("a"),
+	}, mock.Anything).Return(page, nil).Once()
+	sqsClient = &mockSQS{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
+	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	stats = &Stats{}
+	require.NoError(t, backfill(s3Client, sqsClient, testIntegration, input, stats))
+	s3Client.AssertExpectations(t)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(t, uint64(2), stats.NumFiles)
+	progress = readProgressFile(t, input.ProgressFile)
+	assert.Equal(t, "b", progress.LastKey)
+	assert.True(t, progress.Done)
+
+	// a different time range cannot reuse the progress file
+	input.Start = testStart.Add(time.Hour)
+	err = backfill(&mockS3{}, &mockSQS{}, testIntegration, input, &Stats{})
+	require.Error(t, err)
+}
+
+func readProgressFile(t *testing.T, path string) *Progress {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var progress Progress
+	require.NoError(t, jsoniter.Unmarshal(data, &progress))
+	return &progress
+}
+
+type mockS3 struct {
+	s3iface.S3API
+	mock.Mock
+}
+
+func (m *mockS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, f func(page *s3.ListObjectsV2Output, morePages bool) bool) error {
+	args := m.Called(input, f)
+	f(args.Get(0).(*s3.ListObjectsV2Output), false)
+	return args.Error(1)
+}
+
+type mockSQS struct {
+	sqsiface.SQSAPI
+	mock.Mock
+}
+
+// nolint (golint)
+func (m *mockSQS) GetQueueUrl(input *sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.GetQueueUrlOutput), args.Error(1)
+}
+
+func (m *mockSQS) SendMessageBatch(input *sqs.SendMessageBatchInput) (*sqs.SendMessageBatchOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.SendMessageBatchOutput), args.Error(1)
+}
@@ -20,6 +20,8 @@ package delivery
 
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -36,6 +38,9 @@ var (
 
 	outputClient outputs.API = outputs.New(awsSession)
 
+	// Every delivery attempt is recorded in the alert deliveries table
+	dynamoClient dynamodbiface.DynamoDBAPI = dynamodb.New(awsSession)
+
 	// Lazy-load the SQS client - we only need it to retry failed alerts
 	sqsClient sqsiface.SQSAPI
 )
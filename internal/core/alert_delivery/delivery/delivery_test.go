@@ -19,6 +19,8 @@ package delivery
  */
 
 import (
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/stretchr/testify/mock"
@@ -47,3 +49,23 @@ func (m *mockLambdaClient) Invoke(input *lambda.InvokeInput) (*lambda.InvokeOutp
 	args := m.Called(input)
 	return args.Get(0).(*lambda.InvokeOutput), args.Error(1)
 }
+
+type mockDynamoClient struct {
+	dynamodbiface.DynamoDBAPI
+}
+
+var deliveryItems []map[string]*dynamodb.AttributeValue // store delivery items here for tests to verify
+
+func (m mockDynamoClient) BatchWriteItem(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
+	for _, requests := range input.RequestItems {
+		for _, request := range requests {
+			deliveryItems = append(deliveryItems, request.PutRequest.Item)
+		}
+	}
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func init() {
+	// Delivery attempts are recorded by every dispatch
+	dynamoClient = &mockDynamoClient{}
+}
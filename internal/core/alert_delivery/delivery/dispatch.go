@@ -32,6 +32,8 @@ type outputStatus struct {
 	outputID   string
 	success    bool
 	needsRetry bool
+	message    string
+	statusCode int
 }
 
 // Send an alert to one specific output (run as a child goroutine).
@@ -47,7 +49,8 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		// Otherwise, the main routine will wait forever for this to finish.
 		if r := recover(); r != nil {
 			zap.L().Error("panic sending alert", append(commonFields, zap.Any("panic", r))...)
-			statusChannel <- outputStatus{outputID: *output.OutputID, success: false, needsRetry: false}
+			statusChannel <- outputStatus{
+				outputID: *output.OutputID, success: false, needsRetry: false, message: "panic sending alert"}
 		}
 	}()
 
@@ -76,15 +79,23 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		alertDeliveryError = outputClient.Sns(alert, output.OutputConfig.Sns)
 	case "asana":
 		alertDeliveryError = outputClient.Asana(alert, output.OutputConfig.Asana)
+	case "customwebhook":
+		alertDeliveryError = outputClient.CustomWebhook(alert, output.OutputConfig.CustomWebhook)
 	default:
 		zap.L().Warn("unsupported output type", commonFields...)
-		statusChannel <- outputStatus{outputID: *output.OutputID, success: false, needsRetry: false}
+		statusChannel <- outputStatus{
+			outputID: *output.OutputID, success: false, needsRetry: false, message: "unsupported output type"}
 		return
 	}
 	if alertDeliveryError != nil {
 		zap.L().Warn("failed to send alert", append(commonFields, zap.Error(alertDeliveryError))...)
 		statusChannel <- outputStatus{
-			outputID: *output.OutputID, success: false, needsRetry: !alertDeliveryError.Permanent}
+			outputID:   *output.OutputID,
+			success:    false,
+			needsRetry: !alertDeliveryError.Permanent,
+			message:    alertDeliveryError.Message,
+			statusCode: alertDeliveryError.StatusCode,
+		}
 		return
 	}
 
@@ -92,13 +103,22 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 	statusChannel <- outputStatus{outputID: *output.OutputID, success: true, needsRetry: false}
 }
 
-// Dispatch sends the alert to each of its designated outputs.
+// Dispatch sends the alert to each of its designated outputs and records the delivery attempts.
 //
-// Returns true if the alert was sent successfully, false if it needs to be retried.
+// Returns true if the alert delivery is over, false if it needs to be retried for the outputs left in alert.OutputIDs.
 func dispatch(alert *alertmodels.Alert) bool {
 	outputs, err := getAlertOutputs(alert)
 
 	if err != nil {
+		if retryExpired(alert) {
+			zap.L().Error("alert delivery permanently failed, exceeded max retry duration",
+				zap.Time("alertCreatedAt", *alert.CreatedAt),
+				zap.String("policyId", *alert.PolicyID),
+				zap.String("severity", *alert.Severity),
+				zap.Error(err),
+			)
+			return true
+		}
 		zap.L().Warn("failed to get the outputs for the alert",
 			zap.String("policyId", *alert.PolicyID),
 			zap.String("severity", *alert.Severity),
@@ -123,18 +143,36 @@ func dispatch(alert *alertmodels.Alert) bool {
 	}
 
 	// Wait until all outputs have finished, gathering any that need to be retried.
+	if alert.DeliveryAttempts == nil {
+		alert.DeliveryAttempts = make(map[string]int, len(outputs))
+	}
 	var retryOutputs []*string
+	deliveries := make([]*outputmodels.AlertDelivery, 0, len(outputs))
 	for range outputs {
 		status := <-statusChannel
-		if status.needsRetry {
+		alert.DeliveryAttempts[status.outputID]++
+		delivery := newDelivery(alert, &status)
+
+		switch {
+		case status.success:
+			delivery.Status = aws.String(outputmodels.DeliverySucceeded)
+		case status.needsRetry && !retryExhausted(alert, status.outputID):
+			delivery.Status = aws.String(outputmodels.DeliveryRetrying)
 			retryOutputs = append(retryOutputs, aws.String(status.outputID))
-		} else if !status.success {
+		default:
+			delivery.Status = aws.String(outputmodels.DeliveryFailed)
 			zap.L().Error(
 				"permanently failed to send alert to output",
 				zap.String("outputID", status.outputID),
+				zap.Int("attempts", delivery.Attempt),
+				zap.Time("alertCreatedAt", *alert.CreatedAt),
+				zap.String("policyId", *alert.PolicyID),
+				zap.String("severity", *alert.Severity),
 			)
 		}
+		deliveries = append(deliveries, delivery)
 	}
+	storeDeliveries(deliveries)
 
 	if len(retryOutputs) > 0 {
 		alert.OutputIDs = retryOutputs // Replace the outputs with the set that failed
@@ -19,10 +19,12 @@ package delivery
  */
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -69,7 +71,7 @@ func TestSendPanic(t *testing.T) {
 		panic("panicking")
 	})
 	go send(sampleAlert(), alertOutput, ch)
-	require.Equal(t, outputStatus{outputID: *alertOutput.OutputID}, <-ch)
+	require.Equal(t, outputStatus{outputID: *alertOutput.OutputID, message: "panic sending alert"}, <-ch)
 	mockOutputsClient.AssertExpectations(t)
 }
 
@@ -80,7 +82,7 @@ func TestSendUnsupportedOutput(t *testing.T) {
 	ch := make(chan outputStatus, 1)
 
 	send(sampleAlert(), alertOutput, ch)
-	assert.Equal(t, outputStatus{outputID: *alertOutput.OutputID}, <-ch)
+	assert.Equal(t, outputStatus{outputID: *alertOutput.OutputID, message: "panic sending alert"}, <-ch)
 	mockClient.AssertExpectations(t)
 }
 
@@ -89,10 +91,12 @@ func TestSendTransientFailure(t *testing.T) {
 	outputClient = mockClient
 	setCaches()
 	ch := make(chan outputStatus, 1)
-	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(
+		&outputs.AlertDeliveryError{Message: "request failed", StatusCode: 503})
 
 	send(sampleAlert(), alertOutput, ch)
-	assert.Equal(t, outputStatus{outputID: *alertOutput.OutputID, needsRetry: true}, <-ch)
+	assert.Equal(t, outputStatus{
+		outputID: *alertOutput.OutputID, needsRetry: true, message: "request failed", statusCode: 503}, <-ch)
 	mockClient.AssertExpectations(t)
 }
 
@@ -118,12 +122,85 @@ func TestDispatchFailure(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDispatchRecordsDeliveries(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	setCaches()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(
+		&outputs.AlertDeliveryError{Message: "request failed", StatusCode: 503})
+	deliveryItems = nil
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	assert.False(t, dispatch(alert))
+	assert.False(t, dispatch(alert))
+	assert.Equal(t, map[string]int{"output-id": 2}, alert.DeliveryAttempts)
+	assert.Equal(t, []*string{aws.String("output-id")}, alert.OutputIDs)
+
+	require.Len(t, deliveryItems, 2)
+	var delivery deliveryItem
+	require.NoError(t, dynamodbattribute.UnmarshalMap(deliveryItems[1], &delivery))
+	assert.Equal(t, "output-id", *delivery.OutputID)
+	assert.Equal(t, "alert-id", *delivery.AlertID)
+	assert.Equal(t, "test-rule-id", *delivery.PolicyID)
+	assert.Equal(t, 2, delivery.Attempt)
+	assert.Equal(t, outputmodels.DeliveryRetrying, *delivery.Status)
+	assert.Equal(t, 503, delivery.StatusCode)
+	assert.Equal(t, "request failed", *delivery.Error)
+	assert.True(t, strings.HasSuffix(*delivery.DeliveryID, ":alert-id:2"))
+	assert.Equal(t, delivery.AttemptedAt.Add(deliveryRetention).Unix(), delivery.ExpiresAt)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDispatchMaxAttemptsExceeded(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	setCaches()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
+	deliveryItems = nil
+
+	alert := sampleAlert()
+	alert.DeliveryAttempts = map[string]int{"output-id": getMaxDeliveryAttempts() - 1}
+	assert.True(t, dispatch(alert))
+
+	require.Len(t, deliveryItems, 1)
+	var delivery deliveryItem
+	require.NoError(t, dynamodbattribute.UnmarshalMap(deliveryItems[0], &delivery))
+	assert.Equal(t, getMaxDeliveryAttempts(), delivery.Attempt)
+	assert.Equal(t, outputmodels.DeliveryFailed, *delivery.Status)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDispatchPermanentFailure(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	setCaches()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{Permanent: true})
+	deliveryItems = nil
+
+	assert.True(t, dispatch(sampleAlert()))
+
+	require.Len(t, deliveryItems, 1)
+	var delivery deliveryItem
+	require.NoError(t, dynamodbattribute.UnmarshalMap(deliveryItems[0], &delivery))
+	assert.Equal(t, outputmodels.DeliveryFailed, *delivery.Status)
+	mockClient.AssertExpectations(t)
+}
+
 func TestDispatchSuccess(t *testing.T) {
 	mockClient := &mockOutputsClient{}
 	outputClient = mockClient
 	setCaches()
 	mockClient.On("Slack", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+	deliveryItems = nil
+
 	assert.True(t, dispatch(sampleAlert()))
+
+	require.Len(t, deliveryItems, 1)
+	var delivery deliveryItem
+	require.NoError(t, dynamodbattribute.UnmarshalMap(deliveryItems[0], &delivery))
+	assert.Equal(t, outputmodels.DeliverySucceeded, *delivery.Status)
+	assert.Nil(t, delivery.Error)
 }
 
 func TestDispatchUseCachedDefault(t *testing.T) {
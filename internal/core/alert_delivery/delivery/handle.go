@@ -38,7 +38,29 @@ func mustParseInt(text string) int {
 }
 
 func getMaxRetryDuration() time.Duration {
-	return time.Duration(mustParseInt(os.Getenv("ALERT_RETRY_DURATION_MINS"))) * time.Minute
+	durationMins := os.Getenv("ALERT_RETRY_DURATION_MINS")
+	if durationMins == "" {
+		durationMins = "30"
+	}
+	return time.Duration(mustParseInt(durationMins)) * time.Minute
+}
+
+func getMaxDeliveryAttempts() int {
+	attempts := os.Getenv("MAX_DELIVERY_ATTEMPTS")
+	if attempts == "" {
+		attempts = "10"
+	}
+	return mustParseInt(attempts)
+}
+
+// retryExpired returns true if the alert is too old to be retried
+func retryExpired(alert *models.Alert) bool {
+	return time.Since(*alert.CreatedAt) > getMaxRetryDuration()
+}
+
+// retryExhausted returns true if the alert must not be sent to the output anymore
+func retryExhausted(alert *models.Alert, outputID string) bool {
+	return alert.DeliveryAttempts[outputID] >= getMaxDeliveryAttempts() || retryExpired(alert)
 }
 
 // HandleAlerts sends each alert to its outputs and puts failed alerts back on the queue to retry.
@@ -49,21 +71,12 @@ func HandleAlerts(alerts []*models.Alert) {
 
 	for _, alert := range alerts {
 		if !dispatch(alert) {
-			if time.Since(*alert.CreatedAt) > getMaxRetryDuration() {
-				zap.L().Error(
-					"alert delivery permanently failed, exceeded max retry duration",
-					zap.Strings("failedOutputs", aws.StringValueSlice(alert.OutputIDs)),
-					zap.Time("alertCreatedAt", *alert.CreatedAt),
-					zap.String("policyId", *alert.PolicyID),
-					zap.String("severity", *alert.Severity),
-				)
-			} else {
-				zap.L().Warn("will retry delivery of alert",
-					zap.String("policyId", *alert.PolicyID),
-					zap.String("severity", *alert.Severity),
-				)
-				failedAlerts = append(failedAlerts, alert)
-			}
+			zap.L().Warn("will retry delivery of alert",
+				zap.Strings("failedOutputs", aws.StringValueSlice(alert.OutputIDs)),
+				zap.String("policyId", *alert.PolicyID),
+				zap.String("severity", *alert.Severity),
+			)
+			failedAlerts = append(failedAlerts, splitByOutput(alert)...)
 		}
 	}
 
@@ -71,3 +84,17 @@ func HandleAlerts(alerts []*models.Alert) {
 		retry(failedAlerts)
 	}
 }
+
+// splitByOutput returns a copy of the alert for each of its outputs, so that each output is retried with its own backoff.
+func splitByOutput(alert *models.Alert) []*models.Alert {
+	if len(alert.OutputIDs) <= 1 {
+		return []*models.Alert{alert}
+	}
+	result := make([]*models.Alert, len(alert.OutputIDs))
+	for i, outputID := range alert.OutputIDs {
+		outputAlert := *alert
+		outputAlert.OutputIDs = []*string{outputID}
+		result[i] = &outputAlert
+	}
+	return result
+}
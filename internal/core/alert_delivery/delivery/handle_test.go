@@ -23,8 +23,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
@@ -70,3 +72,17 @@ func TestHandleAlertsTemporarilyFailed(t *testing.T) {
 	HandleAlerts(alerts)
 	assert.Equal(t, 3, sqsMessages)
 }
+
+func TestSplitByOutput(t *testing.T) {
+	alert := sampleAlert()
+	alert.OutputIDs = aws.StringSlice([]string{"output-id-1", "output-id-2"})
+
+	result := splitByOutput(alert)
+	require.Len(t, result, 2)
+	assert.Equal(t, aws.StringSlice([]string{"output-id-1"}), result[0].OutputIDs)
+	assert.Equal(t, aws.StringSlice([]string{"output-id-2"}), result[1].OutputIDs)
+	assert.Equal(t, alert.PolicyID, result[1].PolicyID)
+
+	alert = sampleAlert()
+	assert.Equal(t, []*models.Alert{alert}, splitByOutput(alert))
+}
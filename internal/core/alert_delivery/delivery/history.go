@@ -0,0 +1,108 @@
+package delivery
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"go.uber.org/zap"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+const (
+	maxDynamoBackoff = 30 * time.Second
+
+	// The delivery attempts are deleted from the table after this period
+	deliveryRetention = 30 * 24 * time.Hour
+
+	// Fixed-width timestamps so that the delivery IDs sort in chronological order
+	deliveryIDTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+// deliveryItem is the delivery attempt stored in the alert deliveries table.
+type deliveryItem struct {
+	outputmodels.AlertDelivery
+
+	// ExpiresAt is the time in epoch seconds after which Dynamo deletes the item
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// newDelivery creates the record of the last attempt to send the alert to the output of the status.
+func newDelivery(alert *alertmodels.Alert, status *outputStatus) *outputmodels.AlertDelivery {
+	attempt := alert.DeliveryAttempts[status.outputID]
+	attemptedAt := time.Now().UTC()
+
+	// Policy failures don't have an alert ID, they are identified by their policy
+	alertKey := aws.StringValue(alert.AlertID)
+	if alertKey == "" {
+		alertKey = aws.StringValue(alert.PolicyID)
+	}
+
+	delivery := &outputmodels.AlertDelivery{
+		OutputID:    aws.String(status.outputID),
+		DeliveryID:  aws.String(attemptedAt.Format(deliveryIDTimeLayout) + ":" + alertKey + ":" + strconv.Itoa(attempt)),
+		AlertID:     alert.AlertID,
+		PolicyID:    alert.PolicyID,
+		Attempt:     attempt,
+		AttemptedAt: &attemptedAt,
+		StatusCode:  status.statusCode,
+	}
+	if status.message != "" {
+		delivery.Error = aws.String(status.message)
+	}
+	return delivery
+}
+
+// storeDeliveries records the delivery attempts in the alert deliveries table.
+//
+// Failing to record them doesn't fail the delivery of the alerts, the error is only logged.
+func storeDeliveries(deliveries []*outputmodels.AlertDelivery) {
+	if len(deliveries) == 0 {
+		return
+	}
+
+	tableName := os.Getenv("DELIVERIES_TABLE_NAME")
+	requests := make([]*dynamodb.WriteRequest, len(deliveries))
+	for i, delivery := range deliveries {
+		item, err := dynamodbattribute.MarshalMap(&deliveryItem{
+			AlertDelivery: *delivery,
+			ExpiresAt:     delivery.AttemptedAt.Add(deliveryRetention).Unix(),
+		})
+		if err != nil {
+			zap.L().Error("failed to marshal alert delivery", zap.Error(err))
+			return
+		}
+		requests[i] = &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: item}}
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{tableName: requests},
+	}
+	if err := dynamodbbatch.BatchWriteItem(dynamoClient, maxDynamoBackoff, input); err != nil {
+		zap.L().Error("failed to store alert deliveries", zap.Error(err))
+	}
+}
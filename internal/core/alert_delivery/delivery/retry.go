@@ -33,14 +33,47 @@ import (
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 )
 
-const maxSQSBackoff = 30 * time.Second
+const (
+	maxSQSBackoff = 30 * time.Second
+
+	// SQS does not allow delaying a message for longer than 15 minutes
+	maxSQSDelaySeconds = 900
+)
 
 // Generate a random int between lower (inclusive) and upper (exclusive).
 func randomInt(lower, upper int) int {
 	return rand.Intn(upper-lower) + lower
 }
 
-// retry a batch of failed outputs by putting them all back on the queue with random delays.
+// retryDelay returns the number of seconds to wait before sending the alert to its outputs again.
+//
+// The delay doubles with each delivery attempt, starting from minDelaySeconds and up to maxDelaySeconds,
+// and is randomized so that the alerts which failed together are not retried together.
+func retryDelay(alert *models.Alert, minDelaySeconds, maxDelaySeconds int) int {
+	attempts := 1
+	for _, outputID := range alert.OutputIDs {
+		if outputAttempts := alert.DeliveryAttempts[*outputID]; outputAttempts > attempts {
+			attempts = outputAttempts
+		}
+	}
+
+	backoff := minDelaySeconds
+	for i := 1; i < attempts && backoff < maxDelaySeconds; i++ {
+		backoff *= 2
+	}
+	if backoff > maxDelaySeconds {
+		backoff = maxDelaySeconds
+	}
+	if backoff > maxSQSDelaySeconds {
+		backoff = maxSQSDelaySeconds
+	}
+	if minDelaySeconds > backoff {
+		minDelaySeconds = backoff
+	}
+	return randomInt(minDelaySeconds, backoff+1)
+}
+
+// retry a batch of failed outputs by putting them all back on the queue with exponential backoff delays.
 func retry(alerts []*models.Alert) {
 	zap.L().Warn("queueing failed alerts for future retry", zap.Int("failedAlerts", len(alerts)))
 	input := &sqs.SendMessageBatchInput{
@@ -59,7 +92,7 @@ func retry(alerts []*models.Alert) {
 		}
 
 		input.Entries[i] = &sqs.SendMessageBatchRequestEntry{
-			DelaySeconds: aws.Int64(int64(randomInt(minDelaySeconds, maxDelaySeconds))),
+			DelaySeconds: aws.Int64(int64(retryDelay(alert, minDelaySeconds, maxDelaySeconds))),
 			Id:           aws.String(strconv.Itoa(i)),
 			MessageBody:  aws.String(body),
 		}
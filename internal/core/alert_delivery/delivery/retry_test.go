@@ -20,9 +20,11 @@ package delivery
 
 import (
 	"errors"
+	"testing"
 
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/assert"
 )
 
 type mockSQSClient struct {
@@ -41,3 +43,20 @@ func (m mockSQSClient) SendMessageBatch(input *sqs.SendMessageBatchInput) (*sqs.
 		Successful: make([]*sqs.SendMessageBatchResultEntry, len(input.Entries)),
 	}, nil
 }
+
+func TestRetryDelay(t *testing.T) {
+	alert := sampleAlert()
+	assert.Equal(t, 10, retryDelay(alert, 10, 300))
+
+	alert.DeliveryAttempts = map[string]int{"output-id": 3}
+	delay := retryDelay(alert, 10, 300)
+	assert.True(t, delay >= 10 && delay <= 40)
+
+	alert.DeliveryAttempts = map[string]int{"output-id": 20}
+	delay = retryDelay(alert, 10, 300)
+	assert.True(t, delay >= 10 && delay <= 300)
+
+	// SQS limits the delay to 15 minutes
+	delay = retryDelay(alert, 10, 86400)
+	assert.True(t, delay >= 10 && delay <= 900)
+}
@@ -64,4 +64,10 @@ type Alert struct {
 
 	// Title is the optional title for the alert
 	Title *string `json:"title,omitempty"`
+
+	// DeliveryAttempts is the number of times the alert was sent to each output ID so far.
+	DeliveryAttempts map[string]int `json:"deliveryAttempts,omitempty"`
+
+	// IsUpdate specifies if the alert was already delivered and this is a re-notification
+	IsUpdate bool `json:"isUpdate,omitempty"`
 }
@@ -0,0 +1,74 @@
+package outputs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+// bodyTemplateAlertFuncs bind the alert functions of the body templates of custom webhooks
+var bodyTemplateAlertFuncs = template.FuncMap{
+	"alertTitle": generateAlertTitle,
+	"alertURL":   generateURL,
+}
+
+// CustomWebhook sends an alert to a user-defined HTTP endpoint.
+//
+// The body of the request is the given template rendered with the alert, or the alert encoded as JSON.
+func (client *OutputClient) CustomWebhook(
+	alert *alertmodels.Alert, config *outputmodels.CustomWebhookConfig) *AlertDeliveryError {
+
+	var payload []byte
+	if config.BodyTemplate == nil {
+		var err error
+		if payload, err = jsoniter.Marshal(alert); err != nil {
+			return &AlertDeliveryError{Message: "json marshal error: " + err.Error(), Permanent: true}
+		}
+	} else {
+		bodyTemplate, err := outputmodels.ParseBodyTemplate(*config.BodyTemplate)
+		if err != nil {
+			return &AlertDeliveryError{Message: "invalid body template: " + err.Error(), Permanent: true}
+		}
+		var body bytes.Buffer
+		if err = bodyTemplate.Funcs(bodyTemplateAlertFuncs).Execute(&body, alert); err != nil {
+			return &AlertDeliveryError{Message: "failed to render body template: " + err.Error(), Permanent: true}
+		}
+		payload = body.Bytes()
+	}
+
+	headers := make(map[string]string, len(config.Headers))
+	for _, header := range config.Headers {
+		headers[aws.StringValue(header.Name)] = aws.StringValue(header.Value)
+	}
+
+	postInput := &PostInput{
+		url:           *config.WebhookURL,
+		payload:       payload,
+		headers:       headers,
+		signingSecret: aws.StringValue(config.SigningSecret),
+	}
+	return client.httpWrapper.post(postInput)
+}
@@ -0,0 +1,106 @@
+package outputs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+func customWebhookAlert() *alertmodels.Alert {
+	createdAtTime, _ := time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+	return &alertmodels.Alert{
+		AlertID:    aws.String("alertId"),
+		PolicyID:   aws.String("ruleId"),
+		CreatedAt:  &createdAtTime,
+		PolicyName: aws.String("ruleName"),
+		Severity:   aws.String("HIGH"),
+		Type:       aws.String(alertmodels.RuleType),
+		Title:      aws.String(`"quoted" title`),
+	}
+}
+
+func TestCustomWebhookAlert(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	alert := customWebhookAlert()
+
+	payload, err := jsoniter.Marshal(alert)
+	require.NoError(t, err)
+	expectedPostInput := &PostInput{
+		url:     "https://soar.example.com",
+		payload: payload,
+		headers: map[string]string{},
+	}
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+
+	config := &outputmodels.CustomWebhookConfig{WebhookURL: aws.String("https://soar.example.com")}
+	require.Nil(t, client.CustomWebhook(alert, config))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestCustomWebhookAlertWithTemplate(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	expectedPostInput := &PostInput{
+		url: "https://soar.example.com",
+		payload: []byte(`{"title": "New Alert: \"quoted\" title", "rawTitle": "quoted" title, ` +
+			`"severity": "HIGH", "url": "https://panther.io/alerts/alertId"}`),
+		headers: map[string]string{
+			"Authorization": "Bearer token",
+			"Content-Type":  "application/json; charset=utf-8",
+		},
+		signingSecret: "secret",
+	}
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+
+	config := &outputmodels.CustomWebhookConfig{
+		WebhookURL: aws.String("https://soar.example.com"),
+		Headers: []*outputmodels.WebhookHeader{
+			{Name: aws.String("Authorization"), Value: aws.String("Bearer token")},
+			{Name: aws.String("Content-Type"), Value: aws.String("application/json; charset=utf-8")},
+		},
+		BodyTemplate: aws.String(`{"title": {{json (alertTitle .)}}, "rawTitle": {{.Title}}, ` +
+			`"severity": {{json .Severity}}, "url": {{json (alertURL .)}}}`),
+		SigningSecret: aws.String("secret"),
+	}
+	require.Nil(t, client.CustomWebhook(customWebhookAlert(), config))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestCustomWebhookAlertTemplateError(t *testing.T) {
+	client := &OutputClient{httpWrapper: &mockHTTPWrapper{}}
+
+	config := &outputmodels.CustomWebhookConfig{
+		WebhookURL:   aws.String("https://soar.example.com"),
+		BodyTemplate: aws.String(`{{.NotAnAlertField}}`),
+	}
+	result := client.CustomWebhook(customWebhookAlert(), config)
+	require.NotNil(t, result)
+	assert.True(t, result.Permanent)
+}
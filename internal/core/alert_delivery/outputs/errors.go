@@ -27,6 +27,9 @@ type AlertDeliveryError struct {
 	// For example, outputs which don't exist or errors creating the request are permanent failures.
 	// But any error talking to the output itself can be retried by the Lambda function later.
 	Permanent bool
+
+	// StatusCode is the HTTP status code returned by the output, if the request was sent.
+	StatusCode int
 }
 
 func (e *AlertDeliveryError) Error() string { return e.Message }
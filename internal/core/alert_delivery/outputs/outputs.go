@@ -44,9 +44,13 @@ type HTTPWrapper struct {
 
 // PostInput type
 type PostInput struct {
-	url     string
-	body    map[string]interface{}
+	url  string
+	body map[string]interface{}
+	// payload is sent instead of the JSON encoding of body if it is set
+	payload []byte
 	headers map[string]string
+	// signingSecret is the key used to sign the payload with HMAC-SHA256 if it is set
+	signingSecret string
 }
 
 // HTTPWrapperiface is the interface for our wrapper around Golang's http client
@@ -70,6 +74,7 @@ type API interface {
 	Sqs(*alertmodels.Alert, *outputmodels.SqsConfig) *AlertDeliveryError
 	Sns(*alertmodels.Alert, *outputmodels.SnsConfig) *AlertDeliveryError
 	Asana(*alertmodels.Alert, *outputmodels.AsanaConfig) *AlertDeliveryError
+	CustomWebhook(*alertmodels.Alert, *outputmodels.CustomWebhookConfig) *AlertDeliveryError
 }
 
 // OutputClient encapsulates the clients that allow sending alerts to multiple outputs
@@ -116,11 +121,15 @@ func generateDetailedAlertMessage(alert *alertmodels.Alert) string {
 }
 
 func generateAlertTitle(alert *alertmodels.Alert) string {
+	prefix := "New Alert: "
+	if alert.IsUpdate {
+		prefix = "Alert Update: "
+	}
 	if alert.Title != nil {
-		return "New Alert: " + *alert.Title
+		return prefix + *alert.Title
 	}
 	if aws.StringValue(alert.Type) == alertmodels.RuleType {
-		return "New Alert: " + getDisplayName(alert)
+		return prefix + getDisplayName(alert)
 	}
 	return "Policy Failure: " + getDisplayName(alert)
 }
@@ -67,6 +67,15 @@ func TestGenerateAlertTitleRulePolicyId(t *testing.T) {
 	assert.Equal(t, "New Alert: rule.id", generateAlertTitle(alert))
 }
 
+func TestGenerateAlertTitleUpdate(t *testing.T) {
+	alert := &alertModel.Alert{
+		Type:     aws.String(alertModel.RuleType),
+		Title:    aws.String("my title"),
+		IsUpdate: true,
+	}
+	assert.Equal(t, "Alert Update: my title", generateAlertTitle(alert))
+}
+
 func TestGenerateAlertTitlePolicyName(t *testing.T) {
 	alert := &alertModel.Alert{
 		Type:       aws.String(alertModel.PolicyType),
@@ -20,6 +20,9 @@ package outputs
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"io/ioutil"
 	"net/http"
 
@@ -28,13 +31,17 @@ import (
 
 const (
 	AuthorizationHTTPHeader = "Authorization"
+	SignatureHTTPHeader     = "X-Panther-Signature"
 )
 
 // post sends a JSON body to an endpoint.
 func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryError {
-	payload, err := jsoniter.Marshal(input.body)
-	if err != nil {
-		return &AlertDeliveryError{Message: "json marshal error: " + err.Error(), Permanent: true}
+	payload := input.payload
+	if payload == nil {
+		var err error
+		if payload, err = jsoniter.Marshal(input.body); err != nil {
+			return &AlertDeliveryError{Message: "json marshal error: " + err.Error(), Permanent: true}
+		}
 	}
 
 	request, err := http.NewRequest("POST", input.url, bytes.NewBuffer(payload))
@@ -50,6 +57,11 @@ func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryError {
 		request.Header.Set(key, value)
 	}
 
+	// The signature is set last so that it can't be overwritten by the dynamic headers
+	if input.signingSecret != "" {
+		request.Header.Set(SignatureHTTPHeader, signPayload(input.signingSecret, payload))
+	}
+
 	response, err := client.httpClient.Do(request)
 	if err != nil {
 		return &AlertDeliveryError{Message: "network error: " + err.Error()}
@@ -59,8 +71,15 @@ func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryError {
 	if response.StatusCode < 200 || response.StatusCode > 299 {
 		body, _ := ioutil.ReadAll(response.Body)
 		return &AlertDeliveryError{
-			Message: "request failed: " + response.Status + ": " + string(body)}
+			Message: "request failed: " + response.Status + ": " + string(body), StatusCode: response.StatusCode}
 	}
 
 	return nil
 }
+
+// signPayload returns the HMAC-SHA256 signature of the payload in the format "sha256=<hex digest>"
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload) // nolint: errcheck (never returns an error)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -30,9 +30,10 @@ import (
 
 type mockHTTPClient struct {
 	HTTPiface
-	statusCode   int
-	requestError bool
-	requestBody  string // Request body is saved here for tests to verify
+	statusCode    int
+	requestError  bool
+	requestBody   string // Request body is saved here for tests to verify
+	requestHeader http.Header
 }
 
 var requestEndpoint = "https://runpanther.io"
@@ -46,6 +47,7 @@ func (m *mockHTTPClient) Do(request *http.Request) (*http.Response, error) {
 		panic(err)
 	}
 	m.requestBody = string(requestBytes)
+	m.requestHeader = request.Header
 
 	responseBody := ioutil.NopCloser(bytes.NewReader([]byte("response")))
 	return &http.Response{Body: responseBody, StatusCode: m.statusCode}, nil
@@ -76,7 +78,9 @@ func TestPostNotOk(t *testing.T) {
 		url:  requestEndpoint,
 		body: map[string]interface{}{"abc": 123},
 	}
-	assert.NotNil(t, c.post(postInput))
+	alertDeliveryError := c.post(postInput)
+	assert.NotNil(t, alertDeliveryError)
+	assert.Equal(t, http.StatusBadRequest, alertDeliveryError.StatusCode)
 }
 
 func TestPostOk(t *testing.T) {
@@ -96,3 +100,32 @@ func TestPostCreated(t *testing.T) {
 	}
 	assert.Nil(t, c.post(postInput))
 }
+
+func TestPostPayload(t *testing.T) {
+	httpClient := &mockHTTPClient{statusCode: http.StatusOK}
+	c := &HTTPWrapper{httpClient: httpClient}
+	postInput := &PostInput{
+		url:     requestEndpoint,
+		payload: []byte("raw payload"),
+		headers: map[string]string{"Content-Type": "text/plain"},
+	}
+	assert.Nil(t, c.post(postInput))
+	assert.Equal(t, "raw payload", httpClient.requestBody)
+	assert.Equal(t, "text/plain", httpClient.requestHeader.Get("Content-Type"))
+	assert.Empty(t, httpClient.requestHeader.Get(SignatureHTTPHeader))
+}
+
+func TestPostSigned(t *testing.T) {
+	httpClient := &mockHTTPClient{statusCode: http.StatusOK}
+	c := &HTTPWrapper{httpClient: httpClient}
+	postInput := &PostInput{
+		url:           requestEndpoint,
+		payload:       []byte(`{"abc":123}`),
+		headers:       map[string]string{SignatureHTTPHeader: "forged"},
+		signingSecret: "secret",
+	}
+	assert.Nil(t, c.post(postInput))
+	// echo -n '{"abc":123}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=e2a153a0a9e026d4029170fb19d5c638dbccf0816c67592546443ecf3c7e0335",
+		httpClient.requestHeader.Get(SignatureHTTPHeader))
+}
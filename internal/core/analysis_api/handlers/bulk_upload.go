@@ -221,6 +221,18 @@ func extractZipFile(input *models.BulkUpload) (map[models.ID]*tableItem, error)
 				analysisItem.DedupPeriodMinutes = models.DedupPeriodMinutes(config.DedupPeriodMinutes)
 			}
 
+			if config.AlertSettings != nil {
+				analysisItem.AlertSettings = &models.AlertSettings{
+					DedupFields:                  config.AlertSettings.DedupFields,
+					RenotifyEventCount:           config.AlertSettings.RenotifyEventCount,
+					RenotifyOnSeverityEscalation: config.AlertSettings.RenotifyOnSeverityEscalation,
+					Threshold:                    config.AlertSettings.Threshold,
+				}
+				if err := analysisItem.AlertSettings.Validate(nil); err != nil {
+					return nil, fmt.Errorf("rule ID %s has invalid alert settings: %s", analysisItem.ID, err)
+				}
+			}
+
 			// These "syntax sugar" re-mappings are to make managing rules from the CLI more intuitive
 			if config.PolicyID == "" {
 				analysisItem.ID = models.ID(config.RuleID)
@@ -54,6 +54,7 @@ func CreateRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyR
 		Tests:              input.Tests,
 		Type:               typeRule,
 		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		AlertSettings:      input.AlertSettings,
 	}
 
 	if _, err := writeItem(item, input.UserID, aws.Bool(false)); err != nil {
@@ -66,6 +66,7 @@ type tableItem struct {
 	Tests                     []*models.UnitTest               `json:"tests,omitempty"`
 	VersionID                 models.VersionID                 `json:"versionId,omitempty"`
 	DedupPeriodMinutes        models.DedupPeriodMinutes        `json:"dedupPeriodMinutes,omitempty"`
+	AlertSettings             *models.AlertSettings            `json:"alertSettings,omitempty"`
 
 	// Logic type (policy or rule)
 	Type string `json:"type"`
@@ -160,6 +161,7 @@ func (r *tableItem) Rule() *models.Rule {
 		Tests:              r.Tests,
 		VersionID:          r.VersionID,
 		DedupPeriodMinutes: r.DedupPeriodMinutes,
+		AlertSettings:      r.AlertSettings,
 	}
 	gatewayapi.ReplaceMapSliceNils(result)
 	return result
@@ -48,6 +48,18 @@ func ModifyRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyR
 		Tests:              input.Tests,
 		Type:               typeRule,
 		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		AlertSettings:      input.AlertSettings,
+	}
+
+	// The alert settings are left unchanged if the update does not set them
+	if item.AlertSettings == nil {
+		oldItem, err := dynamoGet(input.ID, true)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if oldItem != nil {
+			item.AlertSettings = oldItem.AlertSettings
+		}
 	}
 
 	if _, err := writeItem(item, input.UserID, aws.Bool(true)); err != nil {
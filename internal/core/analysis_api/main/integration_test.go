@@ -196,6 +196,11 @@ var (
 		Severity:           "HIGH",
 		Tests:              []*models.UnitTest{},
 		DedupPeriodMinutes: 1440,
+		AlertSettings: &models.AlertSettings{
+			DedupFields:        []string{"sourceIPAddress"},
+			RenotifyEventCount: 100,
+			Threshold:          5,
+		},
 	}
 )
 
@@ -542,6 +547,7 @@ func createRuleSuccess(t *testing.T) {
 			Severity:           rule.Severity,
 			UserID:             userID,
 			DedupPeriodMinutes: rule.DedupPeriodMinutes,
+			AlertSettings:      rule.AlertSettings,
 		},
 		HTTPClient: httpClient,
 	})
@@ -692,6 +698,7 @@ func modifyRule(t *testing.T) {
 	rule.Description = "SkyNet integration"
 	rule.DedupPeriodMinutes = 60
 
+	// the alert settings are not set by the update and stay unchanged
 	result, err := apiClient.Operations.ModifyRule(&operations.ModifyRuleParams{
 		Body: &models.UpdateRule{
 			Body:               rule.Body,
@@ -1180,6 +1187,7 @@ func getEnabledRules(t *testing.T) {
 				Severity:           rule.Severity,
 				VersionID:          rule.VersionID,
 				DedupPeriodMinutes: rule.DedupPeriodMinutes,
+				AlertSettings:      rule.AlertSettings,
 			},
 		},
 	}
@@ -40,4 +40,6 @@ var (
 		os.Getenv("OUTPUTS_TABLE_NAME"),
 		os.Getenv("OUTPUTS_DISPLAY_NAME_INDEX_NAME"),
 		awsSession)
+
+	deliveriesTable table.DeliveriesAPI = table.NewDeliveries(os.Getenv("DELIVERIES_TABLE_NAME"), awsSession)
 )
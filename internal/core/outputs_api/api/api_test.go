@@ -21,6 +21,7 @@ package api
 import (
 	"github.com/stretchr/testify/mock"
 
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/internal/core/outputs_api/encryption"
 	"github.com/panther-labs/panther/internal/core/outputs_api/table"
 )
@@ -78,3 +79,15 @@ func (m *mockEncryptionKey) EncryptConfig(config interface{}) ([]byte, error) {
 	args := m.Called(config)
 	return args.Get(0).([]byte), args.Error(1)
 }
+
+type mockDeliveriesTable struct {
+	table.DeliveriesTable
+	mock.Mock
+}
+
+func (m *mockDeliveriesTable) GetDeliveries(
+	outputID *string, pageSize int64, exclusiveStartKey *string) ([]*models.AlertDelivery, *string, error) {
+
+	args := m.Called(outputID, pageSize, exclusiveStartKey)
+	return args.Get(0).([]*models.AlertDelivery), args.Get(1).(*string), args.Error(2)
+}
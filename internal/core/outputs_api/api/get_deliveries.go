@@ -0,0 +1,39 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const defaultDeliveriesPageSize = 25
+
+// GetDeliveries returns the most recent delivery attempts of alerts to an output
+func (API) GetDeliveries(input *models.GetDeliveriesInput) (*models.GetDeliveriesOutput, error) {
+	pageSize := int64(defaultDeliveriesPageSize)
+	if input.PageSize != nil {
+		pageSize = *input.PageSize
+	}
+
+	deliveries, lastEvaluatedKey, err := deliveriesTable.GetDeliveries(input.OutputID, pageSize, input.ExclusiveStartKey)
+	if err != nil {
+		return nil, err
+	}
+	return &models.GetDeliveriesOutput{Deliveries: deliveries, LastEvaluatedKey: lastEvaluatedKey}, nil
+}
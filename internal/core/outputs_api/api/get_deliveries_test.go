@@ -0,0 +1,80 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestGetDeliveries(t *testing.T) {
+	mockDeliveriesTable := &mockDeliveriesTable{}
+	deliveriesTable = mockDeliveriesTable
+
+	deliveries := []*models.AlertDelivery{{
+		OutputID:   aws.String("outputId"),
+		DeliveryID: aws.String("deliveryId"),
+		Status:     aws.String(models.DeliverySucceeded),
+	}}
+	mockDeliveriesTable.On("GetDeliveries", aws.String("outputId"), int64(25), (*string)(nil)).
+		Return(deliveries, aws.String("deliveryId"), nil)
+
+	result, err := (API{}).GetDeliveries(&models.GetDeliveriesInput{OutputID: aws.String("outputId")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.GetDeliveriesOutput{Deliveries: deliveries, LastEvaluatedKey: aws.String("deliveryId")}, result)
+	mockDeliveriesTable.AssertExpectations(t)
+}
+
+func TestGetDeliveriesPageSize(t *testing.T) {
+	mockDeliveriesTable := &mockDeliveriesTable{}
+	deliveriesTable = mockDeliveriesTable
+
+	mockDeliveriesTable.On("GetDeliveries", aws.String("outputId"), int64(5), aws.String("deliveryId")).
+		Return([]*models.AlertDelivery{}, (*string)(nil), nil)
+
+	result, err := (API{}).GetDeliveries(&models.GetDeliveriesInput{
+		OutputID:          aws.String("outputId"),
+		PageSize:          aws.Int64(5),
+		ExclusiveStartKey: aws.String("deliveryId"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.GetDeliveriesOutput{Deliveries: []*models.AlertDelivery{}}, result)
+	mockDeliveriesTable.AssertExpectations(t)
+}
+
+func TestGetDeliveriesDdbError(t *testing.T) {
+	mockDeliveriesTable := &mockDeliveriesTable{}
+	deliveriesTable = mockDeliveriesTable
+
+	mockDeliveriesTable.On("GetDeliveries", aws.String("outputId"), int64(25), (*string)(nil)).
+		Return([]*models.AlertDelivery{}, (*string)(nil), errors.New("fake error"))
+
+	result, err := (API{}).GetDeliveries(&models.GetDeliveriesInput{OutputID: aws.String("outputId")})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockDeliveriesTable.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package table
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	deliveriesPartitionKey = "outputId"
+	deliveriesSortKey      = "deliveryId"
+)
+
+// DeliveriesAPI defines the interface for the alert deliveries table which can be used for mocking.
+type DeliveriesAPI interface {
+	GetDeliveries(outputID *string, pageSize int64, exclusiveStartKey *string) ([]*models.AlertDelivery, *string, error)
+}
+
+// DeliveriesTable encapsulates a connection to the Dynamo alert deliveries table.
+//
+// The table is written by the alert delivery Lambda, which records every attempt to send an alert to an output.
+type DeliveriesTable struct {
+	Name   *string
+	client dynamodbiface.DynamoDBAPI
+}
+
+// NewDeliveries creates an AWS client to interface with the alert deliveries table.
+func NewDeliveries(name string, sess *session.Session) *DeliveriesTable {
+	return &DeliveriesTable{
+		Name:   aws.String(name),
+		client: dynamodb.New(sess),
+	}
+}
+
+// GetDeliveries returns a page of the delivery attempts to an output, most recent first.
+//
+// The second value returned is the key to pass to get the next page, nil if there are no more attempts.
+func (table *DeliveriesTable) GetDeliveries(
+	outputID *string, pageSize int64, exclusiveStartKey *string) ([]*models.AlertDelivery, *string, error) {
+
+	keyCondition := expression.Key(deliveriesPartitionKey).Equal(expression.Value(outputID))
+	queryExpression, err := expression.NewBuilder().
+		WithKeyCondition(keyCondition).
+		Build()
+	if err != nil {
+		return nil, nil, &genericapi.InternalError{Message: "failed to build expression " + err.Error()}
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 table.Name,
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+		Limit:                     aws.Int64(pageSize),
+		ScanIndexForward:          aws.Bool(false),
+	}
+	if exclusiveStartKey != nil {
+		queryInput.ExclusiveStartKey = map[string]*dynamodb.AttributeValue{
+			deliveriesPartitionKey: {S: outputID},
+			deliveriesSortKey:      {S: exclusiveStartKey},
+		}
+	}
+
+	queryOutput, err := table.client.Query(queryInput)
+	if err != nil {
+		return nil, nil, &genericapi.AWSError{Method: "dynamodb.Query", Err: err}
+	}
+
+	deliveries := make([]*models.AlertDelivery, 0, len(queryOutput.Items))
+	if err = dynamodbattribute.UnmarshalListOfMaps(queryOutput.Items, &deliveries); err != nil {
+		return nil, nil, &genericapi.InternalError{
+			Message: "failed to unmarshal dynamo items to AlertDeliveries: " + err.Error()}
+	}
+
+	var lastEvaluatedKey *string
+	if sortKey, ok := queryOutput.LastEvaluatedKey[deliveriesSortKey]; ok {
+		lastEvaluatedKey = sortKey.S
+	}
+	return deliveries, lastEvaluatedKey, nil
+}
@@ -0,0 +1,118 @@
+package table
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var mockAlertDelivery = &models.AlertDelivery{
+	OutputID:    aws.String("outputId"),
+	DeliveryID:  aws.String("2020-05-20T10:00:00.000000000Z:alertId:1"),
+	AlertID:     aws.String("alertId"),
+	PolicyID:    aws.String("policyId"),
+	Attempt:     1,
+	AttemptedAt: aws.Time(time.Date(2020, 5, 20, 10, 0, 0, 0, time.UTC)),
+	Status:      aws.String(models.DeliveryRetrying),
+	StatusCode:  503,
+	Error:       aws.String("request failed"),
+}
+
+func TestGetDeliveries(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &DeliveriesTable{client: dynamoDBClient, Name: aws.String("testTable")}
+
+	expectedKeyCondition := expression.Key("outputId").Equal(expression.Value(aws.String("outputId")))
+	expectedQueryExpression, _ := expression.NewBuilder().
+		WithKeyCondition(expectedKeyCondition).
+		Build()
+	expectedQueryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String("testTable"),
+		ExpressionAttributeNames:  expectedQueryExpression.Names(),
+		ExpressionAttributeValues: expectedQueryExpression.Values(),
+		KeyConditionExpression:    expectedQueryExpression.KeyCondition(),
+		Limit:                     aws.Int64(1),
+		ScanIndexForward:          aws.Bool(false),
+		ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+			"outputId":   {S: aws.String("outputId")},
+			"deliveryId": {S: aws.String("2020-05-20T11:00:00.000000000Z:alertId:2")},
+		},
+	}
+
+	item, err := dynamodbattribute.MarshalMap(mockAlertDelivery)
+	require.NoError(t, err)
+	dynamoResponse := &dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item},
+		LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+			"outputId":   {S: aws.String("outputId")},
+			"deliveryId": {S: mockAlertDelivery.DeliveryID},
+		},
+	}
+	dynamoDBClient.On("Query", expectedQueryInput).Return(dynamoResponse, nil)
+
+	result, lastEvaluatedKey, err := table.GetDeliveries(
+		aws.String("outputId"), 1, aws.String("2020-05-20T11:00:00.000000000Z:alertId:2"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.AlertDelivery{mockAlertDelivery}, result)
+	assert.Equal(t, mockAlertDelivery.DeliveryID, lastEvaluatedKey)
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestGetDeliveriesLastPage(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &DeliveriesTable{client: dynamoDBClient, Name: aws.String("testTable")}
+
+	dynamoResponse := &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{}}
+	dynamoDBClient.On("Query", mock.Anything).Return(dynamoResponse, nil)
+
+	result, lastEvaluatedKey, err := table.GetDeliveries(aws.String("outputId"), 25, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Nil(t, lastEvaluatedKey)
+	assert.Nil(t, dynamoDBClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput).ExclusiveStartKey)
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestGetDeliveriesQueryError(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &DeliveriesTable{client: dynamoDBClient, Name: aws.String("testTable")}
+
+	dynamoDBClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, errors.New("fake error"))
+
+	result, lastEvaluatedKey, err := table.GetDeliveries(aws.String("outputId"), 25, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Nil(t, lastEvaluatedKey)
+	dynamoDBClient.AssertExpectations(t)
+}
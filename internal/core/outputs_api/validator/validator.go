@@ -34,10 +34,13 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("snsArn", validateAwsArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("goTemplate", validateTemplate); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-var outputTypes = []string{"Slack", "Sns", "PagerDuty", "Github", "Jira", "Opsgenie", "MsTeams", "Sqs", "Asana"}
+var outputTypes = []string{"Slack", "Sns", "PagerDuty", "Github", "Jira", "Opsgenie", "MsTeams", "Sqs", "Asana", "CustomWebhook"}
 
 func ensureOneOutput(sl validator.StructLevel) {
 	input := sl.Current()
@@ -58,3 +61,8 @@ func validateAwsArn(fl validator.FieldLevel) bool {
 	fieldArn, err := arn.Parse(fl.Field().String())
 	return err == nil && fieldArn.Service == "sns"
 }
+
+func validateTemplate(fl validator.FieldLevel) bool {
+	_, err := models.ParseBodyTemplate(fl.Field().String())
+	return err == nil
+}
@@ -29,7 +29,7 @@ import (
 	"github.com/panther-labs/panther/api/lambda/outputs/models"
 )
 
-const outputSet = "Slack|Sns|PagerDuty|Github|Jira|Opsgenie|MsTeams|Sqs|Asana"
+const outputSet = "Slack|Sns|PagerDuty|Github|Jira|Opsgenie|MsTeams|Sqs|Asana|CustomWebhook"
 
 func expectedMsg(structName string, fieldName string, tagName string) string {
 	return fmt.Sprintf(
@@ -116,3 +116,54 @@ func TestAddNonSnsArn(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Sns", "TopicArn", "snsArn"), err.Error())
 }
+
+func TestAddCustomWebhookValid(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mywebhook"),
+		OutputConfig: &models.OutputConfig{
+			CustomWebhook: &models.CustomWebhookConfig{
+				WebhookURL:    aws.String("https://soar.example.com"),
+				Headers:       []*models.WebhookHeader{{Name: aws.String("Authorization"), Value: aws.String("Bearer token")}},
+				BodyTemplate:  aws.String(`{"title": {{json (alertTitle .)}}}`),
+				SigningSecret: aws.String("secret"),
+			},
+		},
+	}))
+}
+
+func TestAddCustomWebhookInvalidTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mywebhook"),
+		OutputConfig: &models.OutputConfig{
+			CustomWebhook: &models.CustomWebhookConfig{
+				WebhookURL:   aws.String("https://soar.example.com"),
+				BodyTemplate: aws.String(`{"title": {{.Title}`),
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.CustomWebhook", "BodyTemplate", "goTemplate"), err.Error())
+}
+
+func TestAddCustomWebhookHeaderWithoutName(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mywebhook"),
+		OutputConfig: &models.OutputConfig{
+			CustomWebhook: &models.CustomWebhookConfig{
+				WebhookURL: aws.String("https://soar.example.com"),
+				Headers:    []*models.WebhookHeader{{Value: aws.String("Bearer token")}},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.CustomWebhook.Headers[0]", "Name", "required"), err.Error())
+}
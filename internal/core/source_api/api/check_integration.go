@@ -21,6 +21,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -75,6 +76,14 @@ func (API) CheckIntegration(input *models.CheckIntegrationInput) (*models.Source
 			out.S3BucketStatus = checkBucket(roleCreds, input.S3Bucket)
 			out.KMSKeyStatus = checkKey(roleCreds, input.KmsKey)
 		}
+
+	case models.IntegrationTypeAWSCloudWatchLogs:
+		// The log events are pushed by the subscription filter of the log group, there is no role to assume
+		out.LogGroupStatus = checkLogGroupName(input.LogGroupName)
+
+	case models.IntegrationTypeSaaSAPI:
+		// The events are pulled by the log processor with the API token of the integration, there is no role to assume
+		out.SaaSAPIStatus = checkSaaSAPI(input.SaaSProvider, input.SaaSEndpoint)
 	default:
 		return nil, checkIntegrationInternalError
 	}
@@ -128,6 +137,47 @@ func checkBucket(roleCredentials *credentials.Credentials, bucket *string) model
 	}
 }
 
+func checkLogGroupName(logGroupName *string) models.SourceIntegrationItemStatus {
+	if aws.StringValue(logGroupName) == "" {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      aws.Bool(false),
+			ErrorMessage: aws.String("log group name is required"),
+		}
+	}
+	if !models.IsValidLogGroupName(*logGroupName) {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      aws.Bool(false),
+			ErrorMessage: aws.String("log group name is not a valid CloudWatch Logs log group name"),
+		}
+	}
+
+	return models.SourceIntegrationItemStatus{
+		Healthy: aws.Bool(true),
+	}
+}
+
+func checkSaaSAPI(provider, endpoint *string) models.SourceIntegrationItemStatus {
+	if aws.StringValue(provider) == "" {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      aws.Bool(false),
+			ErrorMessage: aws.String("SaaS provider is required"),
+		}
+	}
+
+	// the API token is sent to the endpoint, it must not go over plain HTTP
+	endpointURL, err := url.Parse(aws.StringValue(endpoint))
+	if err != nil || endpointURL.Scheme != "https" || endpointURL.Host == "" {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      aws.Bool(false),
+			ErrorMessage: aws.String("SaaS API endpoint must be an https URL"),
+		}
+	}
+
+	return models.SourceIntegrationItemStatus{
+		Healthy: aws.Bool(true),
+	}
+}
+
 func getCredentialsWithStatus(roleARN string) (*credentials.Credentials, models.SourceIntegrationItemStatus) {
 	zap.L().Debug("checking role", zap.String("roleArn", roleARN))
 	// Setup new credentials with the role
@@ -188,6 +238,16 @@ func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (st
 			return "log processing role cannot access kms key", aws.BoolValue(status.KMSKeyStatus.Healthy), nil
 		}
 		return "", true, nil
+	case models.IntegrationTypeAWSCloudWatchLogs:
+		if !aws.BoolValue(status.LogGroupStatus.Healthy) {
+			return aws.StringValue(status.LogGroupStatus.ErrorMessage), false, nil
+		}
+		return "", true, nil
+	case models.IntegrationTypeSaaSAPI:
+		if !aws.BoolValue(status.SaaSAPIStatus.Healthy) {
+			return aws.StringValue(status.SaaSAPIStatus.ErrorMessage), false, nil
+		}
+		return "", true, nil
 	default:
 		return "", false, errors.New("invalid integration type")
 	}
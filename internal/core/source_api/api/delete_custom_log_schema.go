@@ -0,0 +1,69 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var (
+	deleteCustomLogSchemaInternalError = &genericapi.InternalError{Message: "Failed to delete custom log schema. Please try again later"}
+)
+
+// DeleteCustomLogSchema deletes a custom log schema that is not used by any source.
+//
+// The Glue table of the log type and the data already processed are kept.
+func (API) DeleteCustomLogSchema(input *models.DeleteCustomLogSchemaInput) error {
+	// log types are used by the log analysis sources (scanning integrations have none)
+	integrations, err := db.ScanIntegrations(&models.ListIntegrationsInput{})
+	if err != nil {
+		zap.L().Error("failed to fetch integrations", zap.Error(err))
+		return deleteCustomLogSchemaInternalError
+	}
+	for _, integration := range integrations {
+		for _, logType := range integration.LogTypes {
+			if aws.StringValue(logType) == *input.LogType {
+				return &genericapi.InUseError{
+					Message: fmt.Sprintf("log type %s is used by source %s", *input.LogType, aws.StringValue(integration.IntegrationLabel)),
+				}
+			}
+		}
+	}
+
+	schema, err := schemasDB.GetCustomLogSchema(input.LogType)
+	if err != nil {
+		zap.L().Error("failed to get custom log schema", zap.String("logType", *input.LogType), zap.Error(err))
+		return deleteCustomLogSchemaInternalError
+	}
+	if schema == nil {
+		return &genericapi.DoesNotExistError{Message: "Custom log schema does not exist"}
+	}
+
+	if err := schemasDB.DeleteCustomLogSchema(input.LogType); err != nil {
+		zap.L().Error("failed to delete custom log schema", zap.String("logType", *input.LogType), zap.Error(err))
+		return deleteCustomLogSchemaInternalError
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func scanIntegrationsOutput(t *testing.T, logTypes ...string) *dynamodb.ScanOutput {
+	item, err := dynamodbattribute.MarshalMap(&models.SourceIntegrationMetadata{
+		IntegrationID:    aws.String(testIntegrationID),
+		IntegrationLabel: aws.String(testIntegrationLabel),
+		IntegrationType:  aws.String(models.IntegrationTypeAWS3),
+		LogTypes:         aws.StringSlice(logTypes),
+	})
+	require.NoError(t, err)
+	return &dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{item}}
+}
+
+func TestDeleteCustomLogSchema(t *testing.T) {
+	mockClient := &mockDDBClient{}
+	db = &ddb.DDB{Client: mockClient, TableName: "test"}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test-schemas"}
+
+	existing := &models.CustomLogSchema{CustomLogSchemaSettings: testCustomLogSchemaInput().CustomLogSchemaSettings}
+	mockClient.On("Scan", mock.Anything).Return(scanIntegrationsOutput(t, "AWS.CloudTrail"), nil)
+	mockClient.On("GetItem", mock.Anything).Return(getCustomLogSchemaOutput(t, existing), nil)
+	mockClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+
+	err := apiTest.DeleteCustomLogSchema(&models.DeleteCustomLogSchemaInput{LogType: aws.String("Custom.MyApp")})
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteCustomLogSchemaInUse(t *testing.T) {
+	mockClient := &mockDDBClient{}
+	db = &ddb.DDB{Client: mockClient, TableName: "test"}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test-schemas"}
+
+	mockClient.On("Scan", mock.Anything).Return(scanIntegrationsOutput(t, "AWS.CloudTrail", "Custom.MyApp"), nil)
+
+	err := apiTest.DeleteCustomLogSchema(&models.DeleteCustomLogSchemaInput{LogType: aws.String("Custom.MyApp")})
+	assert.IsType(t, &genericapi.InUseError{}, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteCustomLogSchemaDoesNotExist(t *testing.T) {
+	mockClient := &mockDDBClient{}
+	db = &ddb.DDB{Client: mockClient, TableName: "test"}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test-schemas"}
+
+	mockClient.On("Scan", mock.Anything).Return(scanIntegrationsOutput(t), nil)
+	mockClient.On("GetItem", mock.Anything).Return(getCustomLogSchemaOutput(t, nil), nil)
+
+	err := apiTest.DeleteCustomLogSchema(&models.DeleteCustomLogSchemaInput{LogType: aws.String("Custom.MyApp")})
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	mockClient.AssertExpectations(t)
+}
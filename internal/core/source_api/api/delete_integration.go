@@ -89,6 +89,14 @@ func (API) DeleteIntegration(input *models.DeleteIntegrationInput) (err error) {
 			integrationForDeletePermissions = integration
 		}
 	}
+	if *integration.IntegrationType == models.IntegrationTypeSaaSAPI {
+		if err = DeleteSaaSAPIToken(*integration.IntegrationID); err != nil {
+			zap.L().Error("failed to delete SaaS API token of integration",
+				zap.String("integrationId", *input.IntegrationID),
+				zap.Error(err))
+			return deleteIntegrationInternalError
+		}
+	}
 	err = db.DeleteIntegrationItem(input)
 	if err != nil {
 		return deleteIntegrationInternalError
@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,28 @@ func TestDeleteIntegrationItem(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeleteSaaSIntegration(t *testing.T) {
+	mockClient := &mockDDBClient{}
+	db = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockSecrets := &mockSecretsClient{}
+	SecretsClient = mockSecrets
+
+	mockClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	mockClient.On("GetItem", mock.Anything).Return(generateGetItemOutput(models.IntegrationTypeSaaSAPI), nil)
+	mockSecrets.On("DeleteSecret", &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(models.SaaSAPITokenSecretPrefix + testIntegrationID),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	}).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+	result := apiTest.DeleteIntegration(&models.DeleteIntegrationInput{
+		IntegrationID: aws.String(testIntegrationID),
+	})
+
+	assert.NoError(t, result)
+	mockClient.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+}
+
 func TestDeleteLogIntegration(t *testing.T) {
 	mockClient := &mockDDBClient{}
 	db = &ddb.DDB{Client: mockClient, TableName: "test"}
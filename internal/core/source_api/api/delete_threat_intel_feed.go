@@ -0,0 +1,63 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var (
+	deleteThreatIntelFeedInternalError = &genericapi.InternalError{Message: "Failed to delete threat intel feed. Please try again later"}
+)
+
+// DeleteThreatIntelFeed deletes a threat intelligence feed.
+//
+// Events already processed keep the matches of the feed in their p_matched_indicators field.
+func (API) DeleteThreatIntelFeed(input *models.DeleteThreatIntelFeedInput) error {
+	key := aws.String(models.ThreatIntelFeedKey(*input.FeedName))
+	_, err := threatIntelS3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(threatIntelBucket),
+		Key:    key,
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NotFound" {
+			return &genericapi.DoesNotExistError{Message: fmt.Sprintf("threat intel feed %s does not exist", *input.FeedName)}
+		}
+		zap.L().Error("failed to get threat intel feed", zap.String("feedName", *input.FeedName), zap.Error(err))
+		return deleteThreatIntelFeedInternalError
+	}
+
+	_, err = threatIntelS3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(threatIntelBucket),
+		Key:    key,
+	})
+	if err != nil {
+		zap.L().Error("failed to delete threat intel feed", zap.String("feedName", *input.FeedName), zap.Error(err))
+		return deleteThreatIntelFeedInternalError
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestDeleteThreatIntelFeed(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+
+	s3Mock.On("HeadObject", mock.Anything).Return(&s3.HeadObjectOutput{}, nil)
+	s3Mock.On("DeleteObject", mock.Anything).Return(&s3.DeleteObjectOutput{}, nil)
+	err := apiTest.DeleteThreatIntelFeed(&models.DeleteThreatIntelFeedInput{FeedName: aws.String("botnet-c2")})
+	require.NoError(t, err)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestDeleteThreatIntelFeedDoesNotExist(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+
+	s3Mock.On("HeadObject", mock.Anything).Return(&s3.HeadObjectOutput{}, awserr.New("NotFound", "Not Found", nil))
+	err := apiTest.DeleteThreatIntelFeed(&models.DeleteThreatIntelFeedInput{FeedName: aws.String("botnet-c2")})
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	s3Mock.AssertExpectations(t)
+}
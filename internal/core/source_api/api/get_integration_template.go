@@ -31,6 +31,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 const (
@@ -85,6 +86,15 @@ type templateCacheItem struct {
 func (API) GetIntegrationTemplate(input *models.GetIntegrationTemplateInput) (*models.SourceIntegrationTemplate, error) {
 	zap.L().Debug("constructing source template")
 
+	switch *input.IntegrationType {
+	case models.IntegrationTypeAWSCloudWatchLogs, models.IntegrationTypeSaaSAPI:
+		// The log group is subscribed to a destination of the Panther account and SaaS APIs are pulled
+		// from the Panther account, no role is needed
+		return nil, &genericapi.InvalidInputError{
+			Message: "there is no template for " + *input.IntegrationType + " sources",
+		}
+	}
+
 	// Get the template
 	template, err := getTemplate(input.IntegrationType)
 	if err != nil {
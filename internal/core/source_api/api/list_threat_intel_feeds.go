@@ -0,0 +1,78 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var (
+	listThreatIntelFeedsInternalError = &genericapi.InternalError{Message: "Failed to list threat intel feeds. Please try again later"}
+)
+
+// ListThreatIntelFeeds returns all threat intelligence feeds without their indicators.
+func (API) ListThreatIntelFeeds(_ *models.ListThreatIntelFeedsInput) ([]*models.ThreatIntelFeedSummary, error) {
+	result := []*models.ThreatIntelFeedSummary{}
+	var getErr error
+	err := threatIntelS3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(threatIntelBucket),
+		Prefix: aws.String(models.ThreatIntelFeedPrefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			var feed *models.ThreatIntelFeed
+			if feed, getErr = getThreatIntelFeed(object.Key); getErr != nil {
+				return false
+			}
+			result = append(result, summarizeThreatIntelFeed(feed))
+		}
+		return true
+	})
+	if err == nil {
+		err = getErr
+	}
+	if err != nil {
+		zap.L().Error("failed to list threat intel feeds", zap.Error(err))
+		return nil, listThreatIntelFeedsInternalError
+	}
+	return result, nil
+}
+
+func getThreatIntelFeed(key *string) (*models.ThreatIntelFeed, error) {
+	output, err := threatIntelS3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(threatIntelBucket),
+		Key:    key,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s", *key)
+	}
+	defer output.Body.Close()
+
+	var feed models.ThreatIntelFeed
+	if err := jsoniter.NewDecoder(output.Body).Decode(&feed); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", *key)
+	}
+	return &feed, nil
+}
@@ -0,0 +1,68 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestListThreatIntelFeeds(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+
+	feed := &models.ThreatIntelFeed{
+		ThreatIntelFeedSettings: testThreatIntelFeedInput().ThreatIntelFeedSettings,
+		LastModifiedBy:          aws.String(testUserID),
+	}
+	body, err := jsoniter.Marshal(feed)
+	require.NoError(t, err)
+	s3Mock.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{{Key: aws.String("threat_intel/botnet-c2.json")}},
+	}, nil)
+	s3Mock.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(body))}, nil)
+
+	result, err := apiTest.ListThreatIntelFeeds(&models.ListThreatIntelFeedsInput{})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "botnet-c2", *result[0].FeedName)
+	assert.Equal(t, 4, *result[0].IndicatorCount)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestListThreatIntelFeedsEmpty(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+
+	s3Mock.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{}, nil)
+	result, err := apiTest.ListThreatIntelFeeds(&models.ListThreatIntelFeedsInput{})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
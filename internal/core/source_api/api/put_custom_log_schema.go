@@ -0,0 +1,92 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var (
+	putCustomLogSchemaInternalError = &genericapi.InternalError{Message: "Failed to save custom log schema. Please try again later"}
+)
+
+// PutCustomLogSchema adds a new custom log schema or appends fields to an existing one.
+func (API) PutCustomLogSchema(input *models.PutCustomLogSchemaInput) (*models.CustomLogSchema, error) {
+	// Ensure the log processor will be able to build a parser for the schema
+	if err := input.CustomLogSchemaSettings.Validate(); err != nil {
+		return nil, &genericapi.InvalidInputError{Message: err.Error()}
+	}
+
+	existingSchema, err := schemasDB.GetCustomLogSchema(input.LogType)
+	if err != nil {
+		zap.L().Error("failed to get custom log schema", zap.String("logType", *input.LogType), zap.Error(err))
+		return nil, putCustomLogSchemaInternalError
+	}
+	if existingSchema != nil {
+		if err := validateSchemaUpdate(existingSchema, input); err != nil {
+			return nil, err
+		}
+	}
+
+	schema := &models.CustomLogSchema{
+		CustomLogSchemaSettings: input.CustomLogSchemaSettings,
+		LastModified:            aws.Time(time.Now()),
+		LastModifiedBy:          input.UserID,
+	}
+	if err := schemasDB.PutCustomLogSchema(schema); err != nil {
+		zap.L().Error("failed to store custom log schema", zap.String("logType", *input.LogType), zap.Error(err))
+		return nil, putCustomLogSchemaInternalError
+	}
+	return schema, nil
+}
+
+// The columns of the Glue table of a log type are derived from the schema fields, and data already stored
+// must still match them. Therefore the format cannot change and existing fields cannot be removed,
+// renamed or change type. New fields can only be appended.
+func validateSchemaUpdate(existingSchema *models.CustomLogSchema, input *models.PutCustomLogSchemaInput) error {
+	if aws.StringValue(existingSchema.Format) != aws.StringValue(input.Format) {
+		return &genericapi.InvalidInputError{
+			Message: fmt.Sprintf("the format of log type %s cannot be changed", *input.LogType),
+		}
+	}
+	if len(input.Fields) < len(existingSchema.Fields) {
+		return &genericapi.InvalidInputError{
+			Message: fmt.Sprintf("fields of log type %s cannot be removed", *input.LogType),
+		}
+	}
+	for i, existingField := range existingSchema.Fields {
+		field := input.Fields[i]
+		if aws.StringValue(existingField.Name) != aws.StringValue(field.Name) ||
+			aws.StringValue(existingField.Type) != aws.StringValue(field.Type) {
+
+			return &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("field %s of log type %s cannot be renamed or change type, new fields must be appended",
+					aws.StringValue(existingField.Name), *input.LogType),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func testCustomLogSchemaInput() *models.PutCustomLogSchemaInput {
+	return &models.PutCustomLogSchemaInput{
+		CustomLogSchemaSettings: models.CustomLogSchemaSettings{
+			LogType:     aws.String("Custom.MyApp"),
+			Description: aws.String("My application logs"),
+			Format:      aws.String(models.CustomLogFormatJSON),
+			Fields: []*models.CustomLogField{
+				{
+					Name:        aws.String("message"),
+					Type:        aws.String(models.CustomFieldTypeString),
+					Description: aws.String("The message"),
+				},
+			},
+		},
+		UserID: aws.String(testUserID),
+	}
+}
+
+func getCustomLogSchemaOutput(t *testing.T, schema *models.CustomLogSchema) *dynamodb.GetItemOutput {
+	if schema == nil {
+		return &dynamodb.GetItemOutput{}
+	}
+	item, err := dynamodbattribute.MarshalMap(schema)
+	require.NoError(t, err)
+	return &dynamodb.GetItemOutput{Item: item}
+}
+
+func TestPutCustomLogSchema(t *testing.T) {
+	mockClient := &modelstest.MockDDBClient{}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockClient.On("GetItem", mock.Anything).Return(getCustomLogSchemaOutput(t, nil), nil)
+
+	schema, err := apiTest.PutCustomLogSchema(testCustomLogSchemaInput())
+	require.NoError(t, err)
+	assert.Equal(t, "Custom.MyApp", *schema.LogType)
+	assert.Equal(t, testUserID, *schema.LastModifiedBy)
+	assert.NotNil(t, schema.LastModified)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPutCustomLogSchemaInvalid(t *testing.T) {
+	mockClient := &modelstest.MockDDBClient{}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test"}
+
+	input := testCustomLogSchemaInput()
+	input.EventTimeField = aws.String("message") // not a timestamp
+	_, err := apiTest.PutCustomLogSchema(input)
+	require.Error(t, err)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPutCustomLogSchemaAppendField(t *testing.T) {
+	existing := &models.CustomLogSchema{CustomLogSchemaSettings: testCustomLogSchemaInput().CustomLogSchemaSettings}
+	mockClient := &modelstest.MockDDBClient{}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockClient.On("GetItem", mock.Anything).Return(getCustomLogSchemaOutput(t, existing), nil)
+
+	input := testCustomLogSchemaInput()
+	input.Fields = append(input.Fields, &models.CustomLogField{
+		Name:        aws.String("count"),
+		Type:        aws.String(models.CustomFieldTypeBigInt),
+		Description: aws.String("The count"),
+	})
+	schema, err := apiTest.PutCustomLogSchema(input)
+	require.NoError(t, err)
+	assert.Len(t, schema.Fields, 2)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPutCustomLogSchemaChangeField(t *testing.T) {
+	existing := &models.CustomLogSchema{CustomLogSchemaSettings: testCustomLogSchemaInput().CustomLogSchemaSettings}
+	mockClient := &modelstest.MockDDBClient{}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockClient.On("GetItem", mock.Anything).Return(getCustomLogSchemaOutput(t, existing), nil)
+
+	input := testCustomLogSchemaInput()
+	input.Fields[0].Type = aws.String(models.CustomFieldTypeBigInt)
+	_, err := apiTest.PutCustomLogSchema(input)
+	require.Error(t, err)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPutCustomLogSchemaDDBError(t *testing.T) {
+	mockClient := &modelstest.MockDDBClient{TestErr: true}
+	schemasDB = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockClient.On("GetItem", mock.Anything).Return(getCustomLogSchemaOutput(t, nil), nil)
+
+	_, err := apiTest.PutCustomLogSchema(testCustomLogSchemaInput())
+	assert.Equal(t, putCustomLogSchemaInternalError, err)
+	mockClient.AssertExpectations(t)
+}
@@ -53,6 +53,10 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (*models.Source
 		S3Bucket:          input.S3Bucket,
 		S3Prefix:          input.S3Prefix,
 		KmsKey:            input.KmsKey,
+		LogGroupName:      input.LogGroupName,
+		SaaSProvider:      input.SaaSProvider,
+		SaaSEndpoint:      input.SaaSEndpoint,
+		SaaSAPIToken:      input.SaaSAPIToken,
 	})
 	if err != nil {
 		return nil, putIntegrationInternalError
@@ -100,9 +104,27 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (*models.Source
 	// Generate the new integration
 	newIntegration := generateNewIntegration(input)
 
+	// Store the API token of SaaS integrations outside of the table
+	if *input.IntegrationType == models.IntegrationTypeSaaSAPI {
+		if aws.StringValue(input.SaaSAPIToken) == "" {
+			return nil, &genericapi.InvalidInputError{Message: "SaaS API token is required"}
+		}
+		if err = PutSaaSAPIToken(*newIntegration.IntegrationID, *input.SaaSAPIToken); err != nil {
+			zap.L().Error("failed to store SaaS API token", zap.Error(err))
+			return nil, putIntegrationInternalError
+		}
+	}
+
 	// Batch write to DynamoDB
 	if err = db.PutSourceIntegration(newIntegration); err != nil {
 		err = errors.Wrap(err, "Failed to store source integration in DDB")
+		if *input.IntegrationType == models.IntegrationTypeSaaSAPI {
+			if undoErr := DeleteSaaSAPIToken(*newIntegration.IntegrationID); undoErr != nil {
+				zap.L().Error("failed to delete SaaS API token of integration that was not stored, the secret has to be deleted manually",
+					zap.Error(undoErr),
+					zap.Error(err))
+			}
+		}
 		return nil, putIntegrationInternalError
 	}
 
@@ -147,6 +169,15 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 						*input.IntegrationLabel),
 				}
 			}
+			if *input.IntegrationType == models.IntegrationTypeAWSCloudWatchLogs &&
+				aws.StringValue(existingIntegration.LogGroupName) == aws.StringValue(input.LogGroupName) {
+				// The log events of a log group can only belong to one source
+				return &genericapi.InvalidInputError{
+					Message: fmt.Sprintf("Log group %s of account %s already onboarded",
+						aws.StringValue(input.LogGroupName),
+						*input.AWSAccountID),
+				}
+			}
 		}
 	}
 
@@ -202,9 +233,13 @@ func ScanAllResources(integrations []*models.SourceIntegrationMetadata) error {
 }
 
 func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceIntegrationMetadata {
-	var logProcessingRole *string
-	if *input.IntegrationType == models.IntegrationTypeAWS3 {
+	var logProcessingRole, stackName *string
+	switch *input.IntegrationType {
+	case models.IntegrationTypeAWS3:
 		logProcessingRole = aws.String(generateLogProcessingRoleArn(*input.AWSAccountID, *input.IntegrationLabel))
+		stackName = aws.String(getStackName(*input.IntegrationType, *input.IntegrationLabel))
+	case models.IntegrationTypeAWSScan:
+		stackName = aws.String(getStackName(*input.IntegrationType, *input.IntegrationLabel))
 	}
 
 	return &models.SourceIntegrationMetadata{
@@ -221,8 +256,11 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 		S3Bucket:          input.S3Bucket,
 		S3Prefix:          input.S3Prefix,
 		KmsKey:            input.KmsKey,
+		LogGroupName:      input.LogGroupName,
+		SaaSProvider:      input.SaaSProvider,
+		SaaSEndpoint:      input.SaaSEndpoint,
 		LogTypes:          input.LogTypes,
 		LogProcessingRole: logProcessingRole,
-		StackName:         aws.String(getStackName(*input.IntegrationType, *input.IntegrationLabel)),
+		StackName:         stackName,
 	}
 }
@@ -24,7 +24,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	jsoniter "github.com/json-iterator/go"
@@ -65,6 +68,29 @@ func (client *mockSQSClient) GetQueueAttributes(input *sqs.GetQueueAttributesInp
 	return args.Get(0).(*sqs.GetQueueAttributesOutput), args.Error(1)
 }
 
+// mockSecretsClient mocks API calls to Secrets Manager.
+type mockSecretsClient struct {
+	secretsmanageriface.SecretsManagerAPI
+	mock.Mock
+}
+
+func (client *mockSecretsClient) PutSecretValue(
+	input *secretsmanager.PutSecretValueInput) (*secretsmanager.PutSecretValueOutput, error) {
+
+	args := client.Called(input)
+	return args.Get(0).(*secretsmanager.PutSecretValueOutput), args.Error(1)
+}
+
+func (client *mockSecretsClient) CreateSecret(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error) {
+	args := client.Called(input)
+	return args.Get(0).(*secretsmanager.CreateSecretOutput), args.Error(1)
+}
+
+func (client *mockSecretsClient) DeleteSecret(input *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+	args := client.Called(input)
+	return args.Get(0).(*secretsmanager.DeleteSecretOutput), args.Error(1)
+}
+
 func generateMockSQSBatchInputOutput(integration *models.SourceIntegrationMetadata) (
 	*sqs.SendMessageBatchInput, *sqs.SendMessageBatchOutput, error) {
 
@@ -374,3 +400,190 @@ func TestPutLogIntegrationUpdateSqsQueuePermissionsFailure(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, out)
 }
+
+func TestPutCloudWatchLogsIntegration(t *testing.T) {
+	db = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	mockSQS := &mockSQSClient{}
+	SQSClient = mockSQS
+	evaluateIntegrationFunc = evaluateIntegration
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeAWSCloudWatchLogs),
+			UserID:           aws.String(testUserID),
+			LogGroupName:     aws.String("/aws/lambda/my-function"),
+			LogTypes:         aws.StringSlice([]string{"AWS.VPCFlow"}),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/aws/lambda/my-function", *out.LogGroupName)
+	assert.Nil(t, out.LogProcessingRole)
+	assert.Nil(t, out.StackName)
+	// the log events are pushed, the log processor queue is not involved
+	mockSQS.AssertExpectations(t)
+}
+
+func TestPutCloudWatchLogsIntegrationExists(t *testing.T) {
+	db = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				{
+					"awsAccountId":     {S: aws.String(testAccountID)},
+					"integrationType":  {S: aws.String(models.IntegrationTypeAWSCloudWatchLogs)},
+					"integrationLabel": {S: aws.String("other label")},
+					"logGroupName":     {S: aws.String("/aws/lambda/my-function")},
+				},
+			},
+			TestErr: false,
+		},
+		TableName: "test",
+	}
+	evaluateIntegrationFunc = evaluateIntegration
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeAWSCloudWatchLogs),
+			UserID:           aws.String(testUserID),
+			LogGroupName:     aws.String("/aws/lambda/my-function"),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Log group /aws/lambda/my-function")
+	require.Empty(t, out)
+}
+
+func TestPutCloudWatchLogsIntegrationNoLogGroup(t *testing.T) {
+	db = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	evaluateIntegrationFunc = evaluateIntegration
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeAWSCloudWatchLogs),
+			UserID:           aws.String(testUserID),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log group name is required")
+	require.Empty(t, out)
+}
+
+func TestPutSaaSIntegration(t *testing.T) {
+	db = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	mockSQS := &mockSQSClient{}
+	SQSClient = mockSQS
+	mockSecrets := &mockSecretsClient{}
+	SecretsClient = mockSecrets
+	evaluateIntegrationFunc = evaluateIntegration
+
+	notFoundErr := awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+	mockSecrets.On("PutSecretValue", mock.Anything).Return(&secretsmanager.PutSecretValueOutput{}, notFoundErr)
+	mockSecrets.On("CreateSecret", mock.Anything).Return(&secretsmanager.CreateSecretOutput{}, nil)
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeSaaSAPI),
+			UserID:           aws.String(testUserID),
+			SaaSProvider:     aws.String(models.SaaSProviderOkta),
+			SaaSEndpoint:     aws.String("https://example.okta.com/api/v1/logs"),
+			SaaSAPIToken:     aws.String("secret-token"),
+			LogTypes:         aws.StringSlice([]string{"Okta.SystemLog"}),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.SaaSProviderOkta, *out.SaaSProvider)
+	assert.Equal(t, "https://example.okta.com/api/v1/logs", *out.SaaSEndpoint)
+	assert.Nil(t, out.LogProcessingRole)
+	assert.Nil(t, out.StackName)
+
+	// the token is stored in a secret of the integration
+	createInput := mockSecrets.Calls[1].Arguments.Get(0).(*secretsmanager.CreateSecretInput)
+	assert.Equal(t, models.SaaSAPITokenSecretPrefix+*out.IntegrationID, *createInput.Name)
+	assert.Equal(t, "secret-token", *createInput.SecretString)
+	mockSecrets.AssertExpectations(t)
+	mockSQS.AssertExpectations(t)
+}
+
+func TestPutSaaSIntegrationNoToken(t *testing.T) {
+	db = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	mockSecrets := &mockSecretsClient{}
+	SecretsClient = mockSecrets
+	evaluateIntegrationFunc = evaluateIntegration
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeSaaSAPI),
+			UserID:           aws.String(testUserID),
+			SaaSProvider:     aws.String(models.SaaSProviderOkta),
+			SaaSEndpoint:     aws.String("https://example.okta.com/api/v1/logs"),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SaaS API token is required")
+	require.Empty(t, out)
+	mockSecrets.AssertExpectations(t)
+}
+
+func TestPutSaaSIntegrationPlainHTTPEndpoint(t *testing.T) {
+	db = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	evaluateIntegrationFunc = evaluateIntegration
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeSaaSAPI),
+			UserID:           aws.String(testUserID),
+			SaaSProvider:     aws.String(models.SaaSProviderOkta),
+			SaaSEndpoint:     aws.String("http://example.okta.com/api/v1/logs"),
+			SaaSAPIToken:     aws.String("secret-token"),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SaaS API endpoint must be an https URL")
+	require.Empty(t, out)
+}
+
+// putItemErrorDDBClient fails to store items but lists integrations
+type putItemErrorDDBClient struct {
+	*modelstest.MockDDBClient
+}
+
+func (client *putItemErrorDDBClient) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	return nil, errors.New("fake dynamodb.PutItem error")
+}
+
+func TestPutSaaSIntegrationDatabaseError(t *testing.T) {
+	db = &ddb.DDB{Client: &putItemErrorDDBClient{&modelstest.MockDDBClient{}}, TableName: "test"}
+	mockSecrets := &mockSecretsClient{}
+	SecretsClient = mockSecrets
+	evaluateIntegrationFunc = evaluateIntegration
+
+	mockSecrets.On("PutSecretValue", mock.Anything).Return(&secretsmanager.PutSecretValueOutput{}, nil)
+	// the stored token is removed again
+	mockSecrets.On("DeleteSecret", mock.Anything).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     aws.String(testAccountID),
+			IntegrationLabel: aws.String(testIntegrationLabel),
+			IntegrationType:  aws.String(models.IntegrationTypeSaaSAPI),
+			UserID:           aws.String(testUserID),
+			SaaSProvider:     aws.String(models.SaaSProviderOkta),
+			SaaSEndpoint:     aws.String("https://example.okta.com/api/v1/logs"),
+			SaaSAPIToken:     aws.String("secret-token"),
+		},
+	})
+	assert.Error(t, err)
+	assert.Empty(t, out)
+	mockSecrets.AssertExpectations(t)
+}
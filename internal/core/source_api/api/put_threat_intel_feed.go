@@ -0,0 +1,95 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var (
+	putThreatIntelFeedInternalError = &genericapi.InternalError{Message: "Failed to save threat intel feed. Please try again later"}
+)
+
+// PutThreatIntelFeed adds a new threat intelligence feed or replaces the indicators of an existing one.
+//
+// The indicators are normalized before they are stored, the log processor picks up the changes within minutes.
+func (API) PutThreatIntelFeed(input *models.PutThreatIntelFeedInput) (*models.ThreatIntelFeedSummary, error) {
+	indicators := make([]*models.ThreatIntelIndicator, 0, len(input.Indicators))
+	seen := make(map[string]struct{}, len(input.Indicators)) // type and value of the indicators added
+	for _, indicator := range input.Indicators {
+		value := models.NormalizeThreatIntelIndicator(*indicator.Type, *indicator.Value)
+		if value == "" {
+			return nil, &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("invalid %s indicator %s", *indicator.Type, *indicator.Value),
+			}
+		}
+		key := *indicator.Type + ":" + value
+		if _, duplicate := seen[key]; duplicate {
+			continue
+		}
+		seen[key] = struct{}{}
+		indicators = append(indicators, &models.ThreatIntelIndicator{Type: indicator.Type, Value: aws.String(value)})
+	}
+
+	feed := &models.ThreatIntelFeed{
+		ThreatIntelFeedSettings: models.ThreatIntelFeedSettings{
+			FeedName:    input.FeedName,
+			Description: input.Description,
+			Indicators:  indicators,
+		},
+		LastModified:   aws.Time(time.Now()),
+		LastModifiedBy: input.UserID,
+	}
+	body, err := jsoniter.Marshal(feed)
+	if err != nil {
+		zap.L().Error("failed to marshal threat intel feed", zap.String("feedName", *input.FeedName), zap.Error(err))
+		return nil, putThreatIntelFeedInternalError
+	}
+	_, err = threatIntelS3Client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(threatIntelBucket),
+		Key:         aws.String(models.ThreatIntelFeedKey(*input.FeedName)),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		zap.L().Error("failed to store threat intel feed", zap.String("feedName", *input.FeedName), zap.Error(err))
+		return nil, putThreatIntelFeedInternalError
+	}
+	return summarizeThreatIntelFeed(feed), nil
+}
+
+func summarizeThreatIntelFeed(feed *models.ThreatIntelFeed) *models.ThreatIntelFeedSummary {
+	return &models.ThreatIntelFeedSummary{
+		FeedName:       feed.FeedName,
+		Description:    feed.Description,
+		IndicatorCount: aws.Int(len(feed.Indicators)),
+		LastModified:   feed.LastModified,
+		LastModifiedBy: feed.LastModifiedBy,
+	}
+}
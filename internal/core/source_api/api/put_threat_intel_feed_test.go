@@ -0,0 +1,103 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func testThreatIntelFeedInput() *models.PutThreatIntelFeedInput {
+	return &models.PutThreatIntelFeedInput{
+		ThreatIntelFeedSettings: models.ThreatIntelFeedSettings{
+			FeedName:    aws.String("botnet-c2"),
+			Description: aws.String("Botnet command and control servers"),
+			Indicators: []*models.ThreatIntelIndicator{
+				{Type: aws.String(models.ThreatIntelIndicatorIP), Value: aws.String("192.0.2.10")},
+				{Type: aws.String(models.ThreatIntelIndicatorDomain), Value: aws.String("Evil.Example.com.")},
+				{Type: aws.String(models.ThreatIntelIndicatorDomain), Value: aws.String("evil.example.com")},
+				{Type: aws.String(models.ThreatIntelIndicatorMD5), Value: aws.String("D41D8CD98F00B204E9800998ECF8427E")},
+			},
+		},
+		UserID: aws.String(testUserID),
+	}
+}
+
+func TestPutThreatIntelFeed(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+	threatIntelBucket = "test-bucket"
+
+	var stored models.ThreatIntelFeed
+	s3Mock.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, nil).Run(func(args mock.Arguments) {
+		input := args.Get(0).(*s3.PutObjectInput)
+		require.Equal(t, "test-bucket", *input.Bucket)
+		require.Equal(t, "threat_intel/botnet-c2.json", *input.Key)
+		body, err := ioutil.ReadAll(input.Body)
+		require.NoError(t, err)
+		require.NoError(t, jsoniter.Unmarshal(body, &stored))
+	})
+
+	result, err := apiTest.PutThreatIntelFeed(testThreatIntelFeedInput())
+	require.NoError(t, err)
+	require.Equal(t, "botnet-c2", *result.FeedName)
+	require.Equal(t, 3, *result.IndicatorCount)
+	require.Equal(t, testUserID, *result.LastModifiedBy)
+	require.NotNil(t, result.LastModified)
+
+	// indicators are normalized and deduplicated
+	require.Equal(t, []*models.ThreatIntelIndicator{
+		{Type: aws.String(models.ThreatIntelIndicatorIP), Value: aws.String("192.0.2.10")},
+		{Type: aws.String(models.ThreatIntelIndicatorDomain), Value: aws.String("evil.example.com")},
+		{Type: aws.String(models.ThreatIntelIndicatorMD5), Value: aws.String("d41d8cd98f00b204e9800998ecf8427e")},
+	}, stored.Indicators)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestPutThreatIntelFeedInvalidIP(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+
+	input := testThreatIntelFeedInput()
+	input.Indicators[0].Value = aws.String("192.0.2")
+	_, err := apiTest.PutThreatIntelFeed(input)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestPutThreatIntelFeedS3Error(t *testing.T) {
+	s3Mock := &testutils.S3Mock{}
+	threatIntelS3Client = s3Mock
+
+	s3Mock.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, errors.New("access denied"))
+	_, err := apiTest.PutThreatIntelFeed(testThreatIntelFeedInput())
+	assert.Equal(t, putThreatIntelFeedInternalError, err)
+}
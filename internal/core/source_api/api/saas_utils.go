@@ -0,0 +1,67 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+// PutSaaSAPIToken stores the API token of a SaaS integration, replacing the existing token if there is one.
+//
+// The token is kept out of the integrations table so that it is never returned by the API,
+// the log processor reads it when pulling the events of the integration.
+func PutSaaSAPIToken(integrationID, token string) error {
+	secretName := models.SaaSAPITokenSecretPrefix + integrationID
+	_, err := SecretsClient.PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretName),
+		SecretString: aws.String(token),
+	})
+	if err == nil {
+		return nil
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != secretsmanager.ErrCodeResourceNotFoundException {
+		return errors.Wrapf(err, "failed to update secret %s", secretName)
+	}
+
+	// first token of the integration
+	_, err = SecretsClient.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         aws.String(secretName),
+		Description:  aws.String("The API token Panther uses to pull the audit logs of SaaS integration " + integrationID),
+		SecretString: aws.String(token),
+	})
+	return errors.Wrapf(err, "failed to create secret %s", secretName)
+}
+
+// DeleteSaaSAPIToken removes the API token of a SaaS integration.
+func DeleteSaaSAPIToken(integrationID string) error {
+	secretName := models.SaaSAPITokenSecretPrefix + integrationID
+	_, err := SecretsClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(secretName),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+		return nil
+	}
+	return errors.Wrapf(err, "failed to delete secret %s", secretName)
+}
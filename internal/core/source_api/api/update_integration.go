@@ -21,6 +21,7 @@ package api
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
@@ -51,6 +52,9 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 		S3Bucket:          input.S3Bucket,
 		S3Prefix:          input.S3Prefix,
 		KmsKey:            input.KmsKey,
+		LogGroupName:      input.LogGroupName,
+		SaaSProvider:      integration.SaaSProvider,
+		SaaSEndpoint:      input.SaaSEndpoint,
 	})
 	if err != nil {
 		return nil, err
@@ -64,6 +68,14 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 			*integration.AWSAccountID, reason)}
 	}
 
+	// A new API token replaces the one of the SaaS integration, the existing token is kept otherwise
+	if aws.StringValue(integration.IntegrationType) == models.IntegrationTypeSaaSAPI && aws.StringValue(input.SaaSAPIToken) != "" {
+		if err := PutSaaSAPIToken(*input.IntegrationID, *input.SaaSAPIToken); err != nil {
+			zap.L().Error("failed to update SaaS API token", zap.Error(err))
+			return nil, &genericapi.InternalError{Message: "Failed to update source. Please try again later"}
+		}
+	}
+
 	return db.UpdateItem(&ddb.UpdateIntegrationItem{
 		IntegrationID:      input.IntegrationID,
 		IntegrationLabel:   input.IntegrationLabel,
@@ -73,6 +85,8 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 		S3Bucket:           input.S3Bucket,
 		S3Prefix:           input.S3Prefix,
 		KmsKey:             input.KmsKey,
+		LogGroupName:       input.LogGroupName,
+		SaaSEndpoint:       input.SaaSEndpoint,
 		LogTypes:           input.LogTypes,
 	})
 }
@@ -86,6 +100,16 @@ func (API) UpdateIntegrationLastScanStart(input *models.UpdateIntegrationLastSca
 	})
 }
 
+// UpdateIntegrationLastPull updates a SaaS integration after the log processor pulled its events.
+func (API) UpdateIntegrationLastPull(input *models.UpdateIntegrationLastPullInput) (*models.SourceIntegration, error) {
+	return db.UpdateItem(&ddb.UpdateIntegrationItem{
+		IntegrationID:        input.IntegrationID,
+		LastPullTime:         input.LastPullTime,
+		LastPullErrorMessage: input.LastPullErrorMessage,
+		PullCursor:           input.PullCursor,
+	})
+}
+
 // UpdateIntegrationLastScanEnd updates an integration when a scan ends.
 func (API) UpdateIntegrationLastScanEnd(input *models.UpdateIntegrationLastScanEndInput) (*models.SourceIntegration, error) {
 	return db.UpdateItem(&ddb.UpdateIntegrationItem{
@@ -104,6 +104,30 @@ func TestUpdateIntegrationValidTime(t *testing.T) {
 	}))
 }
 
+func TestUpdateIntegrationLastPull(t *testing.T) {
+	mockClient := &modelstest.MockDDBClient{}
+	db = &ddb.DDB{Client: mockClient, TableName: "test"}
+
+	resp := &dynamodb.UpdateItemOutput{}
+	mockClient.On("UpdateItem", mock.Anything).Return(resp, nil)
+
+	lastPullTime, err := time.Parse(time.RFC3339, "2009-11-10T23:00:00Z")
+	require.NoError(t, err)
+
+	result, err := apiTest.UpdateIntegrationLastPull(&models.UpdateIntegrationLastPullInput{
+		IntegrationID: aws.String(testIntegrationID),
+		LastPullTime:  &lastPullTime,
+		PullCursor:    aws.String("2009-11-10T22:59:59.123Z"),
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	// the error message of the last pull is not set
+	updateInput := mockClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Len(t, updateInput.ExpressionAttributeNames, 2)
+	mockClient.AssertExpectations(t)
+}
+
 func TestUpdateIntegrationLastScanStart(t *testing.T) {
 	mockClient := &modelstest.MockDDBClient{}
 	db = &ddb.DDB{Client: mockClient, TableName: "test"}
@@ -23,6 +23,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 
@@ -31,6 +35,7 @@ import (
 
 var (
 	db                                      = ddb.New(tableName)
+	schemasDB                               = ddb.New(schemasTableName)
 	sess                                    = session.Must(session.NewSession())
 	SQSClient               sqsiface.SQSAPI = sqs.New(sess)
 	maxElapsedTime                          = 5 * time.Second
@@ -38,6 +43,14 @@ var (
 	logProcessorQueueURL                    = os.Getenv("LOG_PROCESSOR_QUEUE_URL")
 	logProcessorQueueArn                    = os.Getenv("LOG_PROCESSOR_QUEUE_ARN")
 	tableName                               = os.Getenv("TABLE_NAME")
+	schemasTableName                        = os.Getenv("SCHEMAS_TABLE_NAME")
+	threatIntelBucket                       = os.Getenv("THREAT_INTEL_BUCKET")
+
+	// threatIntelS3Client stores the threat intelligence feeds the log processor matches events against
+	threatIntelS3Client s3iface.S3API = s3.New(sess)
+
+	// SecretsClient stores the API tokens of SaaS integrations
+	SecretsClient secretsmanageriface.SecretsManagerAPI = secretsmanager.New(sess)
 )
 
 // API provides receiver methods for each route handler.
@@ -0,0 +1,106 @@
+package ddb
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	customLogSchemaHashKey = "logType"
+)
+
+// PutCustomLogSchema adds or replaces a custom log schema in the database.
+func (ddb *DDB) PutCustomLogSchema(input *models.CustomLogSchema) error {
+	item, err := dynamodbattribute.MarshalMap(input)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal custom log schema")
+	}
+
+	_, err = ddb.Client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(ddb.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		return &genericapi.AWSError{Err: err, Method: "Dynamodb.PutItem"}
+	}
+	return nil
+}
+
+// GetCustomLogSchema returns a custom log schema by its log type, or nil if it does not exist.
+func (ddb *DDB) GetCustomLogSchema(logType *string) (*models.CustomLogSchema, error) {
+	output, err := ddb.Client.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(ddb.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			customLogSchemaHashKey: {S: logType},
+		},
+	})
+	if err != nil {
+		return nil, &genericapi.AWSError{Err: err, Method: "Dynamodb.GetItem"}
+	}
+	if output.Item == nil {
+		return nil, nil
+	}
+
+	var schema models.CustomLogSchema
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &schema); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal custom log schema")
+	}
+	return &schema, nil
+}
+
+// ScanCustomLogSchemas returns all custom log schemas.
+func (ddb *DDB) ScanCustomLogSchemas() ([]*models.CustomLogSchema, error) {
+	output, err := ddb.Client.Scan(&dynamodb.ScanInput{
+		TableName: aws.String(ddb.TableName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scan custom log schemas")
+	}
+
+	var schemas []*models.CustomLogSchema
+	if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &schemas); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal custom log schemas")
+	}
+
+	if schemas == nil {
+		schemas = make([]*models.CustomLogSchema, 0)
+	}
+	return schemas, nil
+}
+
+// DeleteCustomLogSchema deletes a custom log schema from the database.
+func (ddb *DDB) DeleteCustomLogSchema(logType *string) error {
+	_, err := ddb.Client.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			customLogSchemaHashKey: {S: logType},
+		},
+		TableName: aws.String(ddb.TableName),
+	})
+	if err != nil {
+		return &genericapi.AWSError{Err: err, Method: "Dynamodb.DeleteItem"}
+	}
+	return nil
+}
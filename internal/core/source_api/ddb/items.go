@@ -38,5 +38,10 @@ type UpdateIntegrationItem struct {
 	S3Bucket             *string    `json:"s3Bucket"`
 	S3Prefix             *string    `json:"s3Prefix"`
 	KmsKey               *string    `json:"kmsKey"`
+	LogGroupName         *string    `json:"logGroupName"`
+	SaaSEndpoint         *string    `json:"saasEndpoint"`
+	LastPullTime         *time.Time `json:"lastPullTime"`
+	LastPullErrorMessage *string    `json:"lastPullErrorMessage"`
+	PullCursor           *string    `json:"pullCursor"`
 	LogTypes             []*string  `json:"logTypes" dynamodbav:"logTypes,stringset"`
 }
@@ -21,20 +21,27 @@ package forwarder
 import (
 	"crypto/md5" // nolint(gosec)
 	"encoding/hex"
+	"sort"
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 
 	policiesoperations "github.com/panther-labs/panther/api/gateway/analysis/client/operations"
+	alertsmodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	alertModel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
 
-const defaultTimePartition = "defaultPartition"
+const (
+	defaultTimePartition = "defaultPartition"
+	alertIDKey           = "id"
+	alertStatusKey       = "status"
+)
 
 func Store(event *AlertDedupEvent) error {
 	alert := &Alert{
@@ -47,18 +54,79 @@ func Store(event *AlertDedupEvent) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal alert")
 	}
-	putItemRequest := &dynamodb.PutItemInput{
-		Item:      marshaledAlert,
-		TableName: aws.String(env.AlertsTable),
+
+	// The alert is updated rather than replaced to keep the fields managed by the alerts API (status, assignee...)
+	update := expression.Set(expression.Name(alertStatusKey),
+		expression.IfNotExists(expression.Name(alertStatusKey), expression.Value(alertsmodels.AlertStatusOpen)))
+	attributes := make([]string, 0, len(marshaledAlert))
+	for attribute := range marshaledAlert {
+		if attribute != alertIDKey {
+			attributes = append(attributes, attribute)
+		}
+	}
+	sort.Strings(attributes) // for a deterministic update expression
+	for _, attribute := range attributes {
+		update = update.Set(expression.Name(attribute), expression.Value(marshaledAlert[attribute]))
 	}
-	_, err = ddbClient.PutItem(putItemRequest)
+	updateExpression, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update expression")
+	}
+
+	updateItemRequest := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			alertIDKey: marshaledAlert[alertIDKey],
+		},
+		TableName:                 aws.String(env.AlertsTable),
+		UpdateExpression:          updateExpression.Update(),
+		ExpressionAttributeNames:  updateExpression.Names(),
+		ExpressionAttributeValues: updateExpression.Values(),
+	}
+	_, err = ddbClient.UpdateItem(updateItemRequest)
 	if err != nil {
 		return errors.Wrap(err, "failed to update store alert")
 	}
 	return nil
 }
 
-func SendAlert(event *AlertDedupEvent) error {
+// ShouldSendAlert decides if the new image of an alert dedup event must be delivered to the outputs.
+// An alert is delivered once its event count reaches the threshold of the rule. After that, an update is
+// delivered every time it receives the number of new events configured in the rule or its severity escalates.
+func ShouldSendAlert(oldEvent, newEvent *AlertDedupEvent) (send, isUpdate bool) {
+	threshold := newEvent.AlertThreshold
+	if threshold < defaultAlertThreshold {
+		threshold = defaultAlertThreshold
+	}
+	if newEvent.EventCount < threshold {
+		return false, false
+	}
+
+	// If the alert count changed, the old image belongs to the previous alert of the dedup string
+	if oldEvent == nil || oldEvent.AlertCount != newEvent.AlertCount || oldEvent.EventCount < threshold {
+		return true, false
+	}
+
+	if renotifyCount := newEvent.RenotifyEventCount; renotifyCount > 0 &&
+		(newEvent.EventCount-threshold)/renotifyCount > (oldEvent.EventCount-threshold)/renotifyCount {
+
+		return true, true
+	}
+	if newEvent.RenotifyOnSeverityEscalation && severityRank(newEvent.Severity) > severityRank(oldEvent.Severity) {
+		return true, true
+	}
+	return false, false
+}
+
+func severityRank(severity string) int {
+	for i, s := range alertsmodels.Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+func SendAlert(event *AlertDedupEvent, isUpdate bool) error {
 	alert, err := getAlert(event)
 	if err != nil {
 		return errors.Wrap(err, "failed to get alert information")
@@ -66,6 +134,7 @@ func SendAlert(event *AlertDedupEvent) error {
 	if alert == nil {
 		return nil
 	}
+	alert.IsUpdate = isUpdate
 	msgBody, err := jsoniter.MarshalToString(alert)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal alert notification")
@@ -47,9 +47,9 @@ type mockDynamoDB struct {
 	mock.Mock
 }
 
-func (m *mockDynamoDB) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+func (m *mockDynamoDB) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
 	args := m.Called(input)
-	return args.Get(0).(*dynamodb.PutItemOutput), args.Error(1)
+	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
 }
 
 type mockSqs struct {
@@ -112,13 +112,33 @@ func TestStore(t *testing.T) {
 	expectedMarshaledAlert, err := dynamodbattribute.MarshalMap(expectedAlert)
 	assert.NoError(t, err)
 
-	expectedPutItemRequest := &dynamodb.PutItemInput{
-		Item:      expectedMarshaledAlert,
-		TableName: aws.String("alertsTable"),
-	}
-
-	ddbMock.On("PutItem", expectedPutItemRequest).Return(&dynamodb.PutItemOutput{}, nil)
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
 	assert.NoError(t, Store(testAlertDedupEvent))
+
+	request := ddbMock.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "alertsTable", *request.TableName)
+	assert.Equal(t, map[string]*dynamodb.AttributeValue{"id": expectedMarshaledAlert["id"]}, request.Key)
+
+	// all the attributes of the alert are set, the status only if the alert is new
+	values := make(map[string]*dynamodb.AttributeValue)
+	for name, value := range request.ExpressionAttributeValues {
+		values[name] = value
+	}
+	for placeholder, attribute := range request.ExpressionAttributeNames {
+		if *attribute == "id" {
+			continue
+		}
+		if *attribute == "status" {
+			assert.Contains(t, *request.UpdateExpression, placeholder+" = if_not_exists("+placeholder+", ")
+			continue
+		}
+		valuePlaceholder := ":" + strings.TrimPrefix(placeholder, "#")
+		assert.Contains(t, *request.UpdateExpression, placeholder+" = "+valuePlaceholder)
+		assert.Equal(t, expectedMarshaledAlert[*attribute], values[valuePlaceholder], *attribute)
+		delete(expectedMarshaledAlert, *attribute)
+	}
+	delete(expectedMarshaledAlert, "id")
+	assert.Empty(t, expectedMarshaledAlert)
 }
 
 // The handler signatures must match those in the LambdaInput struct.
@@ -126,7 +146,7 @@ func TestStoreDDBError(t *testing.T) {
 	ddbMock := &mockDynamoDB{}
 	ddbClient = ddbMock
 
-	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, errors.New("error"))
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("error"))
 	assert.Error(t, Store(testAlertDedupEvent))
 }
 
@@ -163,7 +183,7 @@ func TestSendAlert(t *testing.T) {
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
 	sqsMock.On("SendMessage", expectedSendMessageInput).Return(&sqs.SendMessageOutput{}, nil)
-	assert.NoError(t, SendAlert(testAlertDedupEvent))
+	assert.NoError(t, SendAlert(testAlertDedupEvent, false))
 }
 
 func TestSendAlertWithoutTitle(t *testing.T) {
@@ -211,7 +231,7 @@ func TestSendAlertWithoutTitle(t *testing.T) {
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
 	sqsMock.On("SendMessage", expectedSendMessageInput).Return(&sqs.SendMessageOutput{}, nil)
-	assert.NoError(t, SendAlert(testEvent))
+	assert.NoError(t, SendAlert(testEvent, false))
 	sqsMock.AssertExpectations(t)
 	mockRoundTripper.AssertExpectations(t)
 }
@@ -224,7 +244,7 @@ func TestSendAlertFailureToGetRule(t *testing.T) {
 	httpClient = &http.Client{Transport: mockRoundTripper}
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusInternalServerError), nil).Once()
-	assert.Error(t, SendAlert(testAlertDedupEvent))
+	assert.Error(t, SendAlert(testAlertDedupEvent, false))
 	sqsMock.AssertExpectations(t)
 	mockRoundTripper.AssertExpectations(t)
 }
@@ -237,7 +257,7 @@ func TestSendAlertRuleDoesntExist(t *testing.T) {
 	httpClient = &http.Client{Transport: mockRoundTripper}
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusNotFound), nil).Once()
-	assert.NoError(t, SendAlert(testAlertDedupEvent))
+	assert.NoError(t, SendAlert(testAlertDedupEvent, false))
 	sqsMock.AssertExpectations(t)
 	mockRoundTripper.AssertExpectations(t)
 }
@@ -251,7 +271,7 @@ func TestSendAlertFailureToSendSqsMessage(t *testing.T) {
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
 	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, errors.New("error"))
-	assert.Error(t, SendAlert(testAlertDedupEvent))
+	assert.Error(t, SendAlert(testAlertDedupEvent, false))
 	sqsMock.AssertExpectations(t)
 	mockRoundTripper.AssertExpectations(t)
 }
@@ -260,3 +280,72 @@ func generateResponse(body interface{}, httpCode int) *http.Response {
 	serializedBody, _ := jsoniter.MarshalToString(body)
 	return &http.Response{StatusCode: httpCode, Body: ioutil.NopCloser(strings.NewReader(serializedBody))}
 }
+
+func TestSendAlertUpdate(t *testing.T) {
+	sqsMock := &mockSqs{}
+	sqsClient = sqsMock
+
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+	policyConfig = policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient = policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+	assert.NoError(t, SendAlert(testAlertDedupEvent, true))
+
+	sendMessageInput := sqsMock.Calls[0].Arguments.Get(0).(*sqs.SendMessageInput)
+	var alert alertModel.Alert
+	require.NoError(t, jsoniter.UnmarshalFromString(*sendMessageInput.MessageBody, &alert))
+	assert.True(t, alert.IsUpdate)
+}
+
+func TestShouldSendAlert(t *testing.T) {
+	alertEvent := func(alertCount, eventCount int64, severity string) *AlertDedupEvent {
+		return &AlertDedupEvent{
+			AlertCount:                   alertCount,
+			EventCount:                   eventCount,
+			Severity:                     severity,
+			AlertThreshold:               5,
+			RenotifyEventCount:           10,
+			RenotifyOnSeverityEscalation: true,
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		oldEvent         *AlertDedupEvent
+		newEvent         *AlertDedupEvent
+		expectedSend     bool
+		expectedIsUpdate bool
+	}{
+		{"below threshold", nil, alertEvent(1, 4, "INFO"), false, false},
+		{"new alert reaching threshold", nil, alertEvent(1, 5, "INFO"), true, false},
+		{"new alert after previous alert", alertEvent(1, 100, "INFO"), alertEvent(2, 5, "INFO"), true, false},
+		{"threshold crossed", alertEvent(1, 3, "INFO"), alertEvent(1, 7, "INFO"), true, false},
+		{"already delivered", alertEvent(1, 5, "INFO"), alertEvent(1, 14, "INFO"), false, false},
+		{"renotify event count reached", alertEvent(1, 14, "INFO"), alertEvent(1, 15, "INFO"), true, true},
+		{"renotify event count reached twice", alertEvent(1, 14, "INFO"), alertEvent(1, 40, "INFO"), true, true},
+		{"severity escalated", alertEvent(1, 6, "INFO"), alertEvent(1, 7, "HIGH"), true, true},
+		{"severity deescalated", alertEvent(1, 6, "HIGH"), alertEvent(1, 7, "INFO"), false, false},
+		{"severity escalated below threshold", alertEvent(1, 2, "INFO"), alertEvent(1, 3, "HIGH"), false, false},
+	}
+
+	for _, testCase := range testCases {
+		send, isUpdate := ShouldSendAlert(testCase.oldEvent, testCase.newEvent)
+		assert.Equal(t, testCase.expectedSend, send, testCase.name)
+		assert.Equal(t, testCase.expectedIsUpdate, isUpdate, testCase.name)
+	}
+}
+
+func TestShouldSendAlertDefaultSettings(t *testing.T) {
+	oldEvent := &AlertDedupEvent{AlertCount: 1, EventCount: 1, Severity: "INFO", AlertThreshold: 1}
+	newEvent := &AlertDedupEvent{AlertCount: 1, EventCount: 2, Severity: "CRITICAL", AlertThreshold: 1}
+
+	send, _ := ShouldSendAlert(nil, oldEvent)
+	assert.True(t, send)
+	send, _ = ShouldSendAlert(oldEvent, newEvent)
+	assert.False(t, send)
+}
@@ -38,8 +38,15 @@ type AlertDedupEvent struct {
 	Severity            string    `dynamodbav:"severity,string"`
 	LogTypes            []string  `dynamodbav:"logTypes,stringset"`
 	Title               *string   `dynamodbav:"title,string,omitempty"`
+	// The alert settings of the rule, they are only used to decide when the alert is delivered
+	AlertThreshold               int64 `dynamodbav:"-"`
+	RenotifyEventCount           int64 `dynamodbav:"-"`
+	RenotifyOnSeverityEscalation bool  `dynamodbav:"-"`
 }
 
+// defaultAlertThreshold is the number of events after which an alert is delivered if the rule doesn't specify one
+const defaultAlertThreshold = 1
+
 // Alert contains all the fields associated to the alert stored in DDB
 type Alert struct {
 	ID            string `dynamodbav:"id,string"`
@@ -112,6 +119,7 @@ func FromDynamodDBAttribute(input map[string]events.DynamoDBAttributeValue) (eve
 		EventCount:          eventCount,
 		Severity:            severity.String(),
 		LogTypes:            logTypes.StringSet(),
+		AlertThreshold:      defaultAlertThreshold,
 	}
 
 	title := getOptionalAttribute("title", input)
@@ -119,6 +127,26 @@ func FromDynamodDBAttribute(input map[string]events.DynamoDBAttributeValue) (eve
 		result.Title = aws.String(title.String())
 	}
 
+	// The alert settings are not present in items written by older versions of the rules engine
+	alertThreshold := getOptionalAttribute("alertThreshold", input)
+	if alertThreshold != nil {
+		if result.AlertThreshold, err = alertThreshold.Integer(); err != nil {
+			return nil, errors.Wrap(err, "failed to convert attribute 'alertThreshold' to integer")
+		}
+	}
+
+	renotifyEventCount := getOptionalAttribute("renotifyEventCount", input)
+	if renotifyEventCount != nil {
+		if result.RenotifyEventCount, err = renotifyEventCount.Integer(); err != nil {
+			return nil, errors.Wrap(err, "failed to convert attribute 'renotifyEventCount' to integer")
+		}
+	}
+
+	renotifyOnSeverityEscalation := getOptionalAttribute("renotifyOnSeverityEscalation", input)
+	if renotifyOnSeverityEscalation != nil {
+		result.RenotifyOnSeverityEscalation = renotifyOnSeverityEscalation.Boolean()
+	}
+
 	return result, nil
 }
 
@@ -29,16 +29,19 @@ import (
 
 func TestConvertAttribute(t *testing.T) {
 	expectedAlertDedup := &AlertDedupEvent{
-		RuleID:              "testRuleId",
-		RuleVersion:         "testRuleVersion",
-		DeduplicationString: "testDedup",
-		AlertCount:          10,
-		CreationTime:        time.Unix(1582285279, 0).UTC(),
-		UpdateTime:          time.Unix(1582285280, 0).UTC(),
-		Severity:            "INFO",
-		EventCount:          100,
-		LogTypes:            []string{"Log.Type.1", "Log.Type.2"},
-		Title:               aws.String("test title"),
+		RuleID:                       "testRuleId",
+		RuleVersion:                  "testRuleVersion",
+		DeduplicationString:          "testDedup",
+		AlertCount:                   10,
+		CreationTime:                 time.Unix(1582285279, 0).UTC(),
+		UpdateTime:                   time.Unix(1582285280, 0).UTC(),
+		Severity:                     "INFO",
+		EventCount:                   100,
+		LogTypes:                     []string{"Log.Type.1", "Log.Type.2"},
+		Title:                        aws.String("test title"),
+		AlertThreshold:               5,
+		RenotifyEventCount:           50,
+		RenotifyOnSeverityEscalation: true,
 	}
 
 	alertDedupEvent, err := FromDynamodDBAttribute(getNewTestCase())
@@ -57,10 +60,14 @@ func TestConvertAttributeWithoutOptionalFields(t *testing.T) {
 		Severity:            "INFO",
 		EventCount:          100,
 		LogTypes:            []string{"Log.Type.1", "Log.Type.2"},
+		AlertThreshold:      1,
 	}
 
 	ddbItem := getNewTestCase()
 	delete(ddbItem, "title")
+	delete(ddbItem, "alertThreshold")
+	delete(ddbItem, "renotifyEventCount")
+	delete(ddbItem, "renotifyOnSeverityEscalation")
 	alertDedupEvent, err := FromDynamodDBAttribute(ddbItem)
 	require.NoError(t, err)
 	require.Equal(t, expectedAlertDedup, alertDedupEvent)
@@ -138,6 +145,14 @@ func TestInvalidInteger(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestInvalidAlertThreshold(t *testing.T) {
+	testInput := getNewTestCase()
+	testInput["alertThreshold"] = events.NewNumberAttribute("notaninteger")
+	alertDedupEvent, err := FromDynamodDBAttribute(testInput)
+	require.Nil(t, alertDedupEvent)
+	require.Error(t, err)
+}
+
 func TestInvalidTypeShouldntPanic(t *testing.T) {
 	testInput := getNewTestCase()
 	testInput["alertCreationTime"] = events.NewStringAttribute("string")
@@ -148,15 +163,18 @@ func TestInvalidTypeShouldntPanic(t *testing.T) {
 
 func getNewTestCase() map[string]events.DynamoDBAttributeValue {
 	return map[string]events.DynamoDBAttributeValue{
-		"ruleId":            events.NewStringAttribute("testRuleId"),
-		"ruleVersion":       events.NewStringAttribute("testRuleVersion"),
-		"dedup":             events.NewStringAttribute("testDedup"),
-		"alertCount":        events.NewNumberAttribute("10"),
-		"alertCreationTime": events.NewNumberAttribute("1582285279"),
-		"alertUpdateTime":   events.NewNumberAttribute("1582285280"),
-		"eventCount":        events.NewNumberAttribute("100"),
-		"severity":          events.NewStringAttribute("INFO"),
-		"logTypes":          events.NewStringSetAttribute([]string{"Log.Type.1", "Log.Type.2"}),
-		"title":             events.NewStringAttribute("test title"),
+		"ruleId":                       events.NewStringAttribute("testRuleId"),
+		"ruleVersion":                  events.NewStringAttribute("testRuleVersion"),
+		"dedup":                        events.NewStringAttribute("testDedup"),
+		"alertCount":                   events.NewNumberAttribute("10"),
+		"alertCreationTime":            events.NewNumberAttribute("1582285279"),
+		"alertUpdateTime":              events.NewNumberAttribute("1582285280"),
+		"eventCount":                   events.NewNumberAttribute("100"),
+		"severity":                     events.NewStringAttribute("INFO"),
+		"logTypes":                     events.NewStringSetAttribute([]string{"Log.Type.1", "Log.Type.2"}),
+		"title":                        events.NewStringAttribute("test title"),
+		"alertThreshold":               events.NewNumberAttribute("5"),
+		"renotifyEventCount":           events.NewNumberAttribute("50"),
+		"renotifyOnSeverityEscalation": events.NewBooleanAttribute(true),
 	}
 }
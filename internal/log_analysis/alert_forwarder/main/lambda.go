@@ -59,6 +59,7 @@ func reporterHandler(lc *lambdacontext.LambdaContext, event events.DynamoDBEvent
 			// continuing since there is nothing we can do here
 			continue
 		}
+		// Alerts are stored even below the threshold of their rule, it only controls their delivery to the outputs.
 		// Note that if there is an error in processing any of the messages in the batch, the whole batch will be retried.
 		if err = forwarder.Store(newAlertItem); err != nil {
 			return errors.Wrap(err, "encountered issue while storing alert")
@@ -74,9 +75,9 @@ func reporterHandler(lc *lambdacontext.LambdaContext, event events.DynamoDBEvent
 			}
 		}
 
-		if oldAlertEvent == nil || oldAlertEvent.AlertCount != newAlertItem.AlertCount {
+		if send, isUpdate := forwarder.ShouldSendAlert(oldAlertEvent, newAlertItem); send {
 			// Note that if there is an error in processing any of the messages in the batch, the whole batch will be retried.
-			if err = forwarder.SendAlert(newAlertItem); err != nil {
+			if err = forwarder.SendAlert(newAlertItem, isUpdate); err != nil {
 				return errors.Wrap(err, "encountered issue while sending alert")
 			}
 		}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	jsoniter "github.com/json-iterator/go"
@@ -32,14 +34,17 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
 )
 
+const usersAPIFunctionName = "panther-users-api"
+
 // API has all of the handlers as receiver methods.
 type API struct{}
 
 var (
-	env        envConfig
-	awsSession *session.Session
-	alertsDB   table.API
-	s3Client   s3iface.S3API
+	env          envConfig
+	awsSession   *session.Session
+	alertsDB     table.API
+	s3Client     s3iface.S3API
+	lambdaClient lambdaiface.LambdaAPI
 )
 
 type envConfig struct {
@@ -48,6 +53,7 @@ type envConfig struct {
 	AlertsTableName     string `required:"true" split_words:"true"`
 	RuleIndexName       string `required:"true" split_words:"true"`
 	TimeIndexName       string `required:"true" split_words:"true"`
+	SeverityIndexName   string `required:"true" split_words:"true"`
 	ProcessedDataBucket string `required:"true" split_words:"true"`
 }
 
@@ -61,8 +67,10 @@ func Setup() {
 		Client:                             dynamodb.New(awsSession),
 		RuleIDCreationTimeIndexName:        env.RuleIndexName,
 		TimePartitionCreationTimeIndexName: env.TimeIndexName,
+		SeverityUpdateTimeIndexName:        env.SeverityIndexName,
 	}
 	s3Client = s3.New(awsSession)
+	lambdaClient = lambda.New(awsSession)
 }
 
 // Token used for paginating through the events in an alert
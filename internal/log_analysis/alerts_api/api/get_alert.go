@@ -98,6 +98,10 @@ func (API) GetAlert(input *models.GetAlertInput) (result *models.GetAlertOutput,
 		EventsMatched:          &alertItem.EventCount,
 		Events:                 aws.StringSlice(events),
 		EventsLastEvaluatedKey: aws.String(encodedToken),
+		Status:                 aws.String(alertStatus(alertItem)),
+		AssigneeID:             optionalString(alertItem.AssigneeID),
+		ResolutionNotes:        optionalString(alertItem.ResolutionNotes),
+		StatusHistory:          statusHistory(alertItem.StatusHistory),
 	}
 
 	gatewayapi.ReplaceMapSliceNils(result)
@@ -79,14 +79,14 @@ func (m *tableMock) GetAlert(input *string) (*table.AlertItem, error) {
 	return args.Get(0).(*table.AlertItem), args.Error(1)
 }
 
-func (m *tableMock) ListByRule(rule string, startKey *string, pageSize *int) ([]*table.AlertItem, *string, error) {
-	args := m.Called(rule, startKey, pageSize)
+func (m *tableMock) ListAll(input *models.ListAlertsInput) ([]*table.AlertItem, *string, error) {
+	args := m.Called(input)
 	return args.Get(0).([]*table.AlertItem), args.Get(1).(*string), args.Error(2)
 }
 
-func (m *tableMock) ListAll(startKey *string, pageSize *int) ([]*table.AlertItem, *string, error) {
-	args := m.Called(startKey, pageSize)
-	return args.Get(0).([]*table.AlertItem), args.Get(1).(*string), args.Error(2)
+func (m *tableMock) UpdateStatus(alertID string, change *table.StatusChange) (*table.AlertItem, error) {
+	args := m.Called(alertID, change)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
 }
 
 func init() {
@@ -165,6 +165,8 @@ func TestGetAlert(t *testing.T) {
 		UpdateTime:    aws.Time(time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC)),
 		EventsMatched: aws.Int(5),
 		Events:        aws.StringSlice([]string{"testEvent"}),
+		Status:        aws.String("OPEN"),
+		StatusHistory: []*models.AlertStatusChange{},
 		EventsLastEvaluatedKey:
 		// nolint
 		aws.String("eyJsb2dUeXBlVG9Ub2tlbiI6eyJsb2d0eXBlIjp7InMzT2JqZWN0S2V5IjoicnVsZXMvbG9ndHlwZS95ZWFyPTIwMjAvbW9udGg9MDEvZGF5PTAxL2hvdXI9MDEvMjAyMDAxMDFUMDEwMTAwWi11dWlkNC5qc29uLmd6IiwiZXZlbnRJbmRleCI6MX19fQ=="),
@@ -231,6 +233,8 @@ func TestGetAlertFilterOutS3KeysOutsideTheTimePeriod(t *testing.T) {
 		EventsMatched: aws.Int(5),
 		DedupString:   aws.String("dedupString"),
 		Events:        aws.StringSlice([]string{"testEvent"}),
+		Status:        aws.String("OPEN"),
+		StatusHistory: []*models.AlertStatusChange{},
 		EventsLastEvaluatedKey:
 		// nolint
 		aws.String("eyJsb2dUeXBlVG9Ub2tlbiI6eyJsb2d0eXBlIjp7InMzT2JqZWN0S2V5IjoicnVsZXMvbG9ndHlwZS95ZWFyPTIwMjAvbW9udGg9MDEvZGF5PTAxL2hvdXI9MDEvMjAyMDAxMDFUMDEwNTAwWi11dWlkNC5qc29uLmd6IiwiZXZlbnRJbmRleCI6MX19fQ=="),
@@ -19,10 +19,13 @@ package api
  */
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+
 	"github.com/panther-labs/panther/api/lambda/alerts/models"
 	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 // ListAlerts retrieves alert and event details.
@@ -33,38 +36,77 @@ func (API) ListAlerts(input *models.ListAlertsInput) (result *models.ListAlertsO
 		operation.Log(err)
 	}()
 
-	result = &models.ListAlertsOutput{}
-	var alertItems []*table.AlertItem
-	if input.RuleID != nil { // list per specific ruleId
-		alertItems, result.LastEvaluatedKey, err = alertsDB.ListByRule(*input.RuleID, input.ExclusiveStartKey, input.PageSize)
-	} else { // list all alerts time desc order
-		alertItems, result.LastEvaluatedKey, err = alertsDB.ListAll(input.ExclusiveStartKey, input.PageSize)
+	if err = validateListAlertsInput(input); err != nil {
+		return nil, err
 	}
+
+	alertItems, lastEvaluatedKey, err := alertsDB.ListAll(input)
 	if err != nil {
 		return nil, err
 	}
 
-	result.Alerts = alertItemsToAlertSummary(alertItems)
+	result = &models.ListAlertsOutput{
+		Alerts:           alertItemsToAlertSummary(alertItems),
+		LastEvaluatedKey: lastEvaluatedKey,
+	}
 
 	gatewayapi.ReplaceMapSliceNils(result)
 	return result, nil
 }
 
-// alertItemsToAlertSummary converts a DDB Alert Item to an Alert Summary that will be returned by the API
+// validateListAlertsInput checks the ranges of the filters
+func validateListAlertsInput(input *models.ListAlertsInput) error {
+	if input.CreatedAtAfter != nil && input.CreatedAtBefore != nil && input.CreatedAtAfter.After(*input.CreatedAtBefore) {
+		return &genericapi.InvalidInputError{Message: "createdAtAfter must not be after createdAtBefore"}
+	}
+	if input.UpdatedAtAfter != nil && input.UpdatedAtBefore != nil && input.UpdatedAtAfter.After(*input.UpdatedAtBefore) {
+		return &genericapi.InvalidInputError{Message: "updatedAtAfter must not be after updatedAtBefore"}
+	}
+	if input.EventCountMin != nil && input.EventCountMax != nil && *input.EventCountMin > *input.EventCountMax {
+		return &genericapi.InvalidInputError{Message: "eventCountMin must not be greater than eventCountMax"}
+	}
+	return nil
+}
+
+// alertItemsToAlertSummary converts DDB Alert Items to Alert Summaries that will be returned by the API
 func alertItemsToAlertSummary(items []*table.AlertItem) []*models.AlertSummary {
 	result := make([]*models.AlertSummary, len(items))
 
 	for i, item := range items {
-		result[i] = &models.AlertSummary{
-			AlertID:       &item.AlertID,
-			RuleID:        &item.RuleID,
-			DedupString:   &item.DedupString,
-			CreationTime:  &item.CreationTime,
-			Severity:      &item.Severity,
-			UpdateTime:    &item.UpdateTime,
-			EventsMatched: &item.EventCount,
-		}
+		result[i] = alertItemToAlertSummary(item)
 	}
 
 	return result
 }
+
+// alertItemToAlertSummary converts a DDB Alert Item to an Alert Summary that will be returned by the API
+func alertItemToAlertSummary(item *table.AlertItem) *models.AlertSummary {
+	return &models.AlertSummary{
+		AlertID:       &item.AlertID,
+		RuleID:        &item.RuleID,
+		DedupString:   &item.DedupString,
+		CreationTime:  &item.CreationTime,
+		Severity:      &item.Severity,
+		UpdateTime:    &item.UpdateTime,
+		EventsMatched: &item.EventCount,
+		Title:         optionalString(item.Title),
+		LogTypes:      aws.StringSlice(item.LogTypes),
+		Status:        aws.String(alertStatus(item)),
+		AssigneeID:    optionalString(item.AssigneeID),
+	}
+}
+
+// alertStatus returns the status of an alert, alerts created before statuses were introduced are open
+func alertStatus(item *table.AlertItem) string {
+	if item.Status == "" {
+		return models.AlertStatusOpen
+	}
+	return item.Status
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
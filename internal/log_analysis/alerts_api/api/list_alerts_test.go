@@ -28,6 +28,7 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/alerts/models"
 	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 var (
@@ -55,6 +56,8 @@ var (
 			Severity:      aws.String("INFO"),
 			DedupString:   aws.String("dedupString"),
 			EventsMatched: aws.Int(100),
+			LogTypes:      aws.StringSlice([]string{"AWS.CloudTrail"}),
+			Status:        aws.String("OPEN"),
 		},
 	}
 )
@@ -69,7 +72,7 @@ func TestListAlertsForRule(t *testing.T) {
 		ExclusiveStartKey: aws.String("startKey"),
 	}
 
-	tableMock.On("ListByRule", "ruleId", aws.String("startKey"), aws.Int(10)).
+	tableMock.On("ListAll", input).
 		Return(alertItems, aws.String("lastKey"), nil)
 	result, err := API{}.ListAlerts(input)
 	require.NoError(t, err)
@@ -89,7 +92,7 @@ func TestListAllAlerts(t *testing.T) {
 		ExclusiveStartKey: aws.String("startKey"),
 	}
 
-	tableMock.On("ListAll", aws.String("startKey"), aws.Int(10)).
+	tableMock.On("ListAll", input).
 		Return(alertItems, aws.String("lastKey"), nil)
 	result, err := API{}.ListAlerts(input)
 	require.NoError(t, err)
@@ -99,3 +102,41 @@ func TestListAllAlerts(t *testing.T) {
 		LastEvaluatedKey: aws.String("lastKey"),
 	}, result)
 }
+
+func TestListAlertsFilters(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListAlertsInput{
+		Status:         aws.String(models.AlertStatusTriaging),
+		AssigneeID:     aws.String("0d5e4f5a-9c8b-4a1e-8f3d-2b6c7a8e9f10"),
+		Severity:       aws.StringSlice([]string{"HIGH", "CRITICAL"}),
+		UpdatedAtAfter: aws.Time(timeInTest.Add(-time.Hour)),
+		TitleContains:  aws.String("root login"),
+		SortBy:         aws.String(models.SortBySeverity),
+	}
+
+	tableMock.On("ListAll", input).Return([]*table.AlertItem{}, (*string)(nil), nil)
+	result, err := API{}.ListAlerts(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ListAlertsOutput{Alerts: []*models.AlertSummary{}}, result)
+}
+
+func TestListAlertsInvalidRange(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListAlertsInput{
+		CreatedAtAfter:  aws.Time(timeInTest),
+		CreatedAtBefore: aws.Time(timeInTest.Add(-time.Hour)),
+	}
+	result, err := API{}.ListAlerts(input)
+	require.IsType(t, &genericapi.InvalidInputError{}, err)
+	assert.Nil(t, result)
+
+	input = &models.ListAlertsInput{EventCountMin: aws.Int(10), EventCountMax: aws.Int(1)}
+	result, err = API{}.ListAlerts(input)
+	require.IsType(t, &genericapi.InvalidInputError{}, err)
+	assert.Nil(t, result)
+	tableMock.AssertExpectations(t)
+}
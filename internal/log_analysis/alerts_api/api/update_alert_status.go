@@ -0,0 +1,97 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	usersmodels "github.com/panther-labs/panther/api/lambda/users/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// UpdateAlertStatus changes the status, assignee and resolution notes of an alert
+func (API) UpdateAlertStatus(input *models.UpdateAlertStatusInput) (result *models.UpdateAlertStatusOutput, err error) {
+	operation := common.OpLogManager.Start("updateAlertStatus")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	if assigneeID := aws.StringValue(input.AssigneeID); assigneeID != "" {
+		if err = validateAssignee(assigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	change := &table.StatusChange{
+		Status:          *input.Status,
+		AssigneeID:      input.AssigneeID,
+		ResolutionNotes: input.ResolutionNotes,
+		UserID:          *input.UserID,
+		Time:            time.Now().UTC(),
+	}
+	alertItem, err := alertsDB.UpdateStatus(*input.AlertID, change)
+	if err != nil {
+		return nil, err
+	}
+	if alertItem == nil {
+		err = &genericapi.DoesNotExistError{Message: "alertId=" + *input.AlertID + " does not exist"}
+		return nil, err
+	}
+
+	result = alertItemToAlertSummary(alertItem)
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result, nil
+}
+
+// validateAssignee checks that the user exists with the users-api
+func validateAssignee(userID string) error {
+	input := &usersmodels.LambdaInput{
+		GetUser: &usersmodels.GetUserInput{ID: &userID},
+	}
+	var user usersmodels.GetUserOutput
+	if err := genericapi.Invoke(lambdaClient, usersAPIFunctionName, input, &user); err != nil {
+		if lambdaErr, ok := err.(*genericapi.LambdaError); ok && aws.StringValue(lambdaErr.ErrorType) == "DoesNotExistError" {
+			return &genericapi.InvalidInputError{Message: "assigneeId=" + userID + " is not a Panther user"}
+		}
+		return err
+	}
+	return nil
+}
+
+// statusHistory converts the status changes stored with an alert to the API model
+func statusHistory(changes []*table.StatusChange) []*models.AlertStatusChange {
+	result := make([]*models.AlertStatusChange, len(changes))
+	for i, change := range changes {
+		result[i] = &models.AlertStatusChange{
+			Status:          aws.String(change.Status),
+			AssigneeID:      change.AssigneeID,
+			ResolutionNotes: change.ResolutionNotes,
+			UserID:          aws.String(change.UserID),
+			Time:            aws.Time(change.Time),
+		}
+	}
+	return result
+}
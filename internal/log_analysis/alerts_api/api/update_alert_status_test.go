@@ -0,0 +1,153 @@
+package api
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testAlertID    = "8c1b7f1a597d0480354e66c3a6266ccc"
+	testUserID     = "f6cfaf0a-4a4c-4ec0-a9f4-5d1b8c2e4e1f"
+	testAssigneeID = "0d5e4f5a-9c8b-4a1e-8f3d-2b6c7a8e9f10"
+)
+
+func TestUpdateAlertStatus(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+
+	input := &models.UpdateAlertStatusInput{
+		AlertID:         aws.String(testAlertID),
+		Status:          aws.String(models.AlertStatusTriaging),
+		AssigneeID:      aws.String(testAssigneeID),
+		ResolutionNotes: aws.String("looking into it"),
+		UserID:          aws.String(testUserID),
+	}
+
+	lambdaMock.On("Invoke", mock.Anything).Return(
+		&lambda.InvokeOutput{Payload: []byte(`{"id":"` + testAssigneeID + `"}`)}, nil).Once()
+	updatedItem := &table.AlertItem{
+		AlertID:         testAlertID,
+		RuleID:          "ruleId",
+		CreationTime:    timeInTest,
+		UpdateTime:      timeInTest,
+		Severity:        "INFO",
+		EventCount:      1,
+		Status:          models.AlertStatusTriaging,
+		AssigneeID:      testAssigneeID,
+		ResolutionNotes: "looking into it",
+	}
+	tableMock.On("UpdateStatus", testAlertID, mock.Anything).Return(updatedItem, nil).Once()
+
+	result, err := API{}.UpdateAlertStatus(input)
+	require.NoError(t, err)
+	require.Equal(t, &models.UpdateAlertStatusOutput{
+		AlertID:       aws.String(testAlertID),
+		RuleID:        aws.String("ruleId"),
+		DedupString:   aws.String(""),
+		CreationTime:  aws.Time(timeInTest),
+		UpdateTime:    aws.Time(timeInTest),
+		EventsMatched: aws.Int(1),
+		Severity:      aws.String("INFO"),
+		LogTypes:      []*string{},
+		Status:        aws.String(models.AlertStatusTriaging),
+		AssigneeID:    aws.String(testAssigneeID),
+	}, result)
+
+	change := tableMock.Calls[0].Arguments.Get(1).(*table.StatusChange)
+	require.Equal(t, models.AlertStatusTriaging, change.Status)
+	require.Equal(t, aws.String(testAssigneeID), change.AssigneeID)
+	require.Equal(t, aws.String("looking into it"), change.ResolutionNotes)
+	require.Equal(t, testUserID, change.UserID)
+	require.WithinDuration(t, time.Now(), change.Time, time.Minute)
+
+	// the users-api was asked for the assignee
+	invoke := lambdaMock.Calls[0].Arguments.Get(0).(*lambda.InvokeInput)
+	require.Equal(t, "panther-users-api", *invoke.FunctionName)
+	require.Contains(t, string(invoke.Payload), `"getUser":{"id":"`+testAssigneeID+`"}`)
+}
+
+func TestUpdateAlertStatusWithoutAssignee(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+
+	input := &models.UpdateAlertStatusInput{
+		AlertID:    aws.String(testAlertID),
+		Status:     aws.String(models.AlertStatusClosed),
+		AssigneeID: aws.String(""), // unassign
+		UserID:     aws.String(testUserID),
+	}
+	tableMock.On("UpdateStatus", testAlertID, mock.Anything).Return(&table.AlertItem{AlertID: testAlertID}, nil).Once()
+
+	_, err := API{}.UpdateAlertStatus(input)
+	require.NoError(t, err)
+	lambdaMock.AssertNotCalled(t, "Invoke", mock.Anything)
+}
+
+func TestUpdateAlertStatusUnknownAssignee(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+
+	input := &models.UpdateAlertStatusInput{
+		AlertID:    aws.String(testAlertID),
+		Status:     aws.String(models.AlertStatusTriaging),
+		AssigneeID: aws.String(testAssigneeID),
+		UserID:     aws.String(testUserID),
+	}
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{
+		FunctionError: aws.String("Unhandled"),
+		Payload:       []byte(`{"errorMessage":"does not exist","errorType":"DoesNotExistError"}`),
+	}, nil).Once()
+
+	_, err := API{}.UpdateAlertStatus(input)
+	require.IsType(t, &genericapi.InvalidInputError{}, err)
+	tableMock.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAlertStatusDoesNotExist(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.UpdateAlertStatusInput{
+		AlertID: aws.String(testAlertID),
+		Status:  aws.String(models.AlertStatusClosed),
+		UserID:  aws.String(testUserID),
+	}
+	tableMock.On("UpdateStatus", testAlertID, mock.Anything).Return((*table.AlertItem)(nil), nil).Once()
+
+	_, err := API{}.UpdateAlertStatus(input)
+	require.IsType(t, &genericapi.DoesNotExistError{}, err)
+}
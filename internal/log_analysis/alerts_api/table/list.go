@@ -19,6 +19,9 @@ package table
  */
 
 import (
+	"sort"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
@@ -26,91 +29,493 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
 )
 
-func (table *AlertsTable) ListByRule(ruleID string, exclusiveStartKey *string, pageSize *int) (
-	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+// maxQueriesPerPage caps the queries made to fill a page when filters skip most alerts,
+// a smaller page is returned with a last evaluated key once it is reached
+const maxQueriesPerPage = 10
 
-	return table.list(RuleIDKey, ruleID, exclusiveStartKey, pageSize)
-}
+// severityIndexKey are the attributes of the key of the severity index
+var severityIndexKey = []string{AlertIDKey, SeverityKey, UpdateTimeKey}
 
-func (table *AlertsTable) ListAll(exclusiveStartKey *string, pageSize *int) (
-	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+// severityCursors is the last evaluated key of the lists using the severity index,
+// it holds the position in each severity partition that was read, nil for the exhausted ones
+type severityCursors map[string]DynamoItem
 
-	return table.list(TimePartitionKey, TimePartitionValue, exclusiveStartKey, pageSize)
-}
+// ListAll returns a page of alerts matching the filters of the input in the requested order, last evaluated key, any error
+func (table *AlertsTable) ListAll(input *models.ListAlertsInput) (summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+	var items []DynamoItem
+	switch aws.StringValue(input.SortBy) {
+	case models.SortByUpdateTime:
+		items, lastEvaluatedKey, err = table.listByUpdateTime(input)
+	case models.SortBySeverity:
+		items, lastEvaluatedKey, err = table.listBySeverity(input)
+	default:
+		items, lastEvaluatedKey, err = table.listByCreationTime(input)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
 
-// list returns a page of alerts ordered by creationTime, last evaluated key, any error
-func (table *AlertsTable) list(ddbKey, ddbValue string, exclusiveStartKey *string, pageSize *int) (
-	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &summaries)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "UnmarshalListOfMaps() failed")
+	}
+	return summaries, lastEvaluatedKey, nil
+}
 
-	// pick index
-	var index string
-	if ddbKey == RuleIDKey {
-		index = table.RuleIDCreationTimeIndexName
-	} else if ddbKey == TimePartitionKey {
-		index = table.TimePartitionCreationTimeIndexName
-	} else {
-		return nil, nil, errors.New("unknown key" + ddbKey)
+// listByCreationTime queries the rule index if a rule is set and the time partition index otherwise
+func (table *AlertsTable) listByCreationTime(input *models.ListAlertsInput) ([]DynamoItem, *string, error) {
+	index, hashKey, hashValue := table.TimePartitionCreationTimeIndexName, TimePartitionKey, TimePartitionValue
+	if input.RuleID != nil {
+		index, hashKey, hashValue = table.RuleIDCreationTimeIndexName, RuleIDKey, *input.RuleID
 	}
 
 	// queries require and = condition on primary key
-	keyCondition := expression.Key(ddbKey).Equal(expression.Value(&ddbValue))
+	keyCondition := expression.Key(hashKey).Equal(expression.Value(hashValue))
+	if timeRange, ok := keyTimeRange(CreationTimeKey, input.CreatedAtAfter, input.CreatedAtBefore); ok {
+		keyCondition = keyCondition.And(timeRange)
+	}
+	queryInput, err := table.queryInput(index, keyCondition, input, RuleIDKey, CreationTimeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if input.ExclusiveStartKey != nil {
+		err = jsoniter.UnmarshalFromString(*input.ExclusiveStartKey, &queryInput.ExclusiveStartKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to Unmarshal ExclusiveStartKey")
+		}
+	}
 
-	queryExpression, err := expression.NewBuilder().
-		WithKeyCondition(keyCondition).
-		Build()
+	items, lastKey, err := table.queryPage(queryInput, input.PageSize, []string{AlertIDKey, hashKey, CreationTimeKey})
+	if err != nil {
+		return nil, nil, err
+	}
 
+	// If DDB returned a LastEvaluatedKey (the "primary key of the item where the operation stopped"),
+	// it means there are more alerts to be returned. Return populated `lastEvaluatedKey` JSON blob in the response.
+	if len(lastKey) == 0 {
+		return items, nil, nil
+	}
+	lastEvaluatedKey, err := jsoniter.MarshalToString(lastKey)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to build expression")
+		return nil, nil, errors.Wrap(err, "failed to Marshal LastEvaluatedKey")
 	}
+	return items, &lastEvaluatedKey, nil
+}
 
-	var queryResultsLimit *int64
-	if pageSize != nil {
-		queryResultsLimit = aws.Int64(int64(*pageSize))
+// listBySeverity reads the severity partitions one after the other, each one ordered by update time
+func (table *AlertsTable) listBySeverity(input *models.ListAlertsInput) ([]DynamoItem, *string, error) {
+	cursors, err := decodeSeverityCursors(input.ExclusiveStartKey)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	var queryExclusiveStartKey map[string]*dynamodb.AttributeValue
-	if exclusiveStartKey != nil {
-		queryExclusiveStartKey = make(map[string]*dynamodb.AttributeValue)
-		err = jsoniter.UnmarshalFromString(*exclusiveStartKey, &queryExclusiveStartKey)
+	severities := listedSeverities(input)
+	var items []DynamoItem
+	for _, severity := range severities {
+		startKey, started := cursors[severity]
+		if started && startKey == nil { // the partition was exhausted by a previous page
+			continue
+		}
+
+		pageSize := input.PageSize
+		if pageSize != nil {
+			pageSize = aws.Int(*pageSize - len(items))
+		}
+		queryInput, err := table.severityQueryInput(input, severity)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to Unmarshal ExclusiveStartKey")
+			return nil, nil, err
+		}
+		queryInput.ExclusiveStartKey = startKey
+
+		partitionItems, lastKey, err := table.queryPage(queryInput, pageSize, severityIndexKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, partitionItems...)
+		cursors[severity] = lastKey
+		// the next partition is only read once this one is exhausted
+		if len(lastKey) > 0 {
+			break
+		}
+	}
+
+	lastEvaluatedKey, err := cursors.encode(severities)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, lastEvaluatedKey, nil
+}
+
+// listByUpdateTime merges the severity partitions, each one ordered by update time
+//
+// A query of a partition that is not exhausted stops at an update time, the alerts left to read in that partition
+// come after it. Only the alerts up to the least advanced of those update times (the first one in sort order)
+// can be added to the page, the partitions resume from the last alert added to the page on the next query.
+func (table *AlertsTable) listByUpdateTime(input *models.ListAlertsInput) ([]DynamoItem, *string, error) {
+	cursors, err := decodeSeverityCursors(input.ExclusiveStartKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type candidate struct {
+		severity   string
+		item       DynamoItem
+		updateTime time.Time
+	}
+
+	severities := listedSeverities(input)
+	ascending := sortAscending(input)
+	// before returns true if the update time of a sorts before the one of b
+	before := func(a, b time.Time) bool {
+		if ascending {
+			return a.Before(b)
+		}
+		return a.After(b)
+	}
+
+	var items []DynamoItem
+	for query := 0; query < maxQueriesPerPage; query++ {
+		if input.PageSize != nil && len(items) >= *input.PageSize {
+			break
+		}
+
+		var candidates []*candidate
+		read := make(map[string]int)
+		lastKeys := make(map[string]DynamoItem)
+		var boundary *time.Time
+		for _, severity := range severities {
+			startKey, started := cursors[severity]
+			if started && startKey == nil {
+				continue
+			}
+
+			queryInput, err := table.severityQueryInput(input, severity)
+			if err != nil {
+				return nil, nil, err
+			}
+			queryInput.ExclusiveStartKey = startKey
+			if input.PageSize != nil {
+				queryInput.Limit = aws.Int64(int64(*input.PageSize - len(items)))
+			}
+			output, err := table.query(queryInput)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, item := range output.Items {
+				candidates = append(candidates, &candidate{
+					severity:   severity,
+					item:       item,
+					updateTime: itemTime(item, UpdateTimeKey),
+				})
+			}
+			read[severity] = len(output.Items)
+			lastKeys[severity] = output.LastEvaluatedKey
+			if len(output.LastEvaluatedKey) > 0 {
+				if stop := itemTime(output.LastEvaluatedKey, UpdateTimeKey); boundary == nil || before(stop, *boundary) {
+					boundary = &stop
+				}
+			}
 		}
+		if len(lastKeys) == 0 { // all the partitions are exhausted
+			break
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if !candidates[i].updateTime.Equal(candidates[j].updateTime) {
+				return before(candidates[i].updateTime, candidates[j].updateTime)
+			}
+			return aws.StringValue(candidates[i].item[AlertIDKey].S) < aws.StringValue(candidates[j].item[AlertIDKey].S)
+		})
+
+		added := make(map[string]int)
+		lastAdded := make(map[string]DynamoItem)
+		for _, c := range candidates {
+			if input.PageSize != nil && len(items) >= *input.PageSize {
+				break
+			}
+			if boundary != nil && before(*boundary, c.updateTime) {
+				break
+			}
+			items = append(items, c.item)
+			added[c.severity]++
+			lastAdded[c.severity] = c.item
+		}
+
+		for severity, lastKey := range lastKeys {
+			switch {
+			case added[severity] == read[severity]: // the partition resumes where the query stopped
+				cursors[severity] = lastKey
+			case added[severity] > 0:
+				cursors[severity] = itemKey(lastAdded[severity], severityIndexKey)
+			}
+		}
+
+		// without a page size, a single page of each partition is read
+		if input.PageSize == nil && len(items) > 0 {
+			break
+		}
+	}
+
+	lastEvaluatedKey, err := cursors.encode(severities)
+	if err != nil {
+		return nil, nil, err
 	}
+	return items, lastEvaluatedKey, nil
+}
 
-	var queryInput = &dynamodb.QueryInput{
+// severityQueryInput returns the query of a partition of the severity index
+func (table *AlertsTable) severityQueryInput(input *models.ListAlertsInput, severity string) (*dynamodb.QueryInput, error) {
+	keyCondition := expression.Key(SeverityKey).Equal(expression.Value(severity))
+	if timeRange, ok := keyTimeRange(UpdateTimeKey, input.UpdatedAtAfter, input.UpdatedAtBefore); ok {
+		keyCondition = keyCondition.And(timeRange)
+	}
+	return table.queryInput(table.SeverityUpdateTimeIndexName, keyCondition, input, SeverityKey, UpdateTimeKey)
+}
+
+// queryInput returns the query of an index, the filters of the input on the key attributes must be in the key condition
+func (table *AlertsTable) queryInput(index string, keyCondition expression.KeyConditionBuilder,
+	input *models.ListAlertsInput, keyAttributes ...string) (*dynamodb.QueryInput, error) {
+
+	builder := expression.NewBuilder().WithKeyCondition(keyCondition)
+	if filter, ok := filterCondition(input, keyAttributes...); ok {
+		// NOTE: the filter is applied after the query reads a page, so pages can have less items than requested
+		builder = builder.WithFilter(filter)
+	}
+	queryExpression, err := builder.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build expression")
+	}
+
+	return &dynamodb.QueryInput{
 		TableName:                 &table.AlertsTableName,
-		ScanIndexForward:          aws.Bool(false),
+		ScanIndexForward:          aws.Bool(sortAscending(input)),
 		ExpressionAttributeNames:  queryExpression.Names(),
 		ExpressionAttributeValues: queryExpression.Values(),
 		KeyConditionExpression:    queryExpression.KeyCondition(),
-		ExclusiveStartKey:         queryExclusiveStartKey,
+		FilterExpression:          queryExpression.Filter(),
 		IndexName:                 aws.String(index),
-		Limit:                     queryResultsLimit,
+	}, nil
+}
+
+// queryPage runs the query until it returns pageSize items or the index is exhausted and returns the items
+// with the key to resume from, keyAttributes are the attributes of the key of the queried index
+//
+// Without a page size, a single query is made and it returns up to 1 MB of alerts.
+func (table *AlertsTable) queryPage(input *dynamodb.QueryInput, pageSize *int, keyAttributes []string) (
+	items []DynamoItem, lastEvaluatedKey DynamoItem, err error) {
+
+	if pageSize == nil {
+		output, err := table.query(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Items, output.LastEvaluatedKey, nil
+	}
+
+	input.Limit = aws.Int64(int64(*pageSize))
+	for query := 0; query < maxQueriesPerPage; query++ {
+		output, err := table.query(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, output.Items...)
+		lastEvaluatedKey = output.LastEvaluatedKey
+
+		if len(items) > *pageSize { // resume after the last item of the page
+			items = items[:*pageSize]
+			return items, itemKey(items[len(items)-1], keyAttributes), nil
+		}
+		if len(items) == *pageSize || len(lastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = lastEvaluatedKey
 	}
+	return items, lastEvaluatedKey, nil
+}
 
-	queryOutput, err := table.Client.Query(queryInput)
+func (table *AlertsTable) query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	output, err := table.Client.Query(input)
 	if err != nil {
 		// this deserves detailed logging for debugging
-		zap.L().Error("Query()", zap.Error(err), zap.Any("input", queryInput), zap.Any("startKey", queryExclusiveStartKey))
-		return nil, nil, errors.Wrapf(err, "QueryInput() failed for %s,%s", ddbKey, ddbValue)
+		zap.L().Error("Query()", zap.Error(err), zap.Any("input", input))
+		return nil, errors.Wrapf(err, "QueryInput() failed for %s", aws.StringValue(input.IndexName))
 	}
+	return output, nil
+}
 
-	err = dynamodbattribute.UnmarshalListOfMaps(queryOutput.Items, &summaries)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "UnmarshalListOfMaps() failed")
+// filterCondition returns the condition matching the filters of the input except for the ones on the key attributes,
+// false if the filters match all alerts
+func filterCondition(input *models.ListAlertsInput, keyAttributes ...string) (expression.ConditionBuilder, bool) {
+	inKey := make(map[string]bool, len(keyAttributes))
+	for _, attribute := range keyAttributes {
+		inKey[attribute] = true
 	}
 
-	// If DDB returned a LastEvaluatedKey (the "primary key of the item where the operation stopped"),
-	// it means there are more alerts to be returned. Return populated `lastEvaluatedKey` JSON blob in the response.
-	if len(queryOutput.LastEvaluatedKey) > 0 {
-		lastEvaluatedKeySerialized, err := jsoniter.MarshalToString(queryOutput.LastEvaluatedKey)
-		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to Marshal LastEvaluatedKey)")
+	var conditions []expression.ConditionBuilder
+	if input.RuleID != nil && !inKey[RuleIDKey] {
+		conditions = append(conditions, expression.Name(RuleIDKey).Equal(expression.Value(*input.RuleID)))
+	}
+	if input.Status != nil {
+		status := expression.Name(StatusKey).Equal(expression.Value(*input.Status))
+		if *input.Status == models.AlertStatusOpen { // alerts created before statuses were introduced are open
+			status = status.Or(expression.Name(StatusKey).AttributeNotExists())
 		}
-		lastEvaluatedKey = &lastEvaluatedKeySerialized
+		conditions = append(conditions, status)
+	}
+	if input.AssigneeID != nil {
+		conditions = append(conditions, expression.Name(AssigneeIDKey).Equal(expression.Value(*input.AssigneeID)))
+	}
+	if len(input.Severity) > 0 && !inKey[SeverityKey] {
+		severities := make([]expression.OperandBuilder, len(input.Severity))
+		for i, severity := range input.Severity {
+			severities[i] = expression.Value(*severity)
+		}
+		conditions = append(conditions, expression.Name(SeverityKey).In(severities[0], severities[1:]...))
+	}
+	if timeRange, ok := timeRangeCondition(CreationTimeKey, input.CreatedAtAfter, input.CreatedAtBefore); ok && !inKey[CreationTimeKey] {
+		conditions = append(conditions, timeRange)
+	}
+	if timeRange, ok := timeRangeCondition(UpdateTimeKey, input.UpdatedAtAfter, input.UpdatedAtBefore); ok && !inKey[UpdateTimeKey] {
+		conditions = append(conditions, timeRange)
+	}
+	if input.LogType != nil {
+		conditions = append(conditions, expression.Name(LogTypesKey).Contains(*input.LogType))
+	}
+	if input.TitleContains != nil {
+		conditions = append(conditions, expression.Name(TitleKey).Contains(*input.TitleContains))
+	}
+	if input.EventCountMin != nil {
+		conditions = append(conditions, expression.Name(EventCountKey).GreaterThanEqual(expression.Value(*input.EventCountMin)))
+	}
+	if input.EventCountMax != nil {
+		conditions = append(conditions, expression.Name(EventCountKey).LessThanEqual(expression.Value(*input.EventCountMax)))
 	}
 
-	return summaries, lastEvaluatedKey, nil
+	switch len(conditions) {
+	case 0:
+		return expression.ConditionBuilder{}, false
+	case 1:
+		return conditions[0], true
+	default:
+		return conditions[0].And(conditions[1], conditions[2:]...), true
+	}
+}
+
+// keyTimeRange returns the key condition on a time range, false if the range is not set
+func keyTimeRange(key string, after, before *time.Time) (expression.KeyConditionBuilder, bool) {
+	switch {
+	case after != nil && before != nil:
+		return expression.Key(key).Between(timeValue(*after), timeValue(*before)), true
+	case after != nil:
+		return expression.Key(key).GreaterThanEqual(timeValue(*after)), true
+	case before != nil:
+		return expression.Key(key).LessThanEqual(timeValue(*before)), true
+	default:
+		return expression.KeyConditionBuilder{}, false
+	}
+}
+
+// timeRangeCondition returns the filter condition on a time range, false if the range is not set
+func timeRangeCondition(name string, after, before *time.Time) (expression.ConditionBuilder, bool) {
+	switch {
+	case after != nil && before != nil:
+		return expression.Name(name).Between(timeValue(*after), timeValue(*before)), true
+	case after != nil:
+		return expression.Name(name).GreaterThanEqual(timeValue(*after)), true
+	case before != nil:
+		return expression.Name(name).LessThanEqual(timeValue(*before)), true
+	default:
+		return expression.ConditionBuilder{}, false
+	}
+}
+
+// timeValue returns the value of a time, alert times are stored in UTC
+func timeValue(t time.Time) expression.ValueBuilder {
+	return expression.Value(t.UTC())
+}
+
+// itemTime returns the time stored in an attribute of the item, the zero time if it is not valid
+func itemTime(item DynamoItem, key string) time.Time {
+	attribute, ok := item[key]
+	if !ok {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339Nano, aws.StringValue(attribute.S))
+	return t
+}
+
+// itemKey returns the key of an item in an index, it can be used to resume a query after the item
+func itemKey(item DynamoItem, keyAttributes []string) DynamoItem {
+	key := make(DynamoItem, len(keyAttributes))
+	for _, attribute := range keyAttributes {
+		key[attribute] = item[attribute]
+	}
+	return key
+}
+
+// listedSeverities returns the severities matching the input in the requested order
+func listedSeverities(input *models.ListAlertsInput) (result []string) {
+	for _, severity := range models.Severities {
+		if len(input.Severity) > 0 && !containsSeverity(input.Severity, severity) {
+			continue
+		}
+		result = append(result, severity)
+	}
+	if !sortAscending(input) {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return result
+}
+
+func containsSeverity(severities []*string, severity string) bool {
+	for _, s := range severities {
+		if aws.StringValue(s) == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// sortAscending returns true if the alerts are listed in ascending order, they are listed in descending order by default
+func sortAscending(input *models.ListAlertsInput) bool {
+	return aws.StringValue(input.SortDir) == models.SortAscending
+}
+
+func decodeSeverityCursors(exclusiveStartKey *string) (severityCursors, error) {
+	cursors := make(severityCursors)
+	if exclusiveStartKey == nil {
+		return cursors, nil
+	}
+	if err := jsoniter.UnmarshalFromString(*exclusiveStartKey, &cursors); err != nil {
+		return nil, errors.Wrap(err, "failed to Unmarshal ExclusiveStartKey")
+	}
+	return cursors, nil
+}
+
+// encode returns the last evaluated key of the list, nil if all the partitions of the severities are exhausted
+func (cursors severityCursors) encode(severities []string) (*string, error) {
+	exhausted := true
+	for _, severity := range severities {
+		if key, started := cursors[severity]; !started || key != nil {
+			exhausted = false
+			break
+		}
+	}
+	if exhausted {
+		return nil, nil
+	}
+
+	lastEvaluatedKey, err := jsoniter.MarshalToString(cursors)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to Marshal LastEvaluatedKey")
+	}
+	return &lastEvaluatedKey, nil
 }
@@ -0,0 +1,317 @@
+package table
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+var listTime = time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func newListTable() (*AlertsTable, *mockDynamoDB) {
+	mockDdbClient := &mockDynamoDB{}
+	return &AlertsTable{
+		AlertsTableName:                    "alertsTableName",
+		RuleIDCreationTimeIndexName:        "ruleIDCreationTimeIndexName",
+		TimePartitionCreationTimeIndexName: "timePartitionCreationTimeIndexName",
+		SeverityUpdateTimeIndexName:        "severityUpdateTimeIndexName",
+		Client:                             mockDdbClient,
+	}, mockDdbClient
+}
+
+// listItem returns an alert updated the given number of minutes after listTime
+func listItem(id, severity string, minutes int) DynamoItem {
+	updateTime := listTime.Add(time.Duration(minutes) * time.Minute).Format(time.RFC3339Nano)
+	return DynamoItem{
+		AlertIDKey:       {S: aws.String(id)},
+		RuleIDKey:        {S: aws.String("ruleId")},
+		TimePartitionKey: {S: aws.String(TimePartitionValue)},
+		SeverityKey:      {S: aws.String(severity)},
+		CreationTimeKey:  {S: aws.String(listTime.Format(time.RFC3339Nano))},
+		UpdateTimeKey:    {S: aws.String(updateTime)},
+	}
+}
+
+func alertIDs(items []*AlertItem) (ids []string) {
+	for _, item := range items {
+		ids = append(ids, item.AlertID)
+	}
+	return ids
+}
+
+// queryIndex matches the queries of an index
+func queryIndex(index string) interface{} {
+	return mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return aws.StringValue(input.IndexName) == index
+	})
+}
+
+// querySeverity matches the queries of a partition of the severity index
+func querySeverity(severity string) interface{} {
+	return mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		for _, value := range input.ExpressionAttributeValues {
+			if aws.StringValue(value.S) == severity {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestListAllByRule(t *testing.T) {
+	table, mockDdbClient := newListTable()
+	input := &models.ListAlertsInput{
+		RuleID:         aws.String("ruleId"),
+		CreatedAtAfter: aws.Time(listTime),
+		TitleContains:  aws.String("root"),
+		PageSize:       aws.Int(10),
+	}
+
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []DynamoItem{listItem("alert-1", "HIGH", 0)},
+	}, nil).Once()
+
+	result, lastEvaluatedKey, err := table.ListAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alert-1"}, alertIDs(result))
+	assert.Nil(t, lastEvaluatedKey)
+	mockDdbClient.AssertExpectations(t)
+
+	queryInput := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "ruleIDCreationTimeIndexName", *queryInput.IndexName)
+	assert.Equal(t, "(#1 = :1) AND (#2 >= :2)", *queryInput.KeyConditionExpression)
+	assert.Equal(t, "contains (#0, :0)", *queryInput.FilterExpression)
+	assert.Equal(t, int64(10), *queryInput.Limit)
+	assert.False(t, *queryInput.ScanIndexForward)
+}
+
+func TestListAllFillsFilteredPages(t *testing.T) {
+	table, mockDdbClient := newListTable()
+	input := &models.ListAlertsInput{
+		LogType:  aws.String("AWS.CloudTrail"),
+		SortDir:  aws.String(models.SortAscending),
+		PageSize: aws.Int(2),
+	}
+
+	// the first query only returns one alert matching the filters, the second one returns more than needed
+	firstKey := DynamoItem{AlertIDKey: {S: aws.String("skipped")}}
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("alert-1", "HIGH", 0)},
+		LastEvaluatedKey: firstKey,
+	}, nil).Once()
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("alert-2", "HIGH", 1), listItem("alert-3", "HIGH", 2)},
+		LastEvaluatedKey: DynamoItem{AlertIDKey: {S: aws.String("alert-4")}},
+	}, nil).Once()
+
+	result, lastEvaluatedKey, err := table.ListAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alert-1", "alert-2"}, alertIDs(result))
+	mockDdbClient.AssertExpectations(t)
+
+	queryInput := mockDdbClient.Calls[1].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "timePartitionCreationTimeIndexName", *queryInput.IndexName)
+	assert.True(t, *queryInput.ScanIndexForward)
+
+	// the next page resumes after the last alert of this one
+	require.NotNil(t, lastEvaluatedKey)
+	var key DynamoItem
+	require.NoError(t, jsoniter.UnmarshalFromString(*lastEvaluatedKey, &key))
+	assert.Equal(t, itemKey(listItem("alert-2", "HIGH", 1), []string{AlertIDKey, TimePartitionKey, CreationTimeKey}), key)
+}
+
+func TestListAllBySeverity(t *testing.T) {
+	table, mockDdbClient := newListTable()
+	input := &models.ListAlertsInput{
+		Severity: aws.StringSlice([]string{"LOW", "CRITICAL", "HIGH"}),
+		SortBy:   aws.String(models.SortBySeverity),
+		PageSize: aws.Int(3),
+	}
+
+	// the critical alerts are exhausted and the page is filled with high ones
+	mockDdbClient.On("Query", querySeverity("CRITICAL")).Return(&dynamodb.QueryOutput{
+		Items: []DynamoItem{listItem("critical-1", "CRITICAL", 0)},
+	}, nil).Once()
+	mockDdbClient.On("Query", querySeverity("HIGH")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("high-1", "HIGH", 5), listItem("high-2", "HIGH", 4)},
+		LastEvaluatedKey: itemKey(listItem("high-2", "HIGH", 4), severityIndexKey),
+	}, nil).Once()
+
+	result, lastEvaluatedKey, err := table.ListAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"critical-1", "high-1", "high-2"}, alertIDs(result))
+	mockDdbClient.AssertExpectations(t)
+	require.NotNil(t, lastEvaluatedKey)
+
+	// the next page resumes the high alerts and then reads the low ones
+	mockDdbClient.On("Query", querySeverity("HIGH")).Return(&dynamodb.QueryOutput{}, nil).Once()
+	mockDdbClient.On("Query", querySeverity("LOW")).Return(&dynamodb.QueryOutput{
+		Items: []DynamoItem{listItem("low-1", "LOW", 0)},
+	}, nil).Once()
+
+	input.ExclusiveStartKey = lastEvaluatedKey
+	result, lastEvaluatedKey, err = table.ListAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"low-1"}, alertIDs(result))
+	assert.Nil(t, lastEvaluatedKey)
+	mockDdbClient.AssertExpectations(t)
+
+	queryInput := mockDdbClient.Calls[2].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "severityUpdateTimeIndexName", *queryInput.IndexName)
+	assert.Equal(t, itemKey(listItem("high-2", "HIGH", 4), severityIndexKey), queryInput.ExclusiveStartKey)
+	assert.Nil(t, queryInput.FilterExpression)
+}
+
+func TestListAllByUpdateTime(t *testing.T) {
+	table, mockDdbClient := newListTable()
+	input := &models.ListAlertsInput{
+		Severity:       aws.StringSlice([]string{"HIGH", "LOW"}),
+		UpdatedAtAfter: aws.Time(listTime),
+		SortBy:         aws.String(models.SortByUpdateTime),
+		PageSize:       aws.Int(4),
+	}
+
+	// the high alerts stop at minute 8, the low alert of minute 7 may come after the unread high ones
+	mockDdbClient.On("Query", querySeverity("HIGH")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("high-10", "HIGH", 10), listItem("high-8", "HIGH", 8)},
+		LastEvaluatedKey: itemKey(listItem("high-8", "HIGH", 8), severityIndexKey),
+	}, nil).Once()
+	mockDdbClient.On("Query", querySeverity("LOW")).Return(&dynamodb.QueryOutput{
+		Items: []DynamoItem{listItem("low-9", "LOW", 9), listItem("low-7", "LOW", 7)},
+	}, nil).Once()
+	// the next queries fill the page with the low alert of minute 7, it comes before the next high alert
+	mockDdbClient.On("Query", querySeverity("HIGH")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("high-6", "HIGH", 6)},
+		LastEvaluatedKey: itemKey(listItem("high-6", "HIGH", 6), severityIndexKey),
+	}, nil).Once()
+	mockDdbClient.On("Query", querySeverity("LOW")).Return(&dynamodb.QueryOutput{
+		Items: []DynamoItem{listItem("low-7", "LOW", 7)},
+	}, nil).Once()
+
+	result, lastEvaluatedKey, err := table.ListAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"high-10", "low-9", "high-8", "low-7"}, alertIDs(result))
+	mockDdbClient.AssertExpectations(t)
+
+	queryInput := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "severityUpdateTimeIndexName", *queryInput.IndexName)
+	assert.Equal(t, "(#0 = :0) AND (#1 >= :1)", *queryInput.KeyConditionExpression)
+	assert.Equal(t, int64(4), *queryInput.Limit)
+	// the low alerts resume after the last one added to the page
+	queryInput = mockDdbClient.Calls[3].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, itemKey(listItem("low-9", "LOW", 9), severityIndexKey), queryInput.ExclusiveStartKey)
+	assert.Equal(t, int64(1), *queryInput.Limit)
+
+	require.NotNil(t, lastEvaluatedKey)
+	cursors, err := decodeSeverityCursors(lastEvaluatedKey)
+	require.NoError(t, err)
+	// the high alerts resume after the last one added to the page and the low alerts are exhausted
+	assert.Equal(t, severityCursors{
+		"HIGH": itemKey(listItem("high-8", "HIGH", 8), severityIndexKey),
+		"LOW":  nil,
+	}, cursors)
+}
+
+func TestListAllByUpdateTimeStopsAtFirstBoundary(t *testing.T) {
+	table, mockDdbClient := newListTable()
+	input := &models.ListAlertsInput{
+		Severity: aws.StringSlice([]string{"HIGH", "LOW"}),
+		SortBy:   aws.String(models.SortByUpdateTime),
+		PageSize: aws.Int(4),
+	}
+
+	// both partitions stop, the unread high alerts may come before the low alert of minute 5
+	mockDdbClient.On("Query", querySeverity("HIGH")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("high-10", "HIGH", 10), listItem("high-8", "HIGH", 8)},
+		LastEvaluatedKey: itemKey(listItem("high-8", "HIGH", 8), severityIndexKey),
+	}, nil).Once()
+	mockDdbClient.On("Query", querySeverity("LOW")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("low-9", "LOW", 9), listItem("low-5", "LOW", 5)},
+		LastEvaluatedKey: itemKey(listItem("low-5", "LOW", 5), severityIndexKey),
+	}, nil).Once()
+	// the next queries fill the page with the high alert of minute 6
+	mockDdbClient.On("Query", querySeverity("HIGH")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("high-6", "HIGH", 6)},
+		LastEvaluatedKey: itemKey(listItem("high-6", "HIGH", 6), severityIndexKey),
+	}, nil).Once()
+	mockDdbClient.On("Query", querySeverity("LOW")).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{listItem("low-5", "LOW", 5)},
+		LastEvaluatedKey: itemKey(listItem("low-5", "LOW", 5), severityIndexKey),
+	}, nil).Once()
+
+	result, lastEvaluatedKey, err := table.ListAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"high-10", "low-9", "high-8", "high-6"}, alertIDs(result))
+	mockDdbClient.AssertExpectations(t)
+
+	require.NotNil(t, lastEvaluatedKey)
+	cursors, err := decodeSeverityCursors(lastEvaluatedKey)
+	require.NoError(t, err)
+	// the low alerts resume after the last one added to the page, not after the one the query stopped at
+	assert.Equal(t, severityCursors{
+		"HIGH": itemKey(listItem("high-6", "HIGH", 6), severityIndexKey),
+		"LOW":  itemKey(listItem("low-9", "LOW", 9), severityIndexKey),
+	}, cursors)
+}
+
+func TestFilterCondition(t *testing.T) {
+	_, ok := filterCondition(&models.ListAlertsInput{})
+	require.False(t, ok)
+
+	input := &models.ListAlertsInput{Status: aws.String("OPEN"), AssigneeID: aws.String("userId")}
+	condition, ok := filterCondition(input)
+	require.True(t, ok)
+	expr, err := expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	require.Equal(t, "((#0 = :0) OR (attribute_not_exists (#0))) AND (#1 = :1)", *expr.Filter())
+
+	condition, ok = filterCondition(&models.ListAlertsInput{Status: aws.String("CLOSED")})
+	require.True(t, ok)
+	expr, err = expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	require.Equal(t, "#0 = :0", *expr.Filter())
+
+	input = &models.ListAlertsInput{
+		RuleID:          aws.String("ruleId"),
+		Severity:        aws.StringSlice([]string{"HIGH", "CRITICAL"}),
+		CreatedAtBefore: aws.Time(listTime),
+		EventCountMin:   aws.Int(10),
+		EventCountMax:   aws.Int(100),
+	}
+	condition, ok = filterCondition(input, RuleIDKey, CreationTimeKey)
+	require.True(t, ok)
+	expr, err = expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	require.Equal(t, "(#0 IN (:0, :1)) AND (#1 >= :2) AND (#1 <= :3)", *expr.Filter())
+
+	// the filters on the key attributes are part of the key condition
+	_, ok = filterCondition(&models.ListAlertsInput{Severity: aws.StringSlice([]string{"HIGH"})}, SeverityKey)
+	require.False(t, ok)
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
 )
 
 const (
@@ -31,13 +33,25 @@ const (
 	AlertIDKey         = "id"
 	TimePartitionKey   = "timePartition"
 	TimePartitionValue = "defaultPartition"
+
+	CreationTimeKey = "creationTime"
+	UpdateTimeKey   = "updateTime"
+	SeverityKey     = "severity"
+	EventCountKey   = "eventCount"
+	LogTypesKey     = "logTypes"
+	TitleKey        = "title"
+
+	StatusKey          = "status"
+	AssigneeIDKey      = "assigneeId"
+	ResolutionNotesKey = "resolutionNotes"
+	StatusHistoryKey   = "statusHistory"
 )
 
 // API defines the interface for the alerts table which can be used for mocking.
 type API interface {
 	GetAlert(*string) (*AlertItem, error)
-	ListByRule(string, *string, *int) ([]*AlertItem, *string, error)
-	ListAll(*string, *int) ([]*AlertItem, *string, error)
+	ListAll(*models.ListAlertsInput) ([]*AlertItem, *string, error)
+	UpdateStatus(string, *StatusChange) (*AlertItem, error)
 }
 
 // AlertsTable encapsulates a connection to the Dynamo alerts table.
@@ -45,6 +59,7 @@ type AlertsTable struct {
 	AlertsTableName                    string
 	RuleIDCreationTimeIndexName        string
 	TimePartitionCreationTimeIndexName string
+	SeverityUpdateTimeIndexName        string
 	Client                             dynamodbiface.DynamoDBAPI
 }
 
@@ -64,4 +79,19 @@ type AlertItem struct {
 	Severity     string    `json:"severity"`
 	EventCount   int       `json:"eventCount"`
 	LogTypes     []string  `json:"logTypes"`
+	Title        string    `json:"title,omitempty"`
+	// The fields below are managed by the alerts API, status is empty for alerts created before it was introduced
+	Status          string          `json:"status,omitempty"`
+	AssigneeID      string          `json:"assigneeId,omitempty"`
+	ResolutionNotes string          `json:"resolutionNotes,omitempty"`
+	StatusHistory   []*StatusChange `json:"statusHistory,omitempty"`
+}
+
+// StatusChange is an update of the status of an alert, nil fields are not changed and empty ones are removed
+type StatusChange struct {
+	Status          string    `json:"status"`
+	AssigneeID      *string   `json:"assigneeId,omitempty"`
+	ResolutionNotes *string   `json:"resolutionNotes,omitempty"`
+	UserID          string    `json:"userId"`
+	Time            time.Time `json:"time"`
 }
@@ -0,0 +1,94 @@
+package table
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+// UpdateStatus applies a status change to an alert and appends it to the status history.
+// It returns the updated alert or nil if the alert does not exist.
+func (table *AlertsTable) UpdateStatus(alertID string, change *StatusChange) (*AlertItem, error) {
+	// empty strings are kept in the history since they record the removal of the assignee or notes
+	encoder := dynamodbattribute.NewEncoder(func(e *dynamodbattribute.Encoder) {
+		e.NullEmptyString = false
+	})
+	history, err := encoder.Encode([]*StatusChange{change})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal status change")
+	}
+
+	// an empty slice would be marshaled to NULL, which list_append rejects
+	emptyHistory := &dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}}
+	update := expression.Set(expression.Name(StatusKey), expression.Value(change.Status)).
+		Set(expression.Name(StatusHistoryKey), expression.ListAppend(
+			expression.IfNotExists(expression.Name(StatusHistoryKey), expression.Value(emptyHistory)),
+			expression.Value(history)))
+	update = setOrRemove(update, AssigneeIDKey, change.AssigneeID)
+	update = setOrRemove(update, ResolutionNotesKey, change.ResolutionNotes)
+
+	updateExpression, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name(AlertIDKey))).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build update expression")
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			AlertIDKey: {S: aws.String(alertID)},
+		},
+		TableName:                 aws.String(table.AlertsTableName),
+		UpdateExpression:          updateExpression.Update(),
+		ConditionExpression:       updateExpression.Condition(),
+		ExpressionAttributeNames:  updateExpression.Names(),
+		ExpressionAttributeValues: updateExpression.Values(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	}
+	output, err := table.Client.UpdateItem(input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "UpdateItem() failed for: "+alertID)
+	}
+
+	alertItem := &AlertItem{}
+	if err = dynamodbattribute.UnmarshalMap(output.Attributes, alertItem); err != nil {
+		return nil, errors.Wrap(err, "UnmarshalMap() failed for: "+alertID)
+	}
+	return alertItem, nil
+}
+
+// setOrRemove updates an optional attribute: nil values are not changed and empty ones are removed
+func setOrRemove(update expression.UpdateBuilder, key string, value *string) expression.UpdateBuilder {
+	if value == nil {
+		return update
+	}
+	if *value == "" {
+		return update.Remove(expression.Name(key))
+	}
+	return update.Set(expression.Name(key), expression.Value(*value))
+}
@@ -0,0 +1,106 @@
+package table
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStatus(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{
+		AlertsTableName: "alertsTableName",
+		Client:          mockDdbClient,
+	}
+
+	change := &StatusChange{
+		Status:          "CLOSED",
+		AssigneeID:      aws.String(""),
+		ResolutionNotes: aws.String("expected"),
+		UserID:          "userId",
+		Time:            time.Now().UTC(),
+	}
+	expectedAlert := &AlertItem{
+		AlertID:         "alertId",
+		RuleID:          "ruleId",
+		Status:          "CLOSED",
+		ResolutionNotes: "expected",
+		StatusHistory:   []*StatusChange{change},
+	}
+	encoder := dynamodbattribute.NewEncoder(func(e *dynamodbattribute.Encoder) {
+		e.NullEmptyString = false
+	})
+	item, err := encoder.Encode(expectedAlert)
+	require.NoError(t, err)
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item.M}, nil)
+
+	result, err := table.UpdateStatus("alertId", change)
+	require.NoError(t, err)
+	require.Equal(t, expectedAlert, result)
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	require.Equal(t, map[string]*dynamodb.AttributeValue{"id": {S: aws.String("alertId")}}, input.Key)
+	require.Equal(t, "alertsTableName", *input.TableName)
+	require.Equal(t, dynamodb.ReturnValueAllNew, *input.ReturnValues)
+	require.NotNil(t, input.ConditionExpression)
+	// the assignee is removed, the notes are set
+	require.Contains(t, *input.UpdateExpression, "REMOVE ")
+	names := make([]string, 0, len(input.ExpressionAttributeNames))
+	for _, name := range input.ExpressionAttributeNames {
+		names = append(names, *name)
+	}
+	require.ElementsMatch(t, []string{"id", "status", "statusHistory", "assigneeId", "resolutionNotes"}, names)
+	// the removal of the assignee is recorded in the history
+	var history, emptyHistory *dynamodb.AttributeValue
+	for _, value := range input.ExpressionAttributeValues {
+		require.Nil(t, value.NULL)
+		if value.L != nil && len(value.L) == 1 {
+			history = value.L[0]
+		}
+		if value.L != nil && len(value.L) == 0 {
+			emptyHistory = value
+		}
+	}
+	require.NotNil(t, history)
+	require.Equal(t, aws.String(""), history.M["assigneeId"].S)
+	// alerts without a history start from an empty list, not NULL
+	require.NotNil(t, emptyHistory)
+}
+
+func TestUpdateStatusDoesNotExist(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{
+		AlertsTableName: "alertsTableName",
+		Client:          mockDdbClient,
+	}
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "does not exist", nil))
+
+	result, err := table.UpdateStatus("alertId", &StatusChange{Status: "CLOSED", UserID: "userId"})
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
@@ -0,0 +1,137 @@
+package main
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/customlogs"
+	"github.com/panther-labs/panther/pkg/awsglue"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/tools/cfngen/gluecf"
+)
+
+const (
+	sourceAPIFunctionName = "panther-source-api"
+	// How frequently to query the source API for new or updated custom log schemas
+	customLogSchemaCacheDuration = 5 * time.Minute
+)
+
+var (
+	// tables of custom log types are named after the log type like all tables, so they all have this prefix
+	customTablePrefix = awsglue.GetTableName(sourcemodels.CustomLogTypePrefix)
+
+	customLogSchemaCache = &customLogSchemaCacheStruct{
+		cacheUpdateTime: time.Unix(0, 0),
+	}
+
+	// customTableCache stores the last modification time of the schema each custom table was created or updated with
+	// The cache is used to avoid attempts to create the same tables in Glue
+	customTableCache = make(map[string]time.Time)
+)
+
+type customLogSchemaCacheStruct struct {
+	cacheUpdateTime time.Time
+	schemas         map[string]*sourcemodels.CustomLogSchema // table name -> schema
+}
+
+// createCustomTable creates the Glue table of a partition of a custom log type, or updates its columns if fields
+// were appended to the schema. Unlike the tables of built-in log types, these are not created when Panther is deployed.
+func createCustomTable(gluePartition *awsglue.GluePartition) error {
+	if !strings.HasPrefix(gluePartition.GetTable(), customTablePrefix) {
+		return nil
+	}
+
+	schema, err := getCustomLogSchema(gluePartition.GetTable())
+	if err != nil {
+		return err
+	}
+	if schema == nil { // the schema was deleted, its table is kept
+		return nil
+	}
+
+	cacheKey := gluePartition.GetDatabase() + "." + gluePartition.GetTable()
+	lastModified := aws.TimeValue(schema.LastModified)
+	if createdWith, ok := customTableCache[cacheKey]; ok && createdWith.Equal(lastModified) {
+		return nil
+	}
+
+	parser, err := customlogs.NewParser(&schema.CustomLogSchemaSettings)
+	if err != nil {
+		return errors.Wrapf(err, "invalid custom log schema %s", aws.StringValue(schema.LogType))
+	}
+	var tableInput *glue.TableInput
+	if gluePartition.GetDatabase() == awsglue.RuleMatchDatabaseName {
+		table := awsglue.NewGlueTableMetadata(
+			models.RuleData, parser.LogType(), parser.Description(), awsglue.GlueTableHourly, parser.EventStruct())
+		tableInput = gluecf.NewGlueTableInput(
+			table, gluePartition.GetS3Bucket(), gluePartition.GetDataFormat(), gluecf.RuleMatchColumns...)
+	} else {
+		table := awsglue.NewGlueTableMetadata(
+			models.LogData, parser.LogType(), parser.Description(), awsglue.GlueTableHourly, parser.EventStruct())
+		tableInput = gluecf.NewGlueTableInput(table, gluePartition.GetS3Bucket(), gluePartition.GetDataFormat())
+	}
+
+	_, err = glueClient.CreateTable(&glue.CreateTableInput{
+		DatabaseName: aws.String(gluePartition.GetDatabase()),
+		TableInput:   tableInput,
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == glue.ErrCodeAlreadyExistsException {
+		_, err = glueClient.UpdateTable(&glue.UpdateTableInput{
+			DatabaseName: aws.String(gluePartition.GetDatabase()),
+			TableInput:   tableInput,
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to create or update glue table %s", cacheKey)
+	}
+	customTableCache[cacheKey] = lastModified // remember
+	return nil
+}
+
+// getCustomLogSchema returns the schema of a custom log type table or nil if there is none
+func getCustomLogSchema(tableName string) (*sourcemodels.CustomLogSchema, error) {
+	expired := customLogSchemaCache.cacheUpdateTime.Add(customLogSchemaCacheDuration).Before(time.Now())
+	if schema, ok := customLogSchemaCache.schemas[tableName]; ok && !expired {
+		return schema, nil
+	}
+
+	// the schema may have been added since the cache was updated
+	input := &sourcemodels.LambdaInput{
+		ListCustomLogSchemas: &sourcemodels.ListCustomLogSchemasInput{},
+	}
+	var output []*sourcemodels.CustomLogSchema
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output); err != nil {
+		return nil, errors.Wrap(err, "failed to list custom log schemas")
+	}
+	customLogSchemaCache.cacheUpdateTime = time.Now()
+	customLogSchemaCache.schemas = make(map[string]*sourcemodels.CustomLogSchema, len(output))
+	for _, schema := range output {
+		customLogSchemaCache.schemas[awsglue.GetTableName(aws.StringValue(schema.LogType))] = schema
+	}
+	return customLogSchemaCache.schemas[tableName], nil
+}
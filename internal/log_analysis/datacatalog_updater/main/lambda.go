@@ -28,6 +28,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	lambdaservice "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -44,8 +46,10 @@ const (
 )
 
 var (
-	validation                   = validator.New()
-	glueClient glueiface.GlueAPI = glue.New(session.Must(session.NewSession(aws.NewConfig().WithMaxRetries(maxRetries))))
+	validation                         = validator.New()
+	awsSession                         = session.Must(session.NewSession(aws.NewConfig().WithMaxRetries(maxRetries)))
+	glueClient   glueiface.GlueAPI     = glue.New(awsSession)
+	lambdaClient lambdaiface.LambdaAPI = lambdaservice.New(awsSession)
 	// partitionPrefixCache is a cache that stores all the prefixes of the partitions we have created
 	// The cache is used to avoid attempts to create the same partitions in Glue table
 	partitionPrefixCache = make(map[string]struct{})
@@ -93,6 +97,12 @@ func process(event events.SQSEvent) error {
 			continue
 		}
 
+		err = createCustomTable(gluePartition)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to create table: %#v", notification)
+			return err
+		}
+
 		err = gluePartition.CreatePartition(glueClient)
 		if err != nil {
 			err = errors.Wrapf(err, "failed to create partition: %#v", notification)
@@ -21,17 +21,22 @@ package main
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 var (
@@ -118,8 +123,55 @@ func TestProcessInvalidS3Key(t *testing.T) {
 	assert.NoError(t, process(getEvent(t, "test")))
 }
 
+func TestProcessCreatesCustomTable(t *testing.T) {
+	mockClient := initTest()
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	mockLambda.On("Invoke", mock.Anything).Return(getCustomLogSchemasOutput(t), nil).Once()
+	mockClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Once()
+	mockClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Twice()
+	mockClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{}, nil).Twice()
+
+	assert.NoError(t, process(getEvent(t, "logs/custom_myapp/year=2020/month=02/day=26/hour=15/item.json.gz")))
+	// The table should be created only once
+	assert.NoError(t, process(getEvent(t, "logs/custom_myapp/year=2020/month=02/day=26/hour=16/item.json.gz")))
+	mockClient.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+
+	createTableInput := mockClient.Calls[0].Arguments.Get(0).(*glue.CreateTableInput)
+	assert.Equal(t, "panther_logs", *createTableInput.DatabaseName)
+	assert.Equal(t, "custom_myapp", *createTableInput.TableInput.Name)
+	assert.Equal(t, "s3://bucket/logs/custom_myapp/", *createTableInput.TableInput.StorageDescriptor.Location)
+	assert.Equal(t, "message", *createTableInput.TableInput.StorageDescriptor.Columns[0].Name)
+}
+
+func TestProcessUpdatesCustomRuleTable(t *testing.T) {
+	mockClient := initTest()
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	mockLambda.On("Invoke", mock.Anything).Return(getCustomLogSchemasOutput(t), nil).Once()
+	mockClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{},
+		awserr.New(glue.ErrCodeAlreadyExistsException, "exists", nil)).Once()
+	mockClient.On("UpdateTable", mock.Anything).Return(&glue.UpdateTableOutput{}, nil).Once()
+	mockClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	mockClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{}, nil).Once()
+
+	assert.NoError(t, process(getEvent(t, "rules/custom_myapp/year=2020/month=02/day=26/hour=15/rule_id=Rule.Id/item.json.gz")))
+	mockClient.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+
+	updateTableInput := mockClient.Calls[1].Arguments.Get(0).(*glue.UpdateTableInput)
+	assert.Equal(t, "panther_rule_matches", *updateTableInput.DatabaseName)
+	columns := updateTableInput.TableInput.StorageDescriptor.Columns
+	assert.Equal(t, "p_alert_update_time", *columns[len(columns)-1].Name)
+}
+
 func initTest() *mockGlue {
 	partitionPrefixCache = make(map[string]struct{})
+	customTableCache = make(map[string]time.Time)
+	customLogSchemaCache.cacheUpdateTime = time.Unix(0, 0)
 	mockClient := &mockGlue{}
 	glueClient = mockClient
 	return mockClient
@@ -144,6 +196,29 @@ func getEvent(t *testing.T, s3Keys ...string) events.SQSEvent {
 	return result
 }
 
+func getCustomLogSchemasOutput(t *testing.T) *lambda.InvokeOutput {
+	schemas := []*sourcemodels.CustomLogSchema{
+		{
+			CustomLogSchemaSettings: sourcemodels.CustomLogSchemaSettings{
+				LogType:     aws.String("Custom.MyApp"),
+				Description: aws.String("My application logs"),
+				Format:      aws.String(sourcemodels.CustomLogFormatJSON),
+				Fields: []*sourcemodels.CustomLogField{
+					{
+						Name:        aws.String("message"),
+						Type:        aws.String(sourcemodels.CustomFieldTypeString),
+						Description: aws.String("The message"),
+					},
+				},
+			},
+			LastModified: aws.Time(time.Now()),
+		},
+	}
+	payload, err := jsoniter.Marshal(schemas)
+	require.NoError(t, err)
+	return &lambda.InvokeOutput{Payload: payload}
+}
+
 type mockGlue struct {
 	glueiface.GlueAPI
 	mock.Mock
@@ -158,3 +233,13 @@ func (m *mockGlue) CreatePartition(input *glue.CreatePartitionInput) (*glue.Crea
 	args := m.Called(input)
 	return args.Get(0).(*glue.CreatePartitionOutput), args.Error(1)
 }
+
+func (m *mockGlue) CreateTable(input *glue.CreateTableInput) (*glue.CreateTableOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.CreateTableOutput), args.Error(1)
+}
+
+func (m *mockGlue) UpdateTable(input *glue.UpdateTableInput) (*glue.UpdateTableOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.UpdateTableOutput), args.Error(1)
+}
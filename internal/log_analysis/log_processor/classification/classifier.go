@@ -19,13 +19,16 @@ package classification
  */
 
 import (
+	"bufio"
 	"container/heap"
 	"fmt"
+	"io"
 	"runtime/debug"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
@@ -35,6 +38,12 @@ import (
 type ClassifierAPI interface {
 	// Classify attempts to classify the provided log line
 	Classify(log string) *ClassifierResult
+	// ClassifyStream attempts to classify the whole stream using a parsers.StreamParser, calling emit with
+	// each raw record and its classification. It returns false if no parser recognizes the stream, in which case
+	// nothing has been consumed and the stream should be classified line by line.
+	ClassifyStream(stream *bufio.Reader, emit func(record []byte, result *ClassifierResult)) (bool, error)
+	// Framing returns how the records to classify are delimited in a stream
+	Framing() parsers.Framing
 	// aggregate stats
 	Stats() *ClassifierStats
 	// per-parser stats, map of LogType -> stats
@@ -49,12 +58,20 @@ type ClassifierResult struct {
 	Events []*parsers.PantherLog
 	// LogType is the identified type of the log
 	LogType *string
+	// AttemptedLogTypes are the log types of the parsers that failed to parse the log
+	// It is only set if the classification process was not successful
+	AttemptedLogTypes []string
 }
 
+// streamHeaderSize is how many bytes are peeked from a stream to decide if a parsers.StreamParser supports it.
+// It must not be larger than the buffer of the bufio.Reader of the stream (4096 bytes by default).
+const streamHeaderSize = 4096
+
 // NewClassifier returns a new instance of a ClassifierAPI implementation
-func NewClassifier() ClassifierAPI {
+// Only the parsers for the given log types will be considered. If no log types are given, all registered parsers are used.
+func NewClassifier(logTypes []string) ClassifierAPI {
 	parserQueue := &ParserPriorityQueue{}
-	parserQueue.initialize()
+	parserQueue.initialize(logTypes)
 	return &Classifier{
 		parsers:     parserQueue,
 		parserStats: make(map[string]*ParserStats),
@@ -94,6 +111,22 @@ func safeLogParse(parser parsers.LogParser, log string) (parsedEvents []*parsers
 	return parsedEvents
 }
 
+// catch panics from stream parsers, log and fail the stream since it cannot be resumed
+func safeStreamParse(parser parsers.StreamParser, stream io.Reader,
+	emit func(record []byte, events []*parsers.PantherLog)) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			zap.L().Error("parser panic",
+				zap.String("parser", parser.LogType()),
+				zap.Error(fmt.Errorf("%v", r)),
+				zap.String("stacktrace", string(debug.Stack())))
+			err = errors.Errorf("parser %s panicked: %v", parser.LogType(), r)
+		}
+	}()
+	return parser.ParseStream(stream, emit)
+}
+
 // Classify attempts to classify the provided log line
 func (c *Classifier) Classify(log string) *ClassifierResult {
 	startClassify := time.Now().UTC()
@@ -129,11 +162,16 @@ func (c *Classifier) Classify(log string) *ClassifierResult {
 	for c.parsers.Len() > 0 {
 		currentItem := c.parsers.Peek()
 
+		timestampFailures := timestampFailureCount(currentItem.parser)
 		startParseTime := time.Now().UTC()
 		parsedEvents := safeLogParse(currentItem.parser, log)
 		endParseTime := time.Now().UTC()
 
 		logType := currentItem.parser.LogType()
+		if failures := timestampFailureCount(currentItem.parser) - timestampFailures; failures > 0 {
+			// counted even if parsing failed, since it is likely the reason why
+			c.getParserStats(logType).TimestampFailureCount += failures
+		}
 
 		// Parser failed to parse event
 		if parsedEvents == nil {
@@ -154,15 +192,7 @@ func (c *Classifier) Classify(log string) *ClassifierResult {
 		result.Events = parsedEvents
 
 		// update per-parser stats
-		var parserStat *ParserStats
-		var parserStatExists bool
-		// lazy create
-		if parserStat, parserStatExists = c.parserStats[logType]; !parserStatExists {
-			parserStat = &ParserStats{
-				LogType: logType,
-			}
-			c.parserStats[logType] = parserStat
-		}
+		parserStat := c.getParserStats(logType)
 		parserStat.ParserTimeMicroseconds += uint64(endParseTime.Sub(startParseTime).Microseconds())
 		parserStat.BytesProcessedCount += uint64(len(log))
 		parserStat.LogLineCount++
@@ -173,11 +203,119 @@ func (c *Classifier) Classify(log string) *ClassifierResult {
 
 	// Put back the popped items to the ParserPriorityQueue.
 	for _, item := range popped {
+		if result.LogType == nil {
+			result.AttemptedLogTypes = append(result.AttemptedLogTypes, item.(*ParserQueueItem).parser.LogType())
+		}
 		heap.Push(c.parsers, item)
 	}
 	return result
 }
 
+// ClassifyStream attempts to classify the whole stream using a parsers.StreamParser
+func (c *Classifier) ClassifyStream(stream *bufio.Reader,
+	emit func(record []byte, result *ClassifierResult)) (bool, error) {
+
+	header, err := stream.Peek(streamHeaderSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull { // EOF or ErrBufferFull means stream is shorter than n
+		return false, errors.Wrap(err, "failed to Peek() stream header")
+	}
+
+	var streamParser parsers.StreamParser
+	for _, item := range c.parsers.items {
+		if parser, ok := item.parser.(parsers.StreamParser); ok && parser.CanParseStream(header) {
+			streamParser = parser
+			break
+		}
+	}
+	if streamParser == nil {
+		return false, nil
+	}
+
+	startClassify := time.Now().UTC()
+	parserStat := c.getParserStats(streamParser.LogType())
+
+	counter := &countingReader{reader: stream}
+	logType := streamParser.LogType()
+	err = safeStreamParse(streamParser, counter, func(record []byte, events []*parsers.PantherLog) {
+		c.stats.LogLineCount++
+		if events == nil {
+			c.stats.ClassificationFailureCount++
+			emit(record, &ClassifierResult{AttemptedLogTypes: []string{logType}})
+			return
+		}
+		c.stats.SuccessfullyClassifiedCount++
+		c.stats.EventCount += uint64(len(events))
+		parserStat.LogLineCount++
+		parserStat.EventCount += uint64(len(events))
+		emit(record, &ClassifierResult{Events: events, LogType: &logType})
+	})
+
+	classifyTime := uint64(time.Since(startClassify).Microseconds())
+	c.stats.ClassifyTimeMicroseconds = classifyTime
+	c.stats.BytesProcessedCount += counter.count
+	parserStat.ParserTimeMicroseconds += classifyTime
+	parserStat.BytesProcessedCount += counter.count
+
+	return true, err
+}
+
+// Framing returns the framing declared by the parsers (see parsers.FramedParser). Since records must be framed
+// before they can be classified, the framing is only used if all parsers agree, otherwise records are lines.
+func (c *Classifier) Framing() parsers.Framing {
+	lineFraming := parsers.Framing{Strategy: parsers.FramingLine}
+	var framing *parsers.Framing
+	for _, item := range c.parsers.items {
+		itemFraming := lineFraming
+		if parser, ok := item.parser.(parsers.FramedParser); ok {
+			itemFraming = parser.Framing()
+		}
+		if framing == nil {
+			framing = &itemFraming
+			continue
+		}
+		if !framing.Equal(itemFraming) {
+			zap.L().Warn("log types have different framings, records are read line by line",
+				zap.String("framing", framing.Strategy), zap.String("otherFraming", itemFraming.Strategy))
+			return lineFraming
+		}
+	}
+	if framing == nil {
+		return lineFraming
+	}
+	return *framing
+}
+
+// getParserStats returns the stats of a log type, creating them lazily
+func (c *Classifier) getParserStats(logType string) *ParserStats {
+	parserStat, parserStatExists := c.parserStats[logType]
+	if !parserStatExists {
+		parserStat = &ParserStats{
+			LogType: logType,
+		}
+		c.parserStats[logType] = parserStat
+	}
+	return parserStat
+}
+
+func timestampFailureCount(parser parsers.LogParser) uint64 {
+	if counter, ok := parser.(parsers.TimestampFailureCounter); ok {
+		return counter.TimestampFailureCount()
+	}
+	return 0
+}
+
+// countingReader counts the bytes read from a stream for stats
+type countingReader struct {
+	reader io.Reader
+	count  uint64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += uint64(n)
+	return n, err
+}
+
 // aggregate stats
 type ClassifierStats struct {
 	ClassifyTimeMicroseconds    uint64 // total time parsing
@@ -194,5 +332,6 @@ type ParserStats struct {
 	BytesProcessedCount    uint64 // input bytes
 	LogLineCount           uint64 // input records
 	EventCount             uint64 // output records
+	TimestampFailureCount  uint64 // timestamps that failed to parse
 	LogType                string
 }
@@ -19,6 +19,10 @@ package classification
  */
 
 import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -26,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 )
 
@@ -52,6 +57,58 @@ func (m *mockParser) LogType() string {
 	return args.String(0)
 }
 
+// framedParser declares a framing of its records
+type framedParser struct {
+	mockParser
+	framing parsers.Framing
+}
+
+func (m *framedParser) New() parsers.LogParser {
+	return m // pass through (not stateful)
+}
+
+func (m *framedParser) Framing() parsers.Framing {
+	return m.framing
+}
+
+// panicStreamParser claims every stream and panics while parsing it
+type panicStreamParser struct {
+	mockParser
+}
+
+func (m *panicStreamParser) New() parsers.LogParser {
+	return m // pass through (not stateful)
+}
+
+func (m *panicStreamParser) CanParseStream(header []byte) bool {
+	return true
+}
+
+func (m *panicStreamParser) ParseStream(stream io.Reader, emit func([]byte, []*parsers.PantherLog)) error {
+	panic("test stream parser panic")
+}
+
+// timestampParser fails to parse timestamps of logs starting with "bad"
+type timestampParser struct {
+	mockParser
+	failures uint64
+}
+
+func (m *timestampParser) New() parsers.LogParser {
+	return m // pass through (not stateful)
+}
+
+func (m *timestampParser) Parse(log string) []*parsers.PantherLog {
+	if strings.HasPrefix(log, "bad") {
+		m.failures++
+	}
+	return m.mockParser.Parse(log)
+}
+
+func (m *timestampParser) TimestampFailureCount() uint64 {
+	return m.failures
+}
+
 // admit to registry.Interface interface
 type TestRegistry map[string]*registry.LogParserMetadata
 
@@ -94,7 +151,7 @@ func TestClassifyRespectsPriorityOfParsers(t *testing.T) {
 		testRegistry.Add(availableParsers[i]) // update registry
 	}
 
-	classifier := NewClassifier()
+	classifier := NewClassifier(nil)
 
 	logLine := "log"
 
@@ -153,7 +210,7 @@ func TestClassifyNoMatch(t *testing.T) {
 		testRegistry.Add(availableParsers[i]) // update registry
 	}
 
-	classifier := NewClassifier()
+	classifier := NewClassifier(nil)
 
 	logLine := "log"
 
@@ -171,11 +228,166 @@ func TestClassifyNoMatch(t *testing.T) {
 	expectedStats.ClassifyTimeMicroseconds = classifier.Stats().ClassifyTimeMicroseconds
 	require.Equal(t, expectedStats, classifier.Stats())
 
-	require.Equal(t, &ClassifierResult{}, result)
+	require.Equal(t, &ClassifierResult{AttemptedLogTypes: []string{"failure"}}, result)
 	failingParser.AssertNumberOfCalls(t, "Parse", 1)
 	require.Nil(t, classifier.ParserStats()[failingParser.LogType()])
 }
 
+func TestClassifyOnlyConfiguredLogTypes(t *testing.T) {
+	configuredParser := &mockParser{}
+	otherParser := &mockParser{}
+
+	configuredParser.On("Parse", mock.Anything).Return(nil)
+	configuredParser.On("LogType").Return("configured")
+	otherParser.On("Parse", mock.Anything).Return([]*parsers.PantherLog{{}})
+	otherParser.On("LogType").Return("other")
+
+	availableParsers := []*registry.LogParserMetadata{
+		{Parser: configuredParser},
+		{Parser: otherParser},
+	}
+	testRegistry := NewTestRegistry()
+	parserRegistry = testRegistry // re-bind as interface
+	for i := range availableParsers {
+		testRegistry.Add(availableParsers[i]) // update registry
+	}
+
+	// unknown log types are skipped
+	classifier := NewClassifier([]string{"configured", "unknown"})
+
+	result := classifier.Classify("log")
+
+	require.Equal(t, &ClassifierResult{AttemptedLogTypes: []string{"configured"}}, result)
+	configuredParser.AssertNumberOfCalls(t, "Parse", 1)
+	otherParser.AssertNotCalled(t, "Parse", mock.Anything)
+	require.Equal(t, uint64(1), classifier.Stats().ClassificationFailureCount)
+}
+
+func TestClassifyStream(t *testing.T) {
+	lineParser := &mockParser{}
+	lineParser.On("Parse", mock.Anything).Return([]*parsers.PantherLog{{}})
+	lineParser.On("LogType").Return("line")
+
+	availableParsers := []*registry.LogParserMetadata{
+		{Parser: lineParser},
+		{Parser: &awslogs.CloudTrailParser{}},
+	}
+	testRegistry := NewTestRegistry()
+	parserRegistry = testRegistry // re-bind as interface
+	for i := range availableParsers {
+		testRegistry.Add(availableParsers[i]) // update registry
+	}
+
+	classifier := NewClassifier(nil)
+
+	//nolint:lll
+	log := `{"Records": [{"eventVersion":"1.05","userIdentity":{"type":"AWSService"},"eventTime":"2018-08-26T14:17:23Z","eventSource":"kms.amazonaws.com","eventName":"Decrypt","awsRegion":"us-west-2","sourceIPAddress":"1.2.3.4","eventID":"1","eventType":"AwsApiCall"},
+{"eventVersion":"1.05","userIdentity":{"type":"AWSService"},"eventTime":"2018-08-26T14:17:23Z","eventSource":"kms.amazonaws.com","eventName":"Decrypt","awsRegion":"us-west-2","sourceIPAddress":"1.2.3.4","eventID":"2","eventType":"AwsApiCall"},
+{"eventVersion":"1.05","eventID":"3"}]}`
+
+	var events []*parsers.PantherLog
+	var failedRecords []string
+	stream := bufio.NewReader(strings.NewReader(log))
+	classified, err := classifier.ClassifyStream(stream, func(record []byte, result *ClassifierResult) {
+		if result.LogType == nil { // failed records are passed through so they can be stored
+			require.Equal(t, []string{"AWS.CloudTrail"}, result.AttemptedLogTypes)
+			failedRecords = append(failedRecords, string(record))
+			return
+		}
+		require.Equal(t, "AWS.CloudTrail", *result.LogType)
+		events = append(events, result.Events...)
+	})
+	require.NoError(t, err)
+	require.True(t, classified)
+	require.Equal(t, 2, len(events))
+	require.Equal(t, []string{`{"eventVersion":"1.05","eventID":"3"}`}, failedRecords)
+
+	expectedStats := &ClassifierStats{
+		ClassifyTimeMicroseconds:    classifier.Stats().ClassifyTimeMicroseconds,
+		BytesProcessedCount:         uint64(len(log)),
+		LogLineCount:                3,
+		EventCount:                  2,
+		SuccessfullyClassifiedCount: 2,
+		ClassificationFailureCount:  1,
+	}
+	require.Equal(t, expectedStats, classifier.Stats())
+	require.Equal(t, uint64(2), classifier.ParserStats()["AWS.CloudTrail"].EventCount)
+	lineParser.AssertNotCalled(t, "Parse", mock.Anything)
+
+	// not a stream, nothing is consumed
+	stream = bufio.NewReader(strings.NewReader("line log"))
+	classified, err = classifier.ClassifyStream(stream, func(record []byte, result *ClassifierResult) {
+		require.Fail(t, "no events expected")
+	})
+	require.NoError(t, err)
+	require.False(t, classified)
+	line, err := stream.ReadString('\n')
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, "line log", line)
+}
+
+func TestClassifierFraming(t *testing.T) {
+	lineParser := &mockParser{}
+	lineParser.On("LogType").Return("line")
+	jsonParser := &framedParser{framing: parsers.Framing{Strategy: parsers.FramingJSON}}
+	jsonParser.On("LogType").Return("json")
+	regexParser := &framedParser{framing: parsers.Framing{
+		Strategy:    parsers.FramingRegex,
+		RecordStart: regexp.MustCompile(`^\d{4}-`),
+	}}
+	regexParser.On("LogType").Return("regex")
+	otherRegexParser := &framedParser{framing: parsers.Framing{
+		Strategy:    parsers.FramingRegex,
+		RecordStart: regexp.MustCompile(`^\d{4}-`),
+	}}
+	otherRegexParser.On("LogType").Return("otherRegex")
+
+	availableParsers := []*registry.LogParserMetadata{
+		{Parser: lineParser},
+		{Parser: jsonParser},
+		{Parser: regexParser},
+		{Parser: otherRegexParser},
+	}
+	testRegistry := NewTestRegistry()
+	parserRegistry = testRegistry // re-bind as interface
+	for i := range availableParsers {
+		testRegistry.Add(availableParsers[i]) // update registry
+	}
+
+	require.Equal(t, parsers.FramingLine, NewClassifier([]string{"line"}).Framing().Strategy)
+	require.Equal(t, parsers.FramingJSON, NewClassifier([]string{"json"}).Framing().Strategy)
+	// parsers with the same framing
+	require.Equal(t, regexParser.framing, NewClassifier([]string{"regex", "otherRegex"}).Framing())
+	// parsers with different framings fall back to lines
+	require.Equal(t, parsers.FramingLine, NewClassifier([]string{"json", "regex"}).Framing().Strategy)
+	require.Equal(t, parsers.FramingLine, NewClassifier([]string{"line", "json"}).Framing().Strategy)
+	require.Equal(t, parsers.FramingLine, NewClassifier(nil).Framing().Strategy)
+}
+
+func TestClassifyTimestampFailures(t *testing.T) {
+	parser := &timestampParser{}
+	parser.On("Parse", "bad timestamp").Return(nil)
+	parser.On("Parse", "good timestamp").Return([]*parsers.PantherLog{{}})
+	parser.On("LogType").Return("timestamps")
+
+	testRegistry := NewTestRegistry()
+	parserRegistry = testRegistry // re-bind as interface
+	testRegistry.Add(&registry.LogParserMetadata{Parser: parser})
+
+	classifier := NewClassifier(nil)
+	result := classifier.Classify("bad timestamp")
+	require.Nil(t, result.LogType)
+	result = classifier.Classify("good timestamp")
+	require.Equal(t, aws.String("timestamps"), result.LogType)
+	result = classifier.Classify("bad timestamp")
+	require.Nil(t, result.LogType)
+
+	// the failures are counted even if parsing failed
+	parserStats := classifier.ParserStats()["timestamps"]
+	require.Equal(t, uint64(2), parserStats.TimestampFailureCount)
+	require.Equal(t, uint64(1), parserStats.LogLineCount)
+}
+
 func TestClassifyParserPanic(t *testing.T) {
 	// uncomment to see the logs produced
 	/*
@@ -199,7 +411,7 @@ func TestClassifyParserPanic(t *testing.T) {
 		testRegistry.Add(availableParsers[i]) // update registry
 	}
 
-	classifier := NewClassifier()
+	classifier := NewClassifier(nil)
 
 	logLine := "log of death"
 
@@ -217,10 +429,28 @@ func TestClassifyParserPanic(t *testing.T) {
 	expectedStats.ClassifyTimeMicroseconds = classifier.Stats().ClassifyTimeMicroseconds
 	require.Equal(t, expectedStats, classifier.Stats())
 
-	require.Equal(t, &ClassifierResult{}, result)
+	require.Equal(t, &ClassifierResult{AttemptedLogTypes: []string{"panic parser"}}, result)
 	panicParser.AssertNumberOfCalls(t, "Parse", 1)
 }
 
+func TestClassifyStreamParserPanic(t *testing.T) {
+	panicParser := &panicStreamParser{}
+	panicParser.On("LogType").Return("panic parser")
+
+	testRegistry := NewTestRegistry()
+	parserRegistry = testRegistry // re-bind as interface
+	testRegistry.Add(&registry.LogParserMetadata{Parser: panicParser})
+
+	classifier := NewClassifier(nil)
+
+	stream := bufio.NewReader(strings.NewReader("stream of death"))
+	classified, err := classifier.ClassifyStream(stream, func(record []byte, result *ClassifierResult) {
+		require.Fail(t, "no events expected")
+	})
+	require.True(t, classified)
+	require.Error(t, err)
+}
+
 func TestClassifyNoLogline(t *testing.T) {
 	testSkipClassify("", t)
 }
@@ -252,7 +482,7 @@ func testSkipClassify(logLine string, t *testing.T) {
 		testRegistry.Add(availableParsers[i]) // update registry
 	}
 
-	classifier := NewClassifier()
+	classifier := NewClassifier(nil)
 
 	repetitions := 1000
 
@@ -19,6 +19,8 @@ package classification
  */
 
 import (
+	"go.uber.org/zap"
+
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 )
@@ -30,17 +32,38 @@ type ParserPriorityQueue struct {
 	items []*ParserQueueItem
 }
 
-// initialize adds all registered parsers to the priority queue
+// initialize adds the registered parsers for the given log types to the priority queue
+// If no log types are given, all registered parsers are added
 // All parsers have the same priority
-func (q *ParserPriorityQueue) initialize() {
-	for _, parserMetadata := range parserRegistry.Elements() {
-		q.items = append(q.items, &ParserQueueItem{
-			parser:  parserMetadata.Parser.New(),
-			penalty: 1,
-		})
+func (q *ParserPriorityQueue) initialize(logTypes []string) {
+	if len(logTypes) == 0 {
+		for _, parserMetadata := range parserRegistry.Elements() {
+			if parserMetadata.Parser == nil { // not a log type that can be classified
+				continue
+			}
+			q.add(parserMetadata.Parser)
+		}
+		return
+	}
+
+	availableParsers := parserRegistry.Elements()
+	for _, logType := range logTypes {
+		parserMetadata, found := availableParsers[logType]
+		if !found || parserMetadata.Parser == nil { // could be a log type that has been removed, skip it rather than fail the whole file
+			zap.L().Warn("no parser registered for log type", zap.String("logType", logType))
+			continue
+		}
+		q.add(parserMetadata.Parser)
 	}
 }
 
+func (q *ParserPriorityQueue) add(parser parsers.LogParser) {
+	q.items = append(q.items, &ParserQueueItem{
+		parser:  parser.New(),
+		penalty: 1,
+	})
+}
+
 // ParserQueueItem contains all the information needed to initialize a schema.
 type ParserQueueItem struct {
 	parser parsers.LogParser
@@ -27,6 +27,10 @@ import (
 
 const (
 	MaxRetries = 20 // setting Max Retries to a higher number - we'd like to retry VERY hard before failing.
+
+	// MaxLineSize is the size of the longest line the log processor reads, longer lines are skipped.
+	// The memory to hold such a line is reserved when sizing the output buffers.
+	MaxLineSize = 10 * 1024 * 1024
 )
 
 // Session AWS Session that can be used by components of the system
@@ -39,11 +43,23 @@ type DataStream struct {
 	// The log type if known
 	// If it is nil, it means the log type hasn't been identified yet
 	LogType *string
+	// The log types configured for the source the data came from
+	// If it is empty, all available parsers will be used for classification
+	LogTypes []string
+	// The id and label of the source the data came from, empty if unknown
+	SourceID    string
+	SourceLabel string
+	// The records of the data if the source delimits them (e.g. the log events of CloudWatch Logs),
+	// they are classified one by one instead of reading the records from Reader
+	Records []string
 }
 
 // Used in a DataStream as meta data to describe the data
 type DataStreamHints struct {
-	S3 *S3DataStreamHints // if nil, no hint
+	S3             *S3DataStreamHints             // if nil, no hint
+	Kinesis        *KinesisDataStreamHints        // if nil, no hint
+	CloudWatchLogs *CloudWatchLogsDataStreamHints // if nil, no hint
+	SaaSAPI        *SaaSAPIDataStreamHints        // if nil, no hint
 }
 
 // Used in a DataStreamHints as meta data to describe the S3 object backing the stream
@@ -52,3 +68,21 @@ type S3DataStreamHints struct {
 	Key         string
 	ContentType string
 }
+
+// Used in a DataStreamHints as meta data to describe the Kinesis stream backing the stream
+type KinesisDataStreamHints struct {
+	StreamARN string
+}
+
+// Used in a DataStreamHints as meta data to describe the CloudWatch log stream backing the stream
+type CloudWatchLogsDataStreamHints struct {
+	Owner     string // the account id
+	LogGroup  string
+	LogStream string
+}
+
+// Used in a DataStreamHints as meta data to describe the SaaS API the events were pulled from
+type SaaSAPIDataStreamHints struct {
+	Provider string
+	Endpoint string
+}
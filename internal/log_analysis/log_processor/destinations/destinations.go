@@ -21,8 +21,10 @@ package destinations
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"go.uber.org/zap"
@@ -59,11 +61,16 @@ func createFirehoseDestination() Destination {
 func createS3Destination(s3BucketName string) Destination {
 	// do not need to check error below, maxS3BufferMemUsageBytes() will panic if not set
 	lambdaSize, _ := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE"))
+	// not set or 0 means there is no late-arrival window
+	lateArrivalWindowHours, _ := strconv.Atoi(os.Getenv("LATE_ARRIVAL_WINDOW_HOURS"))
 	return &S3Destination{
 		s3Uploader:          s3manager.NewUploader(common.Session),
 		snsClient:           sns.New(common.Session),
+		glueClient:          glue.New(common.Session),
 		s3Bucket:            s3BucketName,
 		snsTopicArn:         os.Getenv("SNS_TOPIC_ARN"),
+		outputFormat:        os.Getenv("OUTPUT_FORMAT"),
+		lateArrivalWindow:   time.Duration(lateArrivalWindowHours) * time.Hour,
 		maxBufferedMemBytes: maxS3BufferMemUsageBytes(lambdaSize),
 		maxDuration:         maxDuration,
 	}
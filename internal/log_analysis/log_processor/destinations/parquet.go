@@ -0,0 +1,631 @@
+package destinations
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/panther-labs/panther/tools/cfngen/gluecf"
+)
+
+const (
+	parquetRootName   = "root"
+	parquetRootInName = "Root"
+
+	// Glue types of the parquet nodes that are not primitive
+	glueStructType = "struct"
+	glueArrayType  = "array"
+	glueMapType    = "map"
+
+	// the type that JSON documents (jsoniter.RawMessage) are inferred as, they are stored as strings
+	parquetRawJSONType = "json"
+
+	// events are marshalled to JSON with this timestamp layout (see timestamp.RFC3339)
+	jsonTimestampLayout = "2006-01-02 15:04:05.000000000"
+
+	// row groups are kept small since they are buffered in memory while the file is written
+	parquetRowGroupSizeBytes = 8 * bytesPerMB
+
+	// keys of the file metadata listing the fields that the Parquet types do not describe, so that the events can be
+	// read back as JSON by the rules engine. Fields are paths of names joined by '.', with '[]' for array elements
+	// and '{}' for map values, e.g. `requestParameters` or `resources[].tags{}`
+	rawJSONFieldsMetadataKey = "panther.rawJSONFields"
+	mapFieldsMetadataKey     = "panther.mapFields"
+
+	julianDayOfEpoch = 2440588 // the Julian day of 1970-01-01 used in INT96 timestamps
+	nanosPerDay      = int64(24 * time.Hour)
+)
+
+var (
+	// numbers are decoded as json.Number so that bigints are not rounded
+	parquetJSON = jsoniter.Config{UseNumber: true}.Froze()
+
+	// the Parquet types of the primitive Glue types
+	glueToParquetType = map[string]string{
+		"string":                 "UTF8",
+		"boolean":                "BOOLEAN",
+		"tinyint":                "INT_8",
+		"smallint":               "INT_16",
+		"int":                    "INT32",
+		"bigint":                 "INT64",
+		"float":                  "FLOAT",
+		"double":                 "DOUBLE",
+		gluecf.GlueTimestampType: "INT96",
+	}
+
+	// the mappings used to infer the Glue columns, except JSON documents which are tracked
+	parquetMappings = rawJSONMappings(gluecf.GlueMappings)
+
+	parquetSchemaCache     = make(map[reflect.Type]*parquetSchema) // by event struct type
+	parquetSchemaCacheLock sync.Mutex
+)
+
+// parquetSchema is the Parquet schema of the events of a log type. The columns are inferred from the event struct
+// exactly as the columns of the Glue table (see gluecf.InferJSONColumns()) so the Parquet SerDe can read the data.
+type parquetSchema struct {
+	root       *parquetNode
+	jsonSchema string              // the schema in the JSON format of parquet-go
+	metadata   []*parquet.KeyValue // the file metadata
+}
+
+// parquetNode is a column (or a nested field of a column) in the schema
+type parquetNode struct {
+	name     string         // the JSON field name which is the Glue column name
+	glueType string         // the Glue type for primitives, glueStructType, glueArrayType or glueMapType otherwise
+	children []*parquetNode // struct fields, the array element or the map key and value
+	path     string         // the path of the node using parquet-go in-names, leaves are stored in the table of this path
+	repLevel int32          // the repetition level of array elements and map entries
+	rawJSON  bool           // the string holds a JSON document
+}
+
+// parquetSchemaItem is a node of the parquet-go JSON schema
+type parquetSchemaItem struct {
+	Tag    string
+	Fields []*parquetSchemaItem `json:",omitempty"`
+}
+
+// getParquetSchema returns the (cached) Parquet schema of eventStruct
+func getParquetSchema(eventStruct interface{}) (*parquetSchema, error) {
+	eventType := reflect.TypeOf(eventStruct)
+
+	parquetSchemaCacheLock.Lock()
+	defer parquetSchemaCacheLock.Unlock()
+
+	if ps, ok := parquetSchemaCache[eventType]; ok {
+		return ps, nil
+	}
+	ps, err := newParquetSchema(gluecf.InferJSONColumns(eventStruct, parquetMappings...))
+	if err != nil {
+		return nil, err
+	}
+	parquetSchemaCache[eventType] = ps
+	return ps, nil
+}
+
+func newParquetSchema(columns []gluecf.Column) (*parquetSchema, error) {
+	root := &parquetNode{
+		name:     parquetRootName,
+		glueType: glueStructType,
+		path:     parquetRootInName,
+	}
+	rootItem := &parquetSchemaItem{
+		Tag: fmt.Sprintf("name=%s, inname=%s, repetitiontype=REQUIRED", parquetRootName, parquetRootInName),
+	}
+	for i, column := range columns {
+		child, item, err := newParquetNode(column.Name, column.Type, root.path, fieldInName(i), parquet.FieldRepetitionType_OPTIONAL, 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s", column.Name)
+		}
+		root.children = append(root.children, child)
+		rootItem.Fields = append(rootItem.Fields, item)
+	}
+
+	jsonSchema, err := jsoniter.MarshalToString(rootItem)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal parquet schema")
+	}
+	metadata, err := fieldsMetadata(root)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetSchema{
+		root:       root,
+		jsonSchema: jsonSchema,
+		metadata:   metadata,
+	}, nil
+}
+
+// rawJSONMappings returns the mappings with JSON documents mapped to parquetRawJSONType instead of strings
+func rawJSONMappings(mappings []gluecf.CustomMapping) []gluecf.CustomMapping {
+	rawJSONType := reflect.TypeOf(jsoniter.RawMessage{})
+	result := make([]gluecf.CustomMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		if mapping.From == rawJSONType {
+			mapping.To = parquetRawJSONType
+		}
+		result = append(result, mapping)
+	}
+	return result
+}
+
+// fieldsMetadata returns the file metadata listing the JSON document and map fields of the schema
+func fieldsMetadata(root *parquetNode) ([]*parquet.KeyValue, error) {
+	rawJSONFields, mapFields := []string{}, []string{}
+	var walk func(node *parquetNode, fieldPath string)
+	walk = func(node *parquetNode, fieldPath string) {
+		switch node.glueType {
+		case glueStructType:
+			for _, child := range node.children {
+				walk(child, fieldPath+"."+child.name)
+			}
+		case glueArrayType:
+			walk(node.children[0], fieldPath+"[]")
+		case glueMapType:
+			mapFields = append(mapFields, fieldPath)
+			walk(node.children[1], fieldPath+"{}")
+		default:
+			if node.rawJSON {
+				rawJSONFields = append(rawJSONFields, fieldPath)
+			}
+		}
+	}
+	for _, column := range root.children {
+		walk(column, column.name)
+	}
+
+	rawJSONFieldsValue, err := jsoniter.MarshalToString(rawJSONFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal parquet metadata")
+	}
+	mapFieldsValue, err := jsoniter.MarshalToString(mapFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal parquet metadata")
+	}
+	return []*parquet.KeyValue{
+		{Key: rawJSONFieldsMetadataKey, Value: &rawJSONFieldsValue},
+		{Key: mapFieldsMetadataKey, Value: &mapFieldsValue},
+	}, nil
+}
+
+// newParquetNode returns the node and the parquet-go JSON schema item of the field, repLevel is that of the parent
+func newParquetNode(name, glueType, parentPath, inName string, repetitionType parquet.FieldRepetitionType,
+	repLevel int32) (*parquetNode, *parquetSchemaItem, error) {
+
+	node := &parquetNode{
+		name: name,
+		path: parentPath + "." + inName,
+	}
+	item := &parquetSchemaItem{}
+	tag := fmt.Sprintf("name=%s, inname=%s, repetitiontype=%s", name, inName, repetitionType)
+
+	kind, params := parseGlueType(glueType)
+	switch kind {
+	case glueStructType:
+		node.glueType = glueStructType
+		item.Tag = tag
+		for i, param := range params {
+			fieldName, fieldType := splitGlueField(param)
+			child, childItem, err := newParquetNode(fieldName, fieldType, node.path, fieldInName(i),
+				parquet.FieldRepetitionType_OPTIONAL, repLevel)
+			if err != nil {
+				return nil, nil, err
+			}
+			node.children = append(node.children, child)
+			item.Fields = append(item.Fields, childItem)
+		}
+	case glueArrayType:
+		if len(params) != 1 {
+			return nil, nil, errors.Errorf("invalid array type %s", glueType)
+		}
+		node.glueType = glueArrayType
+		node.repLevel = repLevel + 1
+		item.Tag = tag + ", type=LIST"
+		// parquet-go adds the repeated group "List" between the list and the element
+		element, elementItem, err := newParquetNode("element", params[0], node.path+".List", "Element",
+			parquet.FieldRepetitionType_OPTIONAL, node.repLevel)
+		if err != nil {
+			return nil, nil, err
+		}
+		node.children = []*parquetNode{element}
+		item.Fields = []*parquetSchemaItem{elementItem}
+	case glueMapType:
+		if len(params) != 2 {
+			return nil, nil, errors.Errorf("invalid map type %s", glueType)
+		}
+		node.glueType = glueMapType
+		node.repLevel = repLevel + 1
+		item.Tag = tag + ", type=MAP"
+		// parquet-go adds the repeated group "Key_value" between the map and the key and value
+		key, keyItem, err := newParquetNode("key", params[0], node.path+".Key_value", "Key",
+			parquet.FieldRepetitionType_REQUIRED, node.repLevel)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(key.children) > 0 {
+			return nil, nil, errors.Errorf("invalid map key type %s", params[0])
+		}
+		value, valueItem, err := newParquetNode("value", params[1], node.path+".Key_value", "Value",
+			parquet.FieldRepetitionType_OPTIONAL, node.repLevel)
+		if err != nil {
+			return nil, nil, err
+		}
+		node.children = []*parquetNode{key, value}
+		item.Fields = []*parquetSchemaItem{keyItem, valueItem}
+	case parquetRawJSONType:
+		node.glueType = "string"
+		node.rawJSON = true
+		item.Tag = tag + ", type=" + glueToParquetType[node.glueType]
+	default:
+		parquetType, ok := glueToParquetType[kind]
+		if !ok {
+			return nil, nil, errors.Errorf("unsupported type %s", glueType)
+		}
+		node.glueType = kind
+		item.Tag = tag + ", type=" + parquetType
+	}
+	return node, item, nil
+}
+
+// fieldInName returns the parquet-go in-name of the i-th field, which must be a valid Go identifier
+func fieldInName(i int) string {
+	return "F" + strconv.Itoa(i)
+}
+
+// parseGlueType splits a Glue type into the kind and the type parameters,
+// e.g. `map<string,array<int>>` is ("map", ["string", "array<int>"])
+func parseGlueType(glueType string) (kind string, params []string) {
+	glueType = strings.TrimSpace(glueType)
+	open := strings.IndexByte(glueType, '<')
+	if open < 0 || !strings.HasSuffix(glueType, ">") {
+		return strings.ToLower(glueType), nil
+	}
+	kind = strings.ToLower(glueType[:open])
+	inner := glueType[open+1 : len(glueType)-1]
+	depth, start := 0, 0
+	for i, c := range inner {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, inner[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return kind, append(params, inner[start:])
+}
+
+// splitGlueField splits a struct field `name:type` into the name and the type
+func splitGlueField(field string) (name, glueType string) {
+	colon := strings.IndexByte(field, ':')
+	if colon < 0 {
+		return "", field
+	}
+	return field[:colon], field[colon+1:]
+}
+
+// writeParquet reads the gzipped JSON lines in payload and returns them as a Parquet file compressed with Snappy
+func (ps *parquetSchema) writeParquet(payload []byte) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read JSON payload")
+	}
+
+	output := &parquetBuffer{}
+	parquetWriter, err := writer.NewParquetWriter(output, ps.jsonSchema, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create parquet writer")
+	}
+	parquetWriter.CompressionType = parquet.CompressionCodec_SNAPPY
+	parquetWriter.RowGroupSize = parquetRowGroupSizeBytes
+	parquetWriter.MarshalFunc = ps.marshal
+	parquetWriter.Footer.KeyValueMetadata = ps.metadata
+
+	reader := bufio.NewReader(gzipReader)
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			if writeErr := parquetWriter.Write(line); writeErr != nil {
+				return nil, errors.Wrap(writeErr, "failed to write parquet")
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read JSON payload")
+		}
+	}
+
+	if err := parquetWriter.WriteStop(); err != nil {
+		return nil, errors.Wrap(err, "failed to write parquet")
+	}
+	return output.Bytes(), nil
+}
+
+// marshal shreds the JSON lines src[bgn:end] into column tables (a parquet-go writer MarshalFunc)
+func (ps *parquetSchema) marshal(src []interface{}, bgn, end int, sh *schema.SchemaHandler) (*map[string]*layout.Table, error) {
+	tables := make(map[string]*layout.Table)
+	for i, element := range sh.SchemaElements {
+		if element.GetNumChildren() != 0 {
+			continue
+		}
+		pathStr := sh.IndexMap[int32(i)]
+		table := layout.NewEmptyTable()
+		table.Path = common.StrToPath(pathStr)
+		table.MaxDefinitionLevel, _ = sh.MaxDefinitionLevel(table.Path)
+		table.MaxRepetitionLevel, _ = sh.MaxRepetitionLevel(table.Path)
+		table.RepetitionType = element.GetRepetitionType()
+		table.Type = element.GetType()
+		table.Info = sh.Infos[i]
+		tables[pathStr] = table
+	}
+
+	for _, row := range src[bgn:end] {
+		var event map[string]interface{}
+		if err := parquetJSON.UnmarshalFromString(row.(string), &event); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal event")
+		}
+		for _, column := range ps.root.children {
+			shred(tables, column, event[column.name], 0, 0)
+		}
+	}
+	return &tables, nil
+}
+
+// shred adds value of node to the tables of the leaves of node with the repetition level repLevel,
+// defLevel is the definition level of the parent of node
+func shred(tables map[string]*layout.Table, node *parquetNode, value interface{}, repLevel, defLevel int32) {
+	if value == nil {
+		shredNull(tables, node, repLevel, defLevel)
+		return
+	}
+
+	switch node.glueType {
+	case glueStructType:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			shredNull(tables, node, repLevel, defLevel)
+			return
+		}
+		for _, child := range node.children {
+			shred(tables, child, fields[child.name], repLevel, defLevel+1)
+		}
+
+	case glueArrayType:
+		elements, ok := value.([]interface{})
+		if !ok {
+			shredNull(tables, node, repLevel, defLevel)
+			return
+		}
+		if len(elements) == 0 { // the list is defined but has no elements
+			shredNull(tables, node, repLevel, defLevel+1)
+			return
+		}
+		for i, element := range elements {
+			if i > 0 {
+				repLevel = node.repLevel
+			}
+			// the list is optional and the list group is repeated
+			shred(tables, node.children[0], element, repLevel, defLevel+2)
+		}
+
+	case glueMapType:
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			shredNull(tables, node, repLevel, defLevel)
+			return
+		}
+		keyNode, valueNode := node.children[0], node.children[1]
+		type mapEntry struct {
+			key   interface{}
+			value interface{}
+		}
+		keys := make([]string, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys) // for consistency
+		mapEntries := make([]mapEntry, 0, len(keys))
+		for _, key := range keys {
+			if parquetKey := toParquetValue(keyNode.glueType, key); parquetKey != nil { // keys are required
+				mapEntries = append(mapEntries, mapEntry{key: parquetKey, value: entries[key]})
+			}
+		}
+		if len(mapEntries) == 0 { // the map is defined but has no entries
+			shredNull(tables, node, repLevel, defLevel+1)
+			return
+		}
+		for i, entry := range mapEntries {
+			if i > 0 {
+				repLevel = node.repLevel
+			}
+			// the map is optional and the key_value group is repeated, the key is required
+			addValue(tables, keyNode, entry.key, repLevel, defLevel+2)
+			shred(tables, valueNode, entry.value, repLevel, defLevel+2)
+		}
+
+	default:
+		parquetValue := toParquetValue(node.glueType, value)
+		if parquetValue == nil { // cannot be converted
+			shredNull(tables, node, repLevel, defLevel)
+			return
+		}
+		addValue(tables, node, parquetValue, repLevel, defLevel+1)
+	}
+}
+
+// shredNull adds a null with the definition level defLevel to the tables of all leaves of node
+func shredNull(tables map[string]*layout.Table, node *parquetNode, repLevel, defLevel int32) {
+	if len(node.children) == 0 {
+		addValue(tables, node, nil, repLevel, defLevel)
+		return
+	}
+	for _, child := range node.children {
+		shredNull(tables, child, repLevel, defLevel)
+	}
+}
+
+func addValue(tables map[string]*layout.Table, node *parquetNode, value interface{}, repLevel, defLevel int32) {
+	table := tables[node.path]
+	table.Values = append(table.Values, value)
+	table.RepetitionLevels = append(table.RepetitionLevels, repLevel)
+	table.DefinitionLevels = append(table.DefinitionLevels, defLevel)
+}
+
+// toParquetValue converts a JSON value to the parquet-go value of the Glue type, returns nil if it cannot be converted
+func toParquetValue(glueType string, value interface{}) interface{} {
+	if glueType == "string" {
+		if s, ok := value.(string); ok {
+			return s
+		}
+		// the JSON value is not a string (e.g. an object in a string column), store it as JSON like the JSON SerDe
+		s, err := parquetJSON.MarshalToString(value)
+		if err != nil {
+			return nil
+		}
+		return s
+	}
+
+	if glueType == "boolean" {
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+		return nil
+	}
+
+	if glueType == gluecf.GlueTimestampType {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		t, err := time.Parse(jsonTimestampLayout, s)
+		if err != nil {
+			if t, err = time.Parse(time.RFC3339Nano, s); err != nil {
+				return nil
+			}
+		}
+		return toINT96(t)
+	}
+
+	var number string
+	switch v := value.(type) {
+	case json.Number:
+		number = v.String()
+	case string:
+		number = strings.TrimSpace(v)
+	default:
+		return nil
+	}
+	switch glueType {
+	case "tinyint":
+		if i, err := strconv.ParseInt(number, 10, 8); err == nil {
+			return int32(i)
+		}
+	case "smallint":
+		if i, err := strconv.ParseInt(number, 10, 16); err == nil {
+			return int32(i)
+		}
+	case "int":
+		if i, err := strconv.ParseInt(number, 10, 32); err == nil {
+			return int32(i)
+		}
+	case "bigint":
+		if i, err := strconv.ParseInt(number, 10, 64); err == nil {
+			return i
+		}
+	case "float":
+		if f, err := strconv.ParseFloat(number, 32); err == nil {
+			return float32(f)
+		}
+	case "double":
+		if f, err := strconv.ParseFloat(number, 64); err == nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// toINT96 returns the (deprecated but what the Parquet SerDe reads) INT96 timestamp of t,
+// the nanoseconds of the day followed by the Julian day, both little endian
+func toINT96(t time.Time) string {
+	nanos := t.UTC().UnixNano()
+	days := nanos / nanosPerDay
+	nanosOfDay := nanos % nanosPerDay
+	if nanosOfDay < 0 { // before the epoch
+		days--
+		nanosOfDay += nanosPerDay
+	}
+	var int96 [12]byte
+	binary.LittleEndian.PutUint64(int96[:8], uint64(nanosOfDay))
+	binary.LittleEndian.PutUint32(int96[8:], uint32(days+julianDayOfEpoch))
+	return string(int96[:])
+}
+
+// parquetBuffer is an in memory source.ParquetFile the parquet writer writes to
+type parquetBuffer struct {
+	bytes.Buffer
+}
+
+var _ source.ParquetFile = (*parquetBuffer)(nil)
+
+func (b *parquetBuffer) Seek(offset int64, whence int) (int64, error) {
+	return 0, errors.New("seek is not supported")
+}
+
+func (b *parquetBuffer) Close() error {
+	return nil
+}
+
+func (b *parquetBuffer) Open(name string) (source.ParquetFile, error) {
+	return nil, errors.New("open is not supported")
+}
+
+func (b *parquetBuffer) Create(name string) (source.ParquetFile, error) {
+	return nil, errors.New("create is not supported")
+}
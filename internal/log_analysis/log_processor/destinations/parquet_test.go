@@ -0,0 +1,192 @@
+package destinations
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/tools/cfngen/gluecf"
+)
+
+type parquetTestNested struct {
+	Count *int32   `json:"count,omitempty"`
+	Names []string `json:"names,omitempty"`
+}
+
+type parquetTestDocument struct {
+	Value jsoniter.RawMessage `json:"value,omitempty"`
+}
+
+type parquetTestEvent struct {
+	Name     *string                        `json:"name,omitempty" description:"test field"`
+	Flag     *bool                          `json:"flag,omitempty" description:"test field"`
+	Small    *int8                          `json:"small,omitempty" description:"test field"`
+	Big      *int64                         `json:"big,omitempty" description:"test field"`
+	Ratio    *float64                       `json:"ratio,omitempty" description:"test field"`
+	Time     *timestamp.RFC3339             `json:"time,omitempty" description:"test field"`
+	Tags     []string                       `json:"tags,omitempty" description:"test field"`
+	Labels   map[string]string              `json:"labels,omitempty" description:"test field"`
+	Nested   *parquetTestNested             `json:"nested,omitempty" description:"test field"`
+	Objects  []parquetTestNested            `json:"objects,omitempty" description:"test field"`
+	Document jsoniter.RawMessage            `json:"document,omitempty" description:"test field"`
+	Mapped   map[string]parquetTestDocument `json:"mapped,omitempty" description:"test field"`
+
+	parsers.PantherLog
+}
+
+func TestParseGlueType(t *testing.T) {
+	kind, params := parseGlueType("map<string,array<struct<a:int,b:map<string,string>>>>")
+	assert.Equal(t, "map", kind)
+	assert.Equal(t, []string{"string", "array<struct<a:int,b:map<string,string>>>"}, params)
+
+	kind, params = parseGlueType("struct<a:int,b:map<string,string>>")
+	assert.Equal(t, "struct", kind)
+	assert.Equal(t, []string{"a:int", "b:map<string,string>"}, params)
+
+	kind, params = parseGlueType("bigint")
+	assert.Equal(t, "bigint", kind)
+	assert.Nil(t, params)
+}
+
+func TestParquetSchemaUnsupportedType(t *testing.T) {
+	_, err := newParquetSchema([]gluecf.Column{{Name: "bad", Type: "array<binary>"}})
+	require.Error(t, err)
+}
+
+func TestWriteParquet(t *testing.T) {
+	ps, err := getParquetSchema(&parquetTestEvent{})
+	require.NoError(t, err)
+
+	payload := gzipLines(t,
+		`{"name":"a","flag":true,"small":7,"big":9007199254740993,"ratio":1.5,"time":"2020-01-01 00:01:01.000000001",`+
+			`"tags":["x","y"],"labels":{"k2":"v2","k1":"v1"},"nested":{"count":1,"names":["n"]},`+
+			`"objects":[{"count":2},{"names":["o1","o2"]}],"document":{"a":[1]},"p_log_type":"Test.Parquet"}`,
+		`{"name":{"not":"a string"},"small":1000,"tags":[],"labels":{},"nested":{}}`,
+	)
+
+	parquetData, err := ps.writeParquet(payload)
+	require.NoError(t, err)
+
+	pr, err := reader.NewParquetColumnReader(newParquetTestFile(parquetData), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(2), pr.GetNumRows())
+
+	expectColumn := func(path string, values []interface{}, rls, dls []int32) {
+		actualValues, actualRls, actualDls, err := pr.ReadColumnByPath("root."+path, 10)
+		require.NoError(t, err, path)
+		assert.Equal(t, values, actualValues, path)
+		assert.Equal(t, rls, actualRls, path)
+		assert.Equal(t, dls, actualDls, path)
+	}
+
+	// non-string values are stored as JSON in string columns
+	expectColumn("name", []interface{}{"a", `{"not":"a string"}`}, []int32{0, 0}, []int32{1, 1})
+	expectColumn("flag", []interface{}{true, nil}, []int32{0, 0}, []int32{1, 0})
+	// values out of range are null
+	expectColumn("small", []interface{}{int32(7), nil}, []int32{0, 0}, []int32{1, 0})
+	// large numbers do not lose precision
+	expectColumn("big", []interface{}{int64(9007199254740993), nil}, []int32{0, 0}, []int32{1, 0})
+	expectColumn("ratio", []interface{}{1.5, nil}, []int32{0, 0}, []int32{1, 0})
+	expectColumn("time", []interface{}{
+		toINT96(time.Date(2020, 1, 1, 0, 1, 1, 1, time.UTC)), nil},
+		[]int32{0, 0}, []int32{1, 0})
+	// empty lists and maps are defined
+	expectColumn("tags.list.element", []interface{}{"x", "y", nil}, []int32{0, 1, 0}, []int32{3, 3, 1})
+	// map keys are sorted
+	expectColumn("labels.key_value.key", []interface{}{"k1", "k2", nil}, []int32{0, 1, 0}, []int32{2, 2, 1})
+	expectColumn("labels.key_value.value", []interface{}{"v1", "v2", nil}, []int32{0, 1, 0}, []int32{3, 3, 1})
+	expectColumn("nested.count", []interface{}{int32(1), nil}, []int32{0, 0}, []int32{2, 1})
+	expectColumn("nested.names.list.element", []interface{}{"n", nil}, []int32{0, 0}, []int32{4, 1})
+	expectColumn("objects.list.element.count", []interface{}{int32(2), nil, nil},
+		[]int32{0, 1, 0}, []int32{4, 3, 0})
+	expectColumn("objects.list.element.names.list.element", []interface{}{nil, "o1", "o2", nil},
+		[]int32{0, 1, 2, 0}, []int32{3, 6, 6, 0})
+	// JSON documents are stored as JSON
+	expectColumn("document", []interface{}{`{"a":[1]}`, nil}, []int32{0, 0}, []int32{1, 0})
+	expectColumn("p_log_type", []interface{}{"Test.Parquet", nil}, []int32{0, 0}, []int32{1, 0})
+
+	// the fields that cannot be read back as JSON from the Parquet types are listed in the metadata
+	metadata := make(map[string]string)
+	for _, keyValue := range pr.Footer.KeyValueMetadata {
+		metadata[keyValue.Key] = *keyValue.Value
+	}
+	assert.Equal(t, map[string]string{
+		rawJSONFieldsMetadataKey: `["document","mapped{}.value"]`,
+		mapFieldsMetadataKey:     `["labels","mapped"]`,
+	}, metadata)
+}
+
+func TestToINT96(t *testing.T) {
+	// 1970-01-01 is Julian day 2440588
+	assert.Equal(t, "\x01\x00\x00\x00\x00\x00\x00\x00\x8c\x3d\x25\x00", toINT96(time.Unix(0, 1)))
+	// the day before the epoch, one nanosecond before midnight
+	assert.Equal(t, "\xff\xff\x4e\x91\x94\x4e\x00\x00\x8b\x3d\x25\x00", toINT96(time.Unix(0, -1)))
+}
+
+func gzipLines(t *testing.T, lines ...string) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	for _, line := range lines {
+		_, err := writer.Write([]byte(line + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+// parquetTestFile is an in memory source.ParquetFile to read Parquet data
+type parquetTestFile struct {
+	*bytes.Reader
+	data []byte
+}
+
+func newParquetTestFile(data []byte) *parquetTestFile {
+	return &parquetTestFile{
+		Reader: bytes.NewReader(data),
+		data:   data,
+	}
+}
+
+func (f *parquetTestFile) Write(p []byte) (int, error) {
+	panic("not implemented")
+}
+
+func (f *parquetTestFile) Close() error {
+	return nil
+}
+
+func (f *parquetTestFile) Open(name string) (source.ParquetFile, error) {
+	return newParquetTestFile(f.data), nil
+}
+
+func (f *parquetTestFile) Create(name string) (source.ParquetFile, error) {
+	panic("not implemented")
+}
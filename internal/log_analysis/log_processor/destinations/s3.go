@@ -23,10 +23,12 @@ import (
 	"compress/gzip"
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
 	"github.com/aws/aws-sdk-go/service/sns"
@@ -40,6 +42,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/awsglue"
 )
 
 const (
@@ -48,6 +51,10 @@ const (
 	// 1. The key prefix 2. Timestamp in format `s3ObjectTimestampFormat` 3. UUID4
 	s3ObjectKeyFormat = "%s%s-%s.json.gz"
 
+	// Parquet objects are stored with the key of the JSON object, replacing the suffix
+	jsonS3ObjectKeySuffix    = ".json.gz"
+	parquetS3ObjectKeySuffix = ".parquet"
+
 	// The timestamp format in the S3 objects with second precision: yyyyMMddTHHmmssZ
 	S3ObjectTimestampFormat = "20060102T150405Z"
 
@@ -68,6 +75,10 @@ var (
 	parserRegistry registry.Interface = registry.AvailableParsers() // initialize
 
 	memUsedAtStartupMB int // set in init(), used to size memory buffers for S3 write
+
+	// the formats of the partitions written to by partition prefix, partitions keep the format they are created with
+	partitionDataFormats     = make(map[string]string)
+	partitionDataFormatsLock sync.Mutex
 )
 
 func init() {
@@ -80,21 +91,16 @@ func init() {
 // NOTE: this presumes processing 1 file at a time
 func maxS3BufferMemUsageBytes(lambdaSizeMB int) uint64 {
 	const (
-		/*
-					NOTE:
-					  "More specifically CloudTrail will collect logs for 5 mins or until the max file size of 45MB has been reached.
-					  An important thing worth noting is that these logs get compressed before being sent to S3, once the file size
-					  limit is met or the time limit has been exceeded"
-				    Because CT files are "document" JSON and all on 1 line we currently need to read ALL the uncompressed data into memory.
-			        FIXME: we should switch to streaming JSON reader
-					Below we set the lower bound on memory to be 45MB * 3 (because we convert all the records and parse) plus some for overhead
-		*/
-		largestAllInMemFileMB     = 45
-		processingExpansionFactor = 4
-		memoryFootprint           = largestAllInMemFileMB * processingExpansionFactor
-		minimumScratchMemMB       = 5 // how much overhead is needed to process a file
+		// "document" JSON logs like CloudTrail are decoded incrementally (see parsers.StreamParser) and longer lines
+		// are skipped, so we only need to reserve enough memory to hold the largest single line while it is parsed
+		largestRecordMB     = common.MaxLineSize / bytesPerMB
+		minimumScratchMemMB = 5 // how much overhead is needed to process a file
+		// converting a buffer to Parquet holds a row group of encoded pages, the batch of events being shredded into
+		// columns and the Parquet file (no larger than the JSON) in memory. This is reserved even if the output format
+		// is JSON because partitions created while it was Parquet are still written as Parquet (see sendData())
+		parquetConversionMemMB = 2*parquetRowGroupSizeBytes/bytesPerMB + maxS3BufferSizeBytes/bytesPerMB
 	)
-	maxBufferUsageMB := lambdaSizeMB - memUsedAtStartupMB - memoryFootprint - minimumScratchMemMB
+	maxBufferUsageMB := lambdaSizeMB - memUsedAtStartupMB - largestRecordMB - minimumScratchMemMB - parquetConversionMemMB
 	if maxBufferUsageMB < 5 {
 		panic(fmt.Sprintf("available memory too small for log processing, increase lambda size from %dMB", lambdaSizeMB))
 	}
@@ -106,11 +112,17 @@ func maxS3BufferMemUsageBytes(lambdaSizeMB int) uint64 {
 type S3Destination struct {
 	s3Uploader s3manageriface.UploaderAPI
 	snsClient  snsiface.SNSAPI
+	glueClient glueiface.GlueAPI
 	// s3Bucket is the s3Bucket where the data will be stored
 	s3Bucket string
 	// snsTopic is the SNS Topic ARN where we will send the notification
 	// when we store new data in S3
 	snsTopicArn string
+	// outputFormat is the format of the data stored in S3 (awsglue.JSONDataFormat or awsglue.ParquetDataFormat),
+	// data is written in the format of its partition so this only applies to new partitions
+	outputFormat string
+	// events older than this are stored in the late partition (awsglue.LatePartitionTime), if zero there is no limit
+	lateArrivalWindow time.Duration
 	// thresholds for ejection
 	maxBufferedMemBytes uint64 // max will hold in buffers before ejection
 	maxDuration         time.Duration
@@ -140,6 +152,9 @@ func (destination *S3Destination) SendEvents(parsedEventChannel chan *parsers.Pa
 	// accumulate results gzip'd in a buffer
 	failed := false // set to true on error and loop will drain channel
 	bufferSet := newS3EventBufferSet()
+	if destination.lateArrivalWindow > 0 {
+		bufferSet.lateArrivalTime = time.Now().Add(-destination.lateArrivalWindow).Truncate(time.Hour)
+	}
 	eventsProcessed := 0
 	zap.L().Debug("starting to read events from channel")
 	for event := range parsedEventChannel {
@@ -213,6 +228,12 @@ func (destination *S3Destination) SendEvents(parsedEventChannel chan *parsers.Pa
 	close(sendChan)
 	sendWaitGroup.Wait() // wait until all writes to s3 are done
 
+	if bufferSet.lateEvents > 0 { // the lateEventCount field is used by a metric filter
+		zap.L().Warn("events arrived after the late-arrival window",
+			zap.Int("lateEventCount", bufferSet.lateEvents),
+			zap.Duration("lateArrivalWindow", destination.lateArrivalWindow))
+	}
+
 	zap.L().Debug("finished sending s3 files", zap.Int("events", eventsProcessed))
 }
 
@@ -243,9 +264,29 @@ func (destination *S3Destination) sendData(buffer *s3EventBuffer, errChan chan e
 		return
 	}
 
+	dataFormat, err := destination.partitionDataFormat(buffer)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if dataFormat == awsglue.ParquetDataFormat {
+		var parquetSchema *parquetSchema
+		parquetSchema, err = getParquetSchema(parserRegistry.LookupParser(buffer.logType).GlueTableMetadata.EventStruct())
+		if err != nil {
+			errChan <- errors.Wrapf(err, "failed to create parquet schema for %s", buffer.logType)
+			return
+		}
+		payload, err = parquetSchema.writeParquet(payload)
+		if err != nil {
+			errChan <- errors.Wrapf(err, "failed to convert %s to parquet", buffer.logType)
+			return
+		}
+		key = strings.TrimSuffix(key, jsonS3ObjectKeySuffix) + parquetS3ObjectKeySuffix
+	}
+
 	contentLength = int64(len(payload)) // for logging above
 
-	if _, err := destination.s3Uploader.Upload(&s3manager.UploadInput{
+	if _, err = destination.s3Uploader.Upload(&s3manager.UploadInput{
 		Bucket: aws.String(destination.s3Bucket),
 		Key:    aws.String(key),
 		Body:   bytes.NewReader(payload),
@@ -260,6 +301,30 @@ func (destination *S3Destination) sendData(buffer *s3EventBuffer, errChan chan e
 	}
 }
 
+// partitionDataFormat returns the format the data of the buffer must be written in, which is the format of its
+// partition, so that a change of the output format does not mix formats in a partition Athena could not read
+func (destination *S3Destination) partitionDataFormat(buffer *s3EventBuffer) (string, error) {
+	tableMetadata := parserRegistry.LookupParser(buffer.logType).GlueTableMetadata
+	partitionPrefix := tableMetadata.GetPartitionPrefix(buffer.hour)
+
+	partitionDataFormatsLock.Lock()
+	defer partitionDataFormatsLock.Unlock()
+
+	if dataFormat, ok := partitionDataFormats[partitionPrefix]; ok {
+		return dataFormat, nil
+	}
+	outputFormat := destination.outputFormat
+	if outputFormat == "" {
+		outputFormat = awsglue.JSONDataFormat
+	}
+	dataFormat, err := tableMetadata.GetPartitionDataFormat(destination.glueClient, buffer.hour, outputFormat)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the format of partition %s", partitionPrefix)
+	}
+	partitionDataFormats[partitionPrefix] = dataFormat
+	return dataFormat, nil
+}
+
 func (destination *S3Destination) sendSNSNotification(key string, buffer *s3EventBuffer) error {
 	var err error
 	operation := common.OpLogManager.Start("sendSNSNotification", common.OpLogSNSServiceDim)
@@ -317,6 +382,9 @@ func getS3ObjectKey(logType string, timestamp time.Time) string {
 type s3EventBufferSet struct {
 	totalBufferedMemBytes uint64 // managed by addEvent() and removeBuffer()
 	set                   map[time.Time]map[string]*s3EventBuffer
+	// events before this hour are binned in the late partition, if zero all events are binned by event time
+	lateArrivalTime time.Time
+	lateEvents      int // managed by getBuffer()
 }
 
 func newS3EventBufferSet() *s3EventBufferSet {
@@ -328,6 +396,10 @@ func newS3EventBufferSet() *s3EventBufferSet {
 func (bs *s3EventBufferSet) getBuffer(event *parsers.PantherLog) *s3EventBuffer {
 	// bin by hour (this is our partition size)
 	hour := (time.Time)(*event.PantherEventTime).Truncate(time.Hour)
+	if hour.Before(bs.lateArrivalTime) {
+		hour = awsglue.LatePartitionTime
+		bs.lateEvents++
+	}
 
 	logTypeToBuffer, ok := bs.set[hour]
 	if !ok {
@@ -23,12 +23,15 @@ import (
 	"compress/gzip"
 	"errors"
 	"io/ioutil"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
 	"github.com/aws/aws-sdk-go/service/sns"
@@ -42,6 +45,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/awsglue"
 )
 
 const (
@@ -93,6 +97,28 @@ type mockSns struct {
 	mock.Mock
 }
 
+type mockGlue struct {
+	glueiface.GlueAPI
+	mock.Mock
+}
+
+func (m *mockGlue) GetPartition(input *glue.GetPartitionInput) (*glue.GetPartitionOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.GetPartitionOutput), args.Error(1)
+}
+
+func newGetPartitionOutput(serializationLibrary string) *glue.GetPartitionOutput {
+	return &glue.GetPartitionOutput{
+		Partition: &glue.Partition{
+			StorageDescriptor: &glue.StorageDescriptor{
+				SerdeInfo: &glue.SerDeInfo{
+					SerializationLibrary: aws.String(serializationLibrary),
+				},
+			},
+		},
+	}
+}
+
 // testEvent is a test event used for the purposes of this test
 type testEvent struct {
 	Data string
@@ -157,6 +183,7 @@ var testRegistry = NewTestRegistry()
 
 func initTest() {
 	parserRegistry = testRegistry // re-bind as interface
+	partitionDataFormats = make(map[string]string)
 }
 
 type testS3Destination struct {
@@ -164,22 +191,28 @@ type testS3Destination struct {
 	// back pointers to mocks
 	mockSns        *mockSns
 	mockS3Uploader *mockS3ManagerUploader
+	mockGlue       *mockGlue
 }
 
 func newS3Destination() *testS3Destination {
 	mockSns := &mockSns{}
 	mockS3Uploader := &mockS3ManagerUploader{}
+	mockGlue := &mockGlue{}
+	// all partitions exist and hold JSON unless a test says otherwise
+	mockGlue.On("GetPartition", mock.Anything).Return(newGetPartitionOutput("org.openx.data.jsonserde.JsonSerDe"), nil)
 	return &testS3Destination{
 		S3Destination: S3Destination{
 			snsTopicArn:         "arn:aws:sns:us-west-2:123456789012:test",
 			s3Bucket:            "testbucket",
 			snsClient:           mockSns,
 			s3Uploader:          mockS3Uploader,
+			glueClient:          mockGlue,
 			maxBufferedMemBytes: 10 * 1024 * 1024, // an arbitrary amount enough to hold default test data
 			maxDuration:         maxDuration,
 		},
 		mockSns:        mockSns,
 		mockS3Uploader: mockS3Uploader,
+		mockGlue:       mockGlue,
 	}
 }
 
@@ -254,6 +287,100 @@ func TestSendDataToS3BeforeTerminating(t *testing.T) {
 	assert.Equal(t, expectedSnsPublishInput, publishInput)
 }
 
+func TestSendDataToS3AsParquet(t *testing.T) {
+	initTest()
+
+	destination := newS3Destination()
+	destination.outputFormat = awsglue.ParquetDataFormat
+	destination.mockGlue.ExpectedCalls = nil
+	destination.mockGlue.On("GetPartition", mock.Anything).Return(
+		newGetPartitionOutput("org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe"), nil).Once()
+	eventChannel := make(chan *parsers.PantherLog, 1)
+
+	testEvent := newSimpleTestEvent()
+
+	// wire it up
+	registerMockParser(testLogType, testEvent)
+
+	eventChannel <- testEvent
+
+	// only the Parquet is uploaded
+	destination.mockS3Uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{}, nil).Once()
+	destination.mockSns.On("Publish", mock.Anything).Return(&sns.PublishOutput{}, nil).Once()
+
+	runSendEvents(t, destination, eventChannel, false)
+
+	destination.mockS3Uploader.AssertExpectations(t)
+	destination.mockSns.AssertExpectations(t)
+	destination.mockGlue.AssertExpectations(t)
+
+	parquetUploadInput := destination.mockS3Uploader.Calls[0].Arguments.Get(0).(*s3manager.UploadInput)
+	assert.True(t, strings.HasPrefix(*parquetUploadInput.Key, expectedS3Prefix))
+	assert.True(t, strings.HasSuffix(*parquetUploadInput.Key, ".parquet"))
+	bodyBytes, _ := ioutil.ReadAll(parquetUploadInput.Body)
+	assert.True(t, bytes.HasPrefix(bodyBytes, []byte("PAR1")))
+
+	publishInput := destination.mockSns.Calls[0].Arguments.Get(0).(*sns.PublishInput)
+	var notification models.S3Notification
+	require.NoError(t, jsoniter.UnmarshalFromString(*publishInput.Message, &notification))
+	assert.Equal(t, parquetUploadInput.Key, notification.S3ObjectKey)
+	assert.Equal(t, aws.Int(1), notification.Events)
+}
+
+func TestSendDataToS3InPartitionFormat(t *testing.T) {
+	initTest()
+
+	// the output format was changed to Parquet in the middle of the hour, the partition of which holds JSON
+	destination := newS3Destination()
+	destination.outputFormat = awsglue.ParquetDataFormat
+	eventChannel := make(chan *parsers.PantherLog, 2)
+
+	testEvent := newSimpleTestEvent()
+
+	// wire it up
+	registerMockParser(testLogType, testEvent)
+
+	eventChannel <- testEvent
+	eventChannel <- testEvent
+
+	// two objects are written but the format of the partition is read once
+	destination.maxBufferedMemBytes = 1
+	destination.mockS3Uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{}, nil).Twice()
+	destination.mockSns.On("Publish", mock.Anything).Return(&sns.PublishOutput{}, nil).Twice()
+
+	runSendEvents(t, destination, eventChannel, false)
+
+	destination.mockS3Uploader.AssertExpectations(t)
+	destination.mockSns.AssertExpectations(t)
+	destination.mockGlue.AssertNumberOfCalls(t, "GetPartition", 1)
+
+	for _, call := range destination.mockS3Uploader.Calls {
+		uploadInput := call.Arguments.Get(0).(*s3manager.UploadInput)
+		assert.True(t, strings.HasSuffix(*uploadInput.Key, ".json.gz"))
+	}
+}
+
+func TestSendDataPartitionFormatError(t *testing.T) {
+	initTest()
+
+	destination := newS3Destination()
+	destination.mockGlue.ExpectedCalls = nil
+	destination.mockGlue.On("GetPartition", mock.Anything).Return(&glue.GetPartitionOutput{}, errors.New("fail")).Once()
+	eventChannel := make(chan *parsers.PantherLog, 1)
+
+	testEvent := newSimpleTestEvent()
+
+	// wire it up
+	registerMockParser(testLogType, testEvent)
+
+	eventChannel <- testEvent
+
+	runSendEvents(t, destination, eventChannel, true)
+
+	destination.mockGlue.AssertExpectations(t)
+	destination.mockS3Uploader.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+}
+
 func TestSendDataIfTotalMemSizeLimitHasBeenReached(t *testing.T) {
 	initTest()
 
@@ -434,6 +561,65 @@ func TestSendDataToS3FromMultipleHoursBeforeTerminating(t *testing.T) {
 		strings.HasPrefix(*uploadInput.Key, expectedS3Prefix2)) // order of results is async
 }
 
+func TestSendDataToS3LateEvents(t *testing.T) {
+	initTest()
+
+	destination := newS3Destination()
+	destination.lateArrivalWindow = 24 * time.Hour
+	eventChannel := make(chan *parsers.PantherLog, 2)
+
+	// the recent event is stored in the partition of its event time, the old one in the late partition
+	recentTime := (timestamp.RFC3339)(time.Now().UTC().Add(-time.Hour))
+	recentEvent := newTestEvent(testLogType, recentTime)
+	lateEvent := newTestEvent(testLogType, refTime)
+
+	// wire it up
+	registerMockParser(testLogType, recentEvent)
+
+	eventChannel <- recentEvent
+	eventChannel <- lateEvent
+
+	destination.mockS3Uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{}, nil).Twice()
+	destination.mockSns.On("Publish", mock.Anything).Return(&sns.PublishOutput{}, nil).Twice()
+
+	runSendEvents(t, destination, eventChannel, false)
+
+	destination.mockS3Uploader.AssertExpectations(t)
+	destination.mockSns.AssertExpectations(t)
+
+	recentPrefix := awsglue.GetPartitionPrefix(models.LogData, testLogType, awsglue.GlueTableHourly, (time.Time)(recentTime))
+	latePrefix := "logs/testlogtype/year=1970/month=01/day=01/hour=00/"
+	var keys []string
+	for _, call := range destination.mockS3Uploader.Calls {
+		uploadInput := call.Arguments.Get(0).(*s3manager.UploadInput)
+		keys = append(keys, *uploadInput.Key)
+		if strings.HasPrefix(*uploadInput.Key, latePrefix) {
+			// the event time is kept
+			reader, err := gzip.NewReader(uploadInput.Body)
+			require.NoError(t, err)
+			data, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"p_event_time":"2020-01-01 00:01:01.000000000"`)
+		}
+	}
+	sort.Strings(keys) // order of results is async
+	require.Len(t, keys, 2)
+	assert.True(t, strings.HasPrefix(keys[0], latePrefix))
+	assert.True(t, strings.HasPrefix(keys[1], recentPrefix))
+}
+
+func TestBufferSetLateArrivalTime(t *testing.T) {
+	bs := newS3EventBufferSet() // the window is disabled by default
+	buffer := bs.getBuffer(newTestEvent(testLogType, refTime))
+	assert.Equal(t, (time.Time)(refTime).Truncate(time.Hour), buffer.hour)
+	assert.Zero(t, bs.lateEvents)
+
+	bs.lateArrivalTime = time.Now().Truncate(time.Hour)
+	buffer = bs.getBuffer(newTestEvent(testLogType, refTime))
+	assert.Equal(t, awsglue.LatePartitionTime, buffer.hour)
+	assert.Equal(t, 1, bs.lateEvents)
+}
+
 func TestSendDataFailsIfS3Fails(t *testing.T) {
 	initTest()
 
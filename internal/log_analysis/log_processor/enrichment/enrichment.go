@@ -0,0 +1,55 @@
+package enrichment
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Enricher adds fields to the events after they are classified and before they are sent to the destination
+type Enricher interface {
+	Enrich(event *parsers.PantherLog)
+}
+
+// CreateEnrichers returns the enrichers enabled by configuration, the log processor runs fine without any
+func CreateEnrichers() (enrichers []Enricher) {
+	cityDatabase, asnDatabase := os.Getenv("GEOIP_CITY_DATABASE"), os.Getenv("GEOIP_ASN_DATABASE")
+	if cityDatabase != "" || asnDatabase != "" {
+		geoIP, err := OpenGeoIP(cityDatabase, asnDatabase, geoIPCacheSize)
+		if err != nil {
+			// do not fail the processing of the logs because the enrichment is misconfigured
+			zap.L().Error("failed to open the GeoIP databases, events will not be enriched", zap.Error(err))
+		} else {
+			zap.L().Debug("created GeoIP enricher",
+				zap.String("cityDatabase", cityDatabase), zap.String("asnDatabase", asnDatabase))
+			enrichers = append(enrichers, geoIP)
+		}
+	}
+	if threatIntelBucket := os.Getenv("THREAT_INTEL_BUCKET"); threatIntelBucket != "" {
+		zap.L().Debug("created threat intel enricher", zap.String("bucket", threatIntelBucket))
+		enrichers = append(enrichers, NewThreatIntel(s3.New(common.Session), threatIntelBucket))
+	}
+	return enrichers
+}
@@ -0,0 +1,165 @@
+package enrichment
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strconv"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// the same ip addresses tend to repeat in a batch of logs (e.g. the egress of a VPC or a load balancer)
+	geoIPCacheSize = 10000
+)
+
+// GeoIPDatabase is the subset of a MaxMind database reader used for lookups, used to simplify mocking during testing
+type GeoIPDatabase interface {
+	Lookup(ip net.IP, result interface{}) error
+}
+
+// GeoIPInfo is the geolocation of an ip address
+type GeoIPInfo struct {
+	Country string // ISO 3166-1 country code
+	City    string // English name of the city
+	ASN     string // autonomous system number
+}
+
+// the fields of the records of the GeoIP2/GeoLite2 City and ASN databases that we use
+type cityRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+type asnRecord struct {
+	AutonomousSystemNumber uint `maxminddb:"autonomous_system_number"`
+}
+
+// GeoIP annotates events with the country, city and autonomous system of their ip addresses
+type GeoIP struct {
+	cityDB GeoIPDatabase // nil if not configured
+	asnDB  GeoIPDatabase // nil if not configured
+	cache  *lru.Cache    // ip address -> *GeoIPInfo, nil if nothing was found
+}
+
+// OpenGeoIP opens the MaxMind format databases at the given paths (e.g. shipped in a Lambda layer under /opt),
+// either path may be empty
+func OpenGeoIP(cityDatabasePath, asnDatabasePath string, cacheSize int) (*GeoIP, error) {
+	var cityDB, asnDB GeoIPDatabase
+	if cityDatabasePath != "" {
+		reader, err := maxminddb.Open(cityDatabasePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open city database %s", cityDatabasePath)
+		}
+		cityDB = reader
+	}
+	if asnDatabasePath != "" {
+		reader, err := maxminddb.Open(asnDatabasePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open ASN database %s", asnDatabasePath)
+		}
+		asnDB = reader
+	}
+	return NewGeoIP(cityDB, asnDB, cacheSize)
+}
+
+// NewGeoIP returns a GeoIP enricher looking up the given databases, either may be nil
+func NewGeoIP(cityDB, asnDB GeoIPDatabase, cacheSize int) (*GeoIP, error) {
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GeoIP cache")
+	}
+	return &GeoIP{
+		cityDB: cityDB,
+		asnDB:  asnDB,
+		cache:  cache,
+	}, nil
+}
+
+// Enrich sets the p_ip_geolocations field and the p_any_ip_countries, p_any_ip_cities and p_any_ip_asns sets
+// from the p_any_ip_addresses of the event.
+// NOTE: all the ip addresses of the event are looked up, the parsers do not tell source addresses apart
+func (g *GeoIP) Enrich(event *parsers.PantherLog) {
+	for _, ipAddress := range event.PantherAnyIPAddresses.Values() {
+		info := g.Lookup(ipAddress)
+		if info == nil {
+			continue
+		}
+		event.PantherIPGeolocations = append(event.PantherIPGeolocations, parsers.IPGeolocation{
+			IP:      ipAddress,
+			Country: info.Country,
+			City:    info.City,
+			ASN:     info.ASN,
+		})
+		event.AppendAnyIPCountries(info.Country)
+		event.AppendAnyIPCities(info.City)
+		event.AppendAnyIPASNs(info.ASN)
+	}
+}
+
+// Lookup returns the geolocation of an ip address, nil if it is not valid or not found in the databases
+func (g *GeoIP) Lookup(ipAddress string) *GeoIPInfo {
+	if cached, found := g.cache.Get(ipAddress); found {
+		return cached.(*GeoIPInfo)
+	}
+	info := g.lookup(ipAddress)
+	g.cache.Add(ipAddress, info) // misses are cached too, private addresses are frequent
+	return info
+}
+
+func (g *GeoIP) lookup(ipAddress string) *GeoIPInfo {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() {
+		return nil
+	}
+
+	var info GeoIPInfo
+	if g.cityDB != nil {
+		var record cityRecord
+		if err := g.cityDB.Lookup(ip, &record); err != nil {
+			zap.L().Warn("failed to look up ip address in city database", zap.Error(err))
+		} else {
+			info.Country = record.Country.ISOCode
+			info.City = record.City.Names["en"]
+		}
+	}
+	if g.asnDB != nil {
+		var record asnRecord
+		if err := g.asnDB.Lookup(ip, &record); err != nil {
+			zap.L().Warn("failed to look up ip address in ASN database", zap.Error(err))
+		} else if record.AutonomousSystemNumber != 0 {
+			info.ASN = strconv.FormatUint(uint64(record.AutonomousSystemNumber), 10)
+		}
+	}
+
+	if info == (GeoIPInfo{}) {
+		return nil
+	}
+	return &info
+}
@@ -0,0 +1,140 @@
+package enrichment
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// stubDatabase returns the records of the networks it knows about and counts the lookups
+type stubDatabase struct {
+	cities  map[string]cityRecord
+	asns    map[string]asnRecord
+	lookups int
+}
+
+func (db *stubDatabase) Lookup(ip net.IP, result interface{}) error {
+	db.lookups++
+	switch record := result.(type) {
+	case *cityRecord:
+		*record = db.cities[ip.String()]
+	case *asnRecord:
+		*record = db.asns[ip.String()]
+	default:
+		return errors.New("unexpected record type")
+	}
+	return nil
+}
+
+func newCityRecord(country, city string) cityRecord {
+	var record cityRecord
+	record.Country.ISOCode = country
+	record.City.Names = map[string]string{"en": city, "de": city + "-de"}
+	return record
+}
+
+func TestGeoIPEnrich(t *testing.T) {
+	cityDB := &stubDatabase{
+		cities: map[string]cityRecord{
+			"52.119.169.95": newCityRecord("US", "Seattle"),
+			"2a02:c7f::1":   newCityRecord("GB", "London"),
+		},
+	}
+	asnDB := &stubDatabase{
+		asns: map[string]asnRecord{
+			"52.119.169.95": {AutonomousSystemNumber: 16509},
+		},
+	}
+	geoIP, err := NewGeoIP(cityDB, asnDB, 10)
+	require.NoError(t, err)
+
+	event := &parsers.PantherLog{}
+	event.AppendAnyIPAddresses("52.119.169.95", "2a02:c7f::1", "10.0.0.1", "127.0.0.1")
+	geoIP.Enrich(event)
+	require.Equal(t, []string{"GB", "US"}, event.PantherAnyIPCountries.Values())
+	require.Equal(t, []string{"London", "Seattle"}, event.PantherAnyIPCities.Values())
+	require.Equal(t, []string{"16509"}, event.PantherAnyIPASNs.Values())
+	// each location is kept with its ip address
+	require.Equal(t, []parsers.IPGeolocation{
+		{IP: "2a02:c7f::1", Country: "GB", City: "London"},
+		{IP: "52.119.169.95", Country: "US", City: "Seattle", ASN: "16509"},
+	}, event.PantherIPGeolocations)
+	// the loopback address is not looked up
+	require.Equal(t, 3, cityDB.lookups)
+	require.Equal(t, 3, asnDB.lookups)
+
+	// the second event is served from the cache, misses included
+	event = &parsers.PantherLog{}
+	event.AppendAnyIPAddresses("52.119.169.95", "10.0.0.1")
+	geoIP.Enrich(event)
+	require.Equal(t, []string{"US"}, event.PantherAnyIPCountries.Values())
+	require.Equal(t, 3, cityDB.lookups)
+	require.Equal(t, 3, asnDB.lookups)
+}
+
+func TestGeoIPEnrichNoIPAddresses(t *testing.T) {
+	geoIP, err := NewGeoIP(&stubDatabase{}, nil, 10)
+	require.NoError(t, err)
+
+	event := &parsers.PantherLog{}
+	geoIP.Enrich(event)
+	require.Nil(t, event.PantherAnyIPCountries)
+	require.Nil(t, event.PantherAnyIPCities)
+	require.Nil(t, event.PantherAnyIPASNs)
+	require.Nil(t, event.PantherIPGeolocations)
+}
+
+func TestGeoIPLookupCityOnly(t *testing.T) {
+	cityDB := &stubDatabase{
+		cities: map[string]cityRecord{
+			"52.119.169.95": newCityRecord("US", "Seattle"),
+		},
+	}
+	geoIP, err := NewGeoIP(cityDB, nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, &GeoIPInfo{Country: "US", City: "Seattle"}, geoIP.Lookup("52.119.169.95"))
+	require.Nil(t, geoIP.Lookup("not an ip"))
+}
+
+func TestGeoIPLookupError(t *testing.T) {
+	geoIP, err := NewGeoIP(&failingDatabase{}, nil, 10)
+	require.NoError(t, err)
+	require.Nil(t, geoIP.Lookup("52.119.169.95"))
+}
+
+type failingDatabase struct{}
+
+func (db *failingDatabase) Lookup(ip net.IP, result interface{}) error {
+	return errors.New("corrupt database")
+}
+
+func TestOpenGeoIPMissingDatabase(t *testing.T) {
+	_, err := OpenGeoIP("/does/not/exist.mmdb", "", 10)
+	require.Error(t, err)
+}
+
+func TestCreateEnrichers(t *testing.T) {
+	require.Empty(t, CreateEnrichers())
+}
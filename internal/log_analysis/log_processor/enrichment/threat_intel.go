@@ -0,0 +1,153 @@
+package enrichment
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// How frequently the feeds are reloaded from S3, changes to the feeds are picked up within this duration
+	threatIntelRefreshInterval = 5 * time.Minute
+)
+
+// ThreatIntel tags the events with any field values matching the indicators of the threat intelligence feeds
+// stored in S3 by the source API. It is not safe for concurrent use, the processor enriches events serially.
+type ThreatIntel struct {
+	s3Client    s3iface.S3API
+	bucket      string
+	refreshTime time.Time
+	// indicator type -> normalized indicator -> names of the feeds it belongs to
+	indicators map[string]map[string][]string
+}
+
+// NewThreatIntel returns a ThreatIntel enricher for the feeds stored in bucket, the feeds are loaded on first use
+func NewThreatIntel(s3Client s3iface.S3API, bucket string) *ThreatIntel {
+	return &ThreatIntel{
+		s3Client: s3Client,
+		bucket:   bucket,
+	}
+}
+
+// Enrich sets the p_matched_indicators field from the indicators found in the any fields of the event
+func (ti *ThreatIntel) Enrich(event *parsers.PantherLog) {
+	ti.refresh()
+	if len(ti.indicators) == 0 {
+		return
+	}
+	ti.match(event, models.ThreatIntelIndicatorIP, event.PantherAnyIPAddresses)
+	ti.match(event, models.ThreatIntelIndicatorDomain, event.PantherAnyDomainNames)
+	ti.match(event, models.ThreatIntelIndicatorSHA1, event.PantherAnySHA1Hashes)
+	ti.match(event, models.ThreatIntelIndicatorMD5, event.PantherAnyMD5Hashes)
+	ti.match(event, models.ThreatIntelIndicatorSHA256, event.PantherAnySHA256Hashes)
+}
+
+func (ti *ThreatIntel) match(event *parsers.PantherLog, indicatorType string, values *parsers.PantherAnyString) {
+	indicators := ti.indicators[indicatorType]
+	if len(indicators) == 0 {
+		return
+	}
+	for _, value := range values.Values() {
+		indicator := models.NormalizeThreatIntelIndicator(indicatorType, value)
+		for _, feed := range indicators[indicator] {
+			event.AppendMatchedIndicator(parsers.ThreatIntelMatch{
+				Indicator: indicator,
+				Type:      indicatorType,
+				Feed:      feed,
+			})
+		}
+	}
+}
+
+// refresh reloads the feeds when they are older than the refresh interval
+func (ti *ThreatIntel) refresh() {
+	if time.Since(ti.refreshTime) < threatIntelRefreshInterval {
+		return
+	}
+	// on failure the indicators already loaded are used until the next refresh, rather than failing the processing
+	ti.refreshTime = time.Now()
+	indicators, err := ti.load()
+	if err != nil {
+		zap.L().Error("failed to load threat intel feeds", zap.String("bucket", ti.bucket), zap.Error(err))
+		return
+	}
+	ti.indicators = indicators
+}
+
+func (ti *ThreatIntel) load() (map[string]map[string][]string, error) {
+	indicators := make(map[string]map[string][]string)
+	var keys []*string
+	err := ti.s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(ti.bucket),
+		Prefix: aws.String(models.ThreatIntelFeedPrefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, object.Key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list feeds")
+	}
+
+	for _, key := range keys {
+		feed, err := ti.loadFeed(key)
+		if err != nil {
+			return nil, err
+		}
+		for _, indicator := range feed.Indicators {
+			indicatorType := aws.StringValue(indicator.Type)
+			if indicators[indicatorType] == nil {
+				indicators[indicatorType] = make(map[string][]string)
+			}
+			// the source API stores normalized indicators
+			value := aws.StringValue(indicator.Value)
+			indicators[indicatorType][value] = append(indicators[indicatorType][value], aws.StringValue(feed.FeedName))
+		}
+	}
+	zap.L().Debug("loaded threat intel feeds", zap.Int("feedCount", len(keys)))
+	return indicators, nil
+}
+
+func (ti *ThreatIntel) loadFeed(key *string) (*models.ThreatIntelFeed, error) {
+	output, err := ti.s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(ti.bucket),
+		Key:    key,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get feed %s", *key)
+	}
+	defer output.Body.Close()
+
+	var feed models.ThreatIntelFeed
+	if err := jsoniter.NewDecoder(output.Body).Decode(&feed); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode feed %s", *key)
+	}
+	return &feed, nil
+}
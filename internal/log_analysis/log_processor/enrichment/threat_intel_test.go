@@ -0,0 +1,115 @@
+package enrichment
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func getFeedOutput(t *testing.T, feedName string, indicators ...string) *s3.GetObjectOutput {
+	feed := &models.ThreatIntelFeed{
+		ThreatIntelFeedSettings: models.ThreatIntelFeedSettings{
+			FeedName: aws.String(feedName),
+		},
+	}
+	for i := 0; i < len(indicators); i += 2 {
+		feed.Indicators = append(feed.Indicators, &models.ThreatIntelIndicator{
+			Type:  aws.String(indicators[i]),
+			Value: aws.String(indicators[i+1]),
+		})
+	}
+	body, err := jsoniter.Marshal(feed)
+	require.NoError(t, err)
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(body))}
+}
+
+func mockFeeds(t *testing.T) *testutils.S3Mock {
+	s3Mock := &testutils.S3Mock{}
+	s3Mock.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Key: aws.String("threat_intel/botnet.json")},
+			{Key: aws.String("threat_intel/tor.json")},
+		},
+	}, nil).Once()
+	s3Mock.On("GetObject", &s3.GetObjectInput{Bucket: aws.String("test-bucket"), Key: aws.String("threat_intel/botnet.json")}).
+		Return(getFeedOutput(t, "botnet",
+			models.ThreatIntelIndicatorIP, "192.0.2.10",
+			models.ThreatIntelIndicatorDomain, "evil.example.com",
+			models.ThreatIntelIndicatorSHA256, "ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9"), nil).Once()
+	s3Mock.On("GetObject", &s3.GetObjectInput{Bucket: aws.String("test-bucket"), Key: aws.String("threat_intel/tor.json")}).
+		Return(getFeedOutput(t, "tor", models.ThreatIntelIndicatorIP, "192.0.2.10"), nil).Once()
+	return s3Mock
+}
+
+func TestThreatIntelEnrich(t *testing.T) {
+	s3Mock := mockFeeds(t)
+	threatIntel := NewThreatIntel(s3Mock, "test-bucket")
+
+	event := &parsers.PantherLog{}
+	event.AppendAnyIPAddresses("192.0.2.10", "198.51.100.1")
+	event.AppendAnyDomainNames("Evil.Example.com")
+	event.AppendAnySHA256Hashes("EA8FAC7C65FB589B0D53560F5251F74F9E9B243478DCB6B3EA79B5E36449C8D9")
+	threatIntel.Enrich(event)
+	require.Equal(t, []parsers.ThreatIntelMatch{
+		{Indicator: "evil.example.com", Type: models.ThreatIntelIndicatorDomain, Feed: "botnet"},
+		{Indicator: "192.0.2.10", Type: models.ThreatIntelIndicatorIP, Feed: "botnet"},
+		{Indicator: "ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9", Type: models.ThreatIntelIndicatorSHA256, Feed: "botnet"},
+		{Indicator: "192.0.2.10", Type: models.ThreatIntelIndicatorIP, Feed: "tor"},
+	}, event.PantherMatchedIndicators)
+
+	// the feeds are loaded once per refresh interval
+	event = &parsers.PantherLog{}
+	event.AppendAnyIPAddresses("198.51.100.1")
+	threatIntel.Enrich(event)
+	require.Nil(t, event.PantherMatchedIndicators)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestThreatIntelRefreshError(t *testing.T) {
+	s3Mock := mockFeeds(t)
+	threatIntel := NewThreatIntel(s3Mock, "test-bucket")
+
+	event := &parsers.PantherLog{}
+	event.AppendAnyIPAddresses("192.0.2.10")
+	threatIntel.Enrich(event)
+	require.Len(t, event.PantherMatchedIndicators, 2)
+
+	// the indicators already loaded are kept if the feeds cannot be reloaded
+	threatIntel.refreshTime = time.Now().Add(-threatIntelRefreshInterval)
+	s3Mock.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(nil, errors.New("access denied")).Once()
+	event = &parsers.PantherLog{}
+	event.AppendAnyIPAddresses("192.0.2.10")
+	threatIntel.Enrich(event)
+	require.Len(t, event.PantherMatchedIndicators, 2)
+	s3Mock.AssertExpectations(t)
+}
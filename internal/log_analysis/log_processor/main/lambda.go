@@ -20,26 +20,65 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pullsources"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
 )
 
+// The detail type of the CloudWatch Events that trigger the pull of SaaS APIs
+const scheduledEventDetailType = "Scheduled Event"
+
 func main() {
+	// the databases are opened once per container, not per invocation
+	processor.Enrichers = enrichment.CreateEnrichers()
 	lambda.Start(handle)
 }
 
-func handle(ctx context.Context, event events.SQSEvent) error {
+// handle processes the SQS messages of S3 notifications, the records of Kinesis streams,
+// the log events of CloudWatch Logs subscription filters or pulls the events of SaaS APIs on schedule.
+// The same code is deployed as the panther-log-processor function and the panther-log-puller function (pulls only).
+func handle(ctx context.Context, event json.RawMessage) error {
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
-	return process(lc, event)
+
+	if jsoniter.Get(event, "detail-type").ToString() == scheduledEventDetailType {
+		return processPullSources(ctx, lc)
+	}
+
+	if jsoniter.Get(event, "awslogs", "data").ValueType() == jsoniter.StringValue {
+		var cloudWatchLogsEvent events.CloudwatchLogsEvent
+		if err := jsoniter.Unmarshal(event, &cloudWatchLogsEvent); err != nil {
+			return errors.Wrap(err, "failed to unmarshal cloudwatch logs event")
+		}
+		return processCloudWatchLogs(lc, &cloudWatchLogsEvent)
+	}
+
+	if jsoniter.Get(event, "Records", 0, "eventSource").ToString() == sources.KinesisEventSource {
+		var kinesisEvent events.KinesisEvent
+		if err := jsoniter.Unmarshal(event, &kinesisEvent); err != nil {
+			return errors.Wrap(err, "failed to unmarshal kinesis event")
+		}
+		return processKinesis(lc, kinesisEvent)
+	}
+
+	var sqsEvent events.SQSEvent
+	if err := jsoniter.Unmarshal(event, &sqsEvent); err != nil {
+		return errors.Wrap(err, "failed to unmarshal sqs event")
+	}
+	return process(lc, sqsEvent)
 }
 
 func process(lc *lambdacontext.LambdaContext, event events.SQSEvent) (err error) {
@@ -60,3 +99,55 @@ func process(lc *lambdacontext.LambdaContext, event events.SQSEvent) (err error)
 	err = processor.Process(dataStreams, destinations.CreateDestination())
 	return err
 }
+
+func processKinesis(lc *lambdacontext.LambdaContext, event events.KinesisEvent) (err error) {
+	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	defer func() {
+		operation.Stop().Log(err, zap.Int("kinesisRecordCount", len(event.Records)))
+	}()
+
+	// this is not likely to happen in production but needed to avoid opening sessions in tests w/no events
+	if len(event.Records) == 0 {
+		return err
+	}
+
+	dataStreams, err := sources.ReadKinesisRecords(event.Records)
+	if err != nil {
+		return err
+	}
+	// a failure makes the event source mapping retry the whole batch, so events of the batch may be stored twice
+	err = processor.Process(dataStreams, destinations.CreateDestination())
+	return err
+}
+
+func processCloudWatchLogs(lc *lambdacontext.LambdaContext, event *events.CloudwatchLogsEvent) (err error) {
+	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	defer func() {
+		operation.Stop().Log(err)
+	}()
+
+	dataStreams, err := sources.ReadCloudWatchLogsEvent(event)
+	if err != nil {
+		return err
+	}
+	// this is not likely to happen in production but needed to avoid opening sessions in tests w/no events
+	if len(dataStreams) == 0 {
+		return err
+	}
+	err = processor.Process(dataStreams, destinations.CreateDestination())
+	return err
+}
+
+func processPullSources(ctx context.Context, lc *lambdacontext.LambdaContext) (err error) {
+	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	var pullCount int
+	defer func() {
+		operation.Stop().Log(err, zap.Int("pullCount", pullCount))
+	}()
+
+	// each integration is processed and checkpointed on its own, so a failure does not pull the events of others again
+	pullCount, err = pullsources.PullAll(ctx, time.Now().UTC(), func(dataStream *common.DataStream) error {
+		return processor.Process([]*common.DataStream{dataStream}, destinations.CreateDestination())
+	})
+	return err
+}
@@ -66,3 +66,19 @@ func TestProcessOpLog(t *testing.T) {
 		t.Errorf("unknown type for sqsMessageCount: %#v", sqsMessageCount)
 	}
 }
+
+func TestProcessKinesisOpLog(t *testing.T) {
+	logs := mockLogger()
+	functionName := "myfunction"
+	lc := lambdacontext.LambdaContext{
+		InvokedFunctionArn: functionName,
+	}
+	err := processKinesis(&lc, events.KinesisEvent{
+		Records: []events.KinesisEventRecord{}, // empty, should do no work
+	})
+	require.NoError(t, err)
+	message := common.OpLogNamespace + ":" + common.OpLogComponent + ":" + functionName
+	require.Equal(t, 1, len(logs.FilterMessage(message).All()))
+	_, ok := logs.FilterMessage(message).All()[0].ContextMap()["kinesisRecordCount"]
+	assert.True(t, ok)
+}
@@ -121,4 +121,5 @@ func (event *AuroraMySQLAudit) updatePantherFields(p *AuroraMySQLAuditParser) {
 	event.SetCoreFields(p.LogType(), event.Timestamp, event)
 	event.AppendAnyIPAddressPtrs(event.Host)
 	event.AppendAnyDomainNamePtrs(event.ServerHost)
+	event.AppendAnyUsernamePtrs(event.Username)
 }
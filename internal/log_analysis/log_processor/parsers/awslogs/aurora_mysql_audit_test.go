@@ -55,6 +55,7 @@ func TestAuroraMySQLAuditLog(t *testing.T) {
 	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
 	expectedEvent.AppendAnyIPAddresses("10.0.143.147")
 	expectedEvent.AppendAnyDomainNames("db-instance-name")
+	expectedEvent.AppendAnyUsernames("someuser")
 
 	checkAuroraMysqlAuditLogLog(t, log, expectedEvent)
 }
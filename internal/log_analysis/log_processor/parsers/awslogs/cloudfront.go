@@ -0,0 +1,197 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var CloudFrontDesc = `CloudFront standard logs contain detailed information about every user request that CloudFront receives, in W3C extended log file format.
+Reference: https://docs.aws.amazon.com/AmazonCloudFront/latest/DeveloperGuide/AccessLogs.html`
+
+const (
+	// the number of columns written before field-level encryption was introduced
+	cloudFrontMinNumberOfColumns = 24
+	cloudFrontTimestampLayout    = "2006-01-02 15:04:05"
+)
+
+// nolint:lll
+type CloudFront struct {
+	Timestamp              *timestamp.RFC3339 `json:"timestamp,omitempty" validate:"required" description:"The date and time (UTC) on which the event occurred."`
+	EdgeLocation           *string            `json:"edgeLocation,omitempty" description:"The edge location that served the request. Each edge location is identified by a three-letter code and an arbitrarily assigned number, for example, DFW3."`
+	BytesSent              *int               `json:"bytesSent,omitempty" description:"The total number of bytes that CloudFront served to the viewer in response to the request, including headers."`
+	ClientIP               *string            `json:"clientIp,omitempty" description:"The IP address of the viewer that made the request."`
+	Method                 *string            `json:"method,omitempty" description:"The HTTP request method."`
+	DistributionDomain     *string            `json:"distributionDomain,omitempty" description:"The domain name of the CloudFront distribution, for example, d111111abcdef8.cloudfront.net."`
+	URIStem                *string            `json:"uriStem,omitempty" description:"The portion of the URI that identifies the path and object, for example, /images/cat.jpg."`
+	Status                 *int               `json:"status,omitempty" description:"The HTTP status code, or 000 if the viewer closed the connection before CloudFront could respond."`
+	Referer                *string            `json:"referer,omitempty" description:"The name of the domain that originated the request."`
+	UserAgent              *string            `json:"userAgent,omitempty" description:"The value of the User-Agent header in the request (URL-encoded)."`
+	URIQuery               *string            `json:"uriQuery,omitempty" description:"The query string portion of the URI, if any."`
+	Cookie                 *string            `json:"cookie,omitempty" description:"The cookie header in the request, including name-value pairs and the associated attributes."`
+	EdgeResultType         *string            `json:"edgeResultType,omitempty" description:"How CloudFront classifies the response after the last byte left the edge location (e.g. Hit, RefreshHit, Miss, LimitExceeded, CapacityExceeded, Error, Redirect)."`
+	EdgeRequestID          *string            `json:"edgeRequestId,omitempty" description:"An encrypted string that uniquely identifies a request."`
+	HostHeader             *string            `json:"hostHeader,omitempty" description:"The value that the viewer included in the Host header for this request."`
+	Protocol               *string            `json:"protocol,omitempty" description:"The protocol that the viewer specified in the request: http, https, ws, or wss."`
+	BytesReceived          *int               `json:"bytesReceived,omitempty" description:"The number of bytes of data that the viewer included in the request, including headers."`
+	TimeTaken              *float64           `json:"timeTaken,omitempty" description:"The number of seconds (to the thousandth of a second) between the time a CloudFront edge server receives a viewer's request and the time it writes the last byte of the response."`
+	ForwardedFor           *string            `json:"forwardedFor,omitempty" description:"If the viewer used an HTTP proxy or a load balancer to send the request, the value of the X-Forwarded-For header."`
+	SSLProtocol            *string            `json:"sslProtocol,omitempty" description:"The SSL/TLS protocol that the viewer and server negotiated for transmitting the request and response, if the request used HTTPS."`
+	SSLCipher              *string            `json:"sslCipher,omitempty" description:"The SSL/TLS cipher that the viewer and server negotiated for encrypting the request and response, if the request used HTTPS."`
+	EdgeResponseResultType *string            `json:"edgeResponseResultType,omitempty" description:"How CloudFront classified the response just before returning the response to the viewer."`
+	ProtocolVersion        *string            `json:"protocolVersion,omitempty" description:"The HTTP version that the viewer specified in the request."`
+	FLEStatus              *string            `json:"fleStatus,omitempty" description:"When field-level encryption is configured for a distribution, a code that indicates whether the request body was successfully processed."`
+	FLEEncryptedFields     *int               `json:"fleEncryptedFields,omitempty" description:"The number of fields that CloudFront encrypted and forwarded to the origin."`
+	ClientPort             *int               `json:"clientPort,omitempty" description:"The port number of the request from the viewer."`
+	TimeToFirstByte        *float64           `json:"timeToFirstByte,omitempty" description:"The number of seconds between receiving the request and writing the first byte of the response, as measured on the server."`
+	EdgeDetailedResultType *string            `json:"edgeDetailedResultType,omitempty" description:"When the edgeResultType field is not Error, this field contains the same value. When it is Error, this field contains the specific type of error."`
+	ContentType            *string            `json:"contentType,omitempty" description:"The value of the HTTP Content-Type header of the response."`
+	ContentLength          *int               `json:"contentLength,omitempty" description:"The value of the HTTP Content-Length header of the response."`
+	RangeStart             *int               `json:"rangeStart,omitempty" description:"When the response contains the HTTP Content-Range header, the range start value."`
+	RangeEnd               *int               `json:"rangeEnd,omitempty" description:"When the response contains the HTTP Content-Range header, the range end value."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// CloudFrontParser parses AWS CloudFront standard logs
+type CloudFrontParser struct {
+	CSVReader *csvstream.StreamingCSVReader
+}
+
+func (p *CloudFrontParser) New() parsers.LogParser {
+	reader := csvstream.NewStreamingCSVReader()
+	// non-default settings
+	reader.CVSReader.Comma = '\t'
+	reader.CVSReader.LazyQuotes = true
+	return &CloudFrontParser{
+		CSVReader: reader,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *CloudFrontParser) Parse(log string) []*parsers.PantherLog {
+	// each file starts with the W3C directives, return success but no events
+	if strings.HasPrefix(log, "#Version:") || strings.HasPrefix(log, "#Fields:") {
+		return []*parsers.PantherLog{}
+	}
+
+	record, err := p.CSVReader.Parse(log)
+	if err != nil {
+		zap.L().Debug("failed to parse the log as csv")
+		return nil
+	}
+
+	if len(record) < cloudFrontMinNumberOfColumns {
+		zap.L().Debug("failed to parse the log as csv (wrong number of columns)")
+		return nil
+	}
+
+	timeStamp, err := timestamp.Parse(cloudFrontTimestampLayout, record[0]+" "+record[1])
+	if err != nil {
+		zap.L().Debug("failed to parse timestamp", zap.Error(err))
+		return nil
+	}
+
+	event := &CloudFront{
+		Timestamp:              &timeStamp,
+		EdgeLocation:           parsers.CsvStringToPointer(record[2]),
+		BytesSent:              parsers.CsvStringToIntPointer(record[3]),
+		ClientIP:               parsers.CsvStringToPointer(record[4]),
+		Method:                 parsers.CsvStringToPointer(record[5]),
+		DistributionDomain:     parsers.CsvStringToPointer(record[6]),
+		URIStem:                parsers.CsvStringToPointer(record[7]),
+		Status:                 parsers.CsvStringToIntPointer(record[8]),
+		Referer:                parsers.CsvStringToPointer(record[9]),
+		UserAgent:              parsers.CsvStringToPointer(record[10]),
+		URIQuery:               parsers.CsvStringToPointer(record[11]),
+		Cookie:                 parsers.CsvStringToPointer(record[12]),
+		EdgeResultType:         parsers.CsvStringToPointer(record[13]),
+		EdgeRequestID:          parsers.CsvStringToPointer(record[14]),
+		HostHeader:             parsers.CsvStringToPointer(record[15]),
+		Protocol:               parsers.CsvStringToPointer(record[16]),
+		BytesReceived:          parsers.CsvStringToIntPointer(record[17]),
+		TimeTaken:              parsers.CsvStringToFloat64Pointer(record[18]),
+		ForwardedFor:           parsers.CsvStringToPointer(record[19]),
+		SSLProtocol:            parsers.CsvStringToPointer(record[20]),
+		SSLCipher:              parsers.CsvStringToPointer(record[21]),
+		EdgeResponseResultType: parsers.CsvStringToPointer(record[22]),
+		ProtocolVersion:        parsers.CsvStringToPointer(record[23]),
+	}
+
+	// fields added to the format over time, only present in newer logs
+	if len(record) > 24 {
+		event.FLEStatus = parsers.CsvStringToPointer(record[24])
+	}
+	if len(record) > 25 {
+		event.FLEEncryptedFields = parsers.CsvStringToIntPointer(record[25])
+	}
+	if len(record) > 26 {
+		event.ClientPort = parsers.CsvStringToIntPointer(record[26])
+	}
+	if len(record) > 27 {
+		event.TimeToFirstByte = parsers.CsvStringToFloat64Pointer(record[27])
+	}
+	if len(record) > 28 {
+		event.EdgeDetailedResultType = parsers.CsvStringToPointer(record[28])
+	}
+	if len(record) > 29 {
+		event.ContentType = parsers.CsvStringToPointer(record[29])
+	}
+	if len(record) > 30 {
+		event.ContentLength = parsers.CsvStringToIntPointer(record[30])
+	}
+	if len(record) > 31 {
+		event.RangeStart = parsers.CsvStringToIntPointer(record[31])
+	}
+	if len(record) > 32 {
+		event.RangeEnd = parsers.CsvStringToIntPointer(record[32])
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *CloudFrontParser) LogType() string {
+	return "AWS.CloudFront"
+}
+
+func (event *CloudFront) updatePantherFields(p *CloudFrontParser) {
+	event.SetCoreFields(p.LogType(), event.Timestamp, event)
+	event.AppendAnyIPAddressPtrs(event.ClientIP)
+	if event.ForwardedFor != nil {
+		for _, forwarded := range strings.Split(*event.ForwardedFor, ",") {
+			event.AppendAnyIPAddresses(strings.TrimSpace(forwarded))
+		}
+	}
+	event.AppendAnyDomainNamePtrs(event.DistributionDomain, event.HostHeader)
+}
@@ -0,0 +1,131 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestCloudFrontLog(t *testing.T) {
+	log := "2019-12-04\t21:02:31\tLAX1\t392\t192.0.2.100\tGET\td111111abcdef8.cloudfront.net\t/index.html\t200\t-\t" +
+		"Mozilla/5.0%20(Windows%20NT%2010.0;%20Win64;%20x64)\t-\t-\tHit\tSOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ==\t" +
+		"d111111abcdef8.cloudfront.net\thttps\t23\t0.001\t-\tTLSv1.2\tECDHE-RSA-AES128-GCM-SHA256\tHit\tHTTP/2.0\t-\t-\t11040\t" +
+		"0.001\tHit\ttext/html\t78\t-\t-"
+
+	expectedTime := time.Date(2019, 12, 4, 21, 2, 31, 0, time.UTC)
+	expectedEvent := &CloudFront{
+		Timestamp:              (*timestamp.RFC3339)(&expectedTime),
+		EdgeLocation:           aws.String("LAX1"),
+		BytesSent:              aws.Int(392),
+		ClientIP:               aws.String("192.0.2.100"),
+		Method:                 aws.String("GET"),
+		DistributionDomain:     aws.String("d111111abcdef8.cloudfront.net"),
+		URIStem:                aws.String("/index.html"),
+		Status:                 aws.Int(200),
+		UserAgent:              aws.String("Mozilla/5.0%20(Windows%20NT%2010.0;%20Win64;%20x64)"),
+		EdgeResultType:         aws.String("Hit"),
+		EdgeRequestID:          aws.String("SOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ=="),
+		HostHeader:             aws.String("d111111abcdef8.cloudfront.net"),
+		Protocol:               aws.String("https"),
+		BytesReceived:          aws.Int(23),
+		TimeTaken:              aws.Float64(0.001),
+		SSLProtocol:            aws.String("TLSv1.2"),
+		SSLCipher:              aws.String("ECDHE-RSA-AES128-GCM-SHA256"),
+		EdgeResponseResultType: aws.String("Hit"),
+		ProtocolVersion:        aws.String("HTTP/2.0"),
+		ClientPort:             aws.Int(11040),
+		TimeToFirstByte:        aws.Float64(0.001),
+		EdgeDetailedResultType: aws.String("Hit"),
+		ContentType:            aws.String("text/html"),
+		ContentLength:          aws.Int(78),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.CloudFront")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("192.0.2.100")
+	expectedEvent.AppendAnyDomainNames("d111111abcdef8.cloudfront.net")
+
+	checkCloudFrontLog(t, log, expectedEvent)
+}
+
+func TestCloudFrontLogLegacyColumns(t *testing.T) {
+	log := "2014-05-23\t01:13:11\tFRA2\t182\t192.0.2.10\tGET\td111111abcdef8.cloudfront.net\t/view/my/file.html\t200\t" +
+		"www.displaymyfiles.com\tMozilla/4.0%20(compatible;%20MSIE%205.0b1;%20Mac_PowerPC)\t-\tzip=98101\tRefreshHit\t" +
+		"MRVMF7KydIvxMWfJIglgwHQwZsbG2IhRJ07sn9AkKUFSHS9EXAMPLE==\td111111abcdef8.cloudfront.net\thttp\t-\t0.001\t192.0.2.1, 198.51.100.2\t-\t-\t" +
+		"RefreshHit\tHTTP/1.1"
+
+	expectedTime := time.Date(2014, 5, 23, 1, 13, 11, 0, time.UTC)
+	expectedEvent := &CloudFront{
+		Timestamp:              (*timestamp.RFC3339)(&expectedTime),
+		EdgeLocation:           aws.String("FRA2"),
+		BytesSent:              aws.Int(182),
+		ClientIP:               aws.String("192.0.2.10"),
+		Method:                 aws.String("GET"),
+		DistributionDomain:     aws.String("d111111abcdef8.cloudfront.net"),
+		URIStem:                aws.String("/view/my/file.html"),
+		Status:                 aws.Int(200),
+		Referer:                aws.String("www.displaymyfiles.com"),
+		UserAgent:              aws.String("Mozilla/4.0%20(compatible;%20MSIE%205.0b1;%20Mac_PowerPC)"),
+		Cookie:                 aws.String("zip=98101"),
+		EdgeResultType:         aws.String("RefreshHit"),
+		EdgeRequestID:          aws.String("MRVMF7KydIvxMWfJIglgwHQwZsbG2IhRJ07sn9AkKUFSHS9EXAMPLE=="),
+		HostHeader:             aws.String("d111111abcdef8.cloudfront.net"),
+		Protocol:               aws.String("http"),
+		TimeTaken:              aws.Float64(0.001),
+		ForwardedFor:           aws.String("192.0.2.1, 198.51.100.2"),
+		EdgeResponseResultType: aws.String("RefreshHit"),
+		ProtocolVersion:        aws.String("HTTP/1.1"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.CloudFront")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("192.0.2.10", "192.0.2.1", "198.51.100.2")
+	expectedEvent.AppendAnyDomainNames("d111111abcdef8.cloudfront.net")
+
+	checkCloudFrontLog(t, log, expectedEvent)
+}
+
+func TestCloudFrontLogHeaders(t *testing.T) {
+	parser := (&CloudFrontParser{}).New()
+	require.Equal(t, []*parsers.PantherLog{}, parser.Parse("#Version: 1.0"))
+	//nolint
+	require.Equal(t, []*parsers.PantherLog{}, parser.Parse("#Fields: date time x-edge-location sc-bytes c-ip cs-method cs(Host) cs-uri-stem sc-status cs(Referer) cs(User-Agent)"))
+	require.Nil(t, parser.Parse("2019-12-04\t21:02:31\tLAX1"))
+}
+
+func TestCloudFrontLogType(t *testing.T) {
+	parser := &CloudFrontParser{}
+	require.Equal(t, "AWS.CloudFront", parser.LogType())
+}
+
+func checkCloudFrontLog(t *testing.T, log string, expectedEvent *CloudFront) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&CloudFrontParser{}).New() // important to call New() to initialize reader
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
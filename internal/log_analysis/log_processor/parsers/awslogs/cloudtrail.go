@@ -19,9 +19,13 @@ package awslogs
  */
 
 import (
+	"bytes"
+	"io"
+	"regexp"
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
@@ -32,6 +36,20 @@ import (
 var CloudTrailDesc = `AWSCloudTrail represents the content of a CloudTrail S3 object.
 Log format & samples can be seen here: https://docs.aws.amazon.com/awscloudtrail/latest/userguide/cloudtrail-event-reference.html`
 
+const (
+	cloudTrailRecordsField     = "Records"
+	cloudTrailStreamBufferSize = 64 * 1024
+)
+
+var (
+	// matches the beginning of a CloudTrail S3 object e.g. `{"Records":[{`
+	cloudTrailStreamHeaderRegex = regexp.MustCompile(`^\s*{\s*"Records"\s*:\s*\[\s*{`)
+	// other JSON documents have Records too (e.g. S3 event notifications have an eventVersion and an
+	// eventSource like "aws:s3"), so the first record must also look like a CloudTrail event
+	cloudTrailStreamEventVersionRegex = regexp.MustCompile(`"eventVersion"\s*:\s*"\d+\.\d+"`)
+	cloudTrailStreamEventSourceRegex  = regexp.MustCompile(`"eventSource"\s*:\s*"[\w.-]+\.amazonaws\.com"`)
+)
+
 type CloudTrailRecords struct {
 	Records []*CloudTrail `json:"Records" validate:"required,dive"`
 }
@@ -147,6 +165,50 @@ func (p *CloudTrailParser) Parse(log string) []*parsers.PantherLog {
 	return result
 }
 
+// CanParseStream returns true if the header looks like the start of a CloudTrail S3 object.
+// If the fields of the first record are not in the header, the stream is not claimed and is parsed line by line.
+func (p *CloudTrailParser) CanParseStream(header []byte) bool {
+	return cloudTrailStreamHeaderRegex.Match(header) &&
+		cloudTrailStreamEventVersionRegex.Match(header) &&
+		cloudTrailStreamEventSourceRegex.Match(header)
+}
+
+// ParseStream decodes the Records[*] of a CloudTrail S3 object one at a time, so the whole document
+// never needs to be in memory
+func (p *CloudTrailParser) ParseStream(stream io.Reader, emit func(record []byte, events []*parsers.PantherLog)) error {
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, stream, cloudTrailStreamBufferSize)
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, field string) bool {
+		if field != cloudTrailRecordsField {
+			iter.Skip()
+			return true
+		}
+		return iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+			record := bytes.TrimSpace(iter.SkipAndReturnBytes())
+			if iter.Error != nil {
+				return false
+			}
+			event := &CloudTrail{}
+			if err := jsoniter.Unmarshal(record, event); err != nil {
+				zap.L().Debug("failed to parse log", zap.Error(err))
+				emit(record, nil)
+				return true
+			}
+			event.updatePantherFields(p)
+			if err := parsers.Validator.Struct(event); err != nil {
+				zap.L().Debug("failed to validate log", zap.Error(err))
+				emit(record, nil)
+				return true
+			}
+			emit(record, []*parsers.PantherLog{event.Log()})
+			return true
+		})
+	})
+	if iter.Error != nil && iter.Error != io.EOF {
+		return errors.Wrap(iter.Error, "failed to parse CloudTrail stream")
+	}
+	return nil
+}
+
 // LogType returns the log type supported by this parser
 func (p *CloudTrailParser) LogType() string {
 	return "AWS.CloudTrail"
@@ -167,22 +229,25 @@ func (event *CloudTrail) updatePantherFields(p *CloudTrailParser) {
 	if event.UserIdentity != nil {
 		event.AppendAnyAWSAccountIdPtrs(event.UserIdentity.AccountID)
 		event.AppendAnyAWSARNPtrs(event.UserIdentity.ARN)
+		event.AppendAnyUsernamePtrs(event.UserIdentity.Username)
 
 		if event.UserIdentity.SessionContext != nil {
 			if event.UserIdentity.SessionContext.SessionIssuer != nil {
 				event.AppendAnyAWSAccountIdPtrs(event.UserIdentity.SessionContext.SessionIssuer.AccountID)
 				event.AppendAnyAWSARNPtrs(event.UserIdentity.SessionContext.SessionIssuer.Arn)
+				event.AppendAnyUsernamePtrs(event.UserIdentity.SessionContext.SessionIssuer.Username)
 			}
 		}
 	}
 
 	// polymorphic (unparsed) fields
-	awsExtractor := NewAWSExtractor(&(event.AWSPantherLog))
+	awsExtractor := NewAWSExtractor(&(event.PantherLog))
 	extract.Extract(event.AdditionalEventData, awsExtractor)
 	extract.Extract(event.RequestParameters, awsExtractor)
 	extract.Extract(event.ResponseElements, awsExtractor)
 	extract.Extract(event.ServiceEventDetails, awsExtractor)
-	if event.UserIdentity.SessionContext != nil && event.UserIdentity.SessionContext.WebIDFederationData != nil {
+	if event.UserIdentity != nil && event.UserIdentity.SessionContext != nil &&
+		event.UserIdentity.SessionContext.WebIDFederationData != nil {
 		extract.Extract(event.UserIdentity.SessionContext.WebIDFederationData.Attributes, awsExtractor)
 	}
 }
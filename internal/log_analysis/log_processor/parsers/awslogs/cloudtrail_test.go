@@ -19,12 +19,14 @@ package awslogs
  */
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
@@ -133,6 +135,7 @@ func TestCloudTrailLogDecrypt(t *testing.T) {
 		"arn:aws:sts::888888888888:assumed-role/panther-app-LogProcessor-XXXXXXXXXXXX-FunctionRole-XXXXXXXXXX/panther-log-processor",
 		"arn:aws:lambda:us-east-1:888888888888:function:panther-log-processor")
 	expectedEvent.AppendAnyAWSAccountIds("888888888888")
+	expectedEvent.AppendAnyUsernames("panther-app-LogProcessor-XXXXXXXXXXXX-FunctionRole-XXXXXXXXXX")
 	expectedEvent.AppendAnyIPAddresses("1.2.3.4")
 
 	checkCloudTrailLog(t, log, []*CloudTrail{expectedEvent})
@@ -143,6 +146,49 @@ func TestCloudTrailLogType(t *testing.T) {
 	require.Equal(t, "AWS.CloudTrail", parser.LogType())
 }
 
+func TestCloudTrailParseStream(t *testing.T) {
+	//nolint:lll
+	log := `{"Records": [{"eventVersion":"1.05","userIdentity":{"type":"AWSService","invokedBy":"cloudtrail.amazonaws.com"},"eventTime":"2018-08-26T14:17:23Z","eventSource":"kms.amazonaws.com","eventName":"GenerateDataKey","awsRegion":"us-west-2","sourceIPAddress":"cloudtrail.amazonaws.com","eventID":"7a215e16-e0ad-4f6c-82b9-33ff6bbdedd2","eventType":"AwsApiCall"},
+{"eventVersion":"1.05","eventTime":"2018-08-26T14:17:23Z","eventSource":"kms.amazonaws.com","eventName":"Decrypt","awsRegion":"us-west-2","sourceIPAddress":"1.2.3.4","eventID":"1852a808-86e8-4b4c-9d4d-01a85b6a39cd","eventType":"AwsApiCall"},
+{"eventVersion":"1.05","userIdentity":{"type":"AWSService","invokedBy":"cloudtrail.amazonaws.com"},"eventTime":"2018-08-26T14:17:24Z","eventSource":"kms.amazonaws.com","eventName":"Decrypt","awsRegion":"us-west-2","sourceIPAddress":"1.2.3.4","eventID":"3cff2472-5a91-4bd9-b6d2-8a7a1aaa9086","eventType":"AwsApiCall"}]}`
+
+	parser := &CloudTrailParser{}
+	require.True(t, parser.CanParseStream([]byte(log)))
+
+	var results [][]*parsers.PantherLog
+	var records []string
+	err := parser.ParseStream(strings.NewReader(log), func(record []byte, events []*parsers.PantherLog) {
+		results = append(results, events)
+		records = append(records, string(record))
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(results))
+	//nolint:lll
+	require.Equal(t, `{"eventVersion":"1.05","eventTime":"2018-08-26T14:17:23Z","eventSource":"kms.amazonaws.com","eventName":"Decrypt","awsRegion":"us-west-2","sourceIPAddress":"1.2.3.4","eventID":"1852a808-86e8-4b4c-9d4d-01a85b6a39cd","eventType":"AwsApiCall"}`, records[1])
+	require.Equal(t, 1, len(results[0]))
+	require.Equal(t, "GenerateDataKey", *results[0][0].Event().(*CloudTrail).EventName)
+	require.Nil(t, results[1]) // missing userIdentity fails validation but does not stop the stream
+	require.Equal(t, 1, len(results[2]))
+	require.Equal(t, "3cff2472-5a91-4bd9-b6d2-8a7a1aaa9086", *results[2][0].Event().(*CloudTrail).EventID)
+	require.NotNil(t, results[2][0].PantherAnyIPAddresses)
+}
+
+func TestCloudTrailParseStreamInvalid(t *testing.T) {
+	parser := &CloudTrailParser{}
+	require.False(t, parser.CanParseStream([]byte(`{"eventVersion":"1.05"}`)))
+	// S3 event notifications are also Records with an eventVersion and an eventSource
+	//nolint:lll
+	require.False(t, parser.CanParseStream([]byte(`{"Records":[{"eventVersion":"2.1","eventSource":"aws:s3","awsRegion":"us-west-2","eventTime":"2020-01-01T00:00:00.000Z","eventName":"ObjectCreated:Put"}]}`)))
+	// the first record is not in the header
+	require.False(t, parser.CanParseStream([]byte(`{"Records":[{"userIdentity":{"type":"AWSService"`)))
+
+	log := `{"Records": [{"eventVersion":"1.05",`
+	err := parser.ParseStream(strings.NewReader(log), func(record []byte, events []*parsers.PantherLog) {
+		require.Fail(t, "no events expected")
+	})
+	require.Error(t, err)
+}
+
 func checkCloudTrailLog(t *testing.T, log string, expectedEvents []*CloudTrail) {
 	parser := &CloudTrailParser{}
 	events := parser.Parse(log)
@@ -0,0 +1,157 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var ELBClassicDesc = `Classic Load Balancer access logs capture detailed information about requests sent to your Classic Load Balancer.
+Reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/classic/access-log-collection.html`
+
+const (
+	elbClassicNumberOfColumns = 15
+)
+
+// nolint:lll
+type ELBClassic struct {
+	Timestamp              *timestamp.RFC3339 `json:"timestamp,omitempty" validate:"required" description:"The time when the load balancer received the request from the client, in ISO 8601 format."`
+	ELB                    *string            `json:"elb,omitempty" validate:"required" description:"The name of the load balancer."`
+	ClientIP               *string            `json:"clientIp,omitempty" description:"The IP address of the requesting client."`
+	ClientPort             *int               `json:"clientPort,omitempty" description:"The port of the requesting client."`
+	BackendIP              *string            `json:"backendIp,omitempty" description:"The IP address of the registered instance that processed this request. If the load balancer can't send the request to a registered instance, or if the instance closes the connection before a response can be sent, this value is set to -."`
+	BackendPort            *int               `json:"backendPort,omitempty" description:"The port of the registered instance that processed this request."`
+	RequestProcessingTime  *float64           `json:"requestProcessingTime,omitempty" description:"[HTTP listener] The total time elapsed, in seconds, from the time the load balancer received the request until the time it sent it to a registered instance. [TCP listener] The total time elapsed, in seconds, from the time the load balancer accepted a TCP/SSL connection from a client to the time the load balancer sends the first byte of data to a registered instance. This value is set to -1 if the load balancer can't dispatch the request to a registered instance."`
+	BackendProcessingTime  *float64           `json:"backendProcessingTime,omitempty" description:"[HTTP listener] The total time elapsed, in seconds, from the time the load balancer sent the request to a registered instance until the instance started to send the response headers. [TCP listener] The total time elapsed, in seconds, for the load balancer to successfully establish a connection to a registered instance. This value is set to -1 if the load balancer can't dispatch the request to a registered instance."`
+	ResponseProcessingTime *float64           `json:"responseProcessingTime,omitempty" description:"[HTTP listener] The total time elapsed (in seconds) from the time the load balancer received the response header from the registered instance until it started to send the response to the client. [TCP listener] The total time elapsed, in seconds, from the time the load balancer received the first byte from the registered instance until it started to send the response to the client. This value is set to -1 if the load balancer can't dispatch the request to a registered instance."`
+	ELBStatusCode          *int               `json:"elbStatusCode,omitempty" description:"[HTTP listener] The status code of the response from the load balancer."`
+	BackendStatusCode      *int               `json:"backendStatusCode,omitempty" description:"[HTTP listener] The status code of the response from the registered instance."`
+	ReceivedBytes          *int               `json:"receivedBytes,omitempty" description:"The size of the request, in bytes, received from the client (requester)."`
+	SentBytes              *int               `json:"sentBytes,omitempty" description:"The size of the response, in bytes, sent to the client (requester)."`
+	RequestHTTPMethod      *string            `json:"requestHttpMethod,omitempty" description:"The HTTP method parsed from the request. Not set for TCP listeners."`
+	RequestURL             *string            `json:"requestUrl,omitempty" description:"The HTTP URL parsed from the request. Not set for TCP listeners."`
+	RequestHTTPVersion     *string            `json:"requestHttpVersion,omitempty" description:"The HTTP version parsed from the request. Not set for TCP listeners."`
+	UserAgent              *string            `json:"userAgent,omitempty" description:"[HTTP/HTTPS listener] A User-Agent string that identifies the client that originated the request."`
+	SSLCipher              *string            `json:"sslCipher,omitempty" description:"[HTTPS/SSL listener] The SSL cipher. This value is recorded only if the incoming SSL/TLS connection was established after a successful negotiation."`
+	SSLProtocol            *string            `json:"sslProtocol,omitempty" description:"[HTTPS/SSL listener] The SSL protocol. This value is recorded only if the incoming SSL/TLS connection was established after a successful negotiation."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// ELBClassicParser parses AWS Classic Load Balancer logs
+type ELBClassicParser struct {
+	CSVReader *csvstream.StreamingCSVReader
+}
+
+func (p *ELBClassicParser) New() parsers.LogParser {
+	reader := csvstream.NewStreamingCSVReader()
+	// non-default settings
+	reader.CVSReader.Comma = ' '
+	return &ELBClassicParser{
+		CSVReader: reader,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ELBClassicParser) Parse(log string) []*parsers.PantherLog {
+	record, err := p.CSVReader.Parse(log)
+	if err != nil {
+		zap.L().Debug("failed to parse the log as csv")
+		return nil
+	}
+
+	if len(record) != elbClassicNumberOfColumns {
+		zap.L().Debug("failed to parse the log as csv (wrong number of columns)")
+		return nil
+	}
+
+	timeStamp, err := timestamp.Parse(time.RFC3339Nano, record[0])
+	if err != nil {
+		zap.L().Debug("failed to parse time", zap.Error(err))
+		return nil
+	}
+
+	clientIP, clientPort := splitELBHostPort(record[2])
+	backendIP, backendPort := splitELBHostPort(record[3])
+
+	// TCP listeners log the request as "- - - "
+	requestItems := strings.Split(record[11], " ")
+	if len(requestItems) < 3 {
+		zap.L().Debug("failed to parse request")
+		return nil
+	}
+
+	event := &ELBClassic{
+		Timestamp:              &timeStamp,
+		ELB:                    parsers.CsvStringToPointer(record[1]),
+		ClientIP:               parsers.CsvStringToPointer(clientIP),
+		ClientPort:             parsers.CsvStringToIntPointer(clientPort),
+		BackendIP:              parsers.CsvStringToPointer(backendIP),
+		BackendPort:            parsers.CsvStringToIntPointer(backendPort),
+		RequestProcessingTime:  parsers.CsvStringToFloat64Pointer(record[4]),
+		BackendProcessingTime:  parsers.CsvStringToFloat64Pointer(record[5]),
+		ResponseProcessingTime: parsers.CsvStringToFloat64Pointer(record[6]),
+		ELBStatusCode:          parsers.CsvStringToIntPointer(record[7]),
+		BackendStatusCode:      parsers.CsvStringToIntPointer(record[8]),
+		ReceivedBytes:          parsers.CsvStringToIntPointer(record[9]),
+		SentBytes:              parsers.CsvStringToIntPointer(record[10]),
+		RequestHTTPMethod:      parsers.CsvStringToPointer(requestItems[0]),
+		RequestURL:             parsers.CsvStringToPointer(requestItems[1]),
+		RequestHTTPVersion:     parsers.CsvStringToPointer(requestItems[2]),
+		UserAgent:              parsers.CsvStringToPointer(record[12]),
+		SSLCipher:              parsers.CsvStringToPointer(record[13]),
+		SSLProtocol:            parsers.CsvStringToPointer(record[14]),
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *ELBClassicParser) LogType() string {
+	return "AWS.ELBClassic"
+}
+
+func (event *ELBClassic) updatePantherFields(p *ELBClassicParser) {
+	event.SetCoreFields(p.LogType(), event.Timestamp, event)
+	event.AppendAnyIPAddressPtrs(event.ClientIP, event.BackendIP)
+}
+
+// splitELBHostPort splits "ip:port", returning "-" for the port when there is none
+func splitELBHostPort(hostPort string) (host, port string) {
+	index := strings.LastIndex(hostPort, ":")
+	if index < 0 {
+		return hostPort, "-"
+	}
+	return hostPort[:index], hostPort[index+1:]
+}
@@ -0,0 +1,110 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestELBClassicHTTPLog(t *testing.T) {
+	log := `2015-05-13T23:39:43.945958Z my-loadbalancer 192.168.131.39:2817 10.0.0.1:80 0.000073 0.001048 0.000057 200 200 0 29 ` +
+		`"GET http://www.example.com:80/ HTTP/1.1" "curl/7.38.0" - -`
+
+	expectedTime := time.Date(2015, 5, 13, 23, 39, 43, 945958000, time.UTC)
+	expectedEvent := &ELBClassic{
+		Timestamp:              (*timestamp.RFC3339)(&expectedTime),
+		ELB:                    aws.String("my-loadbalancer"),
+		ClientIP:               aws.String("192.168.131.39"),
+		ClientPort:             aws.Int(2817),
+		BackendIP:              aws.String("10.0.0.1"),
+		BackendPort:            aws.Int(80),
+		RequestProcessingTime:  aws.Float64(0.000073),
+		BackendProcessingTime:  aws.Float64(0.001048),
+		ResponseProcessingTime: aws.Float64(0.000057),
+		ELBStatusCode:          aws.Int(200),
+		BackendStatusCode:      aws.Int(200),
+		ReceivedBytes:          aws.Int(0),
+		SentBytes:              aws.Int(29),
+		RequestHTTPMethod:      aws.String("GET"),
+		RequestURL:             aws.String("http://www.example.com:80/"),
+		RequestHTTPVersion:     aws.String("HTTP/1.1"),
+		UserAgent:              aws.String("curl/7.38.0"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.ELBClassic")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("192.168.131.39", "10.0.0.1")
+
+	checkELBClassicLog(t, log, expectedEvent)
+}
+
+func TestELBClassicTCPLog(t *testing.T) {
+	log := `2015-05-13T23:39:43.945958Z my-loadbalancer 192.168.131.39:2817 - -1 -1 -1 - - 57 502 "- - - " "-" ` +
+		`ECDHE-ECDSA-AES128-GCM-SHA256 TLSv1.2`
+
+	expectedTime := time.Date(2015, 5, 13, 23, 39, 43, 945958000, time.UTC)
+	expectedEvent := &ELBClassic{
+		Timestamp:              (*timestamp.RFC3339)(&expectedTime),
+		ELB:                    aws.String("my-loadbalancer"),
+		ClientIP:               aws.String("192.168.131.39"),
+		ClientPort:             aws.Int(2817),
+		RequestProcessingTime:  aws.Float64(-1),
+		BackendProcessingTime:  aws.Float64(-1),
+		ResponseProcessingTime: aws.Float64(-1),
+		ReceivedBytes:          aws.Int(57),
+		SentBytes:              aws.Int(502),
+		SSLCipher:              aws.String("ECDHE-ECDSA-AES128-GCM-SHA256"),
+		SSLProtocol:            aws.String("TLSv1.2"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.ELBClassic")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("192.168.131.39")
+
+	checkELBClassicLog(t, log, expectedEvent)
+}
+
+func TestELBClassicALBLog(t *testing.T) {
+	log := "http 2018-08-26T14:17:23.186641Z app/my-loadbalancer/50dc6c495c0c9188 192.168.131.39:2817 " +
+		"10.0.0.1:80 0.000 0.001 0.000 200 200 34 366 \"GET http://www.example.com:80/ HTTP/1.1\" " +
+		"\"curl/7.46.0\" - - arn:aws:elasticloadbalancing:us-east-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067 " +
+		"\"Root=1-58337262-36d228ad5d99923122bbe354\" \"-\" \"-\" 0 2018-08-26T14:17:23.186641Z \"forward\" \"-\" \"-\""
+	parser := (&ELBClassicParser{}).New()
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestELBClassicLogType(t *testing.T) {
+	parser := &ELBClassicParser{}
+	require.Equal(t, "AWS.ELBClassic", parser.LogType())
+}
+
+func checkELBClassicLog(t *testing.T, log string, expectedEvent *ELBClassic) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&ELBClassicParser{}).New() // important to call New() to initialize reader
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
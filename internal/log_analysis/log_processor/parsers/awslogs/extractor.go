@@ -23,14 +23,16 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/tidwall/gjson"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 // extracts useful AWS features that can be detected generically (w/context)
 type AWSExtractor struct {
-	pl *AWSPantherLog
+	pl *parsers.PantherLog
 }
 
-func NewAWSExtractor(pl *AWSPantherLog) *AWSExtractor {
+func NewAWSExtractor(pl *parsers.PantherLog) *AWSExtractor {
 	return &AWSExtractor{pl: pl}
 }
 
@@ -96,7 +98,10 @@ func (e *AWSExtractor) Extract(key, value gjson.Result) {
 			})
 		}
 
-	case "ipv6Addresses": // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
+	case
+		"ipv6Addresses", // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
+		"IpV4Addresses", // found in AwsEc2Instance resource details in Security Hub findings
+		"IpV6Addresses": // found in AwsEc2Instance resource details in Security Hub findings
 		if value.IsArray() {
 			value.ForEach(func(v6ListKey, v6ListValue gjson.Result) bool {
 				e.pl.AppendAnyIPAddresses(v6ListValue.Str)
@@ -107,13 +112,24 @@ func (e *AWSExtractor) Extract(key, value gjson.Result) {
 	case
 		"publicIp",         // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
 		"privateIpAddress", // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
-		"ipAddressV4":      // found in GuardDuty findings
+		"ipAddressV4",      // found in GuardDuty findings
+		"SourceIpV4",       // found in Security Hub findings
+		"SourceIpV6",       // found in Security Hub findings
+		"DestinationIpV4",  // found in Security Hub findings
+		"DestinationIpV6":  // found in Security Hub findings
 		e.pl.AppendAnyIPAddresses(value.Str)
 
 	case
-		"publicDnsName",  // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
-		"privateDnsName", // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
-		"domain":         // found in GuardDuty findings
+		"publicDnsName",     // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
+		"privateDnsName",    // found in instanceDetails in CloudTrail and GuardDuty (perhaps others)
+		"domain",            // found in GuardDuty findings
+		"SourceDomain",      // found in Security Hub findings
+		"DestinationDomain": // found in Security Hub findings
 		e.pl.AppendAnyDomainNames(value.Str)
+
+	case
+		"userName", // found in userIdentity in CloudTrail and accessKeyDetails in GuardDuty (perhaps others)
+		"UserName": // found in AwsIamAccessKey resource details in Security Hub findings
+		e.pl.AppendAnyUsernames(value.Str)
 	}
 }
@@ -24,11 +24,12 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/pkg/extract"
 )
 
 func TestAWSExtractor(t *testing.T) {
-	event := AWSPantherLog{}
+	event := parsers.PantherLog{}
 	// add interesting fragments as new extractions are implemented
 	json := (jsoniter.RawMessage)(`
 {
@@ -119,12 +120,32 @@ func TestAWSExtractor(t *testing.T) {
      },
      "remotePortDetails":{"port":32938,"portName":"Unknown"}
   }
+},
+
+"accessKeyDetails":{
+  "accessKeyId":"GeneratedFindingAccessKeyId",
+  "principalId":"GeneratedFindingPrincipalId",
+  "userType":"IAMUser",
+  "userName":"GeneratedFindingUserName"
+},
+
+"Network":{
+  "Direction":"IN",
+  "SourceIpV4":"198.51.100.10",
+  "SourceDomain":"example.com",
+  "DestinationIpV6":"2001:db8::1"
+},
+
+"Details":{
+  "AwsEc2Instance":{
+    "IpV4Addresses":["10.0.0.12"]
+  }
 }
 
 }
 `)
 
-	expectedEvent := AWSPantherLog{}
+	expectedEvent := parsers.PantherLog{}
 	expectedEvent.AppendAnyAWSARNs("arn:aws:iam::123456789012:instance-profile/EC2Dev",
 		"arn:aws:cloudtrail:us-west-2:888888888888:trail/panther-lab-cloudtrail",
 		"arn:aws:ec2:region:111122223333:instance/i-0072230f74b3a798e",
@@ -132,10 +153,11 @@ func TestAWSExtractor(t *testing.T) {
 	expectedEvent.AppendAnyAWSInstanceIds("i-081de1d7604b11e4a", "i-0072230f74b3a798e" /* from ARN */)
 	expectedEvent.AppendAnyAWSAccountIds("123456789012", "888888888888" /* from ARN */, "111122223333" /* from ARN */)
 	expectedEvent.AppendAnyIPAddresses("54.152.215.140", "2001:0db8:85a3:0000:0000:8a2e:0370:7334",
-		"172.31.81.237", "151.80.19.228")
+		"172.31.81.237", "151.80.19.228", "198.51.100.10", "2001:db8::1", "10.0.0.12")
 	expectedEvent.AppendAnyAWSTags("tag1:val1")
+	expectedEvent.AppendAnyUsernames("GeneratedFindingUserName")
 	expectedEvent.AppendAnyDomainNames("ec2-54-152-215-140.compute-1.amazonaws.com", "GeneratedFindingDomainName",
-		"ip-172-31-81-237.ec2.internal")
+		"ip-172-31-81-237.ec2.internal", "example.com")
 
 	extract.Extract(&json, NewAWSExtractor(&event))
 
@@ -102,7 +102,7 @@ func (event *GuardDuty) updatePantherFields(p *GuardDutyParser) {
 	event.AppendAnyAWSAccountIdPtrs(event.AccountID)
 
 	// polymorphic (unparsed) fields
-	awsExtractor := NewAWSExtractor(&(event.AWSPantherLog))
+	awsExtractor := NewAWSExtractor(&(event.PantherLog))
 	extract.Extract(event.Resource, awsExtractor)
 	if event.Service != nil {
 		extract.Extract(event.Service.AdditionalInfo, awsExtractor)
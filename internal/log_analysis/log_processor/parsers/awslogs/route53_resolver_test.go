@@ -0,0 +1,85 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestRoute53ResolverLog(t *testing.T) {
+	//nolint
+	log := `{"version":"1.000000","account_id":"123456789012","region":"us-east-1","vpc_id":"vpc-0a1b2c3d4e5f6a7b8","query_timestamp":"2020-05-12T10:30:02Z","query_name":"www.example.com.","query_type":"A","query_class":"IN","rcode":"NOERROR","answers":[{"Rdata":"cdn.example.net.","Type":"CNAME","Class":"IN"},{"Rdata":"93.184.216.34","Type":"A","Class":"IN"}],"srcaddr":"172.31.10.20","srcport":"43721","transport":"UDP","srcids":{"instance":"i-0a1b2c3d4e5f6a7b8"}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 30, 2, 0, time.UTC)
+	expectedEvent := &Route53Resolver{
+		Version:        aws.String("1.000000"),
+		AccountID:      aws.String("123456789012"),
+		Region:         aws.String("us-east-1"),
+		VPCID:          aws.String("vpc-0a1b2c3d4e5f6a7b8"),
+		QueryTimestamp: (*timestamp.RFC3339)(&expectedTime),
+		QueryName:      aws.String("www.example.com."),
+		QueryType:      aws.String("A"),
+		QueryClass:     aws.String("IN"),
+		Rcode:          aws.String("NOERROR"),
+		Answers: []Route53ResolverAnswer{
+			{Rdata: aws.String("cdn.example.net."), Type: aws.String("CNAME"), Class: aws.String("IN")},
+			{Rdata: aws.String("93.184.216.34"), Type: aws.String("A"), Class: aws.String("IN")},
+		},
+		SrcAddr:   aws.String("172.31.10.20"),
+		SrcPort:   aws.String("43721"),
+		Transport: aws.String("UDP"),
+		SrcIDs: &Route53ResolverSrcIDs{
+			Instance: aws.String("i-0a1b2c3d4e5f6a7b8"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.Route53Resolver")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyAWSAccountIds("123456789012")
+	expectedEvent.AppendAnyIPAddresses("172.31.10.20", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com", "cdn.example.net")
+	expectedEvent.AppendAnyAWSInstanceIds("i-0a1b2c3d4e5f6a7b8")
+
+	checkRoute53ResolverLog(t, log, expectedEvent)
+}
+
+func TestRoute53ResolverLogMissingQuery(t *testing.T) {
+	log := `{"version":"1.000000","account_id":"123456789012","region":"us-east-1","query_timestamp":"2020-05-12T10:30:02Z"}`
+	parser := &Route53ResolverParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestRoute53ResolverLogType(t *testing.T) {
+	parser := &Route53ResolverParser{}
+	require.Equal(t, "AWS.Route53Resolver", parser.LogType())
+}
+
+func checkRoute53ResolverLog(t *testing.T, log string, expectedEvent *Route53Resolver) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &Route53ResolverParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
@@ -0,0 +1,121 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/pkg/extract"
+)
+
+var SecurityHubDesc = `AWS Security Hub findings in the AWS Security Finding Format (ASFF), aggregated from AWS services and partner products.
+Reference: https://docs.aws.amazon.com/securityhub/latest/userguide/securityhub-findings-format.html`
+
+// nolint:lll
+type SecurityHub struct {
+	SchemaVersion         *string              `json:"SchemaVersion,omitempty" validate:"required" description:"The schema version that a finding is formatted for."`
+	ID                    *string              `json:"Id,omitempty" validate:"required" description:"The security findings provider-specific identifier for a finding."`
+	ProductArn            *string              `json:"ProductArn,omitempty" validate:"required" description:"The ARN generated by Security Hub that uniquely identifies a product that generates findings."`
+	GeneratorID           *string              `json:"GeneratorId,omitempty" validate:"required" description:"The identifier for the solution-specific component (a discrete unit of logic) that generated a finding."`
+	AwsAccountID          *string              `json:"AwsAccountId,omitempty" validate:"required,len=12,numeric" description:"The AWS account ID that a finding is generated in."`
+	Types                 []string             `json:"Types,omitempty" description:"One or more finding types in the format of namespace/category/classifier that classify a finding."`
+	FirstObservedAt       *timestamp.RFC3339   `json:"FirstObservedAt,omitempty" description:"Indicates when the security-findings provider first observed the potential security issue that a finding captured."`
+	LastObservedAt        *timestamp.RFC3339   `json:"LastObservedAt,omitempty" description:"Indicates when the security-findings provider most recently observed the potential security issue that a finding captured."`
+	CreatedAt             *timestamp.RFC3339   `json:"CreatedAt,omitempty" validate:"required" description:"Indicates when the security-findings provider created the potential security issue that a finding captured."`
+	UpdatedAt             *timestamp.RFC3339   `json:"UpdatedAt,omitempty" validate:"required" description:"Indicates when the security-findings provider last updated the finding record."`
+	Severity              *SecurityHubSeverity `json:"Severity,omitempty" validate:"required" description:"A finding's severity."`
+	Confidence            *int                 `json:"Confidence,omitempty" description:"A finding's confidence, on a scale of 0 to 100. Confidence is defined as the likelihood that a finding accurately identifies the behavior or issue that it was intended to identify."`
+	Criticality           *int                 `json:"Criticality,omitempty" description:"The level of importance assigned to the resources associated with the finding, on a scale of 0 to 100."`
+	Title                 *string              `json:"Title,omitempty" validate:"required" description:"A finding's title."`
+	Description           *string              `json:"Description,omitempty" validate:"required" description:"A finding's description."`
+	Remediation           *jsoniter.RawMessage `json:"Remediation,omitempty" description:"A data type that describes the remediation options for a finding."`
+	SourceURL             *string              `json:"SourceUrl,omitempty" description:"A URL that links to a page about the current finding in the security-findings provider's solution."`
+	ProductFields         map[string]string    `json:"ProductFields,omitempty" description:"A data type where security-findings providers can include additional solution-specific details that aren't part of the defined AwsSecurityFinding format."`
+	UserDefinedFields     map[string]string    `json:"UserDefinedFields,omitempty" description:"A list of name/value string pairs associated with the finding."`
+	Malware               *jsoniter.RawMessage `json:"Malware,omitempty" description:"A list of malware related to a finding."`
+	Network               *jsoniter.RawMessage `json:"Network,omitempty" description:"The details of network-related information about a finding."`
+	Process               *jsoniter.RawMessage `json:"Process,omitempty" description:"The details of process-related information about a finding."`
+	ThreatIntelIndicators *jsoniter.RawMessage `json:"ThreatIntelIndicators,omitempty" description:"Threat intelligence details related to a finding."`
+	Resources             *jsoniter.RawMessage `json:"Resources,omitempty" validate:"required" description:"A set of resource data types that describe the resources that the finding refers to."`
+	Compliance            *jsoniter.RawMessage `json:"Compliance,omitempty" description:"This data type is exclusive to findings that are generated as the result of a check run against a specific rule in a supported security standard."`
+	VerificationState     *string              `json:"VerificationState,omitempty" description:"Indicates the veracity of a finding."`
+	WorkflowState         *string              `json:"WorkflowState,omitempty" description:"The workflow state of a finding (deprecated, see Workflow)."`
+	Workflow              *jsoniter.RawMessage `json:"Workflow,omitempty" description:"Provides information about the status of the investigation into a finding."`
+	RecordState           *string              `json:"RecordState,omitempty" description:"The record state of a finding."`
+	RelatedFindings       *jsoniter.RawMessage `json:"RelatedFindings,omitempty" description:"A list of related findings."`
+	Note                  *jsoniter.RawMessage `json:"Note,omitempty" description:"A user-defined note added to a finding."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type SecurityHubSeverity struct {
+	Label      *string  `json:"Label,omitempty" description:"The severity value of the finding (INFORMATIONAL, LOW, MEDIUM, HIGH or CRITICAL)."`
+	Normalized *int     `json:"Normalized,omitempty" description:"The normalized severity of a finding, on a scale of 0 to 100 (deprecated, see Label)."`
+	Original   *string  `json:"Original,omitempty" description:"The native severity from the finding product that generated the finding."`
+	Product    *float64 `json:"Product,omitempty" description:"The native severity as defined by the AWS service or integrated partner product that generated the finding (deprecated, see Original)."`
+}
+
+// SecurityHubParser parses AWS Security Hub findings
+type SecurityHubParser struct{}
+
+func (p *SecurityHubParser) New() parsers.LogParser {
+	return &SecurityHubParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *SecurityHubParser) Parse(log string) []*parsers.PantherLog {
+	event := &SecurityHub{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *SecurityHubParser) LogType() string {
+	return "AWS.SecurityHub"
+}
+
+func (event *SecurityHub) updatePantherFields(p *SecurityHubParser) {
+	event.SetCoreFields(p.LogType(), event.UpdatedAt, event)
+
+	// structured (parsed) fields
+	event.AppendAnyAWSARNPtrs(event.ProductArn)
+	event.AppendAnyAWSAccountIdPtrs(event.AwsAccountID)
+
+	// polymorphic (unparsed) fields
+	awsExtractor := NewAWSExtractor(&(event.PantherLog))
+	extract.Extract(event.Resources, awsExtractor)
+	extract.Extract(event.Network, awsExtractor)
+	extract.Extract(event.ThreatIntelIndicators, awsExtractor)
+}
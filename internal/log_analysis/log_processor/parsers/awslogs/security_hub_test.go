@@ -0,0 +1,98 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSecurityHubLog(t *testing.T) {
+	//nolint
+	log := `{"SchemaVersion":"2018-10-08","Id":"arn:aws:securityhub:us-east-1:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.9/finding/0a1b2c3d","ProductArn":"arn:aws:securityhub:us-east-1::product/aws/securityhub","GeneratorId":"aws-foundational-security-best-practices/v/1.0.0/EC2.9","AwsAccountId":"123456789012","Types":["Software and Configuration Checks/Industry and Regulatory Standards/AWS-Foundational-Security-Best-Practices"],"FirstObservedAt":"2020-05-12T09:00:00.000Z","LastObservedAt":"2020-05-12T10:00:00.000Z","CreatedAt":"2020-05-12T09:00:00.000Z","UpdatedAt":"2020-05-12T10:00:00.000Z","Severity":{"Label":"HIGH","Normalized":70,"Original":"HIGH","Product":70},"Title":"EC2.9 EC2 instances should not have a public IP address","Description":"This control checks whether EC2 instances have a public IP address.","ProductFields":{"StandardsArn":"arn:aws:securityhub:::standards/aws-foundational-security-best-practices/v/1.0.0","ControlId":"EC2.9"},"Network":{"Direction":"IN","SourceIpV4":"198.51.100.10","DestinationPort":22},"Resources":[{"Type":"AwsEc2Instance","Id":"arn:aws:ec2:us-east-1:123456789012:instance/i-0a1b2c3d4e5f6a7b8","Partition":"aws","Region":"us-east-1","Details":{"AwsEc2Instance":{"IpV4Addresses":["54.152.215.140","172.31.81.237"]}}}],"Compliance":{"Status":"FAILED"},"Workflow":{"Status":"NEW"},"WorkflowState":"NEW","RecordState":"ACTIVE"}`
+
+	expectedFirstObserved := time.Date(2020, 5, 12, 9, 0, 0, 0, time.UTC)
+	expectedLastObserved := time.Date(2020, 5, 12, 10, 0, 0, 0, time.UTC)
+	expectedEvent := &SecurityHub{
+		SchemaVersion:   aws.String("2018-10-08"),
+		ID:              aws.String("arn:aws:securityhub:us-east-1:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.9/finding/0a1b2c3d"),
+		ProductArn:      aws.String("arn:aws:securityhub:us-east-1::product/aws/securityhub"),
+		GeneratorID:     aws.String("aws-foundational-security-best-practices/v/1.0.0/EC2.9"),
+		AwsAccountID:    aws.String("123456789012"),
+		Types:           []string{"Software and Configuration Checks/Industry and Regulatory Standards/AWS-Foundational-Security-Best-Practices"},
+		FirstObservedAt: (*timestamp.RFC3339)(&expectedFirstObserved),
+		LastObservedAt:  (*timestamp.RFC3339)(&expectedLastObserved),
+		CreatedAt:       (*timestamp.RFC3339)(&expectedFirstObserved),
+		UpdatedAt:       (*timestamp.RFC3339)(&expectedLastObserved),
+		Severity: &SecurityHubSeverity{
+			Label:      aws.String("HIGH"),
+			Normalized: aws.Int(70),
+			Original:   aws.String("HIGH"),
+			Product:    aws.Float64(70),
+		},
+		Title:       aws.String("EC2.9 EC2 instances should not have a public IP address"),
+		Description: aws.String("This control checks whether EC2 instances have a public IP address."),
+		ProductFields: map[string]string{
+			"StandardsArn": "arn:aws:securityhub:::standards/aws-foundational-security-best-practices/v/1.0.0",
+			"ControlId":    "EC2.9",
+		},
+		Network:       newRawMessage(`{"Direction":"IN","SourceIpV4":"198.51.100.10","DestinationPort":22}`),
+		Resources:     newRawMessage(`[{"Type":"AwsEc2Instance","Id":"arn:aws:ec2:us-east-1:123456789012:instance/i-0a1b2c3d4e5f6a7b8","Partition":"aws","Region":"us-east-1","Details":{"AwsEc2Instance":{"IpV4Addresses":["54.152.215.140","172.31.81.237"]}}}]`),
+		Compliance:    newRawMessage(`{"Status":"FAILED"}`),
+		Workflow:      newRawMessage(`{"Status":"NEW"}`),
+		WorkflowState: aws.String("NEW"),
+		RecordState:   aws.String("ACTIVE"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.SecurityHub")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedLastObserved)
+	expectedEvent.AppendAnyAWSARNs("arn:aws:securityhub:us-east-1::product/aws/securityhub",
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-0a1b2c3d4e5f6a7b8")
+	expectedEvent.AppendAnyAWSAccountIds("123456789012")
+	expectedEvent.AppendAnyAWSInstanceIds("i-0a1b2c3d4e5f6a7b8")
+	expectedEvent.AppendAnyIPAddresses("198.51.100.10", "54.152.215.140", "172.31.81.237")
+
+	checkSecurityHubLog(t, log, expectedEvent)
+}
+
+func TestSecurityHubLogGuardDutyFinding(t *testing.T) {
+	// a GuardDuty finding has a similar shape but different casing and none of the ASFF fields
+	//nolint
+	log := `{"schemaVersion":"2.0","accountId":"123456789012","region":"eu-west-1","partition":"aws","id":"44b7c4e9781822beb75d3fbd518abf5b","type":"Stealth:IAMUser/LoggingConfigurationModified","severity":5,"createdAt":"2018-08-26T14:17:23.000Z","updatedAt":"2018-08-26T14:17:23.000Z"}`
+	parser := &SecurityHubParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestSecurityHubLogType(t *testing.T) {
+	parser := &SecurityHubParser{}
+	require.Equal(t, "AWS.SecurityHub", parser.LogType())
+}
+
+func checkSecurityHubLog(t *testing.T, log string, expectedEvent *SecurityHub) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &SecurityHubParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
@@ -0,0 +1,116 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var WAFDesc = `AWS WAF full logs contain information about each web request inspected by a web ACL, including the rule that terminated it and the action taken.
+Reference: https://docs.aws.amazon.com/waf/latest/developerguide/logging.html`
+
+// nolint:lll
+type WAF struct {
+	Timestamp                   *timestamp.UnixMillisecond `json:"timestamp,omitempty" validate:"required" description:"The timestamp in milliseconds."`
+	FormatVersion               *int                       `json:"formatVersion,omitempty" description:"The format version for the log."`
+	WebACLID                    *string                    `json:"webaclId,omitempty" validate:"required" description:"The GUID of the web ACL (AWS WAF Classic) or the ARN of the web ACL (AWS WAF)."`
+	TerminatingRuleID           *string                    `json:"terminatingRuleId,omitempty" description:"The ID of the rule that terminated the request. If nothing terminates the request, the value is Default_Action."`
+	TerminatingRuleType         *string                    `json:"terminatingRuleType,omitempty" description:"The type of rule that terminated the request. Possible values: RATE_BASED, REGULAR, GROUP, MANAGED_RULE_GROUP and Default_Action."`
+	Action                      *string                    `json:"action,omitempty" validate:"required" description:"The action. Possible values for a terminating rule: ALLOW and BLOCK. COUNT is not a valid value for a terminating rule."`
+	TerminatingRuleMatchDetails *jsoniter.RawMessage       `json:"terminatingRuleMatchDetails,omitempty" description:"Detailed information about the terminating rule that matched the request."`
+	HTTPSourceName              *string                    `json:"httpSourceName,omitempty" description:"The source of the request. Possible values: CF for Amazon CloudFront, APIGW for Amazon API Gateway, ALB for Application Load Balancer and APPSYNC for AWS AppSync."`
+	HTTPSourceID                *string                    `json:"httpSourceId,omitempty" description:"The source ID. This field shows the ID of the associated resource."`
+	RuleGroupList               *jsoniter.RawMessage       `json:"ruleGroupList,omitempty" description:"The list of rule groups that acted on this request."`
+	RateBasedRuleList           *jsoniter.RawMessage       `json:"rateBasedRuleList,omitempty" description:"The list of rate-based rules that acted on the request."`
+	NonTerminatingMatchingRules *jsoniter.RawMessage       `json:"nonTerminatingMatchingRules,omitempty" description:"The list of non-terminating rules that match the request."`
+	HTTPRequest                 *WAFHTTPRequest            `json:"httpRequest,omitempty" validate:"required" description:"The metadata about the request."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type WAFHTTPRequest struct {
+	ClientIP    *string         `json:"clientIp,omitempty" description:"The IP address of the client sending the request."`
+	Country     *string         `json:"country,omitempty" description:"The source country of the request."`
+	Headers     []WAFHTTPHeader `json:"headers,omitempty" description:"The list of headers."`
+	URI         *string         `json:"uri,omitempty" description:"The URI of the request."`
+	Args        *string         `json:"args,omitempty" description:"The query string."`
+	HTTPVersion *string         `json:"httpVersion,omitempty" description:"The HTTP version."`
+	HTTPMethod  *string         `json:"httpMethod,omitempty" description:"The HTTP method in the request."`
+	RequestID   *string         `json:"requestId,omitempty" description:"The ID of the request, generated by the underlying host service."`
+}
+
+type WAFHTTPHeader struct {
+	Name  *string `json:"name,omitempty" description:"The header name."`
+	Value *string `json:"value,omitempty" description:"The header value."`
+}
+
+// WAFParser parses AWS WAF full logs
+type WAFParser struct{}
+
+func (p *WAFParser) New() parsers.LogParser {
+	return &WAFParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *WAFParser) Parse(log string) []*parsers.PantherLog {
+	event := &WAF{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *WAFParser) LogType() string {
+	return "AWS.WAF"
+}
+
+func (event *WAF) updatePantherFields(p *WAFParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+
+	// AWS WAF Classic uses a GUID, AWS WAF the ARN of the web ACL
+	if event.WebACLID != nil && strings.HasPrefix(*event.WebACLID, "arn:") {
+		event.AppendAnyAWSARNs(*event.WebACLID)
+	}
+	if event.HTTPRequest != nil {
+		event.AppendAnyIPAddressPtrs(event.HTTPRequest.ClientIP)
+		for _, header := range event.HTTPRequest.Headers {
+			if header.Name != nil && strings.EqualFold(*header.Name, "host") {
+				event.AppendAnyDomainNamePtrs(header.Value)
+			}
+		}
+	}
+}
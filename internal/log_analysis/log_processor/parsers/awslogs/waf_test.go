@@ -0,0 +1,122 @@
+package awslogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestWAFLog(t *testing.T) {
+	//nolint
+	log := `{"timestamp":1576280412771,"formatVersion":1,"webaclId":"arn:aws:wafv2:ap-southeast-2:123456789012:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE","terminatingRuleId":"STMTest_SQLi_XSS","terminatingRuleType":"REGULAR","action":"BLOCK","terminatingRuleMatchDetails":[{"conditionType":"SQL_INJECTION","location":"UNKNOWN","matchedData":["10","AND","1"]}],"httpSourceName":"ALB","httpSourceId":"alb","ruleGroupList":[],"rateBasedRuleList":[],"nonTerminatingMatchingRules":[],"httpRequest":{"clientIp":"1.1.1.1","country":"AU","headers":[{"name":"Host","value":"localhost:1989"},{"name":"User-Agent","value":"curl/7.61.1"}],"uri":"/","args":"x=1%20AND%201=1","httpVersion":"HTTP/1.1","httpMethod":"GET","requestId":"rid"}}`
+
+	expectedTime := time.Unix(1576280412, 771000000).UTC()
+	expectedEvent := &WAF{
+		Timestamp:                   (*timestamp.UnixMillisecond)(&expectedTime),
+		FormatVersion:               aws.Int(1),
+		WebACLID:                    aws.String("arn:aws:wafv2:ap-southeast-2:123456789012:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE"),
+		TerminatingRuleID:           aws.String("STMTest_SQLi_XSS"),
+		TerminatingRuleType:         aws.String("REGULAR"),
+		Action:                      aws.String("BLOCK"),
+		TerminatingRuleMatchDetails: newRawMessage(`[{"conditionType":"SQL_INJECTION","location":"UNKNOWN","matchedData":["10","AND","1"]}]`),
+		HTTPSourceName:              aws.String("ALB"),
+		HTTPSourceID:                aws.String("alb"),
+		RuleGroupList:               newRawMessage(`[]`),
+		RateBasedRuleList:           newRawMessage(`[]`),
+		NonTerminatingMatchingRules: newRawMessage(`[]`),
+		HTTPRequest: &WAFHTTPRequest{
+			ClientIP: aws.String("1.1.1.1"),
+			Country:  aws.String("AU"),
+			Headers: []WAFHTTPHeader{
+				{Name: aws.String("Host"), Value: aws.String("localhost:1989")},
+				{Name: aws.String("User-Agent"), Value: aws.String("curl/7.61.1")},
+			},
+			URI:         aws.String("/"),
+			Args:        aws.String("x=1%20AND%201=1"),
+			HTTPVersion: aws.String("HTTP/1.1"),
+			HTTPMethod:  aws.String("GET"),
+			RequestID:   aws.String("rid"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.WAF")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyAWSARNs("arn:aws:wafv2:ap-southeast-2:123456789012:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE")
+	expectedEvent.AppendAnyIPAddresses("1.1.1.1")
+	expectedEvent.AppendAnyDomainNames("localhost:1989")
+
+	checkWAFLog(t, log, expectedEvent)
+}
+
+func TestWAFClassicLog(t *testing.T) {
+	//nolint
+	log := `{"timestamp":1533689070589,"formatVersion":1,"webaclId":"385cb038-3a6f-4f2f-ac64-09ab912af590","terminatingRuleId":"Default_Action","terminatingRuleType":"REGULAR","action":"ALLOW","httpSourceName":"CF","httpSourceId":"i-123","httpRequest":{"clientIp":"192.10.23.23","country":"US","uri":"/index.html","httpVersion":"HTTP/1.1","httpMethod":"GET","requestId":"cloud front Request id"}}`
+
+	expectedTime := time.Unix(1533689070, 589000000).UTC()
+	expectedEvent := &WAF{
+		Timestamp:           (*timestamp.UnixMillisecond)(&expectedTime),
+		FormatVersion:       aws.Int(1),
+		WebACLID:            aws.String("385cb038-3a6f-4f2f-ac64-09ab912af590"),
+		TerminatingRuleID:   aws.String("Default_Action"),
+		TerminatingRuleType: aws.String("REGULAR"),
+		Action:              aws.String("ALLOW"),
+		HTTPSourceName:      aws.String("CF"),
+		HTTPSourceID:        aws.String("i-123"),
+		HTTPRequest: &WAFHTTPRequest{
+			ClientIP:    aws.String("192.10.23.23"),
+			Country:     aws.String("US"),
+			URI:         aws.String("/index.html"),
+			HTTPVersion: aws.String("HTTP/1.1"),
+			HTTPMethod:  aws.String("GET"),
+			RequestID:   aws.String("cloud front Request id"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.WAF")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("192.10.23.23")
+
+	checkWAFLog(t, log, expectedEvent)
+}
+
+func TestWAFLogMissingRequest(t *testing.T) {
+	log := `{"timestamp":1533689070589,"formatVersion":1,"webaclId":"385cb038-3a6f-4f2f-ac64-09ab912af590","action":"ALLOW"}`
+	parser := &WAFParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestWAFLogType(t *testing.T) {
+	parser := &WAFParser{}
+	require.Equal(t, "AWS.WAF", parser.LogType())
+}
+
+func checkWAFLog(t *testing.T, log string, expectedEvent *WAF) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &WAFParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
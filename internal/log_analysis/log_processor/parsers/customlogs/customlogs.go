@@ -0,0 +1,383 @@
+package customlogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+const (
+	defaultCSVDelimiter = ','
+	csvNullValue        = "-"
+)
+
+var (
+	// Go types of the event struct fields for each custom field type (all pointers so missing values are omitted)
+	fieldGoTypes = map[string]reflect.Type{
+		models.CustomFieldTypeString:    reflect.TypeOf((*string)(nil)),
+		models.CustomFieldTypeBigInt:    reflect.TypeOf((*int64)(nil)),
+		models.CustomFieldTypeDouble:    reflect.TypeOf((*float64)(nil)),
+		models.CustomFieldTypeBoolean:   reflect.TypeOf((*bool)(nil)),
+		models.CustomFieldTypeTimestamp: reflect.TypeOf((*timestamp.RFC3339)(nil)),
+		models.CustomFieldTypeJSON:      reflect.TypeOf((*jsoniter.RawMessage)(nil)),
+	}
+
+	pantherLogType = reflect.TypeOf(parsers.PantherLog{})
+)
+
+// Parser parses logs of a user defined schema. Since there is no Go struct for these logs,
+// the event struct is built at runtime from the schema fields with PantherLog embedded at the end.
+type Parser struct {
+	logType     string
+	description string
+	format      string
+	delimiter   rune
+	fields      []*field
+	eventTime   *field // if nil the parse time is used as event time
+	eventType   reflect.Type
+	framing     parsers.Framing
+	csvReader   *csvstream.StreamingCSVReader
+	// timestamps that failed to parse, for the stats of the classifier
+	timestampFailures uint64
+}
+
+// field is a schema field and its position in the event struct
+type field struct {
+	index      int
+	name       string
+	fieldType  string
+	timestamps *timestamp.Parser // only set for timestamp fields
+	indicators []string
+}
+
+// NewParser validates the schema and builds a parser for it
+func NewParser(schema *models.CustomLogSchemaSettings) (*Parser, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Parser{
+		logType:     aws.StringValue(schema.LogType),
+		description: aws.StringValue(schema.Description),
+		format:      aws.StringValue(schema.Format),
+		delimiter:   defaultCSVDelimiter,
+		fields:      make([]*field, len(schema.Fields)),
+	}
+	if delimiter := aws.StringValue(schema.Delimiter); p.format == models.CustomLogFormatCSV && delimiter != "" {
+		p.delimiter = rune(delimiter[0])
+	}
+
+	framing, err := newFraming(schema)
+	if err != nil {
+		return nil, err
+	}
+	p.framing = framing
+
+	structFields := make([]reflect.StructField, 0, len(schema.Fields)+1)
+	for i, schemaField := range schema.Fields {
+		f, err := newField(i, schemaField, aws.StringValue(schema.Timezone))
+		if err != nil {
+			return nil, err
+		}
+		tag := fmt.Sprintf(`json:"%s,omitempty" description:%s`, f.name, strconv.Quote(aws.StringValue(schemaField.Description)))
+		if aws.BoolValue(schemaField.Required) {
+			tag += ` validate:"required"`
+		}
+		structFields = append(structFields, reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: fieldGoTypes[f.fieldType],
+			Tag:  reflect.StructTag(tag),
+		})
+		p.fields[i] = f
+
+		if f.name == aws.StringValue(schema.EventTimeField) {
+			p.eventTime = f
+		}
+	}
+
+	// NOTE: added to end of struct like all parsers, so columns of the schema fields come first
+	structFields = append(structFields, reflect.StructField{
+		Name:      pantherLogType.Name(),
+		Type:      pantherLogType,
+		Anonymous: true,
+	})
+	p.eventType = reflect.StructOf(structFields)
+
+	return p.New().(*Parser), nil
+}
+
+// newFraming builds the framing of a validated schema
+func newFraming(schema *models.CustomLogSchemaSettings) (parsers.Framing, error) {
+	switch aws.StringValue(schema.Framing) {
+	case models.CustomLogFramingJSON:
+		return parsers.Framing{Strategy: parsers.FramingJSON}, nil
+	case models.CustomLogFramingRegex:
+		recordStart, err := regexp.Compile(aws.StringValue(schema.RecordStartPattern))
+		if err != nil {
+			return parsers.Framing{}, errors.Wrap(err, "invalid record start pattern")
+		}
+		return parsers.Framing{Strategy: parsers.FramingRegex, RecordStart: recordStart}, nil
+	default:
+		return parsers.Framing{Strategy: parsers.FramingLine}, nil
+	}
+}
+
+// newField builds a field of a validated schema
+func newField(index int, schemaField *models.CustomLogField, timezone string) (*field, error) {
+	f := &field{
+		index:      index,
+		name:       aws.StringValue(schemaField.Name),
+		fieldType:  aws.StringValue(schemaField.Type),
+		indicators: aws.StringValueSlice(schemaField.Indicators),
+	}
+	if f.fieldType == models.CustomFieldTypeTimestamp {
+		var timeFormats []string
+		if schemaField.TimeFormat != nil {
+			timeFormats = append(timeFormats, *schemaField.TimeFormat)
+		}
+		timeFormats = append(timeFormats, aws.StringValueSlice(schemaField.TimeFormats)...)
+		timestamps, err := timestamp.NewParser(timeFormats, timezone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %q", f.name)
+		}
+		f.timestamps = timestamps
+	}
+	return f, nil
+}
+
+// New returns a new instance of the parser (CSV parsing and the stats are stateful)
+func (p *Parser) New() parsers.LogParser {
+	newParser := *p // the schema derived fields are never modified so they can be shared
+	newParser.timestampFailures = 0
+	if p.format == models.CustomLogFormatCSV {
+		reader := csvstream.NewStreamingCSVReader()
+		reader.CVSReader.Comma = p.delimiter
+		newParser.csvReader = reader
+	}
+	return &newParser
+}
+
+// LogType returns the log type supported by this parser
+func (p *Parser) LogType() string {
+	return p.logType
+}
+
+// Description returns the description of the log type
+func (p *Parser) Description() string {
+	return p.description
+}
+
+// Framing returns how the lines of the log files are aggregated into records
+func (p *Parser) Framing() parsers.Framing {
+	return p.framing
+}
+
+// TimestampFailureCount returns the number of timestamps that failed to parse
+func (p *Parser) TimestampFailureCount() uint64 {
+	return p.timestampFailures
+}
+
+// EventStruct returns a new instance of the event struct, used to infer the Glue table columns
+func (p *Parser) EventStruct() interface{} {
+	return reflect.New(p.eventType).Interface()
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *Parser) Parse(log string) []*parsers.PantherLog {
+	eventPtr := reflect.New(p.eventType)
+	event := eventPtr.Elem()
+
+	var err error
+	if p.format == models.CustomLogFormatCSV {
+		var isHeader bool
+		isHeader, err = p.populateCSV(event, log)
+		if isHeader { // return success but no events
+			return []*parsers.PantherLog{}
+		}
+	} else {
+		err = p.populateJSON(event, log)
+	}
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.String("logType", p.logType), zap.Error(err))
+		return nil
+	}
+
+	pantherLog := event.Field(len(p.fields)).Addr().Interface().(*parsers.PantherLog)
+	var eventTime *timestamp.RFC3339
+	if p.eventTime != nil {
+		eventTime, _ = event.Field(p.eventTime.index).Interface().(*timestamp.RFC3339)
+	}
+	pantherLog.SetCoreFields(p.logType, eventTime, eventPtr.Interface())
+	p.appendIndicators(pantherLog, event)
+
+	if err := parsers.Validator.Struct(eventPtr.Interface()); err != nil {
+		zap.L().Debug("failed to validate log", zap.String("logType", p.logType), zap.Error(err))
+		return nil
+	}
+
+	return pantherLog.Logs()
+}
+
+func (p *Parser) populateJSON(event reflect.Value, log string) error {
+	var object map[string]jsoniter.RawMessage
+	if err := jsoniter.UnmarshalFromString(log, &object); err != nil {
+		return err
+	}
+	for _, f := range p.fields {
+		raw, ok := object[f.name]
+		if !ok || len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		value := string(raw)
+		if f.fieldType != models.CustomFieldTypeJSON && raw[0] == '"' {
+			if err := jsoniter.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+		}
+		if err := p.setField(event, f, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// populateCSV sets the event fields from a CSV record, the columns are in the order of the schema fields
+func (p *Parser) populateCSV(event reflect.Value, log string) (isHeader bool, err error) {
+	record, err := p.csvReader.Parse(log)
+	if err != nil {
+		return false, err
+	}
+	if len(record) != len(p.fields) {
+		return false, errors.Errorf("expected %d columns, found %d", len(p.fields), len(record))
+	}
+	if p.isHeader(record) {
+		return true, nil
+	}
+	for i, f := range p.fields {
+		value := record[i]
+		if value == "" || value == csvNullValue {
+			continue
+		}
+		if err := p.setField(event, f, value); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func (p *Parser) isHeader(record []string) bool {
+	for i, f := range p.fields {
+		if !strings.EqualFold(strings.TrimSpace(record[i]), f.name) {
+			return false
+		}
+	}
+	return true
+}
+
+// setField converts the value to the type of the field and sets it in the event
+func (p *Parser) setField(event reflect.Value, f *field, value string) error {
+	var fieldValue interface{}
+	switch f.fieldType {
+	case models.CustomFieldTypeString:
+		fieldValue = &value
+	case models.CustomFieldTypeBigInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid bigint field %q", f.name)
+		}
+		fieldValue = &n
+	case models.CustomFieldTypeDouble:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid double field %q", f.name)
+		}
+		fieldValue = &n
+	case models.CustomFieldTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid boolean field %q", f.name)
+		}
+		fieldValue = &b
+	case models.CustomFieldTypeTimestamp:
+		ts, err := f.timestamps.Parse(value)
+		if err != nil {
+			p.timestampFailures++
+			return errors.Wrapf(err, "invalid timestamp field %q", f.name)
+		}
+		fieldValue = &ts
+	case models.CustomFieldTypeJSON:
+		if !jsoniter.Valid([]byte(value)) {
+			return errors.Errorf("invalid json field %q", f.name)
+		}
+		raw := jsoniter.RawMessage(value)
+		fieldValue = &raw
+	default:
+		return errors.Errorf("unsupported type %q of field %q", f.fieldType, f.name)
+	}
+	event.Field(f.index).Set(reflect.ValueOf(fieldValue))
+	return nil
+}
+
+func (p *Parser) appendIndicators(pantherLog *parsers.PantherLog, event reflect.Value) {
+	for _, f := range p.fields {
+		if len(f.indicators) == 0 {
+			continue
+		}
+		value, _ := event.Field(f.index).Interface().(*string)
+		if value == nil {
+			continue
+		}
+		for _, indicator := range f.indicators {
+			switch indicator {
+			case models.CustomFieldIndicatorIP:
+				pantherLog.AppendAnyIPAddresses(*value)
+			case models.CustomFieldIndicatorDomain:
+				pantherLog.AppendAnyDomainNames(*value)
+			case models.CustomFieldIndicatorSHA1:
+				pantherLog.AppendAnySHA1Hashes(*value)
+			case models.CustomFieldIndicatorMD5:
+				pantherLog.AppendAnyMD5Hashes(*value)
+			case models.CustomFieldIndicatorSHA256:
+				pantherLog.AppendAnySHA256Hashes(*value)
+			case models.CustomFieldIndicatorAWSARN:
+				pantherLog.AppendAnyAWSARNs(*value)
+			case models.CustomFieldIndicatorAWSAccountID:
+				pantherLog.AppendAnyAWSAccountIds(*value)
+			case models.CustomFieldIndicatorAWSInstanceID:
+				pantherLog.AppendAnyAWSInstanceIds(*value)
+			case models.CustomFieldIndicatorUsername:
+				pantherLog.AppendAnyUsernames(*value)
+			}
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package customlogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func testSchema(format string) *models.CustomLogSchemaSettings {
+	return &models.CustomLogSchemaSettings{
+		LogType:        aws.String("Custom.MyApp"),
+		Description:    aws.String("My application logs"),
+		Format:         aws.String(format),
+		EventTimeField: aws.String("time"),
+		Fields: []*models.CustomLogField{
+			{
+				Name:        aws.String("time"),
+				Type:        aws.String(models.CustomFieldTypeTimestamp),
+				Description: aws.String("The time of the request"),
+				TimeFormat:  aws.String(models.CustomTimeFormatUnix),
+				Required:    aws.Bool(true),
+			},
+			{
+				Name:        aws.String("clientIp"),
+				Type:        aws.String(models.CustomFieldTypeString),
+				Description: aws.String("The IP address of the client"),
+				Indicators:  aws.StringSlice([]string{models.CustomFieldIndicatorIP}),
+			},
+			{
+				Name:        aws.String("bytes"),
+				Type:        aws.String(models.CustomFieldTypeBigInt),
+				Description: aws.String("The size of the response"),
+			},
+			{
+				Name:        aws.String("latency"),
+				Type:        aws.String(models.CustomFieldTypeDouble),
+				Description: aws.String("The latency of the request"),
+			},
+			{
+				Name:        aws.String("cached"),
+				Type:        aws.String(models.CustomFieldTypeBoolean),
+				Description: aws.String("Whether the response was cached"),
+			},
+			{
+				Name:        aws.String("headers"),
+				Type:        aws.String(models.CustomFieldTypeJSON),
+				Description: aws.String("The request headers"),
+			},
+		},
+	}
+}
+
+func TestCustomLogJSON(t *testing.T) {
+	parser, err := NewParser(testSchema(models.CustomLogFormatJSON))
+	require.NoError(t, err)
+	require.Equal(t, "Custom.MyApp", parser.LogType())
+
+	log := `{"time":1573642242.5,"clientIp":"52.119.169.95","bytes":"7119","latency":0.25,"cached":false,` +
+		`"headers":{"Host":"example.com"},"unknown":"ignored"}`
+	events := parser.New().Parse(log)
+	require.Len(t, events, 1)
+	event := events[0]
+
+	expectedEventTime := time.Unix(1573642242, int64(500*time.Millisecond)).UTC()
+	require.Equal(t, "Custom.MyApp", *event.PantherLogType)
+	require.Equal(t, (*timestamp.RFC3339)(&expectedEventTime), event.PantherEventTime)
+	require.NotNil(t, event.PantherAnyIPAddresses)
+
+	eventJSON, err := jsoniter.MarshalToString(event.Event())
+	require.NoError(t, err)
+	var actual map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(eventJSON, &actual))
+	require.Equal(t, "2019-11-13 10:50:42.500000000", actual["time"])
+	require.Equal(t, "52.119.169.95", actual["clientIp"])
+	require.Equal(t, float64(7119), actual["bytes"])
+	require.Equal(t, 0.25, actual["latency"])
+	require.Equal(t, false, actual["cached"])
+	require.Equal(t, map[string]interface{}{"Host": "example.com"}, actual["headers"])
+	require.Equal(t, []interface{}{"52.119.169.95"}, actual["p_any_ip_addresses"])
+	require.Equal(t, "Custom.MyApp", actual["p_log_type"])
+	require.NotContains(t, actual, "unknown")
+}
+
+func TestCustomLogJSONMissingRequired(t *testing.T) {
+	parser, err := NewParser(testSchema(models.CustomLogFormatJSON))
+	require.NoError(t, err)
+	require.Nil(t, parser.Parse(`{"clientIp":"52.119.169.95"}`))
+}
+
+func TestCustomLogJSONInvalidType(t *testing.T) {
+	parser, err := NewParser(testSchema(models.CustomLogFormatJSON))
+	require.NoError(t, err)
+	require.Nil(t, parser.Parse(`{"time":1573642242,"bytes":"many"}`))
+	require.Nil(t, parser.Parse(`not json`))
+}
+
+func TestCustomLogCSV(t *testing.T) {
+	schema := testSchema(models.CustomLogFormatCSV)
+	schema.Delimiter = aws.String("\t")
+	parser, err := NewParser(schema)
+	require.NoError(t, err)
+
+	// header lines are skipped
+	require.Equal(t, 0, len(parser.Parse("time\tclientIp\tbytes\tlatency\tcached\theaders")))
+
+	events := parser.Parse("1573642242\t52.119.169.95\t-\t0.25\ttrue\t")
+	require.Len(t, events, 1)
+	eventJSON, err := jsoniter.MarshalToString(events[0].Event())
+	require.NoError(t, err)
+	var actual map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(eventJSON, &actual))
+	require.Equal(t, "2019-11-13 10:50:42.000000000", actual["time"])
+	require.Equal(t, true, actual["cached"])
+	require.NotContains(t, actual, "bytes")
+	require.NotContains(t, actual, "headers")
+
+	// wrong number of columns
+	require.Nil(t, parser.Parse("1573642242\t52.119.169.95"))
+}
+
+func TestCustomLogInvalidSchema(t *testing.T) {
+	schema := testSchema(models.CustomLogFormatJSON)
+	schema.LogType = aws.String("AWS.CloudTrail")
+	_, err := NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.EventTimeField = aws.String("clientIp")
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.EventTimeField = aws.String("missing")
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.Fields[1].Name = aws.String("Bytes")
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.Fields[2].Indicators = aws.StringSlice([]string{models.CustomFieldIndicatorIP})
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.Fields[1].Name = aws.String("p_log_type")
+	_, err = NewParser(schema)
+	require.Error(t, err)
+}
+
+func TestCustomLogIndicators(t *testing.T) {
+	schema := testSchema(models.CustomLogFormatJSON)
+	schema.Fields = append(schema.Fields,
+		&models.CustomLogField{
+			Name:        aws.String("user"),
+			Type:        aws.String(models.CustomFieldTypeString),
+			Description: aws.String("The user making the request"),
+			Indicators:  aws.StringSlice([]string{models.CustomFieldIndicatorUsername}),
+		},
+		&models.CustomLogField{
+			Name:        aws.String("role"),
+			Type:        aws.String(models.CustomFieldTypeString),
+			Description: aws.String("The IAM role of the instance serving the request"),
+			Indicators:  aws.StringSlice([]string{models.CustomFieldIndicatorAWSARN}),
+		},
+		&models.CustomLogField{
+			Name:        aws.String("instance"),
+			Type:        aws.String(models.CustomFieldTypeString),
+			Description: aws.String("The instance serving the request"),
+			Indicators:  aws.StringSlice([]string{models.CustomFieldIndicatorAWSInstanceID}),
+		},
+		&models.CustomLogField{
+			Name:        aws.String("account"),
+			Type:        aws.String(models.CustomFieldTypeString),
+			Description: aws.String("The account of the instance serving the request"),
+			Indicators:  aws.StringSlice([]string{models.CustomFieldIndicatorAWSAccountID}),
+		},
+		&models.CustomLogField{
+			Name:        aws.String("digest"),
+			Type:        aws.String(models.CustomFieldTypeString),
+			Description: aws.String("The digest of the response body"),
+			Indicators:  aws.StringSlice([]string{models.CustomFieldIndicatorSHA256}),
+		},
+	)
+	parser, err := NewParser(schema)
+	require.NoError(t, err)
+
+	log := `{"time":1573642242,"user":"alice@example.com","role":"arn:aws:iam::123456789012:role/app",` +
+		`"instance":"i-0a1b2c3d4e5f6a7b8","account":"123456789012",` +
+		`"digest":"ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9"}`
+	events := parser.New().Parse(log)
+	require.Len(t, events, 1)
+
+	eventJSON, err := jsoniter.MarshalToString(events[0].Event())
+	require.NoError(t, err)
+	var actual map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(eventJSON, &actual))
+	require.Equal(t, []interface{}{"alice@example.com"}, actual["p_any_usernames"])
+	require.Equal(t, []interface{}{"arn:aws:iam::123456789012:role/app"}, actual["p_any_aws_arns"])
+	require.Equal(t, []interface{}{"i-0a1b2c3d4e5f6a7b8"}, actual["p_any_aws_instance_ids"])
+	require.Equal(t, []interface{}{"123456789012"}, actual["p_any_aws_account_ids"])
+	require.Equal(t, []interface{}{"ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9"}, actual["p_any_sha256_hashes"])
+}
+
+func TestCustomLogFraming(t *testing.T) {
+	parser, err := NewParser(testSchema(models.CustomLogFormatJSON))
+	require.NoError(t, err)
+	require.Equal(t, parsers.FramingLine, parser.Framing().Strategy)
+
+	schema := testSchema(models.CustomLogFormatJSON)
+	schema.Framing = aws.String(models.CustomLogFramingJSON)
+	parser, err = NewParser(schema)
+	require.NoError(t, err)
+	require.Equal(t, parsers.FramingJSON, parser.New().(parsers.FramedParser).Framing().Strategy)
+
+	schema = testSchema(models.CustomLogFormatCSV)
+	schema.Framing = aws.String(models.CustomLogFramingRegex)
+	schema.RecordStartPattern = aws.String(`^\d+,`)
+	parser, err = NewParser(schema)
+	require.NoError(t, err)
+	framing := parser.Framing()
+	require.Equal(t, parsers.FramingRegex, framing.Strategy)
+	require.True(t, framing.RecordStart.MatchString("1573642242,52.119.169.95"))
+}
+
+func TestCustomLogInvalidFraming(t *testing.T) {
+	schema := testSchema(models.CustomLogFormatCSV)
+	schema.Framing = aws.String(models.CustomLogFramingJSON)
+	_, err := NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.Framing = aws.String(models.CustomLogFramingRegex)
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema.RecordStartPattern = aws.String(`^(`)
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.Framing = aws.String("xml")
+	_, err = NewParser(schema)
+	require.Error(t, err)
+}
+
+func TestCustomLogTimeFormats(t *testing.T) {
+	schema := testSchema(models.CustomLogFormatJSON)
+	schema.Timezone = aws.String("America/New_York")
+	schema.Fields[0].TimeFormat = aws.String(models.CustomTimeFormatRFC3339)
+	schema.Fields[0].TimeFormats = aws.StringSlice([]string{"2006-01-02 15:04:05"})
+	parser, err := NewParser(schema)
+	require.NoError(t, err)
+
+	expectedEventTime := (timestamp.RFC3339)(time.Date(2019, 11, 13, 10, 50, 42, 0, time.UTC))
+	events := parser.Parse(`{"time":"2019-11-13T10:50:42Z"}`)
+	require.Len(t, events, 1)
+	require.Equal(t, &expectedEventTime, events[0].PantherEventTime)
+
+	// the second format has no zone, it is in the timezone of the schema
+	events = parser.Parse(`{"time":"2019-11-13 05:50:42"}`)
+	require.Len(t, events, 1)
+	require.Equal(t, &expectedEventTime, events[0].PantherEventTime)
+
+	require.Equal(t, uint64(0), parser.TimestampFailureCount())
+	require.Nil(t, parser.Parse(`{"time":"13/11/2019"}`))
+	require.Equal(t, uint64(1), parser.TimestampFailureCount())
+	require.Equal(t, uint64(0), parser.New().(parsers.TimestampFailureCounter).TimestampFailureCount())
+
+	schema.Timezone = aws.String("Not/AZone")
+	_, err = NewParser(schema)
+	require.Error(t, err)
+
+	schema = testSchema(models.CustomLogFormatJSON)
+	schema.Fields[1].TimeFormats = aws.StringSlice([]string{"2006-01-02"})
+	_, err = NewParser(schema)
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package gsuitelogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var ReportsDesc = `G Suite Reports activity records of the Admin console, Login and other G Suite applications.
+Reference: https://developers.google.com/admin-sdk/reports/v1/reference/activities`
+
+// nolint:lll
+type Reports struct {
+	Kind        *string `json:"kind" validate:"required,eq=admin#reports#activity" description:"The type of API resource. For an activity report, the value is admin#reports#activity."`
+	ID          *ID     `json:"id" validate:"required" description:"Unique identifier for each activity record."`
+	Actor       *Actor  `json:"actor,omitempty" description:"User doing the action."`
+	OwnerDomain *string `json:"ownerDomain,omitempty" description:"This is the domain that is affected by the report's event. For example domain of Admin console or the Drive application's document owner."`
+	IPAddress   *string `json:"ipAddress,omitempty" description:"IP address of the user doing the action. This is the Internet Protocol (IP) address of the user when logging into G Suite which may or may not reflect the user's physical location."`
+	Events      []Event `json:"events,omitempty" description:"Activity events in the report."`
+	ETag        *string `json:"etag,omitempty" description:"ETag of the entry."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type ID struct {
+	ApplicationName *string            `json:"applicationName" validate:"required" description:"Application name to which the event belongs (for example admin, login, drive)."`
+	CustomerID      *string            `json:"customerId,omitempty" description:"The unique identifier for a G Suite account."`
+	Time            *timestamp.RFC3339 `json:"time" validate:"required" description:"Time of occurrence of the activity (UTC)."`
+	UniqueQualifier *string            `json:"uniqueQualifier,omitempty" description:"Unique qualifier if multiple events have the same time."`
+}
+
+// nolint:lll
+type Actor struct {
+	CallerType *string `json:"callerType,omitempty" description:"The type of actor."`
+	Email      *string `json:"email,omitempty" description:"The primary email address of the actor. May be absent if there is no email address associated with the actor."`
+	ProfileID  *string `json:"profileId,omitempty" description:"The unique G Suite profile ID of the actor."`
+	Key        *string `json:"key,omitempty" description:"Only present when callerType is KEY. Can be the consumer_key of the requestor for OAuth 2LO API requests or an identifier for robot accounts."`
+}
+
+// nolint:lll
+type Event struct {
+	Type       *string     `json:"type,omitempty" description:"Type of event. The G Suite service or feature that an administrator changes is identified in the type property which identifies an event using the eventName property."`
+	Name       *string     `json:"name,omitempty" description:"Name of the event. This is the specific name of the activity reported by the API."`
+	Parameters []Parameter `json:"parameters,omitempty" description:"Parameter value pairs for various applications."`
+}
+
+// nolint:lll
+type Parameter struct {
+	Name              *string              `json:"name,omitempty" description:"The name of the parameter."`
+	Value             *string              `json:"value,omitempty" description:"String value of the parameter."`
+	IntValue          *numerics.Integer    `json:"intValue,omitempty" description:"Integer value of the parameter."`
+	BoolValue         *bool                `json:"boolValue,omitempty" description:"Boolean value of the parameter."`
+	MultiValue        []string             `json:"multiValue,omitempty" description:"String values of the parameter."`
+	MultiIntValue     *jsoniter.RawMessage `json:"multiIntValue,omitempty" description:"Integer values of the parameter."`
+	MessageValue      *jsoniter.RawMessage `json:"messageValue,omitempty" description:"Nested parameter value pairs associated with this parameter."`
+	MultiMessageValue *jsoniter.RawMessage `json:"multiMessageValue,omitempty" description:"List of messageValue objects."`
+}
+
+// ReportsParser parses G Suite Reports API activity records
+type ReportsParser struct{}
+
+func (p *ReportsParser) New() parsers.LogParser {
+	return &ReportsParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ReportsParser) Parse(log string) []*parsers.PantherLog {
+	event := &Reports{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *ReportsParser) LogType() string {
+	return "GSuite.Reports"
+}
+
+func (event *Reports) updatePantherFields(p *ReportsParser) {
+	var eventTime *timestamp.RFC3339
+	if event.ID != nil {
+		eventTime = event.ID.Time
+	}
+	event.SetCoreFields(p.LogType(), eventTime, event)
+
+	event.AppendAnyIPAddressPtrs(event.IPAddress)
+	event.AppendAnyDomainNamePtrs(event.OwnerDomain)
+	if event.Actor != nil {
+		event.AppendAnyUsernamePtrs(event.Actor.Email)
+	}
+}
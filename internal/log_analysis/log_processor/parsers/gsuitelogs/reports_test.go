@@ -0,0 +1,154 @@
+package gsuitelogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestReportsLogin(t *testing.T) {
+	//nolint
+	log := `{"kind":"admin#reports#activity","id":{"time":"2020-04-07T18:37:16.428Z","uniqueQualifier":"-2803498725458254016","applicationName":"login","customerId":"C03az79cb"},"etag":"\"JDMC8884sebSctZ17CIssbQ/Q4kP4Ogp5oz1gFH86UdeKP7WJPo\"","actor":{"email":"user@example.com","profileId":"112664471598471234567"},"ipAddress":"136.24.229.58","events":[{"type":"login","name":"login_success","parameters":[{"name":"login_type","value":"google_password"},{"name":"login_challenge_method","multiValue":["password"]},{"name":"is_suspicious","boolValue":false}]}]}`
+
+	expectedTime := time.Date(2020, 4, 7, 18, 37, 16, int(428*time.Millisecond), time.UTC)
+	expectedEvent := &Reports{
+		Kind: aws.String("admin#reports#activity"),
+		ID: &ID{
+			ApplicationName: aws.String("login"),
+			CustomerID:      aws.String("C03az79cb"),
+			Time:            (*timestamp.RFC3339)(&expectedTime),
+			UniqueQualifier: aws.String("-2803498725458254016"),
+		},
+		Actor: &Actor{
+			Email:     aws.String("user@example.com"),
+			ProfileID: aws.String("112664471598471234567"),
+		},
+		IPAddress: aws.String("136.24.229.58"),
+		Events: []Event{
+			{
+				Type: aws.String("login"),
+				Name: aws.String("login_success"),
+				Parameters: []Parameter{
+					{
+						Name:  aws.String("login_type"),
+						Value: aws.String("google_password"),
+					},
+					{
+						Name:       aws.String("login_challenge_method"),
+						MultiValue: []string{"password"},
+					},
+					{
+						Name:      aws.String("is_suspicious"),
+						BoolValue: aws.Bool(false),
+					},
+				},
+			},
+		},
+		ETag: aws.String(`"JDMC8884sebSctZ17CIssbQ/Q4kP4Ogp5oz1gFH86UdeKP7WJPo"`),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("GSuite.Reports")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("136.24.229.58")
+	expectedEvent.AppendAnyUsernames("user@example.com")
+
+	checkReports(t, log, expectedEvent)
+}
+
+func TestReportsAdmin(t *testing.T) {
+	//nolint
+	log := `{"kind":"admin#reports#activity","id":{"time":"2020-04-06T21:01:24.715Z","uniqueQualifier":"6138713495553457063","applicationName":"admin","customerId":"C03az79cb"},"etag":"\"JDMC8884sebSctZ17CIssbQ/k4d7q_XMOAN2bObWhubnVWxWvFY\"","actor":{"callerType":"USER","email":"admin@example.com","profileId":"112664471598471234568"},"ownerDomain":"example.com","ipAddress":"2601:644:8500:7a3:9d1:bc2e:1d4d:2c3b","events":[{"type":"USER_SETTINGS","name":"CHANGE_PASSWORD","parameters":[{"name":"USER_EMAIL","value":"user@example.com"},{"name":"MAX_RETRIES","intValue":"3"}]}]}`
+
+	expectedTime := time.Date(2020, 4, 6, 21, 1, 24, int(715*time.Millisecond), time.UTC)
+	expectedEvent := &Reports{
+		Kind: aws.String("admin#reports#activity"),
+		ID: &ID{
+			ApplicationName: aws.String("admin"),
+			CustomerID:      aws.String("C03az79cb"),
+			Time:            (*timestamp.RFC3339)(&expectedTime),
+			UniqueQualifier: aws.String("6138713495553457063"),
+		},
+		Actor: &Actor{
+			CallerType: aws.String("USER"),
+			Email:      aws.String("admin@example.com"),
+			ProfileID:  aws.String("112664471598471234568"),
+		},
+		OwnerDomain: aws.String("example.com"),
+		IPAddress:   aws.String("2601:644:8500:7a3:9d1:bc2e:1d4d:2c3b"),
+		Events: []Event{
+			{
+				Type: aws.String("USER_SETTINGS"),
+				Name: aws.String("CHANGE_PASSWORD"),
+				Parameters: []Parameter{
+					{
+						Name:  aws.String("USER_EMAIL"),
+						Value: aws.String("user@example.com"),
+					},
+					{
+						Name:     aws.String("MAX_RETRIES"),
+						IntValue: (*numerics.Integer)(aws.Int(3)),
+					},
+				},
+			},
+		},
+		ETag: aws.String(`"JDMC8884sebSctZ17CIssbQ/k4d7q_XMOAN2bObWhubnVWxWvFY"`),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("GSuite.Reports")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("2601:644:8500:7a3:9d1:bc2e:1d4d:2c3b")
+	expectedEvent.AppendAnyDomainNames("example.com")
+	expectedEvent.AppendAnyUsernames("admin@example.com")
+
+	checkReports(t, log, expectedEvent)
+}
+
+func TestReportsNotActivity(t *testing.T) {
+	//nolint
+	log := `{"kind":"admin#reports#usageReport","id":{"time":"2020-04-06T21:01:24.715Z","applicationName":"admin"}}`
+	parser := &ReportsParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestReportsMissingRequiredField(t *testing.T) {
+	log := `{"kind":"admin#reports#activity","ipAddress":"136.24.229.58"}`
+	parser := &ReportsParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestReportsType(t *testing.T) {
+	parser := &ReportsParser{}
+	require.Equal(t, "GSuite.Reports", parser.LogType())
+}
+
+func checkReports(t *testing.T, log string, expectedEvent *Reports) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ReportsParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
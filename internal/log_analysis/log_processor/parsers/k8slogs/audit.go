@@ -0,0 +1,141 @@
+package k8slogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+	"github.com/panther-labs/panther/pkg/extract"
+)
+
+var AuditDesc = `Kubernetes API server audit events, one per stage of each request (e.g. as written by the log backend or the EKS audit log).
+Reference: https://kubernetes.io/docs/tasks/debug-application-cluster/audit/`
+
+// nolint:lll
+type Audit struct {
+	Kind                     *string              `json:"kind" validate:"required,eq=Event" description:"The kind of the object, always Event."`
+	APIVersion               *string              `json:"apiVersion" validate:"required,oneof=audit.k8s.io/v1 audit.k8s.io/v1beta1" description:"The version of the audit event schema."`
+	Level                    *string              `json:"level" validate:"required" description:"The audit level the event was generated at (Metadata, Request or RequestResponse)."`
+	AuditID                  *string              `json:"auditID" validate:"required" description:"Unique audit ID, generated for each request."`
+	Stage                    *string              `json:"stage" validate:"required" description:"The stage of the request handling when the event was generated (RequestReceived, ResponseStarted, ResponseComplete or Panic)."`
+	RequestURI               *string              `json:"requestURI" validate:"required" description:"The URI of the request as sent by the client."`
+	Verb                     *string              `json:"verb" validate:"required" description:"The Kubernetes verb of the request (e.g. get, list, create). For non-resource requests, this is the lower-cased HTTP method."`
+	User                     *UserInfo            `json:"user" validate:"required" description:"The authenticated user information."`
+	ImpersonatedUser         *UserInfo            `json:"impersonatedUser,omitempty" description:"The impersonated user information."`
+	SourceIPs                []string             `json:"sourceIPs,omitempty" description:"The source IPs the request originated from and the intermediate proxies."`
+	UserAgent                *string              `json:"userAgent,omitempty" description:"The user agent string reported by the client."`
+	ObjectRef                *ObjectReference     `json:"objectRef,omitempty" description:"The object reference this request is targeted at. Does not apply for List-type requests or non-resource requests."`
+	ResponseStatus           *Status              `json:"responseStatus,omitempty" description:"The response status, populated even when the response object is not a Status."`
+	RequestObject            *jsoniter.RawMessage `json:"requestObject,omitempty" description:"The API object from the request, in JSON format. Only logged at Request level and higher."`
+	ResponseObject           *jsoniter.RawMessage `json:"responseObject,omitempty" description:"The API object returned in the response, in JSON format. Only logged at RequestResponse level."`
+	RequestReceivedTimestamp *timestamp.RFC3339   `json:"requestReceivedTimestamp,omitempty" description:"The time the request reached the API server."`
+	StageTimestamp           *timestamp.RFC3339   `json:"stageTimestamp,omitempty" description:"The time the request reached the current audit stage."`
+	Annotations              map[string]string    `json:"annotations,omitempty" description:"Annotations of the event, e.g. the authorization decision and reason."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type UserInfo struct {
+	Username *string              `json:"username,omitempty" description:"The name that uniquely identifies this user among all active users."`
+	UID      *string              `json:"uid,omitempty" description:"A unique value that identifies this user across time."`
+	Groups   []string             `json:"groups,omitempty" description:"The names of groups this user is a part of."`
+	Extra    *jsoniter.RawMessage `json:"extra,omitempty" description:"Any additional information provided by the authenticator (e.g. the IAM ARN of EKS users)."`
+}
+
+// nolint:lll
+type ObjectReference struct {
+	Resource        *string `json:"resource,omitempty" description:"The resource type of the object."`
+	Namespace       *string `json:"namespace,omitempty" description:"The namespace of the object."`
+	Name            *string `json:"name,omitempty" description:"The name of the object."`
+	UID             *string `json:"uid,omitempty" description:"The UID of the object."`
+	APIGroup        *string `json:"apiGroup,omitempty" description:"The name of the API group that contains the referred object. The empty string represents the core API group."`
+	APIVersion      *string `json:"apiVersion,omitempty" description:"The version of the API group that contains the referred object."`
+	ResourceVersion *string `json:"resourceVersion,omitempty" description:"The resource version of the object."`
+	Subresource     *string `json:"subresource,omitempty" description:"The subresource of the request (e.g. status, log)."`
+}
+
+// nolint:lll
+type Status struct {
+	Metadata *jsoniter.RawMessage `json:"metadata,omitempty" description:"Standard list metadata."`
+	Status   *string              `json:"status,omitempty" description:"Status of the operation, Success or Failure."`
+	Message  *string              `json:"message,omitempty" description:"A human-readable description of the status of this operation."`
+	Reason   *string              `json:"reason,omitempty" description:"A machine-readable description of why this operation is in the Failure status."`
+	Details  *jsoniter.RawMessage `json:"details,omitempty" description:"Extended data associated with the reason."`
+	Code     *int                 `json:"code,omitempty" description:"Suggested HTTP return code for this status."`
+}
+
+// AuditParser parses Kubernetes audit events
+type AuditParser struct{}
+
+func (p *AuditParser) New() parsers.LogParser {
+	return &AuditParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AuditParser) Parse(log string) []*parsers.PantherLog {
+	event := &Audit{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *AuditParser) LogType() string {
+	return "Kubernetes.Audit"
+}
+
+func (event *Audit) updatePantherFields(p *AuditParser) {
+	// an event is written per stage of a request, the time of the stage tells them apart
+	eventTime := event.StageTimestamp
+	if eventTime == nil {
+		eventTime = event.RequestReceivedTimestamp
+	}
+	event.SetCoreFields(p.LogType(), eventTime, event)
+
+	for _, sourceIP := range event.SourceIPs {
+		event.AppendAnyIPAddresses(sourceIP)
+	}
+	event.User.appendAnyFields(&event.PantherLog)
+	event.ImpersonatedUser.appendAnyFields(&event.PantherLog)
+}
+
+func (user *UserInfo) appendAnyFields(pl *parsers.PantherLog) {
+	if user == nil {
+		return
+	}
+	pl.AppendAnyUsernamePtrs(user.Username)
+	// authenticators add the identity they mapped to the user here (e.g. the IAM ARN on EKS)
+	extract.Extract(user.Extra, awslogs.NewAWSExtractor(pl))
+}
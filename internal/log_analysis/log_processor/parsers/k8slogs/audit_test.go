@@ -0,0 +1,135 @@
+package k8slogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAuditCreatePod(t *testing.T) {
+	//nolint
+	log := `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"5a7fa9f5-3c69-4bd1-9bdf-5b8c1a7b4a58","stage":"ResponseComplete","requestURI":"/api/v1/namespaces/default/pods","verb":"create","user":{"username":"kubernetes-admin","uid":"heptio-authenticator-aws:123456789012:AROAEXAMPLEID","groups":["system:masters","system:authenticated"],"extra":{"accessKeyId":["ASIAEXAMPLEKEY"],"arn":["arn:aws:sts::123456789012:assumed-role/KubernetesAdmin/session"]}},"sourceIPs":["10.0.0.5","192.168.1.1"],"userAgent":"kubectl/v1.18.2 (darwin/amd64) kubernetes/52c56ce","objectRef":{"resource":"pods","namespace":"default","name":"nginx","apiVersion":"v1"},"responseStatus":{"metadata":{},"code":201},"requestReceivedTimestamp":"2020-05-12T10:00:00.123456Z","stageTimestamp":"2020-05-12T10:00:00.234567Z","annotations":{"authorization.k8s.io/decision":"allow","authorization.k8s.io/reason":""}}`
+
+	expectedReceived := time.Date(2020, 5, 12, 10, 0, 0, 123456000, time.UTC)
+	expectedStage := time.Date(2020, 5, 12, 10, 0, 0, 234567000, time.UTC)
+	expectedEvent := &Audit{
+		Kind:       aws.String("Event"),
+		APIVersion: aws.String("audit.k8s.io/v1"),
+		Level:      aws.String("Metadata"),
+		AuditID:    aws.String("5a7fa9f5-3c69-4bd1-9bdf-5b8c1a7b4a58"),
+		Stage:      aws.String("ResponseComplete"),
+		RequestURI: aws.String("/api/v1/namespaces/default/pods"),
+		Verb:       aws.String("create"),
+		User: &UserInfo{
+			Username: aws.String("kubernetes-admin"),
+			UID:      aws.String("heptio-authenticator-aws:123456789012:AROAEXAMPLEID"),
+			Groups:   []string{"system:masters", "system:authenticated"},
+			Extra:    newRawMessage(`{"accessKeyId":["ASIAEXAMPLEKEY"],"arn":["arn:aws:sts::123456789012:assumed-role/KubernetesAdmin/session"]}`),
+		},
+		SourceIPs: []string{"10.0.0.5", "192.168.1.1"},
+		UserAgent: aws.String("kubectl/v1.18.2 (darwin/amd64) kubernetes/52c56ce"),
+		ObjectRef: &ObjectReference{
+			Resource:   aws.String("pods"),
+			Namespace:  aws.String("default"),
+			Name:       aws.String("nginx"),
+			APIVersion: aws.String("v1"),
+		},
+		ResponseStatus: &Status{
+			Metadata: newRawMessage(`{}`),
+			Code:     aws.Int(201),
+		},
+		RequestReceivedTimestamp: (*timestamp.RFC3339)(&expectedReceived),
+		StageTimestamp:           (*timestamp.RFC3339)(&expectedStage),
+		Annotations: map[string]string{
+			"authorization.k8s.io/decision": "allow",
+			"authorization.k8s.io/reason":   "",
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Kubernetes.Audit")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedStage)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "192.168.1.1")
+	expectedEvent.AppendAnyUsernames("kubernetes-admin")
+	expectedEvent.AppendAnyAWSARNs("arn:aws:sts::123456789012:assumed-role/KubernetesAdmin/session")
+	expectedEvent.AppendAnyAWSAccountIds("123456789012")
+
+	checkAudit(t, log, expectedEvent)
+}
+
+func TestAuditRequestReceived(t *testing.T) {
+	//nolint
+	log := `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"0f8d4c7e-26f1-4f59-9d36-9e26c4b7a9a1","stage":"RequestReceived","requestURI":"/healthz","verb":"get","user":{"username":"system:anonymous","groups":["system:unauthenticated"]},"sourceIPs":["10.0.0.6"],"requestReceivedTimestamp":"2020-05-12T10:00:01.000001Z"}`
+
+	expectedReceived := time.Date(2020, 5, 12, 10, 0, 1, 1000, time.UTC)
+	expectedEvent := &Audit{
+		Kind:       aws.String("Event"),
+		APIVersion: aws.String("audit.k8s.io/v1"),
+		Level:      aws.String("Metadata"),
+		AuditID:    aws.String("0f8d4c7e-26f1-4f59-9d36-9e26c4b7a9a1"),
+		Stage:      aws.String("RequestReceived"),
+		RequestURI: aws.String("/healthz"),
+		Verb:       aws.String("get"),
+		User: &UserInfo{
+			Username: aws.String("system:anonymous"),
+			Groups:   []string{"system:unauthenticated"},
+		},
+		SourceIPs:                []string{"10.0.0.6"},
+		RequestReceivedTimestamp: (*timestamp.RFC3339)(&expectedReceived),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Kubernetes.Audit")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedReceived)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.6")
+	expectedEvent.AppendAnyUsernames("system:anonymous")
+
+	checkAudit(t, log, expectedEvent)
+}
+
+func TestAuditOtherKind(t *testing.T) {
+	//nolint
+	log := `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"0f8d4c7e-26f1-4f59-9d36-9e26c4b7a9a1","stage":"RequestReceived","requestURI":"/healthz","verb":"get","user":{"username":"system:anonymous"}}`
+	parser := &AuditParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestAuditType(t *testing.T) {
+	parser := &AuditParser{}
+	require.Equal(t, "Kubernetes.Audit", parser.LogType())
+}
+
+func checkAudit(t *testing.T, log string, expectedEvent *Audit) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &AuditParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func newRawMessage(jsonString string) *jsoniter.RawMessage {
+	rawMsg := (jsoniter.RawMessage)(jsonString)
+	return &rawMsg
+}
@@ -0,0 +1,214 @@
+package k8slogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var EKSAuthenticatorDesc = `EKS authenticator logs record the authentication of IAM identities to the Kubernetes API server of EKS clusters.
+The log lines are in logfmt format (key=value pairs) and are delivered to the authenticator-* streams of the cluster log group.
+Only the lines of authentications (with an arn, accesskeyid or path field) are classified.
+Reference: https://docs.aws.amazon.com/eks/latest/userguide/control-plane-logs.html`
+
+// nolint:lll
+type EKSAuthenticator struct {
+	Time        *timestamp.RFC3339 `json:"time" validate:"required" description:"The time of the log line."`
+	Level       *string            `json:"level" validate:"required" description:"The level of the log line (e.g. info, warning)."`
+	Msg         *string            `json:"msg" validate:"required" description:"The message of the log line (e.g. access granted, access denied)."`
+	ARN         *string            `json:"arn,omitempty" description:"The ARN of the IAM identity that authenticated."`
+	AccessKeyID *string            `json:"accesskeyid,omitempty" description:"The access key ID of the IAM identity that authenticated."`
+	AccountID   *string            `json:"accountid,omitempty" description:"The AWS account ID of the IAM identity that authenticated."`
+	UserID      *string            `json:"userid,omitempty" description:"The unique ID of the IAM identity that authenticated."`
+	Session     *string            `json:"session,omitempty" description:"The session name of the assumed role that authenticated."`
+	Username    *string            `json:"username,omitempty" description:"The Kubernetes username the IAM identity is mapped to."`
+	UID         *string            `json:"uid,omitempty" description:"The Kubernetes UID the IAM identity is mapped to."`
+	Groups      []string           `json:"groups,omitempty" description:"The Kubernetes groups the IAM identity is mapped to."`
+	Role        *string            `json:"role,omitempty" description:"The IAM role of a role mapping."`
+	Client      *string            `json:"client,omitempty" description:"The address and port of the client of the authentication request."`
+	Method      *string            `json:"method,omitempty" description:"The HTTP method of the authentication request."`
+	Path        *string            `json:"path,omitempty" description:"The path of the authentication request."`
+	Error       *string            `json:"error,omitempty" description:"The error of a failed authentication."`
+	Extra       map[string]string  `json:"extra,omitempty" description:"The other fields of the log line."`
+
+	// NOTE: added to end of struct to allow expansion later
+	awslogs.AWSPantherLog
+}
+
+// EKSAuthenticatorParser parses EKS authenticator logs
+type EKSAuthenticatorParser struct{}
+
+func (p *EKSAuthenticatorParser) New() parsers.LogParser {
+	return &EKSAuthenticatorParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *EKSAuthenticatorParser) Parse(log string) []*parsers.PantherLog {
+	fields, err := parseLogfmt(log)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+	event, err := newEKSAuthenticator(fields)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *EKSAuthenticatorParser) LogType() string {
+	return "Kubernetes.EKSAuthenticator"
+}
+
+func newEKSAuthenticator(fields map[string]string) (*EKSAuthenticator, error) {
+	event := &EKSAuthenticator{}
+	for key, value := range fields {
+		value := value
+		switch key {
+		case "time":
+			ts, err := timestamp.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, err
+			}
+			event.Time = &ts
+		case "level":
+			event.Level = &value
+		case "msg":
+			event.Msg = &value
+		case "arn":
+			event.ARN = &value
+		case "accesskeyid":
+			event.AccessKeyID = &value
+		case "accountid":
+			event.AccountID = &value
+		case "userid":
+			event.UserID = &value
+		case "session":
+			event.Session = &value
+		case "username":
+			event.Username = &value
+		case "uid":
+			event.UID = &value
+		case "groups":
+			// e.g. [system:masters system:nodes]
+			event.Groups = strings.Fields(strings.Trim(value, "[]"))
+		case "role":
+			event.Role = &value
+		case "client":
+			event.Client = &value
+		case "method":
+			event.Method = &value
+		case "path":
+			event.Path = &value
+		case "error":
+			event.Error = &value
+		default:
+			if event.Extra == nil {
+				event.Extra = make(map[string]string)
+			}
+			event.Extra[key] = value
+		}
+	}
+	// any logrus line has time, level and msg, the lines of the authenticator describe an identity or request
+	if event.ARN == nil && event.AccessKeyID == nil && event.Path == nil {
+		return nil, errors.New("missing arn, accesskeyid or path")
+	}
+	return event, nil
+}
+
+func (event *EKSAuthenticator) updatePantherFields(p *EKSAuthenticatorParser) {
+	event.SetCoreFields(p.LogType(), event.Time, event)
+
+	if event.Client != nil {
+		if host, _, err := net.SplitHostPort(*event.Client); err == nil {
+			event.AppendAnyIPAddresses(host)
+		}
+	}
+	event.AppendAnyAWSARNPtrs(event.ARN, event.Role)
+	event.AppendAnyAWSAccountIdPtrs(event.AccountID)
+	event.AppendAnyUsernamePtrs(event.Username)
+}
+
+// parseLogfmt parses the key=value pairs of a logfmt line (the text format of logrus), values with spaces are quoted
+func parseLogfmt(line string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for i := 0; i < len(line); {
+		if line[i] == ' ' {
+			i++
+			continue
+		}
+
+		eq := strings.IndexByte(line[i:], '=')
+		if eq <= 0 {
+			return nil, errors.New("expected key=value")
+		}
+		key := line[i : i+eq]
+		if strings.ContainsAny(key, ` "`) {
+			return nil, errors.Errorf("invalid key %q", key)
+		}
+		i += eq + 1
+
+		var value string
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for ; end < len(line) && line[end] != '"'; end++ {
+				if line[end] == '\\' { // skip the escaped character
+					end++
+				}
+			}
+			if end >= len(line) {
+				return nil, errors.Errorf("unterminated value of %s", key)
+			}
+			unquoted, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value of %s", key)
+			}
+			value = unquoted
+			i = end + 1
+		} else {
+			end := strings.IndexByte(line[i:], ' ')
+			if end < 0 {
+				end = len(line) - i
+			}
+			value = line[i : i+end]
+			i += end
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
@@ -0,0 +1,130 @@
+package k8slogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestEKSAuthenticatorAccessGranted(t *testing.T) {
+	//nolint
+	log := `time="2020-05-12T10:00:00Z" level=info msg="access granted" arn="arn:aws:iam::123456789012:role/KubernetesAdmin" client="127.0.0.1:51234" groups="[system:masters]" method=POST path=/authenticate uid="heptio-authenticator-aws:123456789012:AROAEXAMPLEID" username=kubernetes-admin`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 0, time.UTC)
+	expectedEvent := &EKSAuthenticator{
+		Time:     (*timestamp.RFC3339)(&expectedTime),
+		Level:    aws.String("info"),
+		Msg:      aws.String("access granted"),
+		ARN:      aws.String("arn:aws:iam::123456789012:role/KubernetesAdmin"),
+		Client:   aws.String("127.0.0.1:51234"),
+		Groups:   []string{"system:masters"},
+		Method:   aws.String("POST"),
+		Path:     aws.String("/authenticate"),
+		UID:      aws.String("heptio-authenticator-aws:123456789012:AROAEXAMPLEID"),
+		Username: aws.String("kubernetes-admin"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Kubernetes.EKSAuthenticator")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("127.0.0.1")
+	expectedEvent.AppendAnyAWSARNs("arn:aws:iam::123456789012:role/KubernetesAdmin")
+	expectedEvent.AppendAnyUsernames("kubernetes-admin")
+
+	checkEKSAuthenticator(t, log, expectedEvent)
+}
+
+func TestEKSAuthenticatorSTSResponse(t *testing.T) {
+	//nolint
+	log := `time="2020-05-12T10:00:00.5Z" level=info msg="STS response" accesskeyid=ASIAEXAMPLEKEY accountid=123456789012 arn="arn:aws:sts::123456789012:assumed-role/KubernetesAdmin/session" client="127.0.0.1:51234" method=POST path=/authenticate session=session userid=AROAEXAMPLEID tokenSource=header`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 500000000, time.UTC)
+	expectedEvent := &EKSAuthenticator{
+		Time:        (*timestamp.RFC3339)(&expectedTime),
+		Level:       aws.String("info"),
+		Msg:         aws.String("STS response"),
+		AccessKeyID: aws.String("ASIAEXAMPLEKEY"),
+		AccountID:   aws.String("123456789012"),
+		ARN:         aws.String("arn:aws:sts::123456789012:assumed-role/KubernetesAdmin/session"),
+		Client:      aws.String("127.0.0.1:51234"),
+		Method:      aws.String("POST"),
+		Path:        aws.String("/authenticate"),
+		Session:     aws.String("session"),
+		UserID:      aws.String("AROAEXAMPLEID"),
+		Extra:       map[string]string{"tokenSource": "header"},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Kubernetes.EKSAuthenticator")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("127.0.0.1")
+	expectedEvent.AppendAnyAWSARNs("arn:aws:sts::123456789012:assumed-role/KubernetesAdmin/session")
+	expectedEvent.AppendAnyAWSAccountIds("123456789012")
+
+	checkEKSAuthenticator(t, log, expectedEvent)
+}
+
+func TestEKSAuthenticatorNotLogfmt(t *testing.T) {
+	parser := &EKSAuthenticatorParser{}
+	require.Nil(t, parser.Parse(`{"kind":"Event","apiVersion":"audit.k8s.io/v1"}`))
+	require.Nil(t, parser.Parse(`time="2020-05-12T10:00:00Z" level=info msg="unterminated`))
+	// time, level and msg are required
+	require.Nil(t, parser.Parse(`level=info msg="access granted"`))
+}
+
+func TestEKSAuthenticatorOtherLogrus(t *testing.T) {
+	parser := &EKSAuthenticatorParser{}
+	// the lines of other programs using logrus are not authenticator logs
+	require.Nil(t, parser.Parse(`time="2020-05-12T10:00:00Z" level=info msg="starting server" addr=":8080"`))
+	require.Nil(t, parser.Parse(`time="2020-05-12T10:00:00Z" level=error msg="request failed" error="connection refused"`))
+}
+
+func TestEKSAuthenticatorType(t *testing.T) {
+	parser := &EKSAuthenticatorParser{}
+	require.Equal(t, "Kubernetes.EKSAuthenticator", parser.LogType())
+}
+
+func TestParseLogfmt(t *testing.T) {
+	fields, err := parseLogfmt(`a=1 b="two words" c="escaped \"quote\"" d= e=x=y`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"a": "1",
+		"b": "two words",
+		"c": `escaped "quote"`,
+		"d": "",
+		"e": "x=y",
+	}, fields)
+
+	_, err = parseLogfmt(`a=1 novalue`)
+	require.Error(t, err)
+}
+
+func checkEKSAuthenticator(t *testing.T, log string, expectedEvent *EKSAuthenticator) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &EKSAuthenticatorParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
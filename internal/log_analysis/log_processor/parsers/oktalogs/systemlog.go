@@ -0,0 +1,211 @@
+package oktalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var SystemLogDesc = `Okta System Log records system events related to your organization in order to provide an audit trail
+that can be used to understand platform activity and to diagnose problems.
+Reference: https://developer.okta.com/docs/reference/api/system-log/`
+
+// nolint:lll
+type SystemLog struct {
+	UUID                  *string                `json:"uuid" validate:"required" description:"Unique identifier for an individual event."`
+	Published             *timestamp.RFC3339     `json:"published" validate:"required" description:"Timestamp when the event is published (UTC)."`
+	EventType             *string                `json:"eventType" validate:"required" description:"Type of event that is published."`
+	Version               *string                `json:"version" validate:"required" description:"Versioning indicator."`
+	Severity              *string                `json:"severity" validate:"required" description:"Indicates how severe the event is: DEBUG, INFO, WARN, ERROR."`
+	LegacyEventType       *string                `json:"legacyEventType,omitempty" description:"Associated Events API Action objectType attribute value."`
+	DisplayMessage        *string                `json:"displayMessage,omitempty" description:"The display message for an event."`
+	Actor                 *Actor                 `json:"actor,omitempty" description:"Describes the entity that performs an action."`
+	Client                *Client                `json:"client,omitempty" description:"The client that requests an action."`
+	Request               *Request               `json:"request,omitempty" description:"The request that initiates an action."`
+	Outcome               *Outcome               `json:"outcome,omitempty" description:"The outcome of an action."`
+	Target                []Actor                `json:"target,omitempty" description:"Zero or more targets of an action."`
+	Transaction           *Transaction           `json:"transaction,omitempty" description:"The transaction details of an action."`
+	DebugContext          *DebugContext          `json:"debugContext,omitempty" description:"The debug request data of an action."`
+	AuthenticationContext *AuthenticationContext `json:"authenticationContext,omitempty" description:"The authentication data of an action."`
+	SecurityContext       *SecurityContext       `json:"securityContext,omitempty" description:"The security data of an action."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type Actor struct {
+	ID          *string              `json:"id" validate:"required" description:"ID of actor."`
+	Type        *string              `json:"type" validate:"required" description:"Type of actor."`
+	AlternateID *string              `json:"alternateId,omitempty" description:"Alternative ID of actor."`
+	DisplayName *string              `json:"displayName,omitempty" description:"Display name of actor."`
+	DetailEntry *jsoniter.RawMessage `json:"detailEntry,omitempty" description:"Details about actor."`
+}
+
+// nolint:lll
+type Client struct {
+	ID                  *string              `json:"id,omitempty" description:"For OAuth requests this is the ID of the OAuth client making the request. For SSWS token requests, this is the ID of the agent making the request."`
+	UserAgent           *UserAgent           `json:"userAgent,omitempty" description:"The user agent used by an actor to perform an action."`
+	GeographicalContext *GeographicalContext `json:"geographicalContext,omitempty" description:"The physical location where the client made its request from."`
+	Zone                *string              `json:"zone,omitempty" description:"The name of the Zone that the client's location is mapped to."`
+	IPAddress           *string              `json:"ipAddress,omitempty" description:"IP address that the client made its request from."`
+	Device              *string              `json:"device,omitempty" description:"Type of device that the client operated from (for example, Computer)."`
+}
+
+// nolint:lll
+type UserAgent struct {
+	Browser      *string `json:"browser,omitempty" description:"If the client is a web browser, this field identifies the type of web browser (for example, CHROME, FIREFOX)."`
+	OS           *string `json:"os,omitempty" description:"The operating system on which the client runs (for example, Microsoft Windows 10)."`
+	RawUserAgent *string `json:"rawUserAgent,omitempty" description:"A raw string representation of the user agent, formatted according to section 5.5.3 of HTTP/1.1 Semantics and Content."`
+}
+
+// nolint:lll
+type GeographicalContext struct {
+	City        *string      `json:"city,omitempty" description:"The city encompassing the area containing the geolocation coordinates, if available (for example, Seattle, San Francisco)."`
+	State       *string      `json:"state,omitempty" description:"Full name of the state or province encompassing the area containing the geolocation coordinates (for example, Montana, Incheon)."`
+	Country     *string      `json:"country,omitempty" description:"Full name of the country encompassing the area containing the geolocation coordinates (for example, France, Uganda)."`
+	PostalCode  *string      `json:"postalCode,omitempty" description:"Postal code of the area encompassing the geolocation coordinates."`
+	Geolocation *Geolocation `json:"geolocation,omitempty" description:"Contains the geolocation coordinates (latitude, longitude)."`
+}
+
+// nolint:lll
+type Geolocation struct {
+	Lat *float64 `json:"lat" validate:"required" description:"Latitude."`
+	Lon *float64 `json:"lon" validate:"required" description:"Longitude."`
+}
+
+// nolint:lll
+type Request struct {
+	IPChain []IPAddress `json:"ipChain,omitempty" description:"If the incoming request passes through any proxies, the IP addresses of those proxies are stored here in the format (clientIp, proxy1, proxy2, ...)."`
+}
+
+// nolint:lll
+type IPAddress struct {
+	IP                  *string              `json:"ip,omitempty" description:"IP address."`
+	GeographicalContext *GeographicalContext `json:"geographicalContext,omitempty" description:"Geographical context of the IP address."`
+	Version             *string              `json:"version,omitempty" description:"IP address version."`
+	Source              *string              `json:"source,omitempty" description:"Details regarding the source."`
+}
+
+// nolint:lll
+type Outcome struct {
+	Result *string `json:"result,omitempty" description:"Result of the action: SUCCESS, FAILURE, SKIPPED, ALLOW, DENY, CHALLENGE, UNKNOWN."`
+	Reason *string `json:"reason,omitempty" description:"Reason for the result, for example INVALID_CREDENTIALS."`
+}
+
+// nolint:lll
+type Transaction struct {
+	ID     *string              `json:"id,omitempty" description:"Unique identifier for this transaction."`
+	Type   *string              `json:"type,omitempty" description:"Describes the kind of transaction: WEB or JOB."`
+	Detail *jsoniter.RawMessage `json:"detail,omitempty" description:"Details for this transaction."`
+}
+
+// nolint:lll
+type DebugContext struct {
+	DebugData *jsoniter.RawMessage `json:"debugData,omitempty" description:"Dynamic field that contains miscellaneous information dependent on the event type."`
+}
+
+// nolint:lll
+type AuthenticationContext struct {
+	AuthenticationProvider *string  `json:"authenticationProvider,omitempty" description:"The system that proves the identity of an actor using the credentials provided to it."`
+	CredentialProvider     *string  `json:"credentialProvider,omitempty" description:"A credential provider is a software service that manages identities and their associated credentials."`
+	CredentialType         *string  `json:"credentialType,omitempty" description:"The underlying technology/scheme used in the credential."`
+	Issuer                 *Issuer  `json:"issuer,omitempty" description:"The specific software entity that created and issued the credential."`
+	Interface              *string  `json:"interface,omitempty" description:"The third party user interface that the actor authenticates through, if any."`
+	AuthenticationStep     *float64 `json:"authenticationStep,omitempty" description:"The zero-based step number in the authentication pipeline."`
+	ExternalSessionID      *string  `json:"externalSessionId,omitempty" description:"A proxy for the actor's session ID."`
+}
+
+// nolint:lll
+type Issuer struct {
+	ID   *string `json:"id,omitempty" description:"Varies depending on the type of authentication."`
+	Type *string `json:"type,omitempty" description:"Type of the issuer."`
+}
+
+// nolint:lll
+type SecurityContext struct {
+	AsNumber *float64 `json:"asNumber,omitempty" description:"Autonomous system number associated with the autonomous system that the event request was sourced to."`
+	AsOrg    *string  `json:"asOrg,omitempty" description:"Organization associated with the autonomous system that the event request was sourced to."`
+	ISP      *string  `json:"isp,omitempty" description:"Internet service provider used to sent the event's request."`
+	Domain   *string  `json:"domain,omitempty" description:"The domain name associated with the IP address of the inbound event request."`
+	IsProxy  *bool    `json:"isProxy,omitempty" description:"Specifies whether an event's request is from a known proxy."`
+}
+
+// SystemLogParser parses Okta System Log events
+type SystemLogParser struct{}
+
+func (p *SystemLogParser) New() parsers.LogParser {
+	return &SystemLogParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *SystemLogParser) Parse(log string) []*parsers.PantherLog {
+	event := &SystemLog{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *SystemLogParser) LogType() string {
+	return "Okta.SystemLog"
+}
+
+func (event *SystemLog) updatePantherFields(p *SystemLogParser) {
+	event.SetCoreFields(p.LogType(), event.Published, event)
+
+	if event.Client != nil {
+		event.AppendAnyIPAddressPtrs(event.Client.IPAddress)
+	}
+	if event.Request != nil {
+		for _, ip := range event.Request.IPChain {
+			event.AppendAnyIPAddressPtrs(ip.IP)
+		}
+	}
+	if event.SecurityContext != nil {
+		event.AppendAnyDomainNamePtrs(event.SecurityContext.Domain)
+	}
+	if event.Actor != nil {
+		event.appendUser(event.Actor)
+	}
+	for i := range event.Target {
+		event.appendUser(&event.Target[i])
+	}
+}
+
+// appendUser adds the login of actors and targets that are users
+func (event *SystemLog) appendUser(actor *Actor) {
+	if actor.Type != nil && *actor.Type == "User" {
+		event.AppendAnyUsernamePtrs(actor.AlternateID)
+	}
+}
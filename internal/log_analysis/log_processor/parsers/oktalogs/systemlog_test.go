@@ -0,0 +1,170 @@
+package oktalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSystemLogUserSessionStart(t *testing.T) {
+	//nolint
+	log := `{"actor":{"id":"00u1qw1mqitPHM8AJ0g7","type":"User","alternateId":"admin@example.com","displayName":"Admin User","detailEntry":null},"client":{"userAgent":{"rawUserAgent":"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.149 Safari/537.36","os":"Mac OS X","browser":"CHROME"},"zone":"null","device":"Computer","id":null,"ipAddress":"136.24.229.58","geographicalContext":{"city":"San Francisco","state":"California","country":"United States","postalCode":"94105","geolocation":{"lat":37.7852,"lon":-122.3874}}},"authenticationContext":{"authenticationProvider":null,"credentialProvider":null,"credentialType":null,"issuer":null,"interface":null,"authenticationStep":0,"externalSessionId":"102bZDNFfWaQSyEZQuDgWt-uQ"},"displayMessage":"User login to Okta","eventType":"user.session.start","outcome":{"result":"SUCCESS","reason":null},"published":"2020-04-07T18:16:20.123Z","securityContext":{"asNumber":701,"asOrg":"verizon","isp":"verizon","domain":"verizon.net","isProxy":false},"severity":"INFO","debugContext":{"debugData":{"requestUri":"/api/v1/authn"}},"legacyEventType":"core.user_auth.login_success","transaction":{"type":"WEB","id":"XozDFEABnt8wqpvQGp15uAAADCc","detail":{}},"uuid":"6ab4b1e4-78f5-11ea-9b7e-0d06b0a3bd94","version":"0","request":{"ipChain":[{"ip":"136.24.229.58","geographicalContext":{"city":"San Francisco","state":"California","country":"United States","postalCode":"94105","geolocation":{"lat":37.7852,"lon":-122.3874}},"version":"V4","source":null},{"ip":"10.0.0.1","version":"V4"}]},"target":[{"id":"0oa1qw1mqitPHM8AJ0g7","type":"AppInstance","alternateId":"Panther","displayName":"Panther","detailEntry":null}]}`
+
+	expectedTime := time.Date(2020, 4, 7, 18, 16, 20, int(123*time.Millisecond), time.UTC)
+	geographicalContext := &GeographicalContext{
+		City:       aws.String("San Francisco"),
+		State:      aws.String("California"),
+		Country:    aws.String("United States"),
+		PostalCode: aws.String("94105"),
+		Geolocation: &Geolocation{
+			Lat: aws.Float64(37.7852),
+			Lon: aws.Float64(-122.3874),
+		},
+	}
+	expectedEvent := &SystemLog{
+		UUID:            aws.String("6ab4b1e4-78f5-11ea-9b7e-0d06b0a3bd94"),
+		Published:       (*timestamp.RFC3339)(&expectedTime),
+		EventType:       aws.String("user.session.start"),
+		Version:         aws.String("0"),
+		Severity:        aws.String("INFO"),
+		LegacyEventType: aws.String("core.user_auth.login_success"),
+		DisplayMessage:  aws.String("User login to Okta"),
+		Actor: &Actor{
+			ID:          aws.String("00u1qw1mqitPHM8AJ0g7"),
+			Type:        aws.String("User"),
+			AlternateID: aws.String("admin@example.com"),
+			DisplayName: aws.String("Admin User"),
+		},
+		Client: &Client{
+			UserAgent: &UserAgent{
+				//nolint
+				RawUserAgent: aws.String("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.149 Safari/537.36"),
+				OS:           aws.String("Mac OS X"),
+				Browser:      aws.String("CHROME"),
+			},
+			Zone:                aws.String("null"),
+			Device:              aws.String("Computer"),
+			IPAddress:           aws.String("136.24.229.58"),
+			GeographicalContext: geographicalContext,
+		},
+		Request: &Request{
+			IPChain: []IPAddress{
+				{
+					IP:                  aws.String("136.24.229.58"),
+					GeographicalContext: geographicalContext,
+					Version:             aws.String("V4"),
+				},
+				{
+					IP:      aws.String("10.0.0.1"),
+					Version: aws.String("V4"),
+				},
+			},
+		},
+		Outcome: &Outcome{
+			Result: aws.String("SUCCESS"),
+		},
+		Target: []Actor{
+			{
+				ID:          aws.String("0oa1qw1mqitPHM8AJ0g7"),
+				Type:        aws.String("AppInstance"),
+				AlternateID: aws.String("Panther"),
+				DisplayName: aws.String("Panther"),
+			},
+		},
+		Transaction: &Transaction{
+			ID:     aws.String("XozDFEABnt8wqpvQGp15uAAADCc"),
+			Type:   aws.String("WEB"),
+			Detail: newRawMessage(`{}`),
+		},
+		DebugContext: &DebugContext{
+			DebugData: newRawMessage(`{"requestUri":"/api/v1/authn"}`),
+		},
+		AuthenticationContext: &AuthenticationContext{
+			AuthenticationStep: aws.Float64(0),
+			ExternalSessionID:  aws.String("102bZDNFfWaQSyEZQuDgWt-uQ"),
+		},
+		SecurityContext: &SecurityContext{
+			AsNumber: aws.Float64(701),
+			AsOrg:    aws.String("verizon"),
+			ISP:      aws.String("verizon"),
+			Domain:   aws.String("verizon.net"),
+			IsProxy:  aws.Bool(false),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Okta.SystemLog")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("136.24.229.58", "10.0.0.1")
+	expectedEvent.AppendAnyDomainNames("verizon.net")
+	expectedEvent.AppendAnyUsernames("admin@example.com")
+
+	checkSystemLog(t, log, expectedEvent)
+}
+
+func TestSystemLogMinimal(t *testing.T) {
+	//nolint
+	log := `{"uuid":"dc9fd3c0-598c-11ea-9b7e-0d06b0a3bd94","published":"2020-02-28T18:01:35.579Z","eventType":"system.org.rate_limit.warning","version":"0","severity":"WARN","displayMessage":"Rate limit warning"}`
+
+	expectedTime := time.Date(2020, 2, 28, 18, 1, 35, int(579*time.Millisecond), time.UTC)
+	expectedEvent := &SystemLog{
+		UUID:           aws.String("dc9fd3c0-598c-11ea-9b7e-0d06b0a3bd94"),
+		Published:      (*timestamp.RFC3339)(&expectedTime),
+		EventType:      aws.String("system.org.rate_limit.warning"),
+		Version:        aws.String("0"),
+		Severity:       aws.String("WARN"),
+		DisplayMessage: aws.String("Rate limit warning"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Okta.SystemLog")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+
+	checkSystemLog(t, log, expectedEvent)
+}
+
+func TestSystemLogMissingRequiredField(t *testing.T) {
+	log := `{"uuid":"dc9fd3c0-598c-11ea-9b7e-0d06b0a3bd94","eventType":"user.session.start"}`
+	parser := &SystemLogParser{}
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestSystemLogType(t *testing.T) {
+	parser := &SystemLogParser{}
+	require.Equal(t, "Okta.SystemLog", parser.LogType())
+}
+
+func checkSystemLog(t *testing.T, log string, expectedEvent *SystemLog) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &SystemLogParser{}
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func newRawMessage(jsonString string) *jsoniter.RawMessage {
+	rawMsg := (jsoniter.RawMessage)(jsonString)
+	return &rawMsg
+}
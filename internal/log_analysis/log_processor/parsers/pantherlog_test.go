@@ -202,3 +202,131 @@ func TestAppendAnyMD5Hashes(t *testing.T) {
 	event.AppendAnyMD5HashPtrs(&value)
 	require.Equal(t, expectedAny, event.PantherAnyMD5Hashes)
 }
+
+func TestAppendAnyAWSAccountIds(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyAWSAccountIds(value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSAccountIds)
+
+	event = PantherLog{}
+	event.AppendAnyAWSAccountIdPtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSAccountIds)
+}
+
+func TestAppendAnyAWSInstanceIds(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyAWSInstanceIds(value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSInstanceIds)
+
+	event = PantherLog{}
+	event.AppendAnyAWSInstanceIdPtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSInstanceIds)
+}
+
+func TestAppendAnyAWSARNs(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyAWSARNs(value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSARNs)
+
+	event = PantherLog{}
+	event.AppendAnyAWSARNPtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSARNs)
+}
+
+func TestAppendAnyAWSTags(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyAWSTags(value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSTags)
+
+	event = PantherLog{}
+	event.AppendAnyAWSTagPtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnyAWSTags)
+}
+
+func TestAppendAnySHA256Hashes(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnySHA256Hashes(value)
+	require.Equal(t, expectedAny, event.PantherAnySHA256Hashes)
+
+	event = PantherLog{}
+	event.AppendAnySHA256HashPtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnySHA256Hashes)
+}
+
+func TestAppendAnyUsernames(t *testing.T) {
+	event := PantherLog{}
+	value := "a"
+	expectedAny := &PantherAnyString{
+		set: map[string]struct{}{
+			value: {},
+		},
+	}
+	event.AppendAnyUsernames(value)
+	require.Equal(t, expectedAny, event.PantherAnyUsernames)
+
+	event = PantherLog{}
+	event.AppendAnyUsernamePtrs(&value)
+	require.Equal(t, expectedAny, event.PantherAnyUsernames)
+}
+
+func TestAppendAnyIPGeolocation(t *testing.T) {
+	event := PantherLog{}
+	event.AppendAnyIPCountries("US")
+	event.AppendAnyIPCities("Seattle")
+	event.AppendAnyIPASNs("16509")
+	require.Equal(t, []string{"US"}, event.PantherAnyIPCountries.Values())
+	require.Equal(t, []string{"Seattle"}, event.PantherAnyIPCities.Values())
+	require.Equal(t, []string{"16509"}, event.PantherAnyIPASNs.Values())
+}
+
+func TestAnyStringValues(t *testing.T) {
+	var any *PantherAnyString
+	require.Nil(t, any.Values())
+
+	any = NewPantherAnyString()
+	AppendAnyString(any, "b", "a", "b")
+	require.Equal(t, []string{"a", "b"}, any.Values())
+}
+
+func TestAppendMatchedIndicator(t *testing.T) {
+	event := PantherLog{}
+	event.AppendMatchedIndicator(ThreatIntelMatch{Indicator: "192.0.2.10", Type: "ip", Feed: "tor"})
+	event.AppendMatchedIndicator(ThreatIntelMatch{Indicator: "evil.example.com", Type: "domain", Feed: "botnet"})
+	event.AppendMatchedIndicator(ThreatIntelMatch{Indicator: "192.0.2.10", Type: "ip", Feed: "botnet"})
+	event.AppendMatchedIndicator(ThreatIntelMatch{Indicator: "192.0.2.10", Type: "ip", Feed: "tor"}) // duplicate
+	require.Equal(t, []ThreatIntelMatch{
+		{Indicator: "evil.example.com", Type: "domain", Feed: "botnet"},
+		{Indicator: "192.0.2.10", Type: "ip", Feed: "botnet"},
+		{Indicator: "192.0.2.10", Type: "ip", Feed: "tor"},
+	}, event.PantherMatchedIndicators)
+}
@@ -0,0 +1,83 @@
+package pantherlogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// UnclassifiedLogType is the log type of log lines that no parser could classify
+const UnclassifiedLogType = "Panther.Unclassified"
+
+var UnclassifiedDesc = `Unclassified contains the log lines that could not be classified by any of the parsers of their source.
+These can be used to find out what data is not being processed and to replay it after adding a parser.`
+
+// nolint:lll
+type Unclassified struct {
+	Line              *string  `json:"line" validate:"required" description:"The log line that could not be classified."`
+	LineNumber        *int64   `json:"lineNumber,omitempty" description:"The line number of the log line in the S3 object (starting from 1)."`
+	S3Bucket          *string  `json:"s3Bucket,omitempty" description:"The S3 bucket of the object the log line was read from."`
+	S3Key             *string  `json:"s3Key,omitempty" description:"The S3 key of the object the log line was read from."`
+	SourceID          *string  `json:"sourceId,omitempty" description:"The id of the source the log line was received from."`
+	SourceLabel       *string  `json:"sourceLabel,omitempty" description:"The label of the source the log line was received from."`
+	AttemptedLogTypes []string `json:"attemptedLogTypes,omitempty" description:"The log types of the parsers that failed to parse the log line."`
+	LogGroup          *string  `json:"logGroup,omitempty" description:"The CloudWatch log group the log line was received from."`
+	LogStream         *string  `json:"logStream,omitempty" description:"The CloudWatch log stream the log line was received from."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// UnclassifiedSource describes where the unclassified log lines were read from
+type UnclassifiedSource struct {
+	S3Bucket    string
+	S3Key       string
+	SourceID    string
+	SourceLabel string
+	LogGroup    string
+	LogStream   string
+}
+
+// NewUnclassified returns the event stored for a log line that could not be classified, the event time is the parse time.
+func NewUnclassified(line string, lineNumber uint64, source *UnclassifiedSource, attemptedLogTypes []string) *parsers.PantherLog {
+	event := &Unclassified{
+		Line:              aws.String(line),
+		LineNumber:        aws.Int64(int64(lineNumber)),
+		AttemptedLogTypes: attemptedLogTypes,
+	}
+	if source != nil {
+		event.S3Bucket = optionalString(source.S3Bucket)
+		event.S3Key = optionalString(source.S3Key)
+		event.SourceID = optionalString(source.SourceID)
+		event.SourceLabel = optionalString(source.SourceLabel)
+		event.LogGroup = optionalString(source.LogGroup)
+		event.LogStream = optionalString(source.LogStream)
+	}
+	event.SetCoreFields(UnclassifiedLogType, nil, event)
+	return event.Log()
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return aws.String(value)
+}
@@ -0,0 +1,52 @@
+package pantherlogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestNewUnclassified(t *testing.T) {
+	source := &UnclassifiedSource{
+		S3Bucket: "bucket",
+		S3Key:    "key",
+		SourceID: "45be7365-688f-4c6f-a4da-803be356e3c7",
+	}
+	events := NewUnclassified("not a log", 3, source, []string{"AWS.VPCFlow", "AWS.ALB"})
+
+	expectedEvent := &Unclassified{
+		Line:              aws.String("not a log"),
+		LineNumber:        aws.Int64(3),
+		S3Bucket:          aws.String("bucket"),
+		S3Key:             aws.String("key"),
+		SourceID:          aws.String("45be7365-688f-4c6f-a4da-803be356e3c7"),
+		AttemptedLogTypes: []string{"AWS.VPCFlow", "AWS.ALB"},
+	}
+	expectedEvent.PantherLogType = aws.String(UnclassifiedLogType)
+	expectedEvent.SetEvent(expectedEvent)
+
+	testutil.EqualPantherLog(t, expectedEvent.Log(), []*parsers.PantherLog{events})
+	require.NoError(t, parsers.Validator.Struct(events.Event()))
+}
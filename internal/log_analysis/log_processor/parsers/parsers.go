@@ -18,7 +18,22 @@ package parsers
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-import "gopkg.in/go-playground/validator.v9"
+import (
+	"io"
+	"regexp"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// The strategies used to split a stream into the records passed to LogParser.Parse
+const (
+	// FramingLine records are single lines, this is the default
+	FramingLine = "line"
+	// FramingJSON records are JSON values which may span many lines (e.g. pretty-printed JSON)
+	FramingJSON = "json"
+	// FramingRegex records start with a line matching a pattern, the lines that follow are part of the record (e.g. stack traces)
+	FramingRegex = "regex"
+)
 
 // LogParser represents a parser for a supported log type
 type LogParser interface {
@@ -33,5 +48,53 @@ type LogParser interface {
 	New() LogParser
 }
 
+// StreamParser is implemented by parsers of "document" logs (e.g. CloudTrail) where a single JSON value
+// holds many records. These files can be very large so instead of reading them line by line into memory,
+// the records are decoded incrementally from the stream.
+type StreamParser interface {
+	LogParser
+
+	// CanParseStream returns true if the header (first bytes) of a stream is of the supported type
+	CanParseStream(header []byte) bool
+
+	// ParseStream reads all records from the stream and calls emit with the raw record and its parsed events.
+	// As with Parse, the events are nil if a record is not of the supported type.
+	// An error is returned if the stream cannot be read or decoded.
+	ParseStream(stream io.Reader, emit func(record []byte, events []*PantherLog)) error
+}
+
+// Framing describes how the records of a log type are delimited in a stream
+type Framing struct {
+	Strategy string
+	// RecordStart matches the first line of each record, only used by FramingRegex
+	RecordStart *regexp.Regexp
+}
+
+// Equal returns true if both framings split a stream in the same records
+func (f Framing) Equal(other Framing) bool {
+	if f.Strategy != other.Strategy {
+		return false
+	}
+	if f.RecordStart == nil || other.RecordStart == nil {
+		return f.RecordStart == other.RecordStart
+	}
+	return f.RecordStart.String() == other.RecordStart.String()
+}
+
+// FramedParser is implemented by parsers of logs whose records may span many lines.
+// The processor aggregates the lines of each record according to the framing before calling Parse.
+type FramedParser interface {
+	LogParser
+
+	// Framing returns how the records of the log type are delimited
+	Framing() Framing
+}
+
+// TimestampFailureCounter is implemented by parsers that count the timestamps they fail to parse,
+// the classifier adds them to the stats of the parser
+type TimestampFailureCounter interface {
+	TimestampFailureCount() uint64
+}
+
 // Validator can be used to validate schemas of log fields
 var Validator = validator.New()
@@ -52,6 +52,45 @@ type PantherLog struct {
 	PantherAnyDomainNames *PantherAnyString `json:"p_any_domain_names,omitempty" description:"Panther added field with collection of domain names associated with the row"`
 	PantherAnySHA1Hashes  *PantherAnyString `json:"p_any_sha1_hashes,omitempty" description:"Panther added field with collection of SHA1 hashes associated with the row"`
 	PantherAnyMD5Hashes   *PantherAnyString `json:"p_any_md5_hashes,omitempty" description:"Panther added field with collection of MD5 hashes associated with the row"`
+
+	// NOTE: the AWS fields were declared by awslogs.AWSPantherLog, they keep the same order so the AWS tables are unchanged
+	PantherAnyAWSAccountIds  *PantherAnyString `json:"p_any_aws_account_ids,omitempty" description:"Panther added field with collection of aws account ids associated with the row"`
+	PantherAnyAWSInstanceIds *PantherAnyString `json:"p_any_aws_instance_ids,omitempty" description:"Panther added field with collection of aws instance ids associated with the row"`
+	PantherAnyAWSARNs        *PantherAnyString `json:"p_any_aws_arns,omitempty" description:"Panther added field with collection of aws arns associated with the row"`
+	PantherAnyAWSTags        *PantherAnyString `json:"p_any_aws_tags,omitempty" description:"Panther added field with collection of aws tags associated with the row"`
+
+	PantherAnySHA256Hashes *PantherAnyString `json:"p_any_sha256_hashes,omitempty" description:"Panther added field with collection of SHA256 hashes associated with the row"`
+	PantherAnyUsernames    *PantherAnyString `json:"p_any_usernames,omitempty" description:"Panther added field with collection of usernames and emails associated with the row"`
+
+	// NOTE: the geolocation fields are set by the enrichment stage of the log processor, not by the parsers
+	PantherAnyIPCountries *PantherAnyString `json:"p_any_ip_countries,omitempty" description:"Panther added field with collection of ISO country codes of the ip addresses associated with the row"`
+	PantherAnyIPCities    *PantherAnyString `json:"p_any_ip_cities,omitempty" description:"Panther added field with collection of cities of the ip addresses associated with the row"`
+	PantherAnyIPASNs      *PantherAnyString `json:"p_any_ip_asns,omitempty" description:"Panther added field with collection of autonomous system numbers of the ip addresses associated with the row"`
+
+	// NOTE: set by the enrichment stage of the log processor from the threat intelligence feeds
+	PantherMatchedIndicators []ThreatIntelMatch `json:"p_matched_indicators,omitempty" description:"Panther added field with the threat intelligence indicators matched by the any fields of the row and their feeds"`
+
+	// NOTE: set by the log processor for the events read from CloudWatch Logs
+	PantherLogGroup  *string `json:"p_log_group,omitempty" description:"Panther added field with the CloudWatch Logs log group the row was read from"`
+	PantherLogStream *string `json:"p_log_stream,omitempty" description:"Panther added field with the CloudWatch Logs log stream the row was read from"`
+
+	// NOTE: set by the enrichment stage of the log processor, unlike the p_any_ip_* fields it keeps each location with its ip address
+	PantherIPGeolocations []IPGeolocation `json:"p_ip_geolocations,omitempty" description:"Panther added field with the geolocation of each ip address associated with the row"`
+}
+
+// ThreatIntelMatch is an indicator of a threat intelligence feed found in the any fields of a row
+type ThreatIntelMatch struct {
+	Indicator string `json:"indicator" description:"The value of the indicator"`
+	Type      string `json:"type" description:"The type of the indicator (ip, domain, sha1, md5 or sha256)"`
+	Feed      string `json:"feed" description:"The name of the threat intelligence feed of the indicator"`
+}
+
+// IPGeolocation is the geolocation of an ip address of a row
+type IPGeolocation struct {
+	IP      string `json:"ip" description:"The ip address"`
+	Country string `json:"country,omitempty" description:"The ISO country code of the ip address"`
+	City    string `json:"city,omitempty" description:"The city of the ip address"`
+	ASN     string `json:"asn,omitempty" description:"The autonomous system number of the ip address"`
 }
 
 type PantherAnyString struct { // needed to declare as struct (rather than map) for CF generation
@@ -65,19 +104,27 @@ func NewPantherAnyString() *PantherAnyString {
 }
 
 func (any *PantherAnyString) MarshalJSON() ([]byte, error) {
-	if any != nil { // copy to slice
-		values := make([]string, len(any.set))
-		i := 0
-		for k := range any.set {
-			values[i] = k
-			i++
-		}
-		sort.Strings(values) // sort for consistency and to improve compression when stored
-		return jsoniter.Marshal(values)
+	if any != nil {
+		return jsoniter.Marshal(any.Values())
 	}
 	return []byte{}, nil
 }
 
+// Values returns the values of the set as a sorted slice
+func (any *PantherAnyString) Values() []string {
+	if any == nil {
+		return nil
+	}
+	values := make([]string, len(any.set))
+	i := 0
+	for k := range any.set {
+		values[i] = k
+		i++
+	}
+	sort.Strings(values) // sort for consistency and to improve compression when stored
+	return values
+}
+
 func (any *PantherAnyString) UnmarshalJSON(jsonBytes []byte) error {
 	var values []string
 	err := jsoniter.Unmarshal(jsonBytes, &values)
@@ -185,6 +232,141 @@ func (pl *PantherLog) AppendAnyMD5Hashes(values ...string) {
 	AppendAnyString(pl.PantherAnyMD5Hashes, values...)
 }
 
+func (pl *PantherLog) AppendAnyAWSAccountIdPtrs(values ...*string) { // nolint
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyAWSAccountIds(*value)
+		}
+	}
+}
+
+func (pl *PantherLog) AppendAnyAWSAccountIds(values ...string) {
+	if pl.PantherAnyAWSAccountIds == nil { // lazy create
+		pl.PantherAnyAWSAccountIds = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyAWSAccountIds, values...)
+}
+
+func (pl *PantherLog) AppendAnyAWSInstanceIdPtrs(values ...*string) { // nolint
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyAWSInstanceIds(*value)
+		}
+	}
+}
+
+func (pl *PantherLog) AppendAnyAWSInstanceIds(values ...string) {
+	if pl.PantherAnyAWSInstanceIds == nil { // lazy create
+		pl.PantherAnyAWSInstanceIds = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyAWSInstanceIds, values...)
+}
+
+func (pl *PantherLog) AppendAnyAWSARNPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyAWSARNs(*value)
+		}
+	}
+}
+
+func (pl *PantherLog) AppendAnyAWSARNs(values ...string) {
+	if pl.PantherAnyAWSARNs == nil { // lazy create
+		pl.PantherAnyAWSARNs = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyAWSARNs, values...)
+}
+
+func (pl *PantherLog) AppendAnyAWSTagPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyAWSTags(*value)
+		}
+	}
+}
+
+// NOTE: value should be of the form <key>:<value>
+func (pl *PantherLog) AppendAnyAWSTags(values ...string) {
+	if pl.PantherAnyAWSTags == nil { // lazy create
+		pl.PantherAnyAWSTags = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyAWSTags, values...)
+}
+
+func (pl *PantherLog) AppendAnySHA256HashPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnySHA256Hashes(*value)
+		}
+	}
+}
+
+func (pl *PantherLog) AppendAnySHA256Hashes(values ...string) {
+	if pl.PantherAnySHA256Hashes == nil { // lazy create
+		pl.PantherAnySHA256Hashes = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnySHA256Hashes, values...)
+}
+
+func (pl *PantherLog) AppendAnyUsernamePtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyUsernames(*value)
+		}
+	}
+}
+
+func (pl *PantherLog) AppendAnyUsernames(values ...string) {
+	if pl.PantherAnyUsernames == nil { // lazy create
+		pl.PantherAnyUsernames = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyUsernames, values...)
+}
+
+func (pl *PantherLog) AppendAnyIPCountries(values ...string) {
+	if pl.PantherAnyIPCountries == nil { // lazy create
+		pl.PantherAnyIPCountries = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyIPCountries, values...)
+}
+
+func (pl *PantherLog) AppendAnyIPCities(values ...string) {
+	if pl.PantherAnyIPCities == nil { // lazy create
+		pl.PantherAnyIPCities = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyIPCities, values...)
+}
+
+func (pl *PantherLog) AppendAnyIPASNs(values ...string) {
+	if pl.PantherAnyIPASNs == nil { // lazy create
+		pl.PantherAnyIPASNs = NewPantherAnyString()
+	}
+	AppendAnyString(pl.PantherAnyIPASNs, values...)
+}
+
+// AppendMatchedIndicator adds a threat intelligence match, keeping the matches unique and sorted
+func (pl *PantherLog) AppendMatchedIndicator(match ThreatIntelMatch) {
+	i := sort.Search(len(pl.PantherMatchedIndicators), func(i int) bool {
+		return !lessThreatIntelMatch(pl.PantherMatchedIndicators[i], match)
+	})
+	if i < len(pl.PantherMatchedIndicators) && pl.PantherMatchedIndicators[i] == match {
+		return
+	}
+	pl.PantherMatchedIndicators = append(pl.PantherMatchedIndicators, ThreatIntelMatch{})
+	copy(pl.PantherMatchedIndicators[i+1:], pl.PantherMatchedIndicators[i:])
+	pl.PantherMatchedIndicators[i] = match
+}
+
+func lessThreatIntelMatch(a, b ThreatIntelMatch) bool {
+	if a.Feed != b.Feed {
+		return a.Feed < b.Feed
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	return a.Indicator < b.Indicator
+}
+
 func AppendAnyString(any *PantherAnyString, values ...string) {
 	// add new if not present
 	for _, v := range values {
@@ -0,0 +1,97 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var AlertDesc = `Suricata alert events are raised when a packet matches a signature.
+Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html#event-type-alert`
+
+// nolint:lll
+type Alert struct {
+	EventHeader
+	EventType        *string              `json:"event_type" validate:"required,eq=alert" description:"The type of the event, always alert."`
+	Alert            *AlertDetails        `json:"alert" validate:"required" description:"The signature that matched."`
+	Flow             *FlowDetails         `json:"flow,omitempty" description:"The flow of the packet, if enabled."`
+	HTTP             *HTTPDetails         `json:"http,omitempty" description:"The HTTP transaction of the packet, if enabled."`
+	TLS              *TLSDetails          `json:"tls,omitempty" description:"The TLS session of the packet, if enabled."`
+	Payload          *string              `json:"payload,omitempty" description:"The payload of the packet (base64), if enabled."`
+	PayloadPrintable *string              `json:"payload_printable,omitempty" description:"The printable characters of the payload of the packet, if enabled."`
+	Packet           *string              `json:"packet,omitempty" description:"The packet (base64), if enabled."`
+	PacketInfo       *jsoniter.RawMessage `json:"packet_info,omitempty" description:"Details of the packet, e.g. its link type."`
+	Stream           *int                 `json:"stream,omitempty" description:"Whether the payload is from a reassembled stream rather than a single packet."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type AlertDetails struct {
+	Action      *string              `json:"action,omitempty" description:"The action taken, allowed or blocked."`
+	GID         *int64               `json:"gid,omitempty" description:"The generator ID of the signature."`
+	SignatureID *int64               `json:"signature_id,omitempty" description:"The ID of the signature."`
+	Rev         *int64               `json:"rev,omitempty" description:"The revision of the signature."`
+	Signature   *string              `json:"signature,omitempty" description:"The message of the signature."`
+	Category    *string              `json:"category,omitempty" description:"The classification of the signature."`
+	Severity    *int                 `json:"severity,omitempty" description:"The priority of the classification, 1 is the highest."`
+	Metadata    *jsoniter.RawMessage `json:"metadata,omitempty" description:"The metadata keywords of the signature."`
+}
+
+// AlertParser parses Suricata alert events
+type AlertParser struct{}
+
+func (p *AlertParser) New() parsers.LogParser {
+	return &AlertParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *AlertParser) Parse(log string) []*parsers.PantherLog {
+	event := &Alert{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *AlertParser) LogType() string {
+	return "Suricata.Alert"
+}
+
+func (event *Alert) updatePantherFields(p *AlertParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.EventHeader.appendAnyFields(&event.PantherLog)
+	event.HTTP.appendAnyFields(&event.PantherLog)
+	event.TLS.appendAnyFields(&event.PantherLog)
+}
@@ -0,0 +1,112 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestAlert(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.123456+0000","flow_id":1805461738637437,"in_iface":"eth0","event_type":"alert","src_ip":"10.0.0.5","src_port":52345,"dest_ip":"93.184.216.34","dest_port":80,"proto":"TCP","tx_id":0,"alert":{"action":"allowed","gid":1,"signature_id":2013028,"rev":7,"signature":"ET POLICY curl User-Agent Outbound","category":"Attempted Information Leak","severity":2,"metadata":{"created_at":["2011_06_14"]}},"http":{"hostname":"www.example.com","url":"/index.html","http_user_agent":"curl/7.64.1","http_method":"GET","protocol":"HTTP/1.1","length":0},"app_proto":"http","flow":{"pkts_toserver":4,"pkts_toclient":3,"bytes_toserver":351,"bytes_toclient":1840,"start":"2020-05-12T10:00:00.012345+0000"}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 123456000, time.UTC)
+	expectedStart := time.Date(2020, 5, 12, 10, 0, 0, 12345000, time.UTC)
+	expectedEvent := &Alert{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(1805461738637437),
+			InIface:   aws.String("eth0"),
+			SrcIP:     aws.String("10.0.0.5"),
+			SrcPort:   aws.Int(52345),
+			DestIP:    aws.String("93.184.216.34"),
+			DestPort:  aws.Int(80),
+			Proto:     aws.String("TCP"),
+			AppProto:  aws.String("http"),
+			TxID:      aws.Int64(0),
+		},
+		EventType: aws.String("alert"),
+		Alert: &AlertDetails{
+			Action:      aws.String("allowed"),
+			GID:         aws.Int64(1),
+			SignatureID: aws.Int64(2013028),
+			Rev:         aws.Int64(7),
+			Signature:   aws.String("ET POLICY curl User-Agent Outbound"),
+			Category:    aws.String("Attempted Information Leak"),
+			Severity:    aws.Int(2),
+			Metadata:    newRawMessage(`{"created_at":["2011_06_14"]}`),
+		},
+		HTTP: &HTTPDetails{
+			Hostname:      aws.String("www.example.com"),
+			URL:           aws.String("/index.html"),
+			HTTPUserAgent: aws.String("curl/7.64.1"),
+			HTTPMethod:    aws.String("GET"),
+			Protocol:      aws.String("HTTP/1.1"),
+			Length:        aws.Int64(0),
+		},
+		Flow: &FlowDetails{
+			PktsToServer:  aws.Int64(4),
+			PktsToClient:  aws.Int64(3),
+			BytesToServer: aws.Int64(351),
+			BytesToClient: aws.Int64(1840),
+			Start:         (*timestamp.SuricataTimestamp)(&expectedStart),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.Alert")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&AlertParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func TestAlertOtherEventType(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.123456+0000","flow_id":1805461738637437,"event_type":"flow","src_ip":"10.0.0.5","src_port":52345,"dest_ip":"93.184.216.34","dest_port":80,"proto":"TCP","flow":{"pkts_toserver":4}}`
+	parser := (&AlertParser{}).New()
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestAlertMissingTimestamp(t *testing.T) {
+	log := `{"event_type":"alert","src_ip":"10.0.0.5","alert":{"signature_id":2013028}}`
+	parser := (&AlertParser{}).New()
+	require.Nil(t, parser.Parse(log))
+}
+
+func TestAlertType(t *testing.T) {
+	parser := &AlertParser{}
+	require.Equal(t, "Suricata.Alert", parser.LogType())
+}
+
+func newRawMessage(jsonString string) *jsoniter.RawMessage {
+	rawMsg := (jsoniter.RawMessage)(jsonString)
+	return &rawMsg
+}
@@ -0,0 +1,133 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var DNSDesc = `Suricata dns events record DNS queries and answers.
+Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html#event-type-dns`
+
+// nolint:lll
+type DNS struct {
+	EventHeader
+	EventType *string     `json:"event_type" validate:"required,eq=dns" description:"The type of the event, always dns."`
+	DNS       *DNSDetails `json:"dns" validate:"required" description:"The DNS query or answer."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type DNSDetails struct {
+	Version     *int                 `json:"version,omitempty" description:"The version of the DNS log format."`
+	Type        *string              `json:"type,omitempty" description:"The type of the message, query or answer."`
+	ID          *int                 `json:"id,omitempty" description:"The ID of the DNS transaction."`
+	Flags       *string              `json:"flags,omitempty" description:"The flags of the answer (hexadecimal)."`
+	QR          *bool                `json:"qr,omitempty" description:"Whether the message is a response."`
+	AA          *bool                `json:"aa,omitempty" description:"Whether the answer is authoritative."`
+	TC          *bool                `json:"tc,omitempty" description:"Whether the message was truncated."`
+	RD          *bool                `json:"rd,omitempty" description:"Whether recursion was desired."`
+	RA          *bool                `json:"ra,omitempty" description:"Whether recursion was available."`
+	Z           *bool                `json:"z,omitempty" description:"The reserved bit of the message."`
+	RRName      *string              `json:"rrname,omitempty" description:"The name of the query."`
+	RRType      *string              `json:"rrtype,omitempty" description:"The type of the query (e.g. A, AAAA, CNAME)."`
+	RCode       *string              `json:"rcode,omitempty" description:"The response code of the answer (e.g. NOERROR, NXDOMAIN)."`
+	TTL         *int64               `json:"ttl,omitempty" description:"The time to live of the answer, for version 1 answers."`
+	RData       *string              `json:"rdata,omitempty" description:"The data of the answer, for version 1 answers."`
+	TxID        *int64               `json:"tx_id,omitempty" description:"The ID of the DNS transaction in the flow."`
+	Answers     []DNSAnswer          `json:"answers,omitempty" description:"The records of the answer, in the detailed format."`
+	Grouped     *jsoniter.RawMessage `json:"grouped,omitempty" description:"The data of the records of the answer grouped by type, in the grouped format."`
+	Authorities []DNSAnswer          `json:"authorities,omitempty" description:"The authority records of the answer."`
+}
+
+// nolint:lll
+type DNSAnswer struct {
+	RRName *string `json:"rrname,omitempty" description:"The name of the record."`
+	RRType *string `json:"rrtype,omitempty" description:"The type of the record."`
+	TTL    *int64  `json:"ttl,omitempty" description:"The time to live of the record."`
+	RData  *string `json:"rdata,omitempty" description:"The data of the record."`
+}
+
+// DNSParser parses Suricata dns events
+type DNSParser struct{}
+
+func (p *DNSParser) New() parsers.LogParser {
+	return &DNSParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *DNSParser) Parse(log string) []*parsers.PantherLog {
+	event := &DNS{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *DNSParser) LogType() string {
+	return "Suricata.DNS"
+}
+
+func (event *DNS) updatePantherFields(p *DNSParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.EventHeader.appendAnyFields(&event.PantherLog)
+	if event.DNS == nil {
+		return
+	}
+	event.AppendAnyDomainNamePtrs(event.DNS.RRName)
+	event.appendRData(event.DNS.RRType, event.DNS.RData)
+	for _, answer := range event.DNS.Answers {
+		event.AppendAnyDomainNamePtrs(answer.RRName)
+		event.appendRData(answer.RRType, answer.RData)
+	}
+}
+
+// appendRData adds the address or name of the data of a record
+func (event *DNS) appendRData(rrType, rData *string) {
+	if rData == nil {
+		return
+	}
+	if net.ParseIP(*rData) != nil {
+		event.AppendAnyIPAddresses(*rData)
+		return
+	}
+	switch aws.StringValue(rrType) {
+	case "CNAME", "PTR", "NS", "MX":
+		event.AppendAnyDomainNames(*rData)
+	}
+}
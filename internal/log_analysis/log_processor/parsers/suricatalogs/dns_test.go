@@ -0,0 +1,135 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestDNSQuery(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.000001+0000","flow_id":952063413215393,"in_iface":"eth0","event_type":"dns","src_ip":"10.0.0.5","src_port":52346,"dest_ip":"10.0.0.1","dest_port":53,"proto":"UDP","dns":{"type":"query","id":48213,"rrname":"www.example.com","rrtype":"A","tx_id":0}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 1000, time.UTC)
+	expectedEvent := &DNS{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(952063413215393),
+			InIface:   aws.String("eth0"),
+			SrcIP:     aws.String("10.0.0.5"),
+			SrcPort:   aws.Int(52346),
+			DestIP:    aws.String("10.0.0.1"),
+			DestPort:  aws.Int(53),
+			Proto:     aws.String("UDP"),
+		},
+		EventType: aws.String("dns"),
+		DNS: &DNSDetails{
+			Type:   aws.String("query"),
+			ID:     aws.Int(48213),
+			RRName: aws.String("www.example.com"),
+			RRType: aws.String("A"),
+			TxID:   aws.Int64(0),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.DNS")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "10.0.0.1")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	checkDNS(t, log, expectedEvent)
+}
+
+func TestDNSAnswer(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.012346+0000","flow_id":952063413215393,"event_type":"dns","src_ip":"10.0.0.1","src_port":53,"dest_ip":"10.0.0.5","dest_port":52346,"proto":"UDP","dns":{"version":2,"type":"answer","id":48213,"flags":"8180","qr":true,"rd":true,"ra":true,"rrname":"www.example.com","rrtype":"A","rcode":"NOERROR","answers":[{"rrname":"www.example.com","rrtype":"CNAME","ttl":60,"rdata":"example.com"},{"rrname":"example.com","rrtype":"A","ttl":3600,"rdata":"93.184.216.34"},{"rrname":"example.com","rrtype":"TXT","ttl":300,"rdata":"v=spf1 -all"}]}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 12346000, time.UTC)
+	expectedEvent := &DNS{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(952063413215393),
+			SrcIP:     aws.String("10.0.0.1"),
+			SrcPort:   aws.Int(53),
+			DestIP:    aws.String("10.0.0.5"),
+			DestPort:  aws.Int(52346),
+			Proto:     aws.String("UDP"),
+		},
+		EventType: aws.String("dns"),
+		DNS: &DNSDetails{
+			Version: aws.Int(2),
+			Type:    aws.String("answer"),
+			ID:      aws.Int(48213),
+			Flags:   aws.String("8180"),
+			QR:      aws.Bool(true),
+			RD:      aws.Bool(true),
+			RA:      aws.Bool(true),
+			RRName:  aws.String("www.example.com"),
+			RRType:  aws.String("A"),
+			RCode:   aws.String("NOERROR"),
+			Answers: []DNSAnswer{
+				{
+					RRName: aws.String("www.example.com"),
+					RRType: aws.String("CNAME"),
+					TTL:    aws.Int64(60),
+					RData:  aws.String("example.com"),
+				},
+				{
+					RRName: aws.String("example.com"),
+					RRType: aws.String("A"),
+					TTL:    aws.Int64(3600),
+					RData:  aws.String("93.184.216.34"),
+				},
+				{
+					RRName: aws.String("example.com"),
+					RRType: aws.String("TXT"),
+					TTL:    aws.Int64(300),
+					RData:  aws.String("v=spf1 -all"),
+				},
+			},
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.DNS")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.1", "10.0.0.5", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com", "example.com")
+
+	checkDNS(t, log, expectedEvent)
+}
+
+func TestDNSType(t *testing.T) {
+	parser := &DNSParser{}
+	require.Equal(t, "Suricata.DNS", parser.LogType())
+}
+
+func checkDNS(t *testing.T, log string, expectedEvent *DNS) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&DNSParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
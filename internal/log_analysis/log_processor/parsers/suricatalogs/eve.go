@@ -0,0 +1,119 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// Suricata writes all its events to eve.json, the type of an event is in its event_type field.
+// Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html
+
+// nolint:lll
+type EventHeader struct {
+	Timestamp    *timestamp.SuricataTimestamp `json:"timestamp" validate:"required" description:"The time of the event."`
+	FlowID       *int64                       `json:"flow_id,omitempty" description:"The ID of the flow the event belongs to, all the events of a flow have the same ID."`
+	PcapCnt      *int64                       `json:"pcap_cnt,omitempty" description:"The number of the packet that triggered the event, in the order the packets were read."`
+	PcapFilename *string                      `json:"pcap_filename,omitempty" description:"The pcap file the event was read from, when reading pcap files."`
+	InIface      *string                      `json:"in_iface,omitempty" description:"The interface the packet was captured on."`
+	VLAN         []int                        `json:"vlan,omitempty" description:"The VLAN tags of the packet."`
+	SrcIP        *string                      `json:"src_ip,omitempty" description:"The source IP address."`
+	SrcPort      *int                         `json:"src_port,omitempty" validate:"omitempty,min=0,max=65535" description:"The source port."`
+	DestIP       *string                      `json:"dest_ip,omitempty" description:"The destination IP address."`
+	DestPort     *int                         `json:"dest_port,omitempty" validate:"omitempty,min=0,max=65535" description:"The destination port."`
+	Proto        *string                      `json:"proto,omitempty" description:"The transport protocol (e.g. TCP, UDP)."`
+	AppProto     *string                      `json:"app_proto,omitempty" description:"The application protocol detected in the flow."`
+	TxID         *int64                       `json:"tx_id,omitempty" description:"The ID of the application layer transaction in the flow."`
+	CommunityID  *string                      `json:"community_id,omitempty" description:"The Community ID flow hash, if enabled."`
+	Host         *string                      `json:"host,omitempty" description:"The sensor name, if configured."`
+}
+
+// nolint:lll
+type FlowDetails struct {
+	PktsToServer  *int64                       `json:"pkts_toserver,omitempty" description:"The number of packets sent to the server."`
+	PktsToClient  *int64                       `json:"pkts_toclient,omitempty" description:"The number of packets sent to the client."`
+	BytesToServer *int64                       `json:"bytes_toserver,omitempty" description:"The number of bytes sent to the server."`
+	BytesToClient *int64                       `json:"bytes_toclient,omitempty" description:"The number of bytes sent to the client."`
+	Start         *timestamp.SuricataTimestamp `json:"start,omitempty" description:"The time of the first packet of the flow."`
+	End           *timestamp.SuricataTimestamp `json:"end,omitempty" description:"The time of the last packet of the flow."`
+	Age           *int64                       `json:"age,omitempty" description:"The duration of the flow in seconds."`
+	State         *string                      `json:"state,omitempty" description:"The state of the flow (e.g. new, established, closed)."`
+	Reason        *string                      `json:"reason,omitempty" description:"The reason the flow was logged (e.g. timeout, shutdown)."`
+	Alerted       *bool                        `json:"alerted,omitempty" description:"Whether alerts were raised in the flow."`
+}
+
+// nolint:lll
+type HTTPDetails struct {
+	Hostname        *string              `json:"hostname,omitempty" description:"The hostname of the request."`
+	URL             *string              `json:"url,omitempty" description:"The URL of the request."`
+	HTTPUserAgent   *string              `json:"http_user_agent,omitempty" description:"The User-Agent header of the request."`
+	HTTPContentType *string              `json:"http_content_type,omitempty" description:"The Content-Type header of the response."`
+	HTTPRefer       *string              `json:"http_refer,omitempty" description:"The Referer header of the request."`
+	HTTPMethod      *string              `json:"http_method,omitempty" description:"The method of the request."`
+	Protocol        *string              `json:"protocol,omitempty" description:"The protocol version (e.g. HTTP/1.1)."`
+	Status          *int                 `json:"status,omitempty" description:"The status code of the response."`
+	Length          *int64               `json:"length,omitempty" description:"The length of the response body."`
+	Redirect        *string              `json:"redirect,omitempty" description:"The Location header of the response, if it is a redirect."`
+	HTTPPort        *int                 `json:"http_port,omitempty" description:"The port of the Host header, if any."`
+	XFF             *string              `json:"xff,omitempty" description:"The X-Forwarded-For header of the request, if enabled."`
+	RequestHeaders  *jsoniter.RawMessage `json:"request_headers,omitempty" description:"The headers of the request, if enabled."`
+	ResponseHeaders *jsoniter.RawMessage `json:"response_headers,omitempty" description:"The headers of the response, if enabled."`
+}
+
+// nolint:lll
+type TLSDetails struct {
+	Subject        *string  `json:"subject,omitempty" description:"The subject of the certificate of the server."`
+	IssuerDN       *string  `json:"issuerdn,omitempty" description:"The issuer of the certificate of the server."`
+	Serial         *string  `json:"serial,omitempty" description:"The serial number of the certificate of the server."`
+	Fingerprint    *string  `json:"fingerprint,omitempty" description:"The SHA1 fingerprint of the certificate of the server."`
+	SNI            *string  `json:"sni,omitempty" description:"The Server Name Indication requested by the client."`
+	Version        *string  `json:"version,omitempty" description:"The TLS version of the session."`
+	NotBefore      *string  `json:"notbefore,omitempty" description:"The start of the validity of the certificate of the server."`
+	NotAfter       *string  `json:"notafter,omitempty" description:"The end of the validity of the certificate of the server."`
+	SessionResumed *bool    `json:"session_resumed,omitempty" description:"Whether the session was resumed."`
+	JA3            *JA3     `json:"ja3,omitempty" description:"The JA3 fingerprint of the client, if enabled."`
+	JA3S           *JA3     `json:"ja3s,omitempty" description:"The JA3S fingerprint of the server, if enabled."`
+	Certificate    *string  `json:"certificate,omitempty" description:"The certificate of the server (base64), if enabled."`
+	Chain          []string `json:"chain,omitempty" description:"The certificate chain of the server (base64), if enabled."`
+}
+
+// nolint:lll
+type JA3 struct {
+	Hash   *string `json:"hash,omitempty" description:"The MD5 hash of the fingerprint."`
+	String *string `json:"string,omitempty" description:"The fingerprint."`
+}
+
+func (header *EventHeader) appendAnyFields(event *parsers.PantherLog) {
+	event.AppendAnyIPAddressPtrs(header.SrcIP, header.DestIP)
+}
+
+func (http *HTTPDetails) appendAnyFields(event *parsers.PantherLog) {
+	if http != nil {
+		event.AppendAnyDomainNamePtrs(http.Hostname)
+	}
+}
+
+func (tls *TLSDetails) appendAnyFields(event *parsers.PantherLog) {
+	if tls != nil {
+		event.AppendAnyDomainNamePtrs(tls.SNI)
+	}
+}
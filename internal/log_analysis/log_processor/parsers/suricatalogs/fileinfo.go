@@ -0,0 +1,98 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var FileInfoDesc = `Suricata fileinfo events record the files transferred over the network, with their hashes if enabled.
+Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html#event-type-fileinfo`
+
+// nolint:lll
+type FileInfo struct {
+	EventHeader
+	EventType *string      `json:"event_type" validate:"required,eq=fileinfo" description:"The type of the event, always fileinfo."`
+	FileInfo  *FileDetails `json:"fileinfo" validate:"required" description:"The file."`
+	HTTP      *HTTPDetails `json:"http,omitempty" description:"The HTTP transaction of the file, if it was transferred over HTTP."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type FileDetails struct {
+	Filename *string `json:"filename,omitempty" description:"The name of the file."`
+	SID      []int64 `json:"sid,omitempty" description:"The IDs of the signatures that matched the file."`
+	Gaps     *bool   `json:"gaps,omitempty" description:"Whether parts of the file are missing."`
+	State    *string `json:"state,omitempty" description:"The state of the transfer (e.g. CLOSED, TRUNCATED)."`
+	MD5      *string `json:"md5,omitempty" description:"The MD5 hash of the file, if enabled."`
+	SHA1     *string `json:"sha1,omitempty" description:"The SHA1 hash of the file, if enabled."`
+	SHA256   *string `json:"sha256,omitempty" description:"The SHA256 hash of the file, if enabled."`
+	Stored   *bool   `json:"stored,omitempty" description:"Whether the file was stored on disk."`
+	FileID   *int64  `json:"file_id,omitempty" description:"The ID of the stored file."`
+	Size     *int64  `json:"size,omitempty" description:"The size of the file in bytes."`
+	TxID     *int64  `json:"tx_id,omitempty" description:"The ID of the transaction the file was transferred in."`
+	Magic    *string `json:"magic,omitempty" description:"The type of the file detected by libmagic, if enabled."`
+}
+
+// FileInfoParser parses Suricata fileinfo events
+type FileInfoParser struct{}
+
+func (p *FileInfoParser) New() parsers.LogParser {
+	return &FileInfoParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *FileInfoParser) Parse(log string) []*parsers.PantherLog {
+	event := &FileInfo{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *FileInfoParser) LogType() string {
+	return "Suricata.FileInfo"
+}
+
+func (event *FileInfo) updatePantherFields(p *FileInfoParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.EventHeader.appendAnyFields(&event.PantherLog)
+	event.HTTP.appendAnyFields(&event.PantherLog)
+	if event.FileInfo != nil {
+		event.AppendAnyMD5HashPtrs(event.FileInfo.MD5)
+		event.AppendAnySHA1HashPtrs(event.FileInfo.SHA1)
+		event.AppendAnySHA256HashPtrs(event.FileInfo.SHA256)
+	}
+}
@@ -0,0 +1,86 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestFileInfo(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.3+0000","flow_id":1805461738637437,"event_type":"fileinfo","src_ip":"93.184.216.34","src_port":80,"dest_ip":"10.0.0.5","dest_port":52345,"proto":"TCP","http":{"hostname":"www.example.com","url":"/index.html","http_method":"GET","status":200},"app_proto":"http","fileinfo":{"filename":"/index.html","sid":[],"gaps":false,"state":"CLOSED","md5":"84238dfc8092e5d9c0dac8ef93371a07","sha1":"4a328d2c5e4e2fc6c4e9bfbc6e8e1ed0fd5b4d7a","sha256":"ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9","stored":false,"size":1256,"tx_id":0}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 300000000, time.UTC)
+	expectedEvent := &FileInfo{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(1805461738637437),
+			SrcIP:     aws.String("93.184.216.34"),
+			SrcPort:   aws.Int(80),
+			DestIP:    aws.String("10.0.0.5"),
+			DestPort:  aws.Int(52345),
+			Proto:     aws.String("TCP"),
+			AppProto:  aws.String("http"),
+		},
+		EventType: aws.String("fileinfo"),
+		FileInfo: &FileDetails{
+			Filename: aws.String("/index.html"),
+			SID:      []int64{},
+			Gaps:     aws.Bool(false),
+			State:    aws.String("CLOSED"),
+			MD5:      aws.String("84238dfc8092e5d9c0dac8ef93371a07"),
+			SHA1:     aws.String("4a328d2c5e4e2fc6c4e9bfbc6e8e1ed0fd5b4d7a"),
+			SHA256:   aws.String("ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9"),
+			Stored:   aws.Bool(false),
+			Size:     aws.Int64(1256),
+			TxID:     aws.Int64(0),
+		},
+		HTTP: &HTTPDetails{
+			Hostname:   aws.String("www.example.com"),
+			URL:        aws.String("/index.html"),
+			HTTPMethod: aws.String("GET"),
+			Status:     aws.Int(200),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.FileInfo")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("93.184.216.34", "10.0.0.5")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+	expectedEvent.AppendAnyMD5Hashes("84238dfc8092e5d9c0dac8ef93371a07")
+	expectedEvent.AppendAnySHA1Hashes("4a328d2c5e4e2fc6c4e9bfbc6e8e1ed0fd5b4d7a")
+	expectedEvent.AppendAnySHA256Hashes("ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&FileInfoParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func TestFileInfoType(t *testing.T) {
+	parser := &FileInfoParser{}
+	require.Equal(t, "Suricata.FileInfo", parser.LogType())
+}
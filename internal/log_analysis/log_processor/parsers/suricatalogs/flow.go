@@ -0,0 +1,92 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var FlowDesc = `Suricata flow events record the network flows when they end.
+Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html#event-type-flow`
+
+// nolint:lll
+type Flow struct {
+	EventHeader
+	EventType *string      `json:"event_type" validate:"required,eq=flow" description:"The type of the event, always flow."`
+	Flow      *FlowDetails `json:"flow" validate:"required" description:"The flow."`
+	TCP       *TCPDetails  `json:"tcp,omitempty" description:"The TCP flags of the flow, for TCP flows."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// nolint:lll
+type TCPDetails struct {
+	TCPFlags   *string `json:"tcp_flags,omitempty" description:"The TCP flags seen in the flow (hexadecimal)."`
+	TCPFlagsTS *string `json:"tcp_flags_ts,omitempty" description:"The TCP flags sent to the server (hexadecimal)."`
+	TCPFlagsTC *string `json:"tcp_flags_tc,omitempty" description:"The TCP flags sent to the client (hexadecimal)."`
+	SYN        *bool   `json:"syn,omitempty" description:"Whether the SYN flag was seen."`
+	FIN        *bool   `json:"fin,omitempty" description:"Whether the FIN flag was seen."`
+	RST        *bool   `json:"rst,omitempty" description:"Whether the RST flag was seen."`
+	PSH        *bool   `json:"psh,omitempty" description:"Whether the PSH flag was seen."`
+	ACK        *bool   `json:"ack,omitempty" description:"Whether the ACK flag was seen."`
+	URG        *bool   `json:"urg,omitempty" description:"Whether the URG flag was seen."`
+	ECN        *bool   `json:"ecn,omitempty" description:"Whether the ECN flag was seen."`
+	CWR        *bool   `json:"cwr,omitempty" description:"Whether the CWR flag was seen."`
+	State      *string `json:"state,omitempty" description:"The TCP state of the flow."`
+}
+
+// FlowParser parses Suricata flow events
+type FlowParser struct{}
+
+func (p *FlowParser) New() parsers.LogParser {
+	return &FlowParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *FlowParser) Parse(log string) []*parsers.PantherLog {
+	event := &Flow{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *FlowParser) LogType() string {
+	return "Suricata.Flow"
+}
+
+func (event *Flow) updatePantherFields(p *FlowParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.EventHeader.appendAnyFields(&event.PantherLog)
+}
@@ -0,0 +1,90 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestFlow(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:01:00.500000+0000","flow_id":1805461738637437,"in_iface":"eth0","event_type":"flow","vlan":[10],"src_ip":"10.0.0.5","src_port":52345,"dest_ip":"93.184.216.34","dest_port":80,"proto":"TCP","app_proto":"http","flow":{"pkts_toserver":4,"pkts_toclient":3,"bytes_toserver":351,"bytes_toclient":1840,"start":"2020-05-12T10:00:00.012345+0000","end":"2020-05-12T10:00:00.300000+0000","age":0,"state":"closed","reason":"timeout","alerted":true},"tcp":{"tcp_flags":"1b","tcp_flags_ts":"1b","tcp_flags_tc":"1b","syn":true,"fin":true,"psh":true,"ack":true,"state":"closed"}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 1, 0, 500000000, time.UTC)
+	expectedStart := time.Date(2020, 5, 12, 10, 0, 0, 12345000, time.UTC)
+	expectedEnd := time.Date(2020, 5, 12, 10, 0, 0, 300000000, time.UTC)
+	expectedEvent := &Flow{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(1805461738637437),
+			InIface:   aws.String("eth0"),
+			VLAN:      []int{10},
+			SrcIP:     aws.String("10.0.0.5"),
+			SrcPort:   aws.Int(52345),
+			DestIP:    aws.String("93.184.216.34"),
+			DestPort:  aws.Int(80),
+			Proto:     aws.String("TCP"),
+			AppProto:  aws.String("http"),
+		},
+		EventType: aws.String("flow"),
+		Flow: &FlowDetails{
+			PktsToServer:  aws.Int64(4),
+			PktsToClient:  aws.Int64(3),
+			BytesToServer: aws.Int64(351),
+			BytesToClient: aws.Int64(1840),
+			Start:         (*timestamp.SuricataTimestamp)(&expectedStart),
+			End:           (*timestamp.SuricataTimestamp)(&expectedEnd),
+			Age:           aws.Int64(0),
+			State:         aws.String("closed"),
+			Reason:        aws.String("timeout"),
+			Alerted:       aws.Bool(true),
+		},
+		TCP: &TCPDetails{
+			TCPFlags:   aws.String("1b"),
+			TCPFlagsTS: aws.String("1b"),
+			TCPFlagsTC: aws.String("1b"),
+			SYN:        aws.Bool(true),
+			FIN:        aws.Bool(true),
+			PSH:        aws.Bool(true),
+			ACK:        aws.Bool(true),
+			State:      aws.String("closed"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.Flow")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&FlowParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func TestFlowType(t *testing.T) {
+	parser := &FlowParser{}
+	require.Equal(t, "Suricata.Flow", parser.LogType())
+}
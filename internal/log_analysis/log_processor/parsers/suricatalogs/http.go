@@ -0,0 +1,76 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var HTTPDesc = `Suricata http events record HTTP requests and responses.
+Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html#event-type-http`
+
+// nolint:lll
+type HTTP struct {
+	EventHeader
+	EventType *string      `json:"event_type" validate:"required,eq=http" description:"The type of the event, always http."`
+	HTTP      *HTTPDetails `json:"http" validate:"required" description:"The HTTP transaction."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// HTTPParser parses Suricata http events
+type HTTPParser struct{}
+
+func (p *HTTPParser) New() parsers.LogParser {
+	return &HTTPParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *HTTPParser) Parse(log string) []*parsers.PantherLog {
+	event := &HTTP{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *HTTPParser) LogType() string {
+	return "Suricata.HTTP"
+}
+
+func (event *HTTP) updatePantherFields(p *HTTPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.EventHeader.appendAnyFields(&event.PantherLog)
+	event.HTTP.appendAnyFields(&event.PantherLog)
+}
@@ -0,0 +1,76 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestHTTP(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.25+0000","flow_id":1805461738637437,"event_type":"http","src_ip":"10.0.0.5","src_port":52345,"dest_ip":"93.184.216.34","dest_port":80,"proto":"TCP","tx_id":0,"http":{"hostname":"www.example.com","http_port":8080,"url":"/index.html","http_user_agent":"curl/7.64.1","http_content_type":"text/html","http_method":"GET","protocol":"HTTP/1.1","status":200,"length":1256}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 250000000, time.UTC)
+	expectedEvent := &HTTP{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(1805461738637437),
+			SrcIP:     aws.String("10.0.0.5"),
+			SrcPort:   aws.Int(52345),
+			DestIP:    aws.String("93.184.216.34"),
+			DestPort:  aws.Int(80),
+			Proto:     aws.String("TCP"),
+			TxID:      aws.Int64(0),
+		},
+		EventType: aws.String("http"),
+		HTTP: &HTTPDetails{
+			Hostname:        aws.String("www.example.com"),
+			HTTPPort:        aws.Int(8080),
+			URL:             aws.String("/index.html"),
+			HTTPUserAgent:   aws.String("curl/7.64.1"),
+			HTTPContentType: aws.String("text/html"),
+			HTTPMethod:      aws.String("GET"),
+			Protocol:        aws.String("HTTP/1.1"),
+			Status:          aws.Int(200),
+			Length:          aws.Int64(1256),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.HTTP")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&HTTPParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func TestHTTPType(t *testing.T) {
+	parser := &HTTPParser{}
+	require.Equal(t, "Suricata.HTTP", parser.LogType())
+}
@@ -0,0 +1,76 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var TLSDesc = `Suricata tls events record TLS handshakes.
+Reference: https://suricata.readthedocs.io/en/latest/output/eve/eve-json-format.html#event-type-tls`
+
+// nolint:lll
+type TLS struct {
+	EventHeader
+	EventType *string     `json:"event_type" validate:"required,eq=tls" description:"The type of the event, always tls."`
+	TLS       *TLSDetails `json:"tls" validate:"required" description:"The TLS session."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// TLSParser parses Suricata tls events
+type TLSParser struct{}
+
+func (p *TLSParser) New() parsers.LogParser {
+	return &TLSParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *TLSParser) Parse(log string) []*parsers.PantherLog {
+	event := &TLS{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *TLSParser) LogType() string {
+	return "Suricata.TLS"
+}
+
+func (event *TLS) updatePantherFields(p *TLSParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Timestamp), event)
+	event.EventHeader.appendAnyFields(&event.PantherLog)
+	event.TLS.appendAnyFields(&event.PantherLog)
+}
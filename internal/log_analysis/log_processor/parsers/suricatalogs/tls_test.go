@@ -0,0 +1,79 @@
+package suricatalogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestTLS(t *testing.T) {
+	//nolint
+	log := `{"timestamp":"2020-05-12T10:00:00.123456+0000","flow_id":1805461738637438,"event_type":"tls","src_ip":"10.0.0.5","src_port":52347,"dest_ip":"93.184.216.34","dest_port":443,"proto":"TCP","tls":{"subject":"C=US, ST=California, L=Los Angeles, O=Internet Corporation for Assigned Names and Numbers, CN=www.example.org","issuerdn":"C=US, O=DigiCert Inc, CN=DigiCert SHA2 Secure Server CA","serial":"0F:D0:78:DD:48:F1:A2:BD:4D:0F:2B:A9:6B:60:38:FE","fingerprint":"7b:b6:98:38:69:70:36:3d:29:19:cc:57:72:84:69:84:ff:5a:1b:85","sni":"www.example.com","version":"TLS 1.2","notbefore":"2018-11-28T00:00:00","notafter":"2020-12-02T12:00:00","ja3":{"hash":"e7d705a3286e19ea42f587b344ee6865","string":"771,49195-49199,0-23-65281,29-23-24,0"}}}`
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 123456000, time.UTC)
+	expectedEvent := &TLS{
+		EventHeader: EventHeader{
+			Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+			FlowID:    aws.Int64(1805461738637438),
+			SrcIP:     aws.String("10.0.0.5"),
+			SrcPort:   aws.Int(52347),
+			DestIP:    aws.String("93.184.216.34"),
+			DestPort:  aws.Int(443),
+			Proto:     aws.String("TCP"),
+		},
+		EventType: aws.String("tls"),
+		TLS: &TLSDetails{
+			//nolint
+			Subject:     aws.String("C=US, ST=California, L=Los Angeles, O=Internet Corporation for Assigned Names and Numbers, CN=www.example.org"),
+			IssuerDN:    aws.String("C=US, O=DigiCert Inc, CN=DigiCert SHA2 Secure Server CA"),
+			Serial:      aws.String("0F:D0:78:DD:48:F1:A2:BD:4D:0F:2B:A9:6B:60:38:FE"),
+			Fingerprint: aws.String("7b:b6:98:38:69:70:36:3d:29:19:cc:57:72:84:69:84:ff:5a:1b:85"),
+			SNI:         aws.String("www.example.com"),
+			Version:     aws.String("TLS 1.2"),
+			NotBefore:   aws.String("2018-11-28T00:00:00"),
+			NotAfter:    aws.String("2020-12-02T12:00:00"),
+			JA3: &JA3{
+				Hash:   aws.String("e7d705a3286e19ea42f587b344ee6865"),
+				String: aws.String("771,49195-49199,0-23-65281,29-23-24,0"),
+			},
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Suricata.TLS")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&TLSParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parser.Parse(log))
+}
+
+func TestTLSType(t *testing.T) {
+	parser := &TLSParser{}
+	require.Equal(t, "Suricata.TLS", parser.LogType())
+}
@@ -21,6 +21,8 @@ package sysloglogs
 import (
 	"errors"
 	"net"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/influxdata/go-syslog/v3"
@@ -50,13 +52,24 @@ type RFC3164 struct {
 	parsers.PantherLog
 }
 
+// rfc3164TimezoneEnv is the environment variable with the time zone of RFC3164 timestamps, which have none
+const rfc3164TimezoneEnv = "SYSLOG_TIMEZONE"
+
 // RFC3164Parser parses Syslog logs in the RFC3164 format
 type RFC3164Parser struct {
 	parser syslog.Machine
+	// location is the time zone of RFC3164 timestamps, which have none
+	location *time.Location
+	// now is used to infer the year of timestamps, it is time.Now unless pinned by tests
+	now func() time.Time
 }
 
 // New returns an initialized LogParser for Syslog RFC3164 logs
 func (p *RFC3164Parser) New() parsers.LogParser {
+	return newRFC3164Parser(rfc3164Location(), time.Now)
+}
+
+func newRFC3164Parser(location *time.Location, now func() time.Time) *RFC3164Parser {
 	return &RFC3164Parser{
 		parser: rfc3164.NewParser(
 			rfc3164.WithBestEffort(),
@@ -64,7 +77,23 @@ func (p *RFC3164Parser) New() parsers.LogParser {
 			rfc3164.WithYear(rfc3164.CurrentYear{}),
 			rfc3164.WithRFC3339(),
 		),
+		location: location,
+		now:      now,
+	}
+}
+
+// rfc3164Location returns the time zone of timestamps without one, UTC unless configured otherwise
+func rfc3164Location() *time.Location {
+	timezone := os.Getenv(rfc3164TimezoneEnv)
+	if timezone == "" {
+		return time.UTC
 	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		zap.L().Error("invalid syslog timezone, using UTC", zap.String("timezone", timezone), zap.Error(err))
+		return time.UTC
+	}
+	return location
 }
 
 // Parse returns the parsed events or nil if parsing failed
@@ -79,6 +108,14 @@ func (p *RFC3164Parser) Parse(log string) []*parsers.PantherLog {
 		return nil
 	}
 	internalRFC3164 := msg.(*rfc3164.SyslogMessage)
+	if internalRFC3164.Timestamp != nil && !hasYear(log) {
+		// RFC3164 timestamps have no zone, the parser reads them as UTC so the wall clock is moved to the configured one
+		ts := *internalRFC3164.Timestamp
+		local := time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(), p.location)
+		// RFC3164 timestamps have no year, the parser sets the current one which is wrong for messages of last year
+		inferred := timestamp.InferYear(local, p.now()).UTC()
+		internalRFC3164.Timestamp = &inferred
+	}
 
 	externalRFC3164 := &RFC3164{
 		Priority:  internalRFC3164.Priority,
@@ -121,3 +158,11 @@ func (event *RFC3164) updatePantherFields(p *RFC3164Parser) {
 		}
 	}
 }
+
+// hasYear returns true if the timestamp of the message is RFC3339 rather than the RFC3164 "Mmm dd hh:mm:ss"
+func hasYear(log string) bool {
+	if end := strings.IndexByte(log, '>'); end >= 0 { // skip the priority
+		log = log[end+1:]
+	}
+	return len(log) > 0 && log[0] >= '0' && log[0] <= '9'
+}
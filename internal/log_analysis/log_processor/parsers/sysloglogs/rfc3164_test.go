@@ -19,6 +19,7 @@ package sysloglogs
  */
 
 import (
+	"os"
 	"testing"
 	"time"
 
@@ -34,10 +35,16 @@ import (
 
 var parserRFC3164 parsers.LogParser
 
+// rfc3164Now pins the time years are inferred from, timestamps after it are from the previous year
+var rfc3164Now = time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func pinnedNow() time.Time {
+	return rfc3164Now
+}
+
 func TestRFC3164(t *testing.T) {
 	zap.ReplaceGlobals(zaptest.NewLogger(t))
-	syslogRFC3164 := &RFC3164Parser{}
-	parserRFC3164 = syslogRFC3164.New()
+	parserRFC3164 = newRFC3164Parser(time.UTC, pinnedNow)
 
 	t.Run("Simple", testRFC3164Simple)
 	t.Run("WithRFC3339Timestamp", testRFC3164WithRFC3339Timestamp)
@@ -50,7 +57,7 @@ func testRFC3164Simple(t *testing.T) {
 	//nolint:lll
 	log := `<13>Dec  2 16:31:03 host app: Test`
 
-	expectedTime := time.Date(time.Now().UTC().Year(), 12, 2, 16, 31, 03, 0, time.UTC)
+	expectedTime := time.Date(2019, 12, 2, 16, 31, 03, 0, time.UTC)
 
 	expectedEvent := &RFC3164{
 		Priority:  aws.Uint8(13),
@@ -105,7 +112,7 @@ func testRFC3164Example1(t *testing.T) {
 	//nolint:lll
 	log := `<34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8`
 
-	expectedTime := time.Date(time.Now().UTC().Year(), 10, 11, 22, 14, 15, 0, time.UTC)
+	expectedTime := time.Date(2019, 10, 11, 22, 14, 15, 0, time.UTC)
 
 	expectedEvent := &RFC3164{
 		Priority:  aws.Uint8(34),
@@ -133,7 +140,7 @@ func testRFC3164Example2(t *testing.T) {
 	//nolint:lll
 	log := `<13>Feb  5 17:32:18 10.0.0.99 Use the BFG!`
 
-	expectedTime := time.Date(time.Now().UTC().Year(), 2, 5, 17, 32, 18, 0, time.UTC)
+	expectedTime := time.Date(2020, 2, 5, 17, 32, 18, 0, time.UTC)
 
 	expectedEvent := &RFC3164{
 		Priority:  aws.Uint8(13),
@@ -161,7 +168,7 @@ func testRFC3164Example3(t *testing.T) {
 	//nolint:lll
 	log := `<165>Aug 24 05:34:00 CST 1987 mymachine myproc[10]: %% It's time to make the do-nuts %%`
 
-	expectedTime := time.Date(time.Now().UTC().Year(), 8, 24, 5, 34, 0, 0, time.UTC)
+	expectedTime := time.Date(2019, 8, 24, 5, 34, 0, 0, time.UTC)
 
 	expectedEvent := &RFC3164{
 		Priority:  aws.Uint8(165),
@@ -184,6 +191,36 @@ func testRFC3164Example3(t *testing.T) {
 	checkRFC3164(t, log, expectedEvent)
 }
 
+func TestRFC3164Timezone(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	parser := newRFC3164Parser(location, pinnedNow)
+
+	logs := parser.Parse(`<13>Dec  2 16:31:03 host app: Test`)
+	require.Len(t, logs, 1)
+	// EST is 5 hours behind UTC
+	expectedTime := time.Date(2019, 12, 2, 21, 31, 03, 0, time.UTC)
+	require.Equal(t, expectedTime, (*time.Time)(logs[0].PantherEventTime).UTC())
+
+	// timestamps with a zone ignore the default one
+	logs = parser.Parse(`<28>2019-12-02T16:49:23+02:00 host app[23410]: Test`)
+	require.Len(t, logs, 1)
+	expectedTime = time.Date(2019, 12, 2, 14, 49, 23, 0, time.UTC)
+	require.Equal(t, expectedTime, (*time.Time)(logs[0].PantherEventTime).UTC())
+}
+
+func TestRFC3164Location(t *testing.T) {
+	defer os.Unsetenv(rfc3164TimezoneEnv)
+
+	require.Equal(t, time.UTC, rfc3164Location())
+
+	require.NoError(t, os.Setenv(rfc3164TimezoneEnv, "America/New_York"))
+	require.Equal(t, "America/New_York", rfc3164Location().String())
+
+	require.NoError(t, os.Setenv(rfc3164TimezoneEnv, "Not/AZone"))
+	require.Equal(t, time.UTC, rfc3164Location())
+}
+
 func TestRFC3164Type(t *testing.T) {
 	parser := &RFC3164Parser{}
 	require.Equal(t, "Syslog.RFC3164", parser.LogType())
@@ -193,3 +230,9 @@ func checkRFC3164(t *testing.T, log string, expectedEvent *RFC3164) {
 	expectedEvent.SetEvent(expectedEvent)
 	testutil.EqualPantherLog(t, expectedEvent.Log(), parserRFC3164.Parse(log))
 }
+
+func TestRFC3164HasYear(t *testing.T) {
+	require.False(t, hasYear(`<13>Dec  2 16:31:03 host app: Test`))
+	require.True(t, hasYear(`<28>2019-12-02T16:49:23+02:00 host app[23410]: Test`))
+	require.False(t, hasYear(``))
+}
@@ -0,0 +1,120 @@
+package timestamp
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Layouts that are not Go time layouts
+const (
+	LayoutRFC3339 = "rfc3339" // RFC3339 with optional fractional seconds
+	LayoutUnix    = "unix"    // seconds since the epoch, with optional fractional part
+	LayoutUnixMs  = "unix_ms" // milliseconds since the epoch
+)
+
+// yearInferenceTolerance is how far in the future a timestamp with an inferred year can be, to account for clock skew
+const yearInferenceTolerance = 24 * time.Hour
+
+// Parser parses timestamps trying a list of layouts in order. It is safe for concurrent use.
+type Parser struct {
+	layouts  []string
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewParser returns a parser for the given layouts (Go time layouts or one of the Layout constants, RFC3339 if none).
+// The timezone is the IANA name of the zone of timestamps without one, UTC if empty.
+// Timestamps of layouts without a year (e.g. RFC3164 "Jan _2 15:04:05") are assumed to be from the last 12 months.
+func NewParser(layouts []string, timezone string) (*Parser, error) {
+	if len(layouts) == 0 {
+		layouts = []string{LayoutRFC3339}
+	}
+	location := time.UTC
+	if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, errors.Wrapf(err, "invalid timezone %q", timezone)
+		}
+	}
+	return &Parser{
+		layouts:  layouts,
+		location: location,
+		now:      time.Now,
+	}, nil
+}
+
+// Parse returns the timestamp in UTC using the first layout matching the value
+func (p *Parser) Parse(value string) (RFC3339, error) {
+	var err error
+	for _, layout := range p.layouts {
+		var t time.Time
+		if t, err = p.parse(layout, value); err == nil {
+			return (RFC3339)(t.UTC()), nil
+		}
+	}
+	if len(p.layouts) > 1 {
+		err = errors.Errorf("timestamp %q does not match any of the layouts %q", value, p.layouts)
+	}
+	return RFC3339{}, err
+}
+
+func (p *Parser) parse(layout, value string) (time.Time, error) {
+	switch layout {
+	case LayoutRFC3339:
+		return time.ParseInLocation(time.RFC3339Nano, value, p.location)
+	case LayoutUnix:
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*float64(time.Second))), nil
+	case LayoutUnixMs:
+		millis, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(0, millis*int64(time.Millisecond)), nil
+	default:
+		t, err := time.ParseInLocation(layout, value, p.location)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t.Year() == 0 { // the layout has no year
+			t = InferYear(t, p.now())
+		}
+		return t, nil
+	}
+}
+
+// InferYear returns the time in the year that makes it the most recent one not in the future of now.
+// It is used for timestamps without a year, like the ones of RFC3164 syslog messages.
+func InferYear(t time.Time, now time.Time) time.Time {
+	now = now.In(t.Location())
+	inferred := time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if inferred.After(now.Add(yearInferenceTolerance)) { // e.g. a December timestamp read in January
+		inferred = time.Date(now.Year()-1, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return inferred
+}
@@ -0,0 +1,99 @@
+package timestamp
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserLayouts(t *testing.T) {
+	parser, err := NewParser([]string{LayoutUnixMs, LayoutRFC3339, "2006-01-02 15:04:05"}, "")
+	require.NoError(t, err)
+
+	for _, value := range []string{"1576371661000", "2019-12-15T01:01:01Z", "2019-12-15T03:01:01+02:00", "2019-12-15 01:01:01"} {
+		ts, err := parser.Parse(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expectedTime, (time.Time)(ts), value)
+	}
+
+	_, err = parser.Parse("Dec 15 01:01:01")
+	assert.Error(t, err)
+}
+
+func TestParserDefaultLayout(t *testing.T) {
+	parser, err := NewParser(nil, "")
+	require.NoError(t, err)
+	ts, err := parser.Parse("2019-12-15T01:01:01Z")
+	require.NoError(t, err)
+	assert.Equal(t, expectedTime, (time.Time)(ts))
+}
+
+func TestParserUnix(t *testing.T) {
+	parser, err := NewParser([]string{LayoutUnix}, "")
+	require.NoError(t, err)
+	ts, err := parser.Parse("1576371661.5")
+	require.NoError(t, err)
+	assert.Equal(t, expectedTime.Add(500*time.Millisecond), (time.Time)(ts))
+	assert.Equal(t, time.UTC, (time.Time)(ts).Location())
+}
+
+func TestParserTimezone(t *testing.T) {
+	parser, err := NewParser([]string{"2006-01-02 15:04:05", LayoutRFC3339}, "America/New_York")
+	require.NoError(t, err)
+
+	// values without a zone are in the default timezone
+	ts, err := parser.Parse("2019-12-14 20:01:01")
+	require.NoError(t, err)
+	assert.Equal(t, expectedTime, (time.Time)(ts))
+	assert.Equal(t, time.UTC, (time.Time)(ts).Location())
+
+	// values with a zone are not affected
+	ts, err = parser.Parse("2019-12-15T01:01:01Z")
+	require.NoError(t, err)
+	assert.Equal(t, expectedTime, (time.Time)(ts))
+
+	_, err = NewParser(nil, "Not/AZone")
+	assert.Error(t, err)
+}
+
+func TestParserInferYear(t *testing.T) {
+	parser, err := NewParser([]string{time.Stamp}, "")
+	require.NoError(t, err)
+	parser.now = func() time.Time { return time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC) }
+
+	ts, err := parser.Parse("Dec 15 01:01:01")
+	require.NoError(t, err)
+	assert.Equal(t, expectedTime, (time.Time)(ts))
+
+	ts, err = parser.Parse("Jan  1 12:00:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), (time.Time)(ts))
+}
+
+func TestInferYear(t *testing.T) {
+	now := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	// clock skew
+	assert.Equal(t, time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC), InferYear(time.Date(0, 6, 15, 12, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, time.Date(2019, 6, 17, 0, 0, 0, 0, time.UTC), InferYear(time.Date(0, 6, 17, 0, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), InferYear(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), now))
+}
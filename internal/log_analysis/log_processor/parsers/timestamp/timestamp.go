@@ -36,6 +36,8 @@ const (
 	ansicWithTZUnmarshalLayout = `"Mon Jan 2 15:04:05 2006 MST"` // similar to time.ANSIC but with MST
 
 	fluentdTimestampLayout = `"2006-01-02 15:04:05 -0700"`
+
+	suricataTimestampLayout = `"2006-01-02T15:04:05.999999999-0700"`
 )
 
 // use these functions to parse all incoming dates to ensure UTC consistency
@@ -125,3 +127,23 @@ func (ts *FluentdTimestamp) UnmarshalJSON(jsonBytes []byte) (err error) {
 	*ts = (FluentdTimestamp)(t)
 	return
 }
+
+// SuricataTimestamp for the timestamps of Suricata EVE logs, like RFC3339 but the zone offset has no colon
+type SuricataTimestamp time.Time
+
+func (ts *SuricataTimestamp) String() string {
+	return (*time.Time)(ts).UTC().String() // ensure UTC
+}
+
+func (ts *SuricataTimestamp) MarshalJSON() ([]byte, error) {
+	return []byte((*time.Time)(ts).UTC().Format(jsonMarshalLayout)), nil // ensure UTC
+}
+
+func (ts *SuricataTimestamp) UnmarshalJSON(jsonBytes []byte) (err error) {
+	t, err := Parse(suricataTimestampLayout, string(jsonBytes))
+	if err != nil {
+		return
+	}
+	*ts = (SuricataTimestamp)(t)
+	return
+}
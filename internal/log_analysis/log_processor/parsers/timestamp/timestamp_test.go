@@ -35,6 +35,7 @@ var (
 	osqueryUnmarshalString         = `"Sun Dec 15 01:01:01 2019 UTC"`
 	unixMillisecondUnmarshalString = `1576371661000`
 	fluentdUnmarshalString         = `"2019-12-15 01:01:01 +0000"`
+	suricataUnmarshalString        = `"2019-12-15T03:01:01.000000+0200"`
 )
 
 func TestTimestampRFC3339_String(t *testing.T) {
@@ -112,3 +113,22 @@ func TestFluentdTimestamp_Unmarshal(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, (FluentdTimestamp)(expectedTime), ts)
 }
+
+func TestSuricataTimestamp_String(t *testing.T) {
+	ts := (SuricataTimestamp)(expectedTime)
+	assert.Equal(t, expectedString, ts.String())
+}
+
+func TestSuricataTimestamp_Marshal(t *testing.T) {
+	ts := (SuricataTimestamp)(expectedTime)
+	jsonTS, err := jsoniter.Marshal(&ts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMarshalString, string(jsonTS))
+}
+
+func TestSuricataTimestamp_Unmarshal(t *testing.T) {
+	var ts SuricataTimestamp
+	err := jsoniter.Unmarshal([]byte(suricataUnmarshalString), &ts)
+	assert.NoError(t, err)
+	assert.Equal(t, (SuricataTimestamp)(expectedTime), ts)
+}
@@ -0,0 +1,101 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var ConnDesc = `Zeek conn.log records TCP, UDP and ICMP connections.
+Reference: https://docs.zeek.org/en/current/scripts/base/protocols/conn/main.zeek.html#type-Conn::Info`
+
+// nolint:lll
+type Conn struct {
+	Ts  *timestamp.RFC3339 `json:"ts" validate:"required" description:"This is the time of the first packet."`
+	UID *string            `json:"uid" validate:"required" description:"A unique identifier of the connection."`
+	ConnectionID
+	Proto         *string  `json:"proto,omitempty" description:"The transport layer protocol of the connection."`
+	Service       *string  `json:"service,omitempty" description:"An identification of an application protocol being sent in the connection."`
+	Duration      *float64 `json:"duration,omitempty" description:"How long the connection lasted in seconds."`
+	OrigBytes     *int64   `json:"orig_bytes,omitempty" description:"The number of payload bytes the originator sent."`
+	RespBytes     *int64   `json:"resp_bytes,omitempty" description:"The number of payload bytes the responder sent."`
+	ConnState     *string  `json:"conn_state,omitempty" description:"The state of the connection (e.g. S0, SF, REJ)."`
+	LocalOrig     *bool    `json:"local_orig,omitempty" description:"If the connection is originated locally, this value will be true."`
+	LocalResp     *bool    `json:"local_resp,omitempty" description:"If the connection is responded to locally, this value will be true."`
+	MissedBytes   *int64   `json:"missed_bytes,omitempty" description:"Indicates the number of bytes missed in content gaps, which is representative of packet loss."`
+	History       *string  `json:"history,omitempty" description:"Records the state history of connections as a string of letters."`
+	OrigPkts      *int64   `json:"orig_pkts,omitempty" description:"Number of packets that the originator sent."`
+	OrigIPBytes   *int64   `json:"orig_ip_bytes,omitempty" description:"Number of IP level bytes that the originator sent (as seen on the wire, taken from the IP total_length header field)."`
+	RespPkts      *int64   `json:"resp_pkts,omitempty" description:"Number of packets that the responder sent."`
+	RespIPBytes   *int64   `json:"resp_ip_bytes,omitempty" description:"Number of IP level bytes that the responder sent (as seen on the wire, taken from the IP total_length header field)."`
+	TunnelParents []string `json:"tunnel_parents,omitempty" description:"If this connection was over a tunnel, indicate the uid values for any encapsulating parent connections used over the lifetime of this inner connection."`
+	OrigL2Addr    *string  `json:"orig_l2_addr,omitempty" description:"Link-layer address of the originator, if available."`
+	RespL2Addr    *string  `json:"resp_l2_addr,omitempty" description:"Link-layer address of the responder, if available."`
+	VLAN          *int     `json:"vlan,omitempty" description:"The outer VLAN for this connection, if applicable."`
+	InnerVLAN     *int     `json:"inner_vlan,omitempty" description:"The inner VLAN for this connection, if applicable."`
+	CommunityID   *string  `json:"community_id,omitempty" description:"The Community ID flow hash of the connection, if the policy computing it is loaded."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// ConnParser parses Zeek conn logs
+type ConnParser struct {
+	reader tsvReader
+}
+
+func (p *ConnParser) New() parsers.LogParser {
+	return &ConnParser{
+		reader: newTSVReader("conn", "uid", "conn_state"),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ConnParser) Parse(log string) []*parsers.PantherLog {
+	event := &Conn{}
+	isEvent, err := p.reader.read(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+	if !isEvent { // header lines have no events
+		return []*parsers.PantherLog{}
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *ConnParser) LogType() string {
+	return "Zeek.Conn"
+}
+
+func (event *Conn) updatePantherFields(p *ConnParser) {
+	event.SetCoreFields(p.LogType(), event.Ts, event)
+	event.AppendAnyIPAddressPtrs(event.OrigH, event.RespH)
+}
@@ -0,0 +1,114 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestConn(t *testing.T) {
+	header := zeekHeader("conn", testConnFields, testConnTypes)
+	line := tsv("1589277600.123456", "CHhAvVGS1DHFjwGM9", "10.0.0.5", "52345", "93.184.216.34", "443", "tcp", "ssl",
+		"1.234567", "517", "4321", "SF", "T", "F", "0", "ShADadFf", "10", "1049", "9", "4833", "(empty)")
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 123456000, time.UTC)
+	expectedEvent := &Conn{
+		Ts:  (*timestamp.RFC3339)(&expectedTime),
+		UID: aws.String("CHhAvVGS1DHFjwGM9"),
+		ConnectionID: ConnectionID{
+			OrigH: aws.String("10.0.0.5"),
+			OrigP: aws.Int(52345),
+			RespH: aws.String("93.184.216.34"),
+			RespP: aws.Int(443),
+		},
+		Proto:         aws.String("tcp"),
+		Service:       aws.String("ssl"),
+		Duration:      aws.Float64(1.234567),
+		OrigBytes:     aws.Int64(517),
+		RespBytes:     aws.Int64(4321),
+		ConnState:     aws.String("SF"),
+		LocalOrig:     aws.Bool(true),
+		LocalResp:     aws.Bool(false),
+		MissedBytes:   aws.Int64(0),
+		History:       aws.String("ShADadFf"),
+		OrigPkts:      aws.Int64(10),
+		OrigIPBytes:   aws.Int64(1049),
+		RespPkts:      aws.Int64(9),
+		RespIPBytes:   aws.Int64(4833),
+		TunnelParents: []string{},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+
+	checkConn(t, header, line, expectedEvent)
+}
+
+func TestConnUnsetFields(t *testing.T) {
+	header := zeekHeader("conn", testConnFields, testConnTypes)
+	line := tsv("1589277600.5", "C4J4Th3PJpwUYZZ6gc", "10.0.0.5", "52346", "10.0.0.1", "53", "udp", "-",
+		"-", "-", "-", "S0", "-", "-", "0", "D", "1", "73", "0", "0", "-")
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 500000000, time.UTC)
+	expectedEvent := &Conn{
+		Ts:  (*timestamp.RFC3339)(&expectedTime),
+		UID: aws.String("C4J4Th3PJpwUYZZ6gc"),
+		ConnectionID: ConnectionID{
+			OrigH: aws.String("10.0.0.5"),
+			OrigP: aws.Int(52346),
+			RespH: aws.String("10.0.0.1"),
+			RespP: aws.Int(53),
+		},
+		Proto:       aws.String("udp"),
+		ConnState:   aws.String("S0"),
+		MissedBytes: aws.Int64(0),
+		History:     aws.String("D"),
+		OrigPkts:    aws.Int64(1),
+		OrigIPBytes: aws.Int64(73),
+		RespPkts:    aws.Int64(0),
+		RespIPBytes: aws.Int64(0),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "10.0.0.1")
+
+	checkConn(t, header, line, expectedEvent)
+}
+
+func TestConnType(t *testing.T) {
+	parser := &ConnParser{}
+	require.Equal(t, "Zeek.Conn", parser.LogType())
+}
+
+func checkConn(t *testing.T, header []string, line string, expectedEvent *Conn) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&ConnParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parseZeekLog(t, parser, header, line))
+}
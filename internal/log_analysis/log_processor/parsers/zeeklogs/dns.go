@@ -0,0 +1,111 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var DNSDesc = `Zeek dns.log records DNS queries and responses.
+Reference: https://docs.zeek.org/en/current/scripts/base/protocols/dns/main.zeek.html#type-DNS::Info`
+
+// nolint:lll
+type DNS struct {
+	Ts  *timestamp.RFC3339 `json:"ts" validate:"required" description:"The earliest time at which a DNS protocol message over the associated connection is observed."`
+	UID *string            `json:"uid" validate:"required" description:"A unique identifier of the connection over which DNS messages are being transferred."`
+	ConnectionID
+	Proto      *string   `json:"proto,omitempty" description:"The transport layer protocol of the connection."`
+	TransID    *int      `json:"trans_id,omitempty" description:"A 16-bit identifier assigned by the program that generated the DNS query. Also used in responses to match up replies to outstanding queries."`
+	RTT        *float64  `json:"rtt,omitempty" description:"Round trip time for the query and response in seconds. This indicates the delay between when the request was seen until the answer started."`
+	Query      *string   `json:"query,omitempty" description:"The domain name that is the subject of the DNS query."`
+	QClass     *int      `json:"qclass,omitempty" description:"The QCLASS value specifying the class of the query."`
+	QClassName *string   `json:"qclass_name,omitempty" description:"A descriptive name for the class of the query."`
+	QType      *int      `json:"qtype,omitempty" description:"A QTYPE value specifying the type of the query."`
+	QTypeName  *string   `json:"qtype_name,omitempty" description:"A descriptive name for the type of the query."`
+	RCode      *int      `json:"rcode,omitempty" description:"The response code value in DNS response messages."`
+	RCodeName  *string   `json:"rcode_name,omitempty" description:"A descriptive name for the response code value."`
+	AA         *bool     `json:"AA,omitempty" description:"The Authoritative Answer bit for response messages specifies that the responding name server is an authority for the domain name in the question section."`
+	TC         *bool     `json:"TC,omitempty" description:"The Truncation bit specifies that the message was truncated."`
+	RD         *bool     `json:"RD,omitempty" description:"The Recursion Desired bit in a request message indicates that the client wants recursive service for this query."`
+	RA         *bool     `json:"RA,omitempty" description:"The Recursion Available bit in a response message indicates that the name server supports recursive queries."`
+	Z          *int      `json:"Z,omitempty" description:"A reserved field that is usually zero in queries and responses."`
+	Answers    []string  `json:"answers,omitempty" description:"The set of resource descriptions in the query answer."`
+	TTLs       []float64 `json:"TTLs,omitempty" description:"The caching intervals of the associated RRs described by the answers field."`
+	Rejected   *bool     `json:"rejected,omitempty" description:"The DNS query was rejected by the server."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// DNSParser parses Zeek dns logs
+type DNSParser struct {
+	reader tsvReader
+}
+
+func (p *DNSParser) New() parsers.LogParser {
+	return &DNSParser{
+		reader: newTSVReader("dns", "uid", "query", "qtype_name"),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *DNSParser) Parse(log string) []*parsers.PantherLog {
+	event := &DNS{}
+	isEvent, err := p.reader.read(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+	if !isEvent { // header lines have no events
+		return []*parsers.PantherLog{}
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *DNSParser) LogType() string {
+	return "Zeek.DNS"
+}
+
+func (event *DNS) updatePantherFields(p *DNSParser) {
+	event.SetCoreFields(p.LogType(), event.Ts, event)
+	event.AppendAnyIPAddressPtrs(event.OrigH, event.RespH)
+	event.AppendAnyDomainNamePtrs(event.Query)
+	// answers are addresses for A and AAAA queries, names for CNAME, PTR etc and descriptions for others (e.g. TXT 10 text)
+	for _, answer := range event.Answers {
+		if net.ParseIP(answer) != nil {
+			event.AppendAnyIPAddresses(answer)
+		} else if !strings.ContainsAny(answer, " <") {
+			event.AppendAnyDomainNames(answer)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestDNS(t *testing.T) {
+	fields := []string{"ts", "uid", "id.orig_h", "id.orig_p", "id.resp_h", "id.resp_p", "proto", "trans_id", "rtt", "query",
+		"qclass", "qclass_name", "qtype", "qtype_name", "rcode", "rcode_name", "AA", "TC", "RD", "RA", "Z", "answers", "TTLs",
+		"rejected"}
+	types := []string{"time", "string", "addr", "port", "addr", "port", "enum", "count", "interval", "string",
+		"count", "string", "count", "string", "count", "string", "bool", "bool", "bool", "bool", "count", "vector[string]",
+		"vector[interval]", "bool"}
+	header := zeekHeader("dns", fields, types)
+	line := tsv("1589277600.000001", "C4J4Th3PJpwUYZZ6gc", "10.0.0.5", "52346", "10.0.0.1", "53", "udp", "48213", "0.012345",
+		"www.example.com", "1", "C_INTERNET", "1", "A", "0", "NOERROR", "F", "F", "T", "T", "0",
+		"example.com,93.184.216.34,TXT 10 v=spf1 -all", "60.000000,3600.000000,300.000000", "F")
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 1000, time.UTC)
+	expectedEvent := &DNS{
+		Ts:  (*timestamp.RFC3339)(&expectedTime),
+		UID: aws.String("C4J4Th3PJpwUYZZ6gc"),
+		ConnectionID: ConnectionID{
+			OrigH: aws.String("10.0.0.5"),
+			OrigP: aws.Int(52346),
+			RespH: aws.String("10.0.0.1"),
+			RespP: aws.Int(53),
+		},
+		Proto:      aws.String("udp"),
+		TransID:    aws.Int(48213),
+		RTT:        aws.Float64(0.012345),
+		Query:      aws.String("www.example.com"),
+		QClass:     aws.Int(1),
+		QClassName: aws.String("C_INTERNET"),
+		QType:      aws.Int(1),
+		QTypeName:  aws.String("A"),
+		RCode:      aws.Int(0),
+		RCodeName:  aws.String("NOERROR"),
+		AA:         aws.Bool(false),
+		TC:         aws.Bool(false),
+		RD:         aws.Bool(true),
+		RA:         aws.Bool(true),
+		Z:          aws.Int(0),
+		Answers:    []string{"example.com", "93.184.216.34", "TXT 10 v=spf1 -all"},
+		TTLs:       []float64{60, 3600, 300},
+		Rejected:   aws.Bool(false),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DNS")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "10.0.0.1", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com", "example.com")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&DNSParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parseZeekLog(t, parser, header, line))
+}
+
+func TestDNSType(t *testing.T) {
+	parser := &DNSParser{}
+	require.Equal(t, "Zeek.DNS", parser.LogType())
+}
@@ -0,0 +1,111 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var FilesDesc = `Zeek files.log records the files transferred over the network, with their hashes.
+Reference: https://docs.zeek.org/en/current/scripts/base/frameworks/files/main.zeek.html#type-Files::Info`
+
+// nolint:lll
+type Files struct {
+	Ts              *timestamp.RFC3339 `json:"ts" validate:"required" description:"The time when the file was first seen."`
+	FUID            *string            `json:"fuid" validate:"required" description:"An identifier associated with a single file."`
+	TxHosts         []string           `json:"tx_hosts,omitempty" description:"If this file was transferred over a network connection this should show the host or hosts that the data sourced from."`
+	RxHosts         []string           `json:"rx_hosts,omitempty" description:"If this file was transferred over a network connection this should show the host or hosts that the data traveled to."`
+	ConnUIDs        []string           `json:"conn_uids,omitempty" description:"Connection UIDs over which the file was transferred."`
+	Source          *string            `json:"source,omitempty" description:"An identification of the source of the file data (e.g. HTTP, SMTP)."`
+	Depth           *int               `json:"depth,omitempty" description:"A value to represent the depth of this file in relation to its source."`
+	Analyzers       []string           `json:"analyzers,omitempty" description:"A set of analysis types done during the file analysis."`
+	MimeType        *string            `json:"mime_type,omitempty" description:"A mime type provided by the strongest file magic signature match against the first bytes of the file."`
+	Filename        *string            `json:"filename,omitempty" description:"A filename for the file if one is available from the source for the file."`
+	Duration        *float64           `json:"duration,omitempty" description:"The duration the file was analyzed for in seconds."`
+	LocalOrig       *bool              `json:"local_orig,omitempty" description:"If the source of this file is a network connection, this field indicates if the data originated from the local network or not."`
+	IsOrig          *bool              `json:"is_orig,omitempty" description:"If the source of this file is a network connection, this field indicates if the file is being sent by the originator of the connection or the responder."`
+	SeenBytes       *int64             `json:"seen_bytes,omitempty" description:"Number of bytes provided to the file analysis engine for the file."`
+	TotalBytes      *int64             `json:"total_bytes,omitempty" description:"Total number of bytes that are supposed to comprise the full file."`
+	MissingBytes    *int64             `json:"missing_bytes,omitempty" description:"The number of bytes in the file stream that were completely missed during the process of analysis."`
+	OverflowBytes   *int64             `json:"overflow_bytes,omitempty" description:"The number of bytes in the file stream that were not delivered to stream file analyzers."`
+	TimedOut        *bool              `json:"timedout,omitempty" description:"Whether the file analysis timed out at least once for the file."`
+	ParentFUID      *string            `json:"parent_fuid,omitempty" description:"Identifier associated with a container file from which this one was extracted as part of the file analysis."`
+	MD5             *string            `json:"md5,omitempty" description:"An MD5 digest of the file contents."`
+	SHA1            *string            `json:"sha1,omitempty" description:"A SHA1 digest of the file contents."`
+	SHA256          *string            `json:"sha256,omitempty" description:"A SHA256 digest of the file contents."`
+	Extracted       *string            `json:"extracted,omitempty" description:"Local filename of extracted file."`
+	ExtractedCutoff *bool              `json:"extracted_cutoff,omitempty" description:"Set to true if the file being extracted was cut off so the whole file was not logged."`
+	ExtractedSize   *int64             `json:"extracted_size,omitempty" description:"The number of bytes extracted to disk."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// FilesParser parses Zeek files logs
+type FilesParser struct {
+	reader tsvReader
+}
+
+func (p *FilesParser) New() parsers.LogParser {
+	return &FilesParser{
+		reader: newTSVReader("files", "fuid", "mime_type", "seen_bytes"),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *FilesParser) Parse(log string) []*parsers.PantherLog {
+	event := &Files{}
+	isEvent, err := p.reader.read(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+	if !isEvent { // header lines have no events
+		return []*parsers.PantherLog{}
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *FilesParser) LogType() string {
+	return "Zeek.Files"
+}
+
+func (event *Files) updatePantherFields(p *FilesParser) {
+	event.SetCoreFields(p.LogType(), event.Ts, event)
+	for _, host := range event.TxHosts {
+		event.AppendAnyIPAddresses(host)
+	}
+	for _, host := range event.RxHosts {
+		event.AppendAnyIPAddresses(host)
+	}
+	event.AppendAnyMD5HashPtrs(event.MD5)
+	event.AppendAnySHA1HashPtrs(event.SHA1)
+	event.AppendAnySHA256HashPtrs(event.SHA256)
+}
@@ -0,0 +1,85 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestFiles(t *testing.T) {
+	fields := []string{"ts", "fuid", "tx_hosts", "rx_hosts", "conn_uids", "source", "depth", "analyzers", "mime_type",
+		"filename", "duration", "local_orig", "is_orig", "seen_bytes", "total_bytes", "missing_bytes", "overflow_bytes",
+		"timedout", "parent_fuid", "md5", "sha1", "sha256", "extracted", "extracted_cutoff", "extracted_size"}
+	types := []string{"time", "string", "set[addr]", "set[addr]", "set[string]", "string", "count", "set[string]", "string",
+		"string", "interval", "bool", "bool", "count", "count", "count", "count",
+		"bool", "string", "string", "string", "string", "string", "bool", "count"}
+	header := zeekHeader("files", fields, types)
+	line := tsv("1589277600.3", "FakNcS1Jfe01uljb3", "93.184.216.34", "10.0.0.5", "CUM0KZ3MLUfNB0cl11", "HTTP", "0",
+		"MD5,SHA1,SHA256", "text/html", "-", "0.000012", "F", "F", "1256", "1256", "0", "0",
+		"F", "-", "84238dfc8092e5d9c0dac8ef93371a07", "4a328d2c5e4e2fc6c4e9bfbc6e8e1ed0fd5b4d7a",
+		"ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9", "-", "-", "-")
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 300000000, time.UTC)
+	expectedEvent := &Files{
+		Ts:            (*timestamp.RFC3339)(&expectedTime),
+		FUID:          aws.String("FakNcS1Jfe01uljb3"),
+		TxHosts:       []string{"93.184.216.34"},
+		RxHosts:       []string{"10.0.0.5"},
+		ConnUIDs:      []string{"CUM0KZ3MLUfNB0cl11"},
+		Source:        aws.String("HTTP"),
+		Depth:         aws.Int(0),
+		Analyzers:     []string{"MD5", "SHA1", "SHA256"},
+		MimeType:      aws.String("text/html"),
+		Duration:      aws.Float64(0.000012),
+		LocalOrig:     aws.Bool(false),
+		IsOrig:        aws.Bool(false),
+		SeenBytes:     aws.Int64(1256),
+		TotalBytes:    aws.Int64(1256),
+		MissingBytes:  aws.Int64(0),
+		OverflowBytes: aws.Int64(0),
+		TimedOut:      aws.Bool(false),
+		MD5:           aws.String("84238dfc8092e5d9c0dac8ef93371a07"),
+		SHA1:          aws.String("4a328d2c5e4e2fc6c4e9bfbc6e8e1ed0fd5b4d7a"),
+		SHA256:        aws.String("ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Files")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("93.184.216.34", "10.0.0.5")
+	expectedEvent.AppendAnyMD5Hashes("84238dfc8092e5d9c0dac8ef93371a07")
+	expectedEvent.AppendAnySHA1Hashes("4a328d2c5e4e2fc6c4e9bfbc6e8e1ed0fd5b4d7a")
+	expectedEvent.AppendAnySHA256Hashes("ea8fac7c65fb589b0d53560f5251f74f9e9b243478dcb6b3ea79b5e36449c8d9")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&FilesParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parseZeekLog(t, parser, header, line))
+}
+
+func TestFilesType(t *testing.T) {
+	parser := &FilesParser{}
+	require.Equal(t, "Zeek.Files", parser.LogType())
+}
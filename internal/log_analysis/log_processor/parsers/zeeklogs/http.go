@@ -0,0 +1,124 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var HTTPDesc = `Zeek http.log records HTTP requests and replies.
+Reference: https://docs.zeek.org/en/current/scripts/base/protocols/http/main.zeek.html#type-HTTP::Info`
+
+// nolint:lll
+type HTTP struct {
+	Ts  *timestamp.RFC3339 `json:"ts" validate:"required" description:"Timestamp for when the request happened."`
+	UID *string            `json:"uid" validate:"required" description:"A unique identifier of the connection."`
+	ConnectionID
+	TransDepth      *int     `json:"trans_depth,omitempty" description:"Represents the pipelined depth into the connection of this request/response transaction."`
+	Method          *string  `json:"method,omitempty" description:"Verb used in the HTTP request (GET, POST, HEAD, etc.)."`
+	Host            *string  `json:"host,omitempty" description:"Value of the HOST header."`
+	URI             *string  `json:"uri,omitempty" description:"URI used in the request."`
+	Referrer        *string  `json:"referrer,omitempty" description:"Value of the Referer header."`
+	Version         *string  `json:"version,omitempty" description:"Value of the version portion of the request."`
+	UserAgent       *string  `json:"user_agent,omitempty" description:"Value of the User-Agent header from the client."`
+	Origin          *string  `json:"origin,omitempty" description:"Value of the Origin header from the client."`
+	RequestBodyLen  *int64   `json:"request_body_len,omitempty" description:"Actual uncompressed content size of the data transferred from the client."`
+	ResponseBodyLen *int64   `json:"response_body_len,omitempty" description:"Actual uncompressed content size of the data transferred from the server."`
+	StatusCode      *int     `json:"status_code,omitempty" description:"Status code returned by the server."`
+	StatusMsg       *string  `json:"status_msg,omitempty" description:"Status message returned by the server."`
+	InfoCode        *int     `json:"info_code,omitempty" description:"Last seen 1xx informational reply code returned by the server."`
+	InfoMsg         *string  `json:"info_msg,omitempty" description:"Last seen 1xx informational reply message returned by the server."`
+	Tags            []string `json:"tags,omitempty" description:"A set of indicators of various attributes discovered and related to a particular request/response pair."`
+	Username        *string  `json:"username,omitempty" description:"Username if basic-auth is performed for the request."`
+	Password        *string  `json:"password,omitempty" description:"Password if basic-auth is performed for the request."`
+	Proxied         []string `json:"proxied,omitempty" description:"All of the headers that may indicate if the request was proxied."`
+	OrigFUIDs       []string `json:"orig_fuids,omitempty" description:"An ordered vector of file unique IDs sent by the client."`
+	OrigFilenames   []string `json:"orig_filenames,omitempty" description:"An ordered vector of filenames from the client."`
+	OrigMimeTypes   []string `json:"orig_mime_types,omitempty" description:"An ordered vector of mime types sent by the client."`
+	RespFUIDs       []string `json:"resp_fuids,omitempty" description:"An ordered vector of file unique IDs sent by the server."`
+	RespFilenames   []string `json:"resp_filenames,omitempty" description:"An ordered vector of filenames from the server."`
+	RespMimeTypes   []string `json:"resp_mime_types,omitempty" description:"An ordered vector of mime types sent by the server."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// HTTPParser parses Zeek http logs
+type HTTPParser struct {
+	reader tsvReader
+}
+
+func (p *HTTPParser) New() parsers.LogParser {
+	return &HTTPParser{
+		reader: newTSVReader("http", "uid", "method", "uri"),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *HTTPParser) Parse(log string) []*parsers.PantherLog {
+	event := &HTTP{}
+	isEvent, err := p.reader.read(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+	if !isEvent { // header lines have no events
+		return []*parsers.PantherLog{}
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *HTTPParser) LogType() string {
+	return "Zeek.HTTP"
+}
+
+func (event *HTTP) updatePantherFields(p *HTTPParser) {
+	event.SetCoreFields(p.LogType(), event.Ts, event)
+	event.AppendAnyIPAddressPtrs(event.OrigH, event.RespH)
+	event.AppendAnyUsernamePtrs(event.Username)
+	if event.Host != nil {
+		// requests can be sent to an address rather than a name
+		if host, _, err := net.SplitHostPort(*event.Host); err == nil {
+			event.appendHost(host)
+		} else {
+			event.appendHost(*event.Host)
+		}
+	}
+}
+
+func (event *HTTP) appendHost(host string) {
+	if net.ParseIP(host) != nil {
+		event.AppendAnyIPAddresses(host)
+	} else {
+		event.AppendAnyDomainNames(host)
+	}
+}
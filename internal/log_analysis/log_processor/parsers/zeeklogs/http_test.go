@@ -0,0 +1,86 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestHTTP(t *testing.T) {
+	fields := []string{"ts", "uid", "id.orig_h", "id.orig_p", "id.resp_h", "id.resp_p", "trans_depth", "method", "host", "uri",
+		"referrer", "version", "user_agent", "origin", "request_body_len", "response_body_len", "status_code", "status_msg",
+		"info_code", "info_msg", "tags", "username", "password", "proxied", "orig_fuids", "orig_filenames", "orig_mime_types",
+		"resp_fuids", "resp_filenames", "resp_mime_types"}
+	types := []string{"time", "string", "addr", "port", "addr", "port", "count", "string", "string", "string",
+		"string", "string", "string", "string", "count", "count", "count", "string",
+		"count", "string", "set[enum]", "string", "string", "set[string]", "vector[string]", "vector[string]", "vector[string]",
+		"vector[string]", "vector[string]", "vector[string]"}
+	header := zeekHeader("http", fields, types)
+	line := tsv("1589277600.25", "CUM0KZ3MLUfNB0cl11", "10.0.0.5", "52347", "93.184.216.34", "80", "1", "GET",
+		"www.example.com:8080", "/index.html", "-", "1.1", "curl/7.64.1", "-", "0", "1256", "200", "OK",
+		"-", "-", "(empty)", "-", "-", "-", "-", "-", "-",
+		"FakNcS1Jfe01uljb3", "-", "text/html")
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 250000000, time.UTC)
+	expectedEvent := &HTTP{
+		Ts:  (*timestamp.RFC3339)(&expectedTime),
+		UID: aws.String("CUM0KZ3MLUfNB0cl11"),
+		ConnectionID: ConnectionID{
+			OrigH: aws.String("10.0.0.5"),
+			OrigP: aws.Int(52347),
+			RespH: aws.String("93.184.216.34"),
+			RespP: aws.Int(80),
+		},
+		TransDepth:      aws.Int(1),
+		Method:          aws.String("GET"),
+		Host:            aws.String("www.example.com:8080"),
+		URI:             aws.String("/index.html"),
+		Version:         aws.String("1.1"),
+		UserAgent:       aws.String("curl/7.64.1"),
+		RequestBodyLen:  aws.Int64(0),
+		ResponseBodyLen: aws.Int64(1256),
+		StatusCode:      aws.Int(200),
+		StatusMsg:       aws.String("OK"),
+		Tags:            []string{},
+		RespFUIDs:       []string{"FakNcS1Jfe01uljb3"},
+		RespMimeTypes:   []string{"text/html"},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.HTTP")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&HTTPParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parseZeekLog(t, parser, header, line))
+}
+
+func TestHTTPType(t *testing.T) {
+	parser := &HTTPParser{}
+	require.Equal(t, "Zeek.HTTP", parser.LogType())
+}
@@ -0,0 +1,99 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+var SSLDesc = `Zeek ssl.log records SSL/TLS handshakes.
+Reference: https://docs.zeek.org/en/current/scripts/base/protocols/ssl/main.zeek.html#type-SSL::Info`
+
+// nolint:lll
+type SSL struct {
+	Ts  *timestamp.RFC3339 `json:"ts" validate:"required" description:"Time when the SSL connection was first detected."`
+	UID *string            `json:"uid" validate:"required" description:"A unique identifier of the connection."`
+	ConnectionID
+	Version              *string  `json:"version,omitempty" description:"SSL/TLS version that the server chose."`
+	Cipher               *string  `json:"cipher,omitempty" description:"SSL/TLS cipher suite that the server chose."`
+	Curve                *string  `json:"curve,omitempty" description:"Elliptic curve the server chose when using ECDH/ECDHE."`
+	ServerName           *string  `json:"server_name,omitempty" description:"Value of the Server Name Indicator SSL/TLS extension. It indicates the server name that the client was requesting."`
+	Resumed              *bool    `json:"resumed,omitempty" description:"Flag to indicate if the session was resumed reusing the key material exchanged in an earlier connection."`
+	LastAlert            *string  `json:"last_alert,omitempty" description:"Last alert that was seen during the connection."`
+	NextProtocol         *string  `json:"next_protocol,omitempty" description:"Next protocol the server chose using the application layer next protocol extension, if present."`
+	Established          *bool    `json:"established,omitempty" description:"Flag to indicate if this ssl session has been established successfully, or if it was aborted during the handshake."`
+	CertChainFUIDs       []string `json:"cert_chain_fuids,omitempty" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the server."`
+	ClientCertChainFUIDs []string `json:"client_cert_chain_fuids,omitempty" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the client."`
+	Subject              *string  `json:"subject,omitempty" description:"Subject of the X.509 certificate offered by the server."`
+	Issuer               *string  `json:"issuer,omitempty" description:"Subject of the signer of the X.509 certificate offered by the server."`
+	ClientSubject        *string  `json:"client_subject,omitempty" description:"Subject of the X.509 certificate offered by the client."`
+	ClientIssuer         *string  `json:"client_issuer,omitempty" description:"Subject of the signer of the X.509 certificate offered by the client."`
+	ValidationStatus     *string  `json:"validation_status,omitempty" description:"Result of certificate validation for this connection."`
+	JA3                  *string  `json:"ja3,omitempty" description:"The JA3 fingerprint of the client, if the JA3 package is loaded."`
+	JA3S                 *string  `json:"ja3s,omitempty" description:"The JA3S fingerprint of the server, if the JA3 package is loaded."`
+
+	// NOTE: added to end of struct to allow expansion later
+	parsers.PantherLog
+}
+
+// SSLParser parses Zeek ssl logs
+type SSLParser struct {
+	reader tsvReader
+}
+
+func (p *SSLParser) New() parsers.LogParser {
+	return &SSLParser{
+		reader: newTSVReader("ssl", "uid", "version", "cipher"),
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *SSLParser) Parse(log string) []*parsers.PantherLog {
+	event := &SSL{}
+	isEvent, err := p.reader.read(log, event)
+	if err != nil {
+		zap.L().Debug("failed to parse log", zap.Error(err))
+		return nil
+	}
+	if !isEvent { // header lines have no events
+		return []*parsers.PantherLog{}
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		zap.L().Debug("failed to validate log", zap.Error(err))
+		return nil
+	}
+	return event.Logs()
+}
+
+// LogType returns the log type supported by this parser
+func (p *SSLParser) LogType() string {
+	return "Zeek.SSL"
+}
+
+func (event *SSL) updatePantherFields(p *SSLParser) {
+	event.SetCoreFields(p.LogType(), event.Ts, event)
+	event.AppendAnyIPAddressPtrs(event.OrigH, event.RespH)
+	event.AppendAnyDomainNamePtrs(event.ServerName)
+}
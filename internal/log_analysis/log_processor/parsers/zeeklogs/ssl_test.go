@@ -0,0 +1,86 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestSSL(t *testing.T) {
+	fields := []string{"ts", "uid", "id.orig_h", "id.orig_p", "id.resp_h", "id.resp_p", "version", "cipher", "curve",
+		"server_name", "resumed", "last_alert", "next_protocol", "established", "cert_chain_fuids", "client_cert_chain_fuids",
+		"subject", "issuer", "client_subject", "client_issuer", "validation_status"}
+	types := []string{"time", "string", "addr", "port", "addr", "port", "string", "string", "string",
+		"string", "bool", "string", "string", "bool", "vector[string]", "vector[string]",
+		"string", "string", "string", "string", "string"}
+	header := zeekHeader("ssl", fields, types)
+	line := tsv("1589277600.123456", "CHhAvVGS1DHFjwGM9", "10.0.0.5", "52345", "93.184.216.34", "443", "TLSv12",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "x25519", "www.example.com", "F", "-", "h2", "T",
+		"FSCSgqDyZxfrBGB6i,FdUzYf1L0XyFB8dSl", "(empty)",
+		`CN=www.example.org,O=Internet Corporation for Assigned Names and Numbers,L=Los Angeles,ST=California,C=US`,
+		"CN=DigiCert SHA2 Secure Server CA,O=DigiCert Inc,C=US", "-", "-", "ok")
+
+	expectedTime := time.Date(2020, 5, 12, 10, 0, 0, 123456000, time.UTC)
+	expectedEvent := &SSL{
+		Ts:  (*timestamp.RFC3339)(&expectedTime),
+		UID: aws.String("CHhAvVGS1DHFjwGM9"),
+		ConnectionID: ConnectionID{
+			OrigH: aws.String("10.0.0.5"),
+			OrigP: aws.Int(52345),
+			RespH: aws.String("93.184.216.34"),
+			RespP: aws.Int(443),
+		},
+		Version:              aws.String("TLSv12"),
+		Cipher:               aws.String("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"),
+		Curve:                aws.String("x25519"),
+		ServerName:           aws.String("www.example.com"),
+		Resumed:              aws.Bool(false),
+		NextProtocol:         aws.String("h2"),
+		Established:          aws.Bool(true),
+		CertChainFUIDs:       []string{"FSCSgqDyZxfrBGB6i", "FdUzYf1L0XyFB8dSl"},
+		ClientCertChainFUIDs: []string{},
+		// the set separator is not escaped in plain strings
+		//nolint
+		Subject:          aws.String("CN=www.example.org,O=Internet Corporation for Assigned Names and Numbers,L=Los Angeles,ST=California,C=US"),
+		Issuer:           aws.String("CN=DigiCert SHA2 Secure Server CA,O=DigiCert Inc,C=US"),
+		ValidationStatus: aws.String("ok"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SSL")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddresses("10.0.0.5", "93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+
+	expectedEvent.SetEvent(expectedEvent)
+	parser := (&SSLParser{}).New()
+	testutil.EqualPantherLog(t, expectedEvent.Log(), parseZeekLog(t, parser, header, line))
+}
+
+func TestSSLType(t *testing.T) {
+	parser := &SSLParser{}
+	require.Equal(t, "Zeek.SSL", parser.LogType())
+}
@@ -0,0 +1,262 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Zeek writes its logs as TSV files, each file starts with header lines describing the columns:
+//
+//	#separator \x09
+//	#set_separator	,
+//	#empty_field	(empty)
+//	#unset_field	-
+//	#path	conn
+//	#open	2020-05-12-10-00-00
+//	#fields	ts	uid	id.orig_h	id.orig_p	id.resp_h	id.resp_p	proto	...
+//	#types	time	string	addr	port	addr	port	enum	...
+//
+// Reference: https://docs.zeek.org/en/current/log-formats.html
+const (
+	zeekSeparator           = `\x09`
+	zeekDefaultSetSeparator = ","
+	zeekDefaultEmptyField   = "(empty)"
+	zeekDefaultUnsetField   = "-"
+)
+
+// nolint:lll
+type ConnectionID struct {
+	OrigH *string `json:"id_orig_h,omitempty" description:"The originator's IP address."`
+	OrigP *int    `json:"id_orig_p,omitempty" validate:"omitempty,min=0,max=65535" description:"The originator's port number."`
+	RespH *string `json:"id_resp_h,omitempty" description:"The responder's IP address."`
+	RespP *int    `json:"id_resp_p,omitempty" validate:"omitempty,min=0,max=65535" description:"The responder's port number."`
+}
+
+// tsvReader reads the lines of a Zeek TSV log file, the columns of the events are read from the header lines.
+// Like VPCFlowParser, the column order is not fixed: Zeek scripts can add and remove columns.
+// NOTE: a reader keeps the header of a file, so it must only be used for the lines of a single file.
+type tsvReader struct {
+	// the #path of the log (e.g. conn)
+	path string
+	// the fields that identify the log when the header has no #path
+	requiredFields []string
+
+	setSeparator string
+	emptyField   string
+	unsetField   string
+	pathMatched  bool
+	fields       []string // column position to JSON field name
+	types        []string // column position to Zeek type
+}
+
+func newTSVReader(path string, requiredFields ...string) tsvReader {
+	return tsvReader{
+		path:           path,
+		requiredFields: requiredFields,
+		setSeparator:   zeekDefaultSetSeparator,
+		emptyField:     zeekDefaultEmptyField,
+		unsetField:     zeekDefaultUnsetField,
+	}
+}
+
+// read reads a line of the file into event. It returns false without error for header lines.
+func (r *tsvReader) read(line string, event interface{}) (bool, error) {
+	if strings.HasPrefix(line, "#") {
+		return false, r.readHeader(line)
+	}
+	if r.fields == nil {
+		return false, errors.New("missing #fields header")
+	}
+	if r.types == nil {
+		return false, errors.New("missing #types header")
+	}
+
+	columns := strings.Split(line, "\t")
+	if len(columns) != len(r.fields) {
+		return false, errors.Errorf("expected %d columns, found %d", len(r.fields), len(columns))
+	}
+
+	// the columns are converted to JSON according to their types and unmarshalled into the event
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+	stream.WriteObjectStart()
+	first := true
+	for i, column := range columns {
+		if column == r.unsetField {
+			continue
+		}
+		if !first {
+			stream.WriteMore()
+		}
+		first = false
+		stream.WriteObjectField(r.fields[i])
+		if err := r.writeValue(stream, r.types[i], column); err != nil {
+			return false, errors.Wrapf(err, "invalid %s", r.fields[i])
+		}
+	}
+	stream.WriteObjectEnd()
+	if stream.Error != nil {
+		return false, stream.Error
+	}
+	return true, jsoniter.Unmarshal(stream.Buffer(), event)
+}
+
+func (r *tsvReader) readHeader(line string) error {
+	// the separator is the only header separated by a space, since the separator is not known yet
+	if strings.HasPrefix(line, "#separator ") {
+		if separator := strings.TrimPrefix(line, "#separator "); separator != zeekSeparator {
+			return errors.Errorf("unsupported separator %s", separator)
+		}
+		return nil
+	}
+
+	var value string
+	header := strings.SplitN(line, "\t", 2)
+	if len(header) == 2 {
+		value = header[1]
+	}
+	switch header[0] {
+	case "#set_separator":
+		r.setSeparator = value
+	case "#empty_field":
+		r.emptyField = value
+	case "#unset_field":
+		r.unsetField = value
+	case "#path":
+		if value != r.path {
+			return errors.Errorf("path %s is not %s", value, r.path)
+		}
+		r.pathMatched = true
+	case "#open", "#close":
+	case "#fields":
+		fields := strings.Split(value, "\t")
+		if !r.pathMatched && !containsAll(fields, r.requiredFields) {
+			return errors.Errorf("fields are not of %s log", r.path)
+		}
+		r.fields = make([]string, len(fields))
+		for i, field := range fields {
+			// record fields are flattened by Zeek (e.g. id.orig_h), dots are not valid in column names
+			r.fields[i] = strings.ReplaceAll(field, ".", "_")
+		}
+		r.types = nil
+	case "#types":
+		types := strings.Split(value, "\t")
+		if len(types) != len(r.fields) {
+			return errors.Errorf("expected %d types, found %d", len(r.fields), len(types))
+		}
+		r.types = types
+	default:
+		return errors.Errorf("unknown header %s", header[0])
+	}
+	return nil
+}
+
+func (r *tsvReader) writeValue(stream *jsoniter.Stream, zeekType, value string) error {
+	// containers are written as arrays, e.g. set[string] or vector[interval]
+	if open := strings.IndexByte(zeekType, '['); open > 0 && strings.HasSuffix(zeekType, "]") {
+		elementType := zeekType[open+1 : len(zeekType)-1]
+		stream.WriteArrayStart()
+		if value != r.emptyField {
+			for i, element := range strings.Split(value, r.setSeparator) {
+				if i > 0 {
+					stream.WriteMore()
+				}
+				if err := r.writeValue(stream, elementType, element); err != nil {
+					return err
+				}
+			}
+		}
+		stream.WriteArrayEnd()
+		return nil
+	}
+
+	switch zeekType {
+	case "time":
+		t, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		stream.WriteString(t.Format(time.RFC3339Nano))
+	case "count", "int", "port":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		stream.WriteInt64(n)
+	case "double", "interval":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		stream.WriteFloat64(f)
+	case "bool":
+		stream.WriteBool(value == "T")
+	default: // string, addr, subnet, enum etc
+		if value == r.emptyField {
+			value = ""
+		}
+		stream.WriteString(value)
+	}
+	return nil
+}
+
+// parseTime parses the seconds since epoch of a Zeek time, e.g. 1589277600.123456
+func parseTime(value string) (time.Time, error) {
+	seconds, fraction := value, ""
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		seconds, fraction = value[:dot], value[dot+1:]
+	}
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int64
+	if fraction != "" {
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+		}
+		nsec, err = strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+func containsAll(values, required []string) bool {
+	for _, r := range required {
+		found := false
+		for _, value := range values {
+			if value == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
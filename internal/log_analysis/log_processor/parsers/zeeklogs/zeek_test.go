@@ -0,0 +1,126 @@
+package zeeklogs
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// tsv joins the columns of a line
+func tsv(columns ...string) string {
+	return strings.Join(columns, "\t")
+}
+
+// zeekHeader returns the header lines of a Zeek log file
+func zeekHeader(path string, fields, types []string) []string {
+	return []string{
+		`#separator \x09`,
+		tsv("#set_separator", ","),
+		tsv("#empty_field", "(empty)"),
+		tsv("#unset_field", "-"),
+		tsv("#path", path),
+		tsv("#open", "2020-05-12-10-00-00"),
+		tsv(append([]string{"#fields"}, fields...)...),
+		tsv(append([]string{"#types"}, types...)...),
+	}
+}
+
+// parseZeekLog parses the header lines of a file, which have no events, then returns the events of the line
+func parseZeekLog(t *testing.T, parser parsers.LogParser, header []string, line string) []*parsers.PantherLog {
+	for _, headerLine := range header {
+		require.Equal(t, []*parsers.PantherLog{}, parser.Parse(headerLine), headerLine)
+	}
+	return parser.Parse(line)
+}
+
+var (
+	testConnFields = []string{"ts", "uid", "id.orig_h", "id.orig_p", "id.resp_h", "id.resp_p", "proto", "service", "duration",
+		"orig_bytes", "resp_bytes", "conn_state", "local_orig", "local_resp", "missed_bytes", "history", "orig_pkts",
+		"orig_ip_bytes", "resp_pkts", "resp_ip_bytes", "tunnel_parents"}
+	testConnTypes = []string{"time", "string", "addr", "port", "addr", "port", "enum", "string", "interval",
+		"count", "count", "string", "bool", "bool", "count", "string", "count",
+		"count", "count", "count", "set[string]"}
+)
+
+func TestZeekOtherPath(t *testing.T) {
+	parser := (&ConnParser{}).New()
+	require.Equal(t, []*parsers.PantherLog{}, parser.Parse(`#separator \x09`))
+	require.Nil(t, parser.Parse(tsv("#path", "dns")))
+}
+
+func TestZeekUnsupportedSeparator(t *testing.T) {
+	parser := (&ConnParser{}).New()
+	require.Nil(t, parser.Parse(`#separator \x2c`))
+}
+
+func TestZeekFieldsWithoutPath(t *testing.T) {
+	// the fields identify the log if there is no #path header
+	parser := (&ConnParser{}).New()
+	require.Equal(t, []*parsers.PantherLog{}, parser.Parse(tsv(append([]string{"#fields"}, testConnFields...)...)))
+
+	parser = (&DNSParser{}).New()
+	require.Nil(t, parser.Parse(tsv(append([]string{"#fields"}, testConnFields...)...)))
+}
+
+func TestZeekMissingHeader(t *testing.T) {
+	line := tsv("1589277600.123456", "CHhAvVGS1DHFjwGM9", "10.0.0.5", "52345", "93.184.216.34", "443", "tcp", "ssl",
+		"1.234567", "517", "4321", "SF", "T", "F", "0", "ShADadFf", "10", "1049", "9", "4833", "(empty)")
+
+	parser := (&ConnParser{}).New()
+	require.Nil(t, parser.Parse(line))
+
+	// the types are needed to convert the columns
+	parser = (&ConnParser{}).New()
+	require.Equal(t, []*parsers.PantherLog{}, parser.Parse(tsv(append([]string{"#fields"}, testConnFields...)...)))
+	require.Nil(t, parser.Parse(line))
+}
+
+func TestZeekColumnCountMismatch(t *testing.T) {
+	parser := (&ConnParser{}).New()
+	header := zeekHeader("conn", testConnFields, testConnTypes)
+	require.Nil(t, parseZeekLog(t, parser, header, tsv("1589277600.123456", "CHhAvVGS1DHFjwGM9")))
+}
+
+func TestZeekInvalidValue(t *testing.T) {
+	parser := (&ConnParser{}).New()
+	header := zeekHeader("conn", testConnFields, testConnTypes)
+	line := tsv("1589277600.123456", "CHhAvVGS1DHFjwGM9", "10.0.0.5", "not a port", "93.184.216.34", "443", "tcp", "ssl",
+		"1.234567", "517", "4321", "SF", "T", "F", "0", "ShADadFf", "10", "1049", "9", "4833", "(empty)")
+	require.Nil(t, parseZeekLog(t, parser, header, line))
+}
+
+func TestZeekParseTime(t *testing.T) {
+	ts, err := parseTime("1589277600.123456")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 5, 12, 10, 0, 0, 123456000, time.UTC), ts)
+
+	ts, err = parseTime("1589277600")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 5, 12, 10, 0, 0, 0, time.UTC), ts)
+
+	_, err = parseTime("yesterday")
+	require.Error(t, err)
+}
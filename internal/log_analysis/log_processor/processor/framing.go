@@ -0,0 +1,198 @@
+package processor
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// maxMultiLineRecordSize bounds the memory used by records spanning many lines, the lines that follow
+	// a record of this size are read as new records (which will likely fail to be classified).
+	// JSON values of this size are read line by line.
+	maxMultiLineRecordSize = 1024 * 1024
+)
+
+// recordReader reads the records of a stream to classify, like bufio.Reader.ReadString()
+// it returns io.EOF together with the last record and errLineTooLarge for the lines it skipped.
+type recordReader interface {
+	ReadRecord() (string, error)
+}
+
+func newRecordReader(stream *bufio.Reader, framing parsers.Framing) recordReader {
+	switch framing.Strategy {
+	case parsers.FramingJSON:
+		reader := &jsonRecordReader{stream: stream}
+		reader.decoder = json.NewDecoder(&recordSizeLimiter{reader: stream, record: reader})
+		return reader
+	case parsers.FramingRegex:
+		if framing.RecordStart != nil {
+			return &regexRecordReader{
+				stream:      stream,
+				recordStart: framing.RecordStart,
+			}
+		}
+	}
+	return &lineRecordReader{stream: stream}
+}
+
+// lineRecordReader reads records terminated by '\n'
+type lineRecordReader struct {
+	stream *bufio.Reader
+}
+
+func (r *lineRecordReader) ReadRecord() (string, error) {
+	return readLine(r.stream)
+}
+
+var errLineTooLarge = errors.New("line is too large")
+
+// readLine reads a line like bufio.Reader.ReadString('\n'), but lines longer than common.MaxLineSize
+// are skipped without being buffered whole and errLineTooLarge is returned instead.
+func readLine(stream *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, err := stream.ReadSlice('\n')
+		if len(line)+len(chunk) > common.MaxLineSize {
+			for err == bufio.ErrBufferFull { // skip the rest of the line
+				_, err = stream.ReadSlice('\n')
+			}
+			if err != nil && err != io.EOF {
+				return "", err
+			}
+			return "", errLineTooLarge
+		}
+		line = append(line, chunk...)
+		if err != bufio.ErrBufferFull {
+			return string(line), err
+		}
+	}
+}
+
+// jsonRecordReader reads a stream of JSON values, which are compacted to a single line.
+// If the stream is not valid JSON or a value is larger than maxMultiLineRecordSize, the rest of it is read
+// line by line (the lines of the value will likely fail to be classified).
+type jsonRecordReader struct {
+	stream  *bufio.Reader
+	decoder *json.Decoder
+	lines   *lineRecordReader // set after the first syntax error
+}
+
+func (r *jsonRecordReader) ReadRecord() (string, error) {
+	if r.lines != nil {
+		return r.lines.ReadRecord()
+	}
+
+	var value json.RawMessage
+	if err := r.decoder.Decode(&value); err != nil {
+		_, isSyntaxError := err.(*json.SyntaxError)
+		if isSyntaxError || err == io.ErrUnexpectedEOF || err == errRecordTooLarge {
+			// the buffer of the decoder starts with the invalid value, after the whitespace following the last value
+			buffered, _ := ioutil.ReadAll(r.decoder.Buffered())
+			buffered = bytes.TrimLeft(buffered, " \t\r\n")
+			r.lines = &lineRecordReader{
+				stream: bufio.NewReader(io.MultiReader(bytes.NewReader(buffered), r.stream)),
+			}
+			return r.lines.ReadRecord()
+		}
+		return "", err // io.EOF when there are no more values
+	}
+
+	var record bytes.Buffer
+	if err := json.Compact(&record, value); err != nil { // not expected since the value was decoded
+		return string(value), nil
+	}
+	return record.String(), nil
+}
+
+var errRecordTooLarge = errors.New("record is too large")
+
+// recordSizeLimiter stops the decoder of a jsonRecordReader from buffering a value larger than maxMultiLineRecordSize
+type recordSizeLimiter struct {
+	reader io.Reader
+	record *jsonRecordReader
+	read   int64
+}
+
+func (r *recordSizeLimiter) Read(p []byte) (int, error) {
+	// the decoder only reads when the value it decodes is incomplete, what it buffered is part of the value
+	allowed := maxMultiLineRecordSize - (r.read - r.record.decoder.InputOffset())
+	if allowed <= 0 {
+		return 0, errRecordTooLarge
+	}
+	if int64(len(p)) > allowed {
+		p = p[:allowed]
+	}
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+// regexRecordReader reads records starting with a line matching a pattern, the following lines
+// that do not match are part of the record. Lines before the first match are read as a record.
+type regexRecordReader struct {
+	stream      *bufio.Reader
+	recordStart *regexp.Regexp
+	next        string // the first line of the next record, already read
+	err         error  // the error to return after the current record (e.g. io.EOF if the next one is the last line)
+}
+
+func (r *regexRecordReader) ReadRecord() (string, error) {
+	var record strings.Builder
+	record.WriteString(r.next)
+	r.next = ""
+	if err := r.err; err != nil {
+		r.err = nil
+		return record.String(), err
+	}
+	for {
+		line, err := readLine(r.stream)
+		if err == errLineTooLarge && record.Len() > 0 {
+			// the record ends before the skipped line
+			r.err = err
+			return record.String(), nil
+		}
+		if record.Len() > 0 && line != "" && r.startsRecord(&record, line) {
+			// the last line of the stream may start a record even without a trailing newline
+			r.next = line
+			r.err = err
+			return record.String(), nil
+		}
+		record.WriteString(line)
+		if err != nil {
+			return record.String(), err
+		}
+	}
+}
+
+// startsRecord returns true if the line starts a new record rather than continuing the current one
+func (r *regexRecordReader) startsRecord(record *strings.Builder, line string) bool {
+	return r.recordStart.MatchString(strings.TrimRight(line, "\r\n")) || record.Len()+len(line) > maxMultiLineRecordSize
+}
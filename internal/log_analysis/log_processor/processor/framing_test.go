@@ -0,0 +1,165 @@
+package processor
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+func readRecords(t *testing.T, input string, framing parsers.Framing) (records []string) {
+	reader := newRecordReader(bufio.NewReader(strings.NewReader(input)), framing)
+	for {
+		record, err := reader.ReadRecord()
+		if err != nil {
+			require.Equal(t, io.EOF, err)
+			return append(records, record)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestLineFraming(t *testing.T) {
+	framing := parsers.Framing{Strategy: parsers.FramingLine}
+	require.Equal(t, []string{"a\n", "b\n", ""}, readRecords(t, "a\nb\n", framing))
+	require.Equal(t, []string{"a\n", "b"}, readRecords(t, "a\nb", framing))
+}
+
+// readRecordsSkipping reads the records of a stream, lines that are too large are read as "<skipped>"
+func readRecordsSkipping(t *testing.T, input string, framing parsers.Framing) (records []string) {
+	reader := newRecordReader(bufio.NewReader(strings.NewReader(input)), framing)
+	for {
+		record, err := reader.ReadRecord()
+		if err == errLineTooLarge {
+			records = append(records, "<skipped>")
+			continue
+		}
+		if err != nil {
+			require.Equal(t, io.EOF, err)
+			return append(records, record)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestLineFramingTooLarge(t *testing.T) {
+	framing := parsers.Framing{Strategy: parsers.FramingLine}
+	large := strings.Repeat("x", common.MaxLineSize+1)
+	require.Equal(t, []string{"a\n", "<skipped>", "b"}, readRecordsSkipping(t, "a\n"+large+"\nb", framing))
+	// the last line of the stream
+	require.Equal(t, []string{"a\n", "<skipped>", ""}, readRecordsSkipping(t, "a\n"+large, framing))
+	// a line of the maximum size is read
+	maxLine := strings.Repeat("x", common.MaxLineSize-1) + "\n"
+	require.Equal(t, []string{maxLine, "b"}, readRecordsSkipping(t, maxLine+"b", framing))
+}
+
+func TestJSONFraming(t *testing.T) {
+	framing := parsers.Framing{Strategy: parsers.FramingJSON}
+	input := `{
+  "a": 1,
+  "b": [1, 2]
+}
+{"c": "d"} {"e":
+  "f"}
+`
+	require.Equal(t, []string{`{"a":1,"b":[1,2]}`, `{"c":"d"}`, `{"e":"f"}`, ""}, readRecords(t, input, framing))
+}
+
+func TestJSONFramingInvalid(t *testing.T) {
+	framing := parsers.Framing{Strategy: parsers.FramingJSON}
+	// after the first invalid value the rest of the stream is read line by line
+	input := "{\"a\":\n1}\n{\"b\" 2}\n{\"c\":\n3}\n"
+	require.Equal(t, []string{`{"a":1}`, "{\"b\" 2}\n", "{\"c\":\n", "3}\n", ""}, readRecords(t, input, framing))
+
+	// truncated value
+	require.Equal(t, []string{`{"a":1}`, `{"b":`}, readRecords(t, "{\"a\":1}\n{\"b\":", framing))
+}
+
+func TestJSONFramingTooLarge(t *testing.T) {
+	framing := parsers.Framing{Strategy: parsers.FramingJSON}
+	// the value is not buffered whole, the rest of the stream is read line by line
+	large := strings.Repeat(`"abcdefgh",`, maxMultiLineRecordSize/10)
+	input := "{\"a\":1}\n{\"b\": [\n" + large + "\n1]}\n{\"c\":3}\n"
+	require.Equal(t, []string{`{"a":1}`, "{\"b\": [\n", large + "\n", "1]}\n", "{\"c\":3}\n", ""},
+		readRecords(t, input, framing))
+}
+
+func TestRegexFraming(t *testing.T) {
+	framing := parsers.Framing{
+		Strategy:    parsers.FramingRegex,
+		RecordStart: regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `),
+	}
+	input := `preamble
+2020-01-01 ERROR failed
+  at main.go:10
+  at main.go:20
+2020-01-01 INFO done
+2020-01-02 ERROR failed
+  at main.go:30`
+	expected := []string{
+		"preamble\n",
+		"2020-01-01 ERROR failed\n  at main.go:10\n  at main.go:20\n",
+		"2020-01-01 INFO done\n",
+		"2020-01-02 ERROR failed\n  at main.go:30",
+	}
+	require.Equal(t, expected, readRecords(t, input, framing))
+
+	// the last line starts a record even without a trailing newline
+	input = "2020-01-01 ERROR a\n  stack\n2020-01-02 ERROR b"
+	expected = []string{"2020-01-01 ERROR a\n  stack\n", "2020-01-02 ERROR b"}
+	require.Equal(t, expected, readRecords(t, input, framing))
+}
+
+func TestRegexFramingMaxRecordSize(t *testing.T) {
+	framing := parsers.Framing{
+		Strategy:    parsers.FramingRegex,
+		RecordStart: regexp.MustCompile(`^START`),
+	}
+	line := strings.Repeat("x", maxMultiLineRecordSize/4) + "\n"
+	records := readRecords(t, "START\n"+strings.Repeat(line, 5), framing)
+	require.Equal(t, []string{"START\n" + strings.Repeat(line, 3), line + line}, records)
+
+	// the bound also applies to the last line without a trailing newline
+	last := strings.TrimSuffix(line, "\n")
+	records = readRecords(t, "START\n"+strings.Repeat(line, 3)+last, framing)
+	require.Equal(t, []string{"START\n" + strings.Repeat(line, 3), last}, records)
+}
+
+func TestRegexFramingLineTooLarge(t *testing.T) {
+	framing := parsers.Framing{
+		Strategy:    parsers.FramingRegex,
+		RecordStart: regexp.MustCompile(`^START`),
+	}
+	large := strings.Repeat("x", common.MaxLineSize+1)
+	records := readRecordsSkipping(t, "START\na\n"+large+"\nb\nSTART\n", framing)
+	require.Equal(t, []string{"START\na\n", "<skipped>", "b\n", "START\n"}, records)
+}
+
+func TestRegexFramingWithoutPattern(t *testing.T) {
+	framing := parsers.Framing{Strategy: parsers.FramingRegex}
+	require.Equal(t, []string{"a\n", "b"}, readRecords(t, "a\nb", framing))
+}
@@ -24,13 +24,16 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/pantherlogs"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
 
@@ -48,6 +51,9 @@ var (
 	// to avoid using up lot of memory.
 	// see also: https://golang.org/doc/effective_go.html#channels
 	ParsedEventBufferSize = 1000
+
+	// Enrichers add fields to the classified events before they are sent to the destination, none by default
+	Enrichers []enrichment.Enricher
 )
 
 // Process orchestrates the tasks of parsing logs, classification, normalization
@@ -106,11 +112,38 @@ func process(dataStreams []*common.DataStream, destination destinations.Destinat
 
 // processStream reads the data from an S3 the dataStream, parses it and writes events to the output channel
 func (p *Processor) run(outputChan chan *parsers.PantherLog) error {
-	var err error
+	if p.input.Records != nil { // the source delimits the records, they can span several lines
+		for _, record := range p.input.Records {
+			p.processLogLine(record, outputChan)
+		}
+		p.logStats(nil)
+		return nil
+	}
+
 	stream := bufio.NewReader(p.input.Reader)
+
+	// "document" logs (e.g. CloudTrail) are parsed as a stream rather than line by line to bound memory usage
+	classified, err := p.classifier.ClassifyStream(stream, func(record []byte, result *classification.ClassifierResult) {
+		if result.LogType == nil {
+			p.sendUnclassified(string(record), result, outputChan)
+			return
+		}
+		p.sendEvents(result, outputChan)
+	})
+	if classified || err != nil {
+		p.logStats(err)
+		return err
+	}
+
+	// records are lines unless the parsers declare a framing for multi-line records
+	records := newRecordReader(stream, p.classifier.Framing())
 	for {
 		var line string
-		line, err = stream.ReadString('\n')
+		line, err = records.ReadRecord()
+		if err == errLineTooLarge {
+			p.skipLine()
+			continue
+		}
 		if err != nil {
 			if err == io.EOF { // we are done
 				err = nil // not really an error
@@ -121,7 +154,7 @@ func (p *Processor) run(outputChan chan *parsers.PantherLog) error {
 		p.processLogLine(line, outputChan)
 	}
 	if err != nil {
-		err = errors.Wrap(err, "failed to ReadString()")
+		err = errors.Wrap(err, "failed to read record")
 	}
 	p.logStats(err) // emit log line describing the processing of the file and any errors
 	return err
@@ -130,6 +163,9 @@ func (p *Processor) run(outputChan chan *parsers.PantherLog) error {
 func (p *Processor) processLogLine(line string, outputChan chan *parsers.PantherLog) {
 	classificationResult := p.classifyLogLine(line)
 	if classificationResult.LogType == nil { // unable to classify, no error, keep parsing (best effort, will be logged)
+		if len(strings.TrimSpace(line)) != 0 {
+			p.sendUnclassified(line, classificationResult, outputChan)
+		}
 		return
 	}
 	p.sendEvents(classificationResult, outputChan)
@@ -144,14 +180,74 @@ func (p *Processor) classifyLogLine(line string) *classification.ClassifierResul
 				zap.String("bucket", p.input.Hints.S3.Bucket),
 				zap.String("key", p.input.Hints.S3.Key))
 		}
+		if p.input.Hints.Kinesis != nil {
+			p.operation.LogWarn(errors.New("failed to classify log line"),
+				zap.Uint64("lineNum", p.classifier.Stats().LogLineCount),
+				zap.String("stream", p.input.Hints.Kinesis.StreamARN))
+		}
+		if p.input.Hints.CloudWatchLogs != nil {
+			p.operation.LogWarn(errors.New("failed to classify log line"),
+				zap.Uint64("lineNum", p.classifier.Stats().LogLineCount),
+				zap.String("logGroup", p.input.Hints.CloudWatchLogs.LogGroup),
+				zap.String("logStream", p.input.Hints.CloudWatchLogs.LogStream))
+		}
+		if p.input.Hints.SaaSAPI != nil {
+			p.operation.LogWarn(errors.New("failed to classify log line"),
+				zap.Uint64("lineNum", p.classifier.Stats().LogLineCount),
+				zap.String("provider", p.input.Hints.SaaSAPI.Provider),
+				zap.String("endpoint", p.input.Hints.SaaSAPI.Endpoint))
+		}
 	}
 	return result
 }
 
 func (p *Processor) sendEvents(result *classification.ClassifierResult, outputChan chan *parsers.PantherLog) {
 	for _, event := range result.Events {
-		outputChan <- event
+		p.sendEvent(event, outputChan)
+	}
+}
+
+// sendEvent enriches a classified event and writes it to the output channel
+func (p *Processor) sendEvent(event *parsers.PantherLog, outputChan chan *parsers.PantherLog) {
+	if p.input.Hints.CloudWatchLogs != nil {
+		event.PantherLogGroup = aws.String(p.input.Hints.CloudWatchLogs.LogGroup)
+		event.PantherLogStream = aws.String(p.input.Hints.CloudWatchLogs.LogStream)
+	}
+	for _, enricher := range p.enrichers {
+		enricher.Enrich(event)
+	}
+	outputChan <- event
+}
+
+// sendUnclassified stores the lines that could not be classified, so they can be queried and replayed after adding a parser
+func (p *Processor) sendUnclassified(line string, result *classification.ClassifierResult, outputChan chan *parsers.PantherLog) {
+	line = strings.TrimRight(line, "\r\n")
+	source := &pantherlogs.UnclassifiedSource{
+		SourceID:    p.input.SourceID,
+		SourceLabel: p.input.SourceLabel,
 	}
+	if p.input.Hints.S3 != nil {
+		source.S3Bucket = p.input.Hints.S3.Bucket
+		source.S3Key = p.input.Hints.S3.Key
+	}
+	if p.input.Hints.CloudWatchLogs != nil {
+		source.LogGroup = p.input.Hints.CloudWatchLogs.LogGroup
+		source.LogStream = p.input.Hints.CloudWatchLogs.LogStream
+	}
+	outputChan <- pantherlogs.NewUnclassified(line, p.classifier.Stats().LogLineCount, source, result.AttemptedLogTypes)
+
+	p.unclassifiedStats.LogLineCount++
+	p.unclassifiedStats.BytesCount += uint64(len(line))
+}
+
+// skipLine counts a line that was too long to be read
+func (p *Processor) skipLine() {
+	p.unclassifiedStats.SkippedLineCount++
+	fields := []zap.Field{zap.Int("maxLineSize", common.MaxLineSize)}
+	if p.input.Hints.S3 != nil {
+		fields = append(fields, zap.String("bucket", p.input.Hints.S3.Bucket), zap.String("key", p.input.Hints.S3.Key))
+	}
+	p.operation.LogWarn(errors.New("skipped line larger than the maximum line size"), fields...)
 }
 
 func (p *Processor) logStats(err error) {
@@ -160,18 +256,38 @@ func (p *Processor) logStats(err error) {
 	for _, parserStats := range p.classifier.ParserStats() {
 		p.operation.Log(err, zap.Any(statsKey, *parserStats))
 	}
+	if p.unclassifiedStats.LogLineCount > 0 || p.unclassifiedStats.SkippedLineCount > 0 {
+		p.operation.Log(err, zap.Any(statsKey, p.unclassifiedStats))
+	}
 }
 
 type Processor struct {
-	input      *common.DataStream
-	classifier classification.ClassifierAPI
-	operation  *oplog.Operation
+	input             *common.DataStream
+	classifier        classification.ClassifierAPI
+	operation         *oplog.Operation
+	enrichers         []enrichment.Enricher
+	unclassifiedStats UnclassifiedStats
+}
+
+// UnclassifiedStats counts the log lines of a source that could not be classified
+type UnclassifiedStats struct {
+	SourceID     string
+	SourceLabel  string
+	LogLineCount uint64 // unclassified input records
+	BytesCount   uint64 // unclassified input bytes
+	// input lines longer than common.MaxLineSize, they are skipped since they cannot be held in memory
+	SkippedLineCount uint64
 }
 
 func NewProcessor(input *common.DataStream) *Processor {
 	return &Processor{
 		input:      input,
-		classifier: classification.NewClassifier(),
+		classifier: classification.NewClassifier(input.LogTypes),
 		operation:  common.OpLogManager.Start(operationName),
+		enrichers:  Enrichers,
+		unclassifiedStats: UnclassifiedStats{
+			SourceID:    input.SourceID,
+			SourceLabel: input.SourceLabel,
+		},
 	}
 }
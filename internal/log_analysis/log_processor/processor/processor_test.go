@@ -19,6 +19,7 @@ package processor
  */
 
 import (
+	"bufio"
 	"fmt"
 	"strings"
 	"testing"
@@ -35,6 +36,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/enrichment"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 	"github.com/panther-labs/panther/pkg/oplog"
@@ -49,6 +51,8 @@ var (
 	testLogLines  uint64 = 2000
 	testLogEvents        = testLogLines // for these tests they are 1-1
 
+	testSourceID    = "testSourceID"
+	testSourceLabel = "testSourceLabel"
 	testBucket      = "testBucket"
 	testKey         = "testKey"
 	testContentType = "testContentType"
@@ -107,6 +111,180 @@ func TestProcess(t *testing.T) {
 	require.Equal(t, testLogEvents, destination.nEvents)
 }
 
+func TestProcessStream(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	dataStream := makeDataStream()
+	p := NewProcessor(dataStream)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	mockStats := &classification.ClassifierStats{
+		LogLineCount:                testLogLines,
+		EventCount:                  testLogLines,
+		SuccessfullyClassifiedCount: testLogLines,
+	}
+	mockParserStats := map[string]*classification.ParserStats{}
+
+	// the whole stream is handled by a stream parser, the line classifier is never called
+	mockClassifier.On("ClassifyStream", mock.Anything, mock.Anything).Return(true, nil).Run(func(args mock.Arguments) {
+		emit := args.Get(1).(func(record []byte, result *classification.ClassifierResult))
+		for i := uint64(0); i < testLogLines-1; i++ {
+			emit([]byte("{}"), &classification.ClassifierResult{
+				Events:  []*parsers.PantherLog{newTestLog()},
+				LogType: &testLogType,
+			})
+		}
+		// the record that failed is sent as unclassified
+		emit([]byte("{}"), &classification.ClassifierResult{AttemptedLogTypes: []string{testLogType}})
+	})
+	mockClassifier.On("Stats", mock.Anything).Return(mockStats)
+	mockClassifier.On("ParserStats", mock.Anything).Return(mockParserStats)
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	err := process([]*common.DataStream{dataStream}, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, testLogEvents, destination.nEvents)
+	require.Equal(t, uint64(1), p.unclassifiedStats.LogLineCount)
+	mockClassifier.AssertNotCalled(t, "Classify", mock.Anything)
+}
+
+// countingEnricher counts the events it enriches
+type countingEnricher struct {
+	nEvents int
+}
+
+func (e *countingEnricher) Enrich(event *parsers.PantherLog) {
+	e.nEvents++
+	event.AppendAnyIPCountries("US")
+}
+
+func TestProcessEnrichers(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	dataStream := makeDataStream()
+	p := NewProcessor(dataStream)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+	enricher := &countingEnricher{}
+	p.enrichers = []enrichment.Enricher{enricher}
+
+	mockStats := &classification.ClassifierStats{
+		LogLineCount:                testLogLines,
+		EventCount:                  testLogLines,
+		SuccessfullyClassifiedCount: testLogLines,
+	}
+	mockParserStats := map[string]*classification.ParserStats{}
+	mockClassifier.standardMocks(mockStats, mockParserStats)
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	err := process([]*common.DataStream{dataStream}, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, testLogEvents, destination.nEvents)
+	require.Equal(t, int(testLogEvents), enricher.nEvents)
+}
+
+func TestProcessFraming(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	dataStream := &common.DataStream{
+		Reader:  strings.NewReader("{\n  \"a\": 1\n}\n{\n  \"a\": 2\n}\n"),
+		LogType: &testLogType,
+		Hints:   common.DataStreamHints{S3: s3Hint},
+	}
+	p := NewProcessor(dataStream)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	// the records are aggregated before classification
+	mockClassifier.On("Classify", `{"a":1}`).Return(&classification.ClassifierResult{
+		Events:  []*parsers.PantherLog{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Classify", `{"a":2}`).Return(&classification.ClassifierResult{
+		Events:  []*parsers.PantherLog{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Classify", "").Return(&classification.ClassifierResult{}).Once()
+	mockClassifier.On("ClassifyStream", mock.Anything, mock.Anything).Return(false, nil)
+	mockClassifier.On("Framing").Return(parsers.Framing{Strategy: parsers.FramingJSON})
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	newProcessorFunc := func(*common.DataStream) *Processor { return p }
+	err := process([]*common.DataStream{dataStream}, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), destination.nEvents)
+	mockClassifier.AssertExpectations(t)
+}
+
+func TestProcessRecords(t *testing.T) {
+	dataStream := &common.DataStream{
+		Records: []string{"line1\n  line2\n", "line3"},
+		LogType: &testLogType,
+		Hints: common.DataStreamHints{
+			CloudWatchLogs: &common.CloudWatchLogsDataStreamHints{LogGroup: "group", LogStream: "stream"},
+		},
+	}
+	p := NewProcessor(dataStream)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	// each record is classified as a whole, even if it spans several lines
+	mockClassifier.On("Classify", "line1\n  line2\n").Return(&classification.ClassifierResult{
+		Events:  []*parsers.PantherLog{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Classify", "line3").Return(&classification.ClassifierResult{
+		Events:  []*parsers.PantherLog{newTestLog()},
+		LogType: &testLogType,
+	}).Once()
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	outputChan := make(chan *parsers.PantherLog, 2)
+	require.NoError(t, p.run(outputChan))
+	close(outputChan)
+	var nEvents int
+	for event := range outputChan {
+		nEvents++
+		require.Equal(t, "group", *event.PantherLogGroup)
+		require.Equal(t, "stream", *event.PantherLogStream)
+	}
+	require.Equal(t, 2, nEvents)
+	mockClassifier.AssertExpectations(t)
+}
+
+func TestProcessSkipsLinesTooLarge(t *testing.T) {
+	dataStream := &common.DataStream{
+		Reader:  strings.NewReader("line1\n" + strings.Repeat("x", common.MaxLineSize+1) + "\nline2"),
+		LogType: &testLogType,
+		Hints:   common.DataStreamHints{S3: s3Hint},
+	}
+	p := NewProcessor(dataStream)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	// the line that is too large is not classified
+	mockClassifier.On("ClassifyStream", mock.Anything, mock.Anything).Return(false, nil)
+	mockClassifier.On("Framing").Return(parsers.Framing{Strategy: parsers.FramingLine})
+	for _, line := range []string{"line1\n", "line2"} {
+		mockClassifier.On("Classify", line).Return(&classification.ClassifierResult{
+			Events:  []*parsers.PantherLog{newTestLog()},
+			LogType: &testLogType,
+		}).Once()
+	}
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	outputChan := make(chan *parsers.PantherLog, 2)
+	require.NoError(t, p.run(outputChan))
+	close(outputChan)
+	require.Len(t, outputChan, 2)
+	require.Equal(t, uint64(1), p.unclassifiedStats.SkippedLineCount)
+	mockClassifier.AssertExpectations(t)
+}
+
 func TestProcessDataStreamError(t *testing.T) {
 	logs := mockLogger()
 
@@ -139,7 +317,7 @@ func TestProcessDataStreamError(t *testing.T) {
 			zap.Any(statsKey, *mockStats),
 
 			// error
-			zap.Error(errors.Wrap(errFailingReader, "failed to ReadString()")), // from run()
+			zap.Error(errors.Wrap(errFailingReader, "failed to read record")), // from run()
 
 			// standard
 			zap.String("namespace", common.OpLogNamespace),
@@ -214,6 +392,8 @@ func TestProcessClassifyFailure(t *testing.T) {
 
 	destination := (&testDestination{}).standardMock()
 	dataStream := makeDataStream()
+	dataStream.SourceID = testSourceID
+	dataStream.SourceLabel = testSourceLabel
 	p := NewProcessor(dataStream)
 	mockClassifier := &testClassifier{}
 	p.classifier = mockClassifier
@@ -245,6 +425,8 @@ func TestProcessClassifyFailure(t *testing.T) {
 		Events:  []*parsers.PantherLog{newTestLog()},
 		LogType: &testLogType,
 	})
+	mockClassifier.On("ClassifyStream", mock.Anything, mock.Anything).Return(false, nil)
+	mockClassifier.On("Framing").Return(parsers.Framing{Strategy: parsers.FramingLine})
 	mockClassifier.On("Stats", mock.Anything).Return(mockStats)
 	mockClassifier.On("ParserStats", mock.Anything).Return(mockParserStats)
 
@@ -252,6 +434,9 @@ func TestProcessClassifyFailure(t *testing.T) {
 	err := process([]*common.DataStream{dataStream}, destination, newProcessorFunc)
 	require.NoError(t, err)
 
+	// the line that failed is sent as unclassified
+	require.Equal(t, testLogEvents, destination.nEvents)
+
 	actual := logs.AllUntimed()
 	expected := []observer.LoggedEntry{
 		{
@@ -305,6 +490,30 @@ func TestProcessClassifyFailure(t *testing.T) {
 				// custom
 				zap.Any(statsKey, *mockParserStats[testLogType]),
 
+				// standard
+				zap.String("namespace", common.OpLogNamespace),
+				zap.String("component", common.OpLogComponent),
+				zap.String("operation", operationName),
+				zap.String("status", oplog.Success),
+				zap.Time("startOp", p.operation.StartTime),
+				zap.Duration("opTime", p.operation.EndTime.Sub(p.operation.StartTime)),
+				zap.Time("endOp", p.operation.EndTime),
+			},
+		},
+		{
+			Entry: zapcore.Entry{
+				Level:   zapcore.InfoLevel,
+				Message: common.OpLogNamespace + ":" + common.OpLogComponent + ":" + operationName,
+			},
+			Context: []zapcore.Field{
+				// custom
+				zap.Any(statsKey, UnclassifiedStats{
+					SourceID:     testSourceID,
+					SourceLabel:  testSourceLabel,
+					LogLineCount: 1,
+					BytesCount:   uint64(len(testLogLine)),
+				}),
+
 				// standard
 				zap.String("namespace", common.OpLogNamespace),
 				zap.String("component", common.OpLogComponent),
@@ -369,6 +578,18 @@ func (c *testClassifier) Classify(log string) *classification.ClassifierResult {
 	return args.Get(0).(*classification.ClassifierResult)
 }
 
+func (c *testClassifier) ClassifyStream(stream *bufio.Reader,
+	emit func(record []byte, result *classification.ClassifierResult)) (bool, error) {
+
+	args := c.Called(stream, emit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (c *testClassifier) Framing() parsers.Framing {
+	args := c.Called()
+	return args.Get(0).(parsers.Framing)
+}
+
 func (c *testClassifier) Stats() *classification.ClassifierStats {
 	args := c.Called()
 	return args.Get(0).(*classification.ClassifierStats)
@@ -385,6 +606,8 @@ func (c *testClassifier) standardMocks(cStats *classification.ClassifierStats, p
 		Events:  []*parsers.PantherLog{newTestLog()},
 		LogType: &testLogType,
 	}).After(parseDelay)
+	c.On("ClassifyStream", mock.Anything, mock.Anything).Return(false, nil)
+	c.On("Framing").Return(parsers.Framing{Strategy: parsers.FramingLine})
 	c.On("Stats", mock.Anything).Return(cStats)
 	c.On("ParserStats", mock.Anything).Return(pStats)
 }
@@ -0,0 +1,138 @@
+package pullsources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+// provider reads the audit log API of a SaaS platform
+type provider interface {
+	// newRequest returns the request of the first page of the events with time in [since, until]
+	newRequest(endpoint string, since, until time.Time) (*http.Request, error)
+	// authorize adds the API token to a request
+	authorize(request *http.Request, token string)
+	// readPage returns the events of a page and the request of the next page, nil if it is the last page
+	readPage(request *http.Request, response *http.Response) ([]jsoniter.RawMessage, *http.Request, error)
+	// eventTime returns the time an event happened
+	eventTime(event jsoniter.RawMessage) (time.Time, error)
+}
+
+var providers = map[string]provider{
+	models.SaaSProviderOkta: oktaProvider{},
+}
+
+// oktaProvider reads the Okta System Log API, the endpoint is https://<org>.okta.com/api/v1/logs
+type oktaProvider struct{}
+
+const oktaTimeLayout = "2006-01-02T15:04:05.000Z"
+
+func (oktaProvider) newRequest(endpoint string, since, until time.Time) (*http.Request, error) {
+	// until is exclusive for Okta, events at the end of the range must be included
+	return newRequest(endpoint, map[string]string{
+		"since":     since.UTC().Format(oktaTimeLayout),
+		"until":     until.Add(time.Millisecond).UTC().Format(oktaTimeLayout),
+		"sortOrder": "ASCENDING",
+		"limit":     "1000",
+	})
+}
+
+func (oktaProvider) authorize(request *http.Request, token string) {
+	request.Header.Set("Authorization", "SSWS "+token)
+}
+
+func (oktaProvider) readPage(request *http.Request, response *http.Response) ([]jsoniter.RawMessage, *http.Request, error) {
+	return readLinkedPage(request, response)
+}
+
+func (oktaProvider) eventTime(event jsoniter.RawMessage) (time.Time, error) {
+	published := jsoniter.Get(event, "published")
+	if published.ValueType() != jsoniter.StringValue {
+		return time.Time{}, errors.New("missing published")
+	}
+	return time.Parse(time.RFC3339Nano, published.ToString())
+}
+
+// newRequest returns a GET request of the endpoint with the parameters added to its query
+func newRequest(endpoint string, params map[string]string) (*http.Request, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	query := endpointURL.Query()
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	endpointURL.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, endpointURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	return request, nil
+}
+
+// readLinkedPage reads a page that is a JSON array of events, the next page is linked in the Link header
+func readLinkedPage(request *http.Request, response *http.Response) ([]jsoniter.RawMessage, *http.Request, error) {
+	var events []jsoniter.RawMessage
+	if err := jsoniter.NewDecoder(response.Body).Decode(&events); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid page")
+	}
+
+	link := nextLink(response.Header)
+	if link == "" {
+		return events, nil, nil
+	}
+	next, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid next link %s", link)
+	}
+	next.Header.Set("Accept", request.Header.Get("Accept"))
+	return events, next, nil
+}
+
+// nextLink returns the target of the rel="next" link of a Link header (RFC 8288), empty if there is none
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		// <https://example.com/logs?after=1>; rel="next", <https://example.com/logs>; rel="self"
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				param = strings.ReplaceAll(strings.TrimSpace(param), " ", "")
+				if strings.EqualFold(param, `rel="next"`) || strings.EqualFold(param, "rel=next") {
+					return strings.Trim(target, "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
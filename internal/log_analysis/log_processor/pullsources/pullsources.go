@@ -0,0 +1,290 @@
+package pullsources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	sourceAPIFunctionName = "panther-source-api"
+
+	// The first pull of an integration starts this long before the integration was created
+	initialLookback = 24 * time.Hour
+	// Events are pulled once they are this old, SaaS platforms can take a while to make new events available
+	pullDelay = 5 * time.Minute
+	// The longest time range pulled at once, this bounds the events buffered in memory when catching up
+	maxPullWindow = time.Hour
+	// Guards against endpoints returning next pages forever
+	maxPages = 1000
+
+	httpTimeout = 30 * time.Second
+)
+
+var (
+	httpClient                                          = &http.Client{Timeout: httpTimeout}
+	lambdaClient  lambdaiface.LambdaAPI                 = lambda.New(common.Session)
+	secretsClient secretsmanageriface.SecretsManagerAPI = secretsmanager.New(common.Session)
+)
+
+// Pull is the outcome of pulling the new events of a SaaS integration
+type Pull struct {
+	Integration *models.SourceIntegration
+	// The pulled events, one JSON object per line. It is nil if there were no new events or the pull failed.
+	DataStream *common.DataStream
+	// The cursor following the pulled events, empty if the cursor did not move.
+	// It must only be stored once the events have been processed.
+	Cursor string
+	Err    error
+}
+
+// PullAll pulls the new events of all SaaS integrations and returns how many were pulled.
+//
+// The integrations are pulled one at a time: the events of an integration are processed and its cursor is
+// checkpointed before the next one is pulled. This bounds the events held in memory to a single pull and if the
+// function times out, only the integrations that were not checkpointed are pulled again.
+// An integration failing to pull or process does not stop the others, the last error is returned.
+func PullAll(ctx context.Context, now time.Time, process func(*common.DataStream) error) (int, error) {
+	integrations, err := listIntegrations()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, integration := range integrations {
+		pull := PullIntegration(ctx, integration, now)
+		if pull.Err != nil {
+			zap.L().Warn("failed to pull events of SaaS integration",
+				zap.String("integrationId", aws.StringValue(integration.IntegrationID)),
+				zap.Error(pull.Err))
+		}
+		if pull.DataStream != nil {
+			if processErr := process(pull.DataStream); processErr != nil {
+				pull.Fail(errors.Wrap(processErr, "failed to process pulled events"))
+				err = processErr
+			}
+		}
+		if checkpointErr := Checkpoint(pull, now); checkpointErr != nil {
+			zap.L().Error("failed to checkpoint pull", zap.Error(checkpointErr))
+			err = checkpointErr
+		}
+	}
+	return len(integrations), err
+}
+
+// PullIntegration pulls the events of a SaaS integration that happened after its cursor.
+//
+// The cursor is the end of the time range of the last pull. Every pull covers the time range from the cursor
+// until pullDelay ago (at most maxPullWindow), so there are no gaps or overlaps between pulls.
+func PullIntegration(ctx context.Context, integration *models.SourceIntegration, now time.Time) *Pull {
+	pull := &Pull{Integration: integration}
+
+	provider, ok := providers[aws.StringValue(integration.SaaSProvider)]
+	if !ok {
+		pull.Err = errors.Errorf("unknown SaaS provider %q", aws.StringValue(integration.SaaSProvider))
+		return pull
+	}
+
+	since, err := cursorTime(integration)
+	if err != nil {
+		pull.Err = err
+		return pull
+	}
+	until := now.Add(-pullDelay).Truncate(time.Second)
+	if until.Sub(since) > maxPullWindow {
+		until = since.Add(maxPullWindow)
+	}
+	if !until.After(since) { // pulled recently
+		return pull
+	}
+
+	token, err := getAPIToken(*integration.IntegrationID)
+	if err != nil {
+		pull.Err = err
+		return pull
+	}
+
+	endpoint := aws.StringValue(integration.SaaSEndpoint)
+	events, err := pullEvents(ctx, provider, endpoint, token, since, until)
+	if err != nil {
+		pull.Err = err
+		return pull
+	}
+
+	pull.Cursor = until.Format(time.RFC3339Nano)
+	if len(events) > 0 {
+		pull.DataStream = newDataStream(integration, events)
+	}
+	return pull
+}
+
+// Checkpoint stores the cursor of a pull, it must be called after its events have been processed.
+// The error of a failed pull is stored as well, so it can be shown with the integration.
+func Checkpoint(pull *Pull, now time.Time) error {
+	input := &models.UpdateIntegrationLastPullInput{
+		IntegrationID:        pull.Integration.IntegrationID,
+		LastPullTime:         aws.Time(now),
+		LastPullErrorMessage: aws.String(""), // clears the error of a previous pull
+	}
+	if pull.Err != nil {
+		input.LastPullErrorMessage = aws.String(pull.Err.Error())
+	}
+	if pull.Cursor != "" {
+		input.PullCursor = aws.String(pull.Cursor)
+	}
+
+	err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName,
+		&models.LambdaInput{UpdateIntegrationLastPull: input}, nil)
+	return errors.Wrapf(err, "failed to store cursor of SaaS integration %s", *input.IntegrationID)
+}
+
+// Fail marks a pull as failed after its events could not be processed, the cursor does not move
+// so the events are pulled again
+func (pull *Pull) Fail(err error) {
+	pull.Cursor = ""
+	pull.Err = err
+}
+
+// pullEvents reads the pages of the events with time in (since, until]
+func pullEvents(ctx context.Context, provider provider, endpoint, token string, since, until time.Time) ([][]byte, error) {
+	request, err := provider.newRequest(endpoint, since, until)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SaaS API endpoint %s", endpoint)
+	}
+
+	var events [][]byte
+	host := request.URL.Host
+	for page := 0; request != nil; page++ {
+		if page == maxPages {
+			return nil, errors.Errorf("pull of %s exceeded %d pages", endpoint, maxPages)
+		}
+		// the token must not be sent to another host than the configured endpoint, even if a page links to it
+		if request.URL.Host != host {
+			return nil, errors.Errorf("page of %s links to another host %s", endpoint, request.URL.Host)
+		}
+
+		var pageEvents []jsoniter.RawMessage
+		pageEvents, request, err = readPage(ctx, provider, request, token)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to pull %s", endpoint)
+		}
+
+		for _, event := range pageEvents {
+			eventTime, err := provider.eventTime(event)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read time of event pulled from %s", endpoint)
+			}
+			// the time ranges of the APIs include their bounds, the events at the start were in the last pull
+			if !eventTime.After(since) || eventTime.After(until) {
+				continue
+			}
+			// each event must be a single line
+			var line bytes.Buffer
+			if err := json.Compact(&line, event); err != nil {
+				return nil, errors.Wrapf(err, "invalid event pulled from %s", endpoint)
+			}
+			events = append(events, line.Bytes())
+		}
+	}
+	return events, nil
+}
+
+func readPage(ctx context.Context, provider provider, request *http.Request,
+	token string) ([]jsoniter.RawMessage, *http.Request, error) {
+
+	provider.authorize(request, token)
+	response, err := httpClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	// the body of errors is not logged, it may contain sensitive details of the account
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, errors.Errorf("unexpected status %s", response.Status)
+	}
+	return provider.readPage(request, response)
+}
+
+func newDataStream(integration *models.SourceIntegration, events [][]byte) *common.DataStream {
+	data := bytes.Join(events, []byte("\n"))
+	return &common.DataStream{
+		Reader: bytes.NewReader(data),
+		Hints: common.DataStreamHints{
+			SaaSAPI: &common.SaaSAPIDataStreamHints{
+				Provider: aws.StringValue(integration.SaaSProvider),
+				Endpoint: aws.StringValue(integration.SaaSEndpoint),
+			},
+		},
+		LogTypes:    aws.StringValueSlice(integration.LogTypes),
+		SourceID:    aws.StringValue(integration.IntegrationID),
+		SourceLabel: aws.StringValue(integration.IntegrationLabel),
+	}
+}
+
+// cursorTime returns the end of the time range of the last pull
+func cursorTime(integration *models.SourceIntegration) (time.Time, error) {
+	if integration.SourceIntegrationPullInformation != nil && aws.StringValue(integration.PullCursor) != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, *integration.PullCursor)
+		return cursor, errors.Wrapf(err, "invalid cursor %s", *integration.PullCursor)
+	}
+	// first pull
+	createdAt := time.Now()
+	if integration.SourceIntegrationMetadata != nil && integration.CreatedAtTime != nil {
+		createdAt = *integration.CreatedAtTime
+	}
+	return createdAt.Add(-initialLookback).Truncate(time.Second), nil
+}
+
+func listIntegrations() (integrations []*models.SourceIntegration, err error) {
+	input := &models.LambdaInput{
+		ListIntegrations: &models.ListIntegrationsInput{
+			IntegrationType: aws.String(models.IntegrationTypeSaaSAPI),
+		},
+	}
+	// the cursors must be current, the integrations are not cached
+	err = genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &integrations)
+	return integrations, errors.Wrap(err, "failed to list SaaS integrations")
+}
+
+func getAPIToken(integrationID string) (string, error) {
+	output, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(models.SaaSAPITokenSecretPrefix + integrationID),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read SaaS API token")
+	}
+	return aws.StringValue(output.SecretString), nil
+}
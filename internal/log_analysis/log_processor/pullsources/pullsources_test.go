@@ -0,0 +1,295 @@
+package pullsources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testIntegrationID = "45c378a7-2e36-4b12-8e16-2d3c49ff1371"
+
+var (
+	testNow    = time.Date(2020, 5, 12, 10, 0, 0, 0, time.UTC)
+	testSince  = time.Date(2020, 5, 12, 9, 30, 0, 0, time.UTC)
+	testUntil  = testNow.Add(-pullDelay)
+	testCursor = testSince.Format(time.RFC3339Nano)
+)
+
+type mockSecretsClient struct {
+	secretsmanageriface.SecretsManagerAPI
+	mock.Mock
+}
+
+func (m *mockSecretsClient) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.GetSecretValueOutput), args.Error(1)
+}
+
+func mockToken() *mockSecretsClient {
+	secretsMock := &mockSecretsClient{}
+	secretsClient = secretsMock
+	secretsMock.On("GetSecretValue", &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String("panther-saas-api-token-" + testIntegrationID),
+	}).Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String("secret")}, nil)
+	return secretsMock
+}
+
+func testIntegration(provider, endpoint string) *models.SourceIntegration {
+	return &models.SourceIntegration{
+		SourceIntegrationMetadata: &models.SourceIntegrationMetadata{
+			IntegrationID:    aws.String(testIntegrationID),
+			IntegrationLabel: aws.String("test"),
+			IntegrationType:  aws.String(models.IntegrationTypeSaaSAPI),
+			LogTypes:         aws.StringSlice([]string{"Okta.SystemLog"}),
+			SaaSProvider:     aws.String(provider),
+			SaaSEndpoint:     aws.String(endpoint),
+		},
+		SourceIntegrationPullInformation: &models.SourceIntegrationPullInformation{
+			PullCursor: aws.String(testCursor),
+		},
+	}
+}
+
+func readStream(t *testing.T, pull *Pull) string {
+	require.NotNil(t, pull.DataStream)
+	data, err := ioutil.ReadAll(pull.DataStream.Reader)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPullIntegrationOkta(t *testing.T) {
+	mockToken()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SSWS secret", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("after") == "" {
+			assert.Equal(t, "2020-05-12T09:30:00.000Z", r.URL.Query().Get("since"))
+			assert.Equal(t, "2020-05-12T09:55:00.001Z", r.URL.Query().Get("until"))
+			assert.Equal(t, "ASCENDING", r.URL.Query().Get("sortOrder"))
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/logs>; rel="self", <%s/api/v1/logs?after=2>; rel="next"`,
+				server.URL, server.URL))
+			// the first event was in the last pull
+			fmt.Fprint(w, `[{"uuid": "1", "published": "2020-05-12T09:30:00.000Z"},
+				{"uuid": "2", "published": "2020-05-12T09:31:00.000Z"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"uuid": "3", "published": "2020-05-12T09:55:00.000Z"}]`)
+	}))
+	defer server.Close()
+
+	integration := testIntegration(models.SaaSProviderOkta, server.URL+"/api/v1/logs")
+	pull := PullIntegration(context.Background(), integration, testNow)
+	require.NoError(t, pull.Err)
+	assert.Equal(t, testUntil.Format(time.RFC3339Nano), pull.Cursor)
+	assert.Equal(t, `{"uuid":"2","published":"2020-05-12T09:31:00.000Z"}`+"\n"+
+		`{"uuid":"3","published":"2020-05-12T09:55:00.000Z"}`, readStream(t, pull))
+	assert.Equal(t, models.SaaSProviderOkta, pull.DataStream.Hints.SaaSAPI.Provider)
+	assert.Equal(t, []string{"Okta.SystemLog"}, pull.DataStream.LogTypes)
+	assert.Equal(t, testIntegrationID, pull.DataStream.SourceID)
+}
+
+func TestPullIntegrationNoEvents(t *testing.T) {
+	mockToken()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	pull := PullIntegration(context.Background(), testIntegration(models.SaaSProviderOkta, server.URL), testNow)
+	require.NoError(t, pull.Err)
+	assert.Nil(t, pull.DataStream)
+	// the cursor moves even if there were no events
+	assert.Equal(t, testUntil.Format(time.RFC3339Nano), pull.Cursor)
+}
+
+func TestPullIntegrationMaxWindow(t *testing.T) {
+	mockToken()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2020-05-12T10:30:00.001Z", r.URL.Query().Get("until"))
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	pull := PullIntegration(context.Background(), testIntegration(models.SaaSProviderOkta, server.URL), testNow.Add(6*time.Hour))
+	require.NoError(t, pull.Err)
+	assert.Equal(t, testSince.Add(maxPullWindow).Format(time.RFC3339Nano), pull.Cursor)
+}
+
+func TestPullIntegrationUpToDate(t *testing.T) {
+	secretsMock := mockToken()
+	pull := PullIntegration(context.Background(), testIntegration(models.SaaSProviderOkta, "https://example.okta.com"),
+		testSince.Add(pullDelay))
+	require.NoError(t, pull.Err)
+	assert.Nil(t, pull.DataStream)
+	assert.Empty(t, pull.Cursor)
+	secretsMock.AssertNotCalled(t, "GetSecretValue", mock.Anything)
+}
+
+func TestPullIntegrationErrorStatus(t *testing.T) {
+	mockToken()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errorSummary": "Invalid token provided"}`)
+	}))
+	defer server.Close()
+
+	pull := PullIntegration(context.Background(), testIntegration(models.SaaSProviderOkta, server.URL), testNow)
+	require.Error(t, pull.Err)
+	assert.Contains(t, pull.Err.Error(), "401 Unauthorized")
+	assert.Nil(t, pull.DataStream)
+	// the events are pulled again
+	assert.Empty(t, pull.Cursor)
+}
+
+func TestPullIntegrationLinkToOtherHost(t *testing.T) {
+	mockToken()
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("token sent to other host")
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, other.URL))
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	pull := PullIntegration(context.Background(), testIntegration(models.SaaSProviderOkta, server.URL), testNow)
+	require.Error(t, pull.Err)
+	assert.Contains(t, pull.Err.Error(), "links to another host")
+}
+
+func TestPullIntegrationUnknownProvider(t *testing.T) {
+	pull := PullIntegration(context.Background(), testIntegration("unknown", "https://example.com"), testNow)
+	require.Error(t, pull.Err)
+}
+
+func TestPullIntegrationFirstPull(t *testing.T) {
+	integration := testIntegration(models.SaaSProviderOkta, "https://example.okta.com")
+	integration.SourceIntegrationPullInformation = nil
+	integration.CreatedAtTime = aws.Time(testNow)
+	since, err := cursorTime(integration)
+	require.NoError(t, err)
+	assert.Equal(t, testNow.Add(-initialLookback), since)
+}
+
+func TestCheckpoint(t *testing.T) {
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Twice()
+
+	pull := &Pull{
+		Integration: testIntegration(models.SaaSProviderOkta, "https://example.okta.com"),
+		Cursor:      "2020-05-12T09:55:00Z",
+	}
+	require.NoError(t, Checkpoint(pull, testNow))
+	// the events could not be processed
+	pull.Fail(fmt.Errorf("unexpected status 401"))
+	require.NoError(t, Checkpoint(pull, testNow))
+	lambdaMock.AssertExpectations(t)
+
+	var input models.LambdaInput
+	payload := lambdaMock.Calls[0].Arguments.Get(0).(*lambda.InvokeInput).Payload
+	require.NoError(t, jsoniter.Unmarshal(payload, &input))
+	assert.Equal(t, "2020-05-12T09:55:00Z", *input.UpdateIntegrationLastPull.PullCursor)
+	assert.Equal(t, "", *input.UpdateIntegrationLastPull.LastPullErrorMessage)
+
+	payload = lambdaMock.Calls[1].Arguments.Get(0).(*lambda.InvokeInput).Payload
+	require.NoError(t, jsoniter.Unmarshal(payload, &input))
+	assert.Nil(t, input.UpdateIntegrationLastPull.PullCursor)
+	assert.Equal(t, "unexpected status 401", *input.UpdateIntegrationLastPull.LastPullErrorMessage)
+}
+
+func TestPullAll(t *testing.T) {
+	mockToken()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"uuid": "2", "published": "2020-05-12T09:31:00.000Z"}]`)
+	}))
+	defer server.Close()
+
+	integrations := []*models.SourceIntegration{
+		testIntegration(models.SaaSProviderOkta, server.URL+"/api/v1/logs"),
+		testIntegration(models.SaaSProviderOkta, server.URL+"/api/v1/logs"),
+	}
+	listPayload, err := jsoniter.Marshal(integrations)
+	require.NoError(t, err)
+
+	// the order of the calls shows each integration is checkpointed before the next one is pulled
+	var calls []string
+	recordCheckpoint := func(args mock.Arguments) {
+		var input models.LambdaInput
+		require.NoError(t, jsoniter.Unmarshal(args.Get(0).(*lambda.InvokeInput).Payload, &input))
+		require.NotNil(t, input.UpdateIntegrationLastPull)
+		calls = append(calls, "checkpoint "+aws.StringValue(input.UpdateIntegrationLastPull.PullCursor))
+	}
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: listPayload}, nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Run(recordCheckpoint).Twice()
+
+	// the events of the second integration could not be processed
+	pullCount, err := PullAll(context.Background(), testNow, func(dataStream *common.DataStream) error {
+		calls = append(calls, "process")
+		if len(calls) > 2 {
+			return fmt.Errorf("failed to write events")
+		}
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, 2, pullCount)
+	lambdaMock.AssertExpectations(t)
+	assert.Equal(t, []string{
+		"process", "checkpoint " + testUntil.Format(time.RFC3339Nano),
+		"process", "checkpoint ",
+	}, calls)
+}
+
+func TestNextLink(t *testing.T) {
+	header := http.Header{}
+	assert.Empty(t, nextLink(header))
+	header.Add("Link", `<https://example.com/logs?after=1>; rel="self"`)
+	assert.Empty(t, nextLink(header))
+	header.Add("Link", `<https://example.com/logs?after=2>; rel="next"`)
+	assert.Equal(t, "https://example.com/logs?after=2", nextLink(header))
+
+	header = http.Header{}
+	header.Set("Link", `<https://api.github.com/orgs/panther/audit-log?after=abc>; rel="next", `+
+		`<https://api.github.com/orgs/panther/audit-log?before=def>; rel="prev"`)
+	assert.Equal(t, "https://api.github.com/orgs/panther/audit-log?after=abc", nextLink(header))
+	assert.False(t, strings.Contains(nextLink(header), ">"))
+}
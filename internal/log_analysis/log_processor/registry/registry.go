@@ -23,10 +23,16 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fluentdsyslogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gsuitelogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/k8slogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/nginxlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/oktalogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osquerylogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osseclogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/pantherlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/suricatalogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/zeeklogs"
 	"github.com/panther-labs/panther/pkg/awsglue"
 )
 
@@ -51,6 +57,16 @@ var (
 			&awslogs.AuroraMySQLAudit{}, awslogs.AuroraMySQLAuditDesc),
 		(&awslogs.GuardDutyParser{}).LogType(): DefaultLogParser(&awslogs.GuardDutyParser{},
 			&awslogs.GuardDuty{}, awslogs.GuardDutyDesc),
+		(&awslogs.WAFParser{}).LogType(): DefaultLogParser(&awslogs.WAFParser{},
+			&awslogs.WAF{}, awslogs.WAFDesc),
+		(&awslogs.CloudFrontParser{}).LogType(): DefaultLogParser(&awslogs.CloudFrontParser{},
+			&awslogs.CloudFront{}, awslogs.CloudFrontDesc),
+		(&awslogs.ELBClassicParser{}).LogType(): DefaultLogParser(&awslogs.ELBClassicParser{},
+			&awslogs.ELBClassic{}, awslogs.ELBClassicDesc),
+		(&awslogs.Route53ResolverParser{}).LogType(): DefaultLogParser(&awslogs.Route53ResolverParser{},
+			&awslogs.Route53Resolver{}, awslogs.Route53ResolverDesc),
+		(&awslogs.SecurityHubParser{}).LogType(): DefaultLogParser(&awslogs.SecurityHubParser{},
+			&awslogs.SecurityHub{}, awslogs.SecurityHubDesc),
 		(&nginxlogs.AccessParser{}).LogType(): DefaultLogParser(&nginxlogs.AccessParser{},
 			&nginxlogs.Access{}, nginxlogs.AccessDesc),
 		(&osquerylogs.DifferentialParser{}).LogType(): DefaultLogParser(&osquerylogs.DifferentialParser{},
@@ -71,6 +87,40 @@ var (
 			&fluentdsyslogs.RFC3164{}, fluentdsyslogs.RFC3164Desc),
 		(&fluentdsyslogs.RFC5424Parser{}).LogType(): DefaultLogParser(&fluentdsyslogs.RFC5424Parser{},
 			&fluentdsyslogs.RFC5424{}, fluentdsyslogs.RFC5424Desc),
+		(&oktalogs.SystemLogParser{}).LogType(): DefaultLogParser(&oktalogs.SystemLogParser{},
+			&oktalogs.SystemLog{}, oktalogs.SystemLogDesc),
+		(&gsuitelogs.ReportsParser{}).LogType(): DefaultLogParser(&gsuitelogs.ReportsParser{},
+			&gsuitelogs.Reports{}, gsuitelogs.ReportsDesc),
+		(&k8slogs.AuditParser{}).LogType(): DefaultLogParser(&k8slogs.AuditParser{},
+			&k8slogs.Audit{}, k8slogs.AuditDesc),
+		(&k8slogs.EKSAuthenticatorParser{}).LogType(): DefaultLogParser(&k8slogs.EKSAuthenticatorParser{},
+			&k8slogs.EKSAuthenticator{}, k8slogs.EKSAuthenticatorDesc),
+		(&zeeklogs.ConnParser{}).LogType(): DefaultLogParser(&zeeklogs.ConnParser{},
+			&zeeklogs.Conn{}, zeeklogs.ConnDesc),
+		(&zeeklogs.DNSParser{}).LogType(): DefaultLogParser(&zeeklogs.DNSParser{},
+			&zeeklogs.DNS{}, zeeklogs.DNSDesc),
+		(&zeeklogs.HTTPParser{}).LogType(): DefaultLogParser(&zeeklogs.HTTPParser{},
+			&zeeklogs.HTTP{}, zeeklogs.HTTPDesc),
+		(&zeeklogs.SSLParser{}).LogType(): DefaultLogParser(&zeeklogs.SSLParser{},
+			&zeeklogs.SSL{}, zeeklogs.SSLDesc),
+		(&zeeklogs.FilesParser{}).LogType(): DefaultLogParser(&zeeklogs.FilesParser{},
+			&zeeklogs.Files{}, zeeklogs.FilesDesc),
+		(&suricatalogs.AlertParser{}).LogType(): DefaultLogParser(&suricatalogs.AlertParser{},
+			&suricatalogs.Alert{}, suricatalogs.AlertDesc),
+		(&suricatalogs.DNSParser{}).LogType(): DefaultLogParser(&suricatalogs.DNSParser{},
+			&suricatalogs.DNS{}, suricatalogs.DNSDesc),
+		(&suricatalogs.HTTPParser{}).LogType(): DefaultLogParser(&suricatalogs.HTTPParser{},
+			&suricatalogs.HTTP{}, suricatalogs.HTTPDesc),
+		(&suricatalogs.TLSParser{}).LogType(): DefaultLogParser(&suricatalogs.TLSParser{},
+			&suricatalogs.TLS{}, suricatalogs.TLSDesc),
+		(&suricatalogs.FlowParser{}).LogType(): DefaultLogParser(&suricatalogs.FlowParser{},
+			&suricatalogs.Flow{}, suricatalogs.FlowDesc),
+		(&suricatalogs.FileInfoParser{}).LogType(): DefaultLogParser(&suricatalogs.FileInfoParser{},
+			&suricatalogs.FileInfo{}, suricatalogs.FileInfoDesc),
+
+		// data written by Panther itself
+		pantherlogs.UnclassifiedLogType: pantherTable(pantherlogs.UnclassifiedLogType,
+			&pantherlogs.Unclassified{}, pantherlogs.UnclassifiedDesc),
 	}
 )
 
@@ -86,9 +136,16 @@ func DefaultLogParser(p parsers.LogParser, eventStruct interface{}, description
 	}
 }
 
+// Tables of data that Panther writes itself (e.g. unclassified log lines) rather than parses, these have no parser
+func pantherTable(logType string, eventStruct interface{}, description string) *LogParserMetadata {
+	return &LogParserMetadata{
+		GlueTableMetadata: awsglue.NewGlueTableMetadata(models.LogData, logType, description, awsglue.GlueTableHourly, eventStruct),
+	}
+}
+
 // Describes each parser
 type LogParserMetadata struct {
-	Parser            parsers.LogParser          // does the work
+	Parser            parsers.LogParser          // does the work, nil if the data is not parsed from logs
 	GlueTableMetadata *awsglue.GlueTableMetadata // describes associated AWS Glue table (used to generate CF)
 }
 
@@ -97,6 +154,18 @@ func AvailableParsers() Registry {
 	return parsersRegistry
 }
 
+// Register adds a parser that is not known at compile time (e.g. of a custom log schema), replacing any parser for the same LogType.
+// NOTE: this is not safe to call while other goroutines use the registry, parsers must be registered before processing.
+func Register(lpm *LogParserMetadata) {
+	parsersRegistry[lpm.Parser.LogType()] = lpm
+}
+
+// Unregister removes a parser added with Register (e.g. of a deleted custom log schema).
+// NOTE: this is not safe to call while other goroutines use the registry, same as Register.
+func Unregister(logType string) {
+	delete(parsersRegistry, logType)
+}
+
 // Return a slice containing just the Glue tables
 func AvailableTables() (tables []*awsglue.GlueTableMetadata) {
 	for _, lpm := range parsersRegistry {
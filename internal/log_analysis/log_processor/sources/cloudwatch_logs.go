@@ -0,0 +1,128 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+)
+
+const (
+	// CloudWatch Logs checks that the destination of a subscription filter is reachable with a control message
+	cloudWatchLogsControlMessage = "CONTROL_MESSAGE"
+)
+
+// The (uncompressed) data of a subscription filter always starts with the message type,
+// this is used to recognize it in the records of Kinesis streams
+var cloudWatchLogsDataPrefix = []byte(`{"messageType":"`)
+
+// ReadCloudWatchLogsEvent returns the DataStream of the log events of a CloudWatch Logs subscription filter
+// sending them directly to the log processor.
+func ReadCloudWatchLogsEvent(event *events.CloudwatchLogsEvent) ([]*common.DataStream, error) {
+	data, err := event.AWSLogs.Parse()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse cloudwatch logs data")
+	}
+	dataStream, err := newCloudWatchLogsDataStream(&data)
+	if err != nil || dataStream == nil {
+		return nil, err
+	}
+	return []*common.DataStream{dataStream}, nil
+}
+
+// isCloudWatchLogsData returns true if reader holds the data of a subscription filter (e.g. in a Kinesis record)
+func isCloudWatchLogsData(reader *bufio.Reader) bool {
+	header, _ := reader.Peek(len(cloudWatchLogsDataPrefix))
+	return bytes.Equal(header, cloudWatchLogsDataPrefix)
+}
+
+func decodeCloudWatchLogsData(reader *bufio.Reader) (*events.CloudwatchLogsData, error) {
+	var data events.CloudwatchLogsData
+	if err := jsoniter.NewDecoder(reader).Decode(&data); err != nil {
+		return nil, errors.Wrap(err, "failed to parse cloudwatch logs data")
+	}
+	return &data, nil
+}
+
+// newCloudWatchLogsDataStream returns a DataStream with a record for the message of each log event,
+// or nil if there are no log events. Messages spanning several lines (e.g. stack traces) are a single record.
+func newCloudWatchLogsDataStream(data *events.CloudwatchLogsData) (*common.DataStream, error) {
+	if data.MessageType == cloudWatchLogsControlMessage || len(data.LogEvents) == 0 {
+		return nil, nil
+	}
+
+	records := make([]string, len(data.LogEvents))
+	for i, logEvent := range data.LogEvents {
+		records[i] = logEvent.Message
+	}
+
+	dataStream := &common.DataStream{
+		Records: records,
+		Hints: common.DataStreamHints{
+			CloudWatchLogs: &common.CloudWatchLogsDataStreamHints{
+				Owner:     data.Owner,
+				LogGroup:  data.LogGroup,
+				LogStream: data.LogStream,
+			},
+		},
+	}
+
+	source, err := getCloudWatchLogsSourceInfo(data.Owner, data.LogGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the source of log group %s of %s", data.LogGroup, data.Owner)
+	}
+	if source == nil {
+		// the log events are pushed, so they are classified with all the parsers rather than dropped
+		zap.L().Warn("there is no source configured for log group, using all log types",
+			zap.String("owner", data.Owner),
+			zap.String("logGroup", data.LogGroup))
+		return dataStream, nil
+	}
+	dataStream.LogTypes = aws.StringValueSlice(source.LogTypes)
+	dataStream.SourceID = aws.StringValue(source.IntegrationID)
+	dataStream.SourceLabel = aws.StringValue(source.IntegrationLabel)
+	return dataStream, nil
+}
+
+// Returns the source integration of the log group of the account,
+// or nil if no source is configured for it.
+func getCloudWatchLogsSourceInfo(owner, logGroup string) (*models.SourceIntegration, error) {
+	sources, err := getSources()
+	if err != nil {
+		return nil, err
+	}
+	for _, integration := range sources {
+		if aws.StringValue(integration.IntegrationType) == models.IntegrationTypeAWSCloudWatchLogs &&
+			aws.StringValue(integration.AWSAccountID) == owner &&
+			aws.StringValue(integration.LogGroupName) == logGroup {
+
+			return integration, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,131 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	cloudWatchLogsIntegration = &models.SourceIntegration{
+		SourceIntegrationMetadata: &models.SourceIntegrationMetadata{
+			AWSAccountID:     aws.String("123456789012"),
+			IntegrationID:    aws.String("integration-id"),
+			IntegrationLabel: aws.String("integration-label"),
+			IntegrationType:  aws.String(models.IntegrationTypeAWSCloudWatchLogs),
+			LogGroupName:     aws.String("/aws/lambda/my-function"),
+			LogTypes:         aws.StringSlice([]string{"AWS.VPCFlow"}),
+		},
+	}
+
+	// the layout of the data sent by subscription filters, the message type comes first
+	testCloudWatchLogsData = `{"messageType":"DATA_MESSAGE","owner":"123456789012",` +
+		`"logGroup":"/aws/lambda/my-function","logStream":"2020/04/01/[$LATEST]0123456789abcdef",` +
+		`"subscriptionFilters":["panther"],"logEvents":[` +
+		`{"id":"1","timestamp":1585699200000,"message":"line1\n"},` +
+		`{"id":"2","timestamp":1585699200001,"message":"line2"}]}`
+)
+
+func TestReadCloudWatchLogsEvent(t *testing.T) {
+	mockSources(t, cloudWatchLogsIntegration)
+
+	event := &events.CloudwatchLogsEvent{
+		AWSLogs: events.CloudwatchLogsRawData{
+			Data: base64.StdEncoding.EncodeToString(gzipData(t, testCloudWatchLogsData)),
+		},
+	}
+
+	dataStreams, err := ReadCloudWatchLogsEvent(event)
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 1)
+	dataStream := dataStreams[0]
+	assert.Equal(t, "/aws/lambda/my-function", dataStream.Hints.CloudWatchLogs.LogGroup)
+	assert.Equal(t, "2020/04/01/[$LATEST]0123456789abcdef", dataStream.Hints.CloudWatchLogs.LogStream)
+	assert.Equal(t, []string{"AWS.VPCFlow"}, dataStream.LogTypes)
+	assert.Equal(t, "integration-id", dataStream.SourceID)
+	assert.Equal(t, "integration-label", dataStream.SourceLabel)
+	assert.Equal(t, []string{"line1\n", "line2"}, dataStream.Records)
+}
+
+func TestReadCloudWatchLogsEventUnknownLogGroup(t *testing.T) {
+	mockSources(t)
+
+	var data events.CloudwatchLogsData
+	require.NoError(t, jsoniter.UnmarshalFromString(testCloudWatchLogsData, &data))
+	dataStream, err := newCloudWatchLogsDataStream(&data)
+	require.NoError(t, err)
+	require.NotNil(t, dataStream)
+	assert.Empty(t, dataStream.LogTypes) // all the parsers are used
+	assert.Empty(t, dataStream.SourceID)
+}
+
+func TestReadCloudWatchLogsControlMessage(t *testing.T) {
+	dataStream, err := newCloudWatchLogsDataStream(&events.CloudwatchLogsData{
+		MessageType: "CONTROL_MESSAGE",
+		LogEvents: []events.CloudwatchLogsLogEvent{
+			{ID: "1", Message: "CWL CONTROL MESSAGE: Checking health of destination Kinesis stream."},
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, dataStream)
+}
+
+func TestReadKinesisRecordsCloudWatchLogs(t *testing.T) {
+	mockSources(t, cloudWatchLogsIntegration)
+
+	records := []events.KinesisEventRecord{
+		newKinesisRecord(testStreamArn1, "1", []byte("line0\n")),
+		newKinesisRecord(testStreamArn1, "2", gzipData(t, testCloudWatchLogsData)),
+	}
+
+	dataStreams, err := ReadKinesisRecords(records)
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 2)
+	assert.Equal(t, testStreamArn1, dataStreams[0].Hints.Kinesis.StreamARN)
+	assert.Equal(t, "/aws/lambda/my-function", dataStreams[1].Hints.CloudWatchLogs.LogGroup)
+	assert.Equal(t, []string{"line1\n", "line2"}, dataStreams[1].Records)
+}
+
+func mockSources(t *testing.T, integrations ...*models.SourceIntegration) {
+	// resetting cache
+	sourceCache.cacheUpdateTime = time.Unix(0, 0)
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+
+	if integrations == nil {
+		integrations = []*models.SourceIntegration{}
+	}
+	marshaledResult, err := jsoniter.Marshal(integrations)
+	require.NoError(t, err)
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: marshaledResult}, nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(noCustomLogSchemasOutput, nil).Once()
+}
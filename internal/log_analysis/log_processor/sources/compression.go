@@ -0,0 +1,189 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const (
+	textContentType  = "text/plain"
+	gzipContentType  = "application/x-gzip"
+	zipContentType   = "application/zip"
+	bzip2ContentType = "application/x-bzip2"
+	zstdContentType  = "application/zstd"
+
+	// http.DetectContentType only uses up to the first 512 bytes
+	contentTypeHeaderSize = 512
+)
+
+var (
+	// http.DetectContentType does not know these formats
+	bzip2Magic       = []byte("BZh")
+	bzip2BlockMagic  = []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59} // first block after the header
+	bzip2StreamMagic = []byte{0x17, 0x72, 0x45, 0x38, 0x50, 0x90} // end of stream after the header (empty data)
+	zstdMagic        = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// detectContentType returns the content type of the data starting with header, the content type can have parameters
+// (e.g. the charset) so it should be compared by prefix
+func detectContentType(header []byte) string {
+	if bytes.HasPrefix(header, zstdMagic) {
+		return zstdContentType
+	}
+	// "BZh" is followed by the block size '1'-'9', then a block or the end of the stream
+	if len(header) >= 10 && bytes.HasPrefix(header, bzip2Magic) && header[3] >= '1' && header[3] <= '9' &&
+		(bytes.HasPrefix(header[4:], bzip2BlockMagic) || bytes.HasPrefix(header[4:], bzip2StreamMagic)) {
+
+		return bzip2ContentType
+	}
+	return http.DetectContentType(header)
+}
+
+// peekContentType returns the content type of the data in reader without consuming it
+func peekContentType(reader *bufio.Reader) (string, error) {
+	header, err := reader.Peek(contentTypeHeaderSize)
+	if err != nil && err != bufio.ErrBufferFull && err != io.EOF { // EOF or ErrBufferFull means data is shorter than n
+		return "", err
+	}
+	return detectContentType(header), nil
+}
+
+// newStreamReader returns a reader of the uncompressed data in reader with the content type,
+// archives of several files (zip) cannot be read as a stream, see newZipReaders()
+func newStreamReader(contentType string, reader io.Reader) (io.Reader, error) {
+	switch {
+	case strings.HasPrefix(contentType, textContentType):
+		return reader, nil
+	case strings.HasPrefix(contentType, gzipContentType):
+		return gzip.NewReader(reader)
+	case strings.HasPrefix(contentType, bzip2ContentType):
+		return bzip2.NewReader(reader), nil
+	case strings.HasPrefix(contentType, zstdContentType):
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return &zstdReader{decoder: decoder}, nil
+	default:
+		return nil, errors.Errorf("unsupported content type %s", contentType)
+	}
+}
+
+// zstdReader releases the resources of the decoder when the data is read since the readers are never closed
+type zstdReader struct {
+	decoder *zstd.Decoder
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.decoder.Read(p)
+	if err != nil {
+		r.decoder.Close()
+		r.decoder = nil
+	}
+	return n, err
+}
+
+// newZipReaders returns a reader of the uncompressed data of each file in the zip archive of the given size.
+// The files can themselves be compressed (e.g. a bundle of gzipped files), but not archives.
+// NOTE: the archive is read at random because the files are listed at its end
+func newZipReaders(reader io.ReaderAt, size int64) ([]io.Reader, error) {
+	archive, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var readers []io.Reader
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		readers = append(readers, &zipFileReader{file: file})
+	}
+	return readers, nil
+}
+
+// zipFileReader opens the file on the first read so that only one file of the archive is decompressed at a time,
+// the file is closed when it is read since the readers are never closed
+type zipFileReader struct {
+	file   *zip.File
+	reader io.Reader
+	closer io.Closer // the file in the archive, nil once closed
+	done   bool
+}
+
+func (r *zipFileReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if r.reader == nil {
+		if err := r.open(); err != nil {
+			r.done = true
+			return 0, errors.Wrapf(err, "failed to read %s in zip archive", r.file.Name)
+		}
+	}
+	n, err := r.reader.Read(p)
+	if err != nil {
+		r.close()
+	}
+	return n, err
+}
+
+func (r *zipFileReader) open() error {
+	fileReader, err := r.file.Open()
+	if err != nil {
+		return err
+	}
+	r.closer = fileReader
+	bufferedReader := bufio.NewReader(fileReader)
+	contentType, err := peekContentType(bufferedReader)
+	if err != nil {
+		r.close()
+		return err
+	}
+	if strings.HasPrefix(contentType, zipContentType) {
+		r.close()
+		return errors.New("nested zip archives are not supported")
+	}
+	if r.reader, err = newStreamReader(contentType, bufferedReader); err != nil {
+		r.close()
+	}
+	return err
+}
+
+func (r *zipFileReader) close() {
+	r.done = true
+	if r.closer != nil {
+		_ = r.closer.Close()
+		r.closer = nil
+	}
+}
@@ -0,0 +1,167 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCompressionData = "{\"a\":1}\n{\"a\":2}\n"
+
+var (
+	testS3Object = &S3ObjectInfo{
+		S3Bucket:    "bucket",
+		S3ObjectKey: "key",
+	}
+
+	// testCompressionData compressed with bzip2 (there is no bzip2 writer in the standard library)
+	testBzip2Data = []byte("\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\x22\x9d\xe2\xe9\x00\x00\x06\x59\x80\x00\x10\x10" +
+		"\x00\x30\x10\x20\x00\x00\x0a\x20\x00\x31\x0c\x08\x12\x80\x7a\x89\xc2\x26\x86\x8b\xe2\xee\x48\xa7\x0a\x12\x04" +
+		"\x53\xbc\x5d\x20")
+)
+
+func TestNewDataStreamsText(t *testing.T) {
+	expectDataStreams(t, []byte(testCompressionData), textContentType, testCompressionData)
+}
+
+func TestNewDataStreamsGzip(t *testing.T) {
+	expectDataStreams(t, gzipData(t, testCompressionData), gzipContentType, testCompressionData)
+}
+
+func TestNewDataStreamsBzip2(t *testing.T) {
+	expectDataStreams(t, testBzip2Data, bzip2ContentType, testCompressionData)
+}
+
+func TestNewDataStreamsZstd(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := zstd.NewWriter(&buffer)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(testCompressionData))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	expectDataStreams(t, buffer.Bytes(), zstdContentType, testCompressionData)
+}
+
+func TestNewDataStreamsZip(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	addZipFile(t, writer, "dir/", nil) // directories are skipped
+	addZipFile(t, writer, "dir/plain.json", []byte("plain\n"))
+	addZipFile(t, writer, "dir/compressed.json.gz", gzipData(t, "compressed\n"))
+	require.NoError(t, writer.Close())
+
+	expectDataStreams(t, buffer.Bytes(), zipContentType, "plain\n", "compressed\n")
+}
+
+func TestNewDataStreamsNestedZip(t *testing.T) {
+	var nested bytes.Buffer
+	nestedWriter := zip.NewWriter(&nested)
+	addZipFile(t, nestedWriter, "file", []byte("data\n"))
+	require.NoError(t, nestedWriter.Close())
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	addZipFile(t, writer, "nested.zip", nested.Bytes())
+	require.NoError(t, writer.Close())
+
+	dataStreams, err := newDataStreams(bytes.NewReader(buffer.Bytes()), testS3Object)
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 1)
+	_, err = ioutil.ReadAll(dataStreams[0].Reader)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nested.zip")
+}
+
+// largeObject is an object reporting a larger size than its data
+type largeObject struct {
+	*bytes.Reader
+	size int64
+}
+
+func (o *largeObject) Size() int64 {
+	return o.size
+}
+
+func TestNewDataStreamsZipTooLarge(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	addZipFile(t, writer, "file", []byte("data\n"))
+	require.NoError(t, writer.Close())
+
+	require.NoError(t, os.Setenv("MAX_ZIP_ARCHIVE_SIZE_MB", "1"))
+	defer os.Unsetenv("MAX_ZIP_ARCHIVE_SIZE_MB")
+	object := &largeObject{Reader: bytes.NewReader(buffer.Bytes()), size: 2 * bytesPerMB}
+	_, err := newDataStreams(object, testS3Object)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "zip archive s3://bucket/key is 2097152 bytes")
+	assert.Contains(t, err.Error(), "larger than the maximum of 1048576 bytes")
+}
+
+func TestNewDataStreamsUnsupported(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n")
+	_, err := newDataStreams(bytes.NewReader(pdf), testS3Object)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported content type application/pdf")
+	assert.Contains(t, err.Error(), "s3://bucket/key")
+}
+
+func TestDetectContentTypeBzip2Text(t *testing.T) {
+	// text starting with the bzip2 magic is still text
+	assert.True(t, strings.HasPrefix(detectContentType([]byte("BZh9 is not a bzip2 header\n")), textContentType))
+}
+
+func expectDataStreams(t *testing.T, data []byte, contentType string, expectedData ...string) {
+	dataStreams, err := newDataStreams(bytes.NewReader(data), testS3Object)
+	require.NoError(t, err)
+	require.Len(t, dataStreams, len(expectedData))
+	for i, dataStream := range dataStreams {
+		assert.True(t, strings.HasPrefix(dataStream.Hints.S3.ContentType, contentType))
+		assert.Equal(t, "key", dataStream.Hints.S3.Key)
+		actualData, err := ioutil.ReadAll(dataStream.Reader)
+		require.NoError(t, err)
+		assert.Equal(t, expectedData[i], string(actualData))
+	}
+}
+
+func gzipData(t *testing.T, data string) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func addZipFile(t *testing.T, writer *zip.Writer, name string, data []byte) {
+	fileWriter, err := writer.Create(name)
+	require.NoError(t, err)
+	_, err = fileWriter.Write(data)
+	require.NoError(t, err)
+}
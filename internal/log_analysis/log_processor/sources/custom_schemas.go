@@ -0,0 +1,64 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// registerCustomLogSchemas fetches the user defined log schemas from the source API and registers a parser for each.
+// The parsers of schemas that have been deleted since the last call are unregistered.
+func registerCustomLogSchemas() error {
+	input := &models.LambdaInput{
+		ListCustomLogSchemas: &models.ListCustomLogSchemasInput{},
+	}
+	var output []*models.CustomLogSchema
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output); err != nil {
+		return err
+	}
+
+	registered := make(map[string]struct{}, len(output))
+	for _, schema := range output {
+		parser, err := customlogs.NewParser(&schema.CustomLogSchemaSettings)
+		if err != nil { // schemas are validated when stored, this should not happen
+			zap.L().Error("invalid custom log schema",
+				zap.String("logType", aws.StringValue(schema.LogType)),
+				zap.Error(err))
+			continue
+		}
+		registry.Register(registry.DefaultLogParser(parser, parser.EventStruct(), parser.Description()))
+		registered[parser.LogType()] = struct{}{}
+	}
+
+	// built-in log types never have the custom prefix, so any other custom log type was deleted
+	for logType := range registry.AvailableParsers().Elements() {
+		if _, ok := registered[logType]; !ok && strings.HasPrefix(logType, models.CustomLogTypePrefix) {
+			registry.Unregister(logType)
+		}
+	}
+	return nil
+}
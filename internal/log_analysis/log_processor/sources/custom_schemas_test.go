@@ -0,0 +1,79 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestRegisterCustomLogSchemas(t *testing.T) {
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+
+	schemas := []*models.CustomLogSchema{
+		{
+			CustomLogSchemaSettings: models.CustomLogSchemaSettings{
+				LogType:     aws.String("Custom.Registered"),
+				Description: aws.String("A custom log type"),
+				Format:      aws.String(models.CustomLogFormatJSON),
+				Fields: []*models.CustomLogField{
+					{
+						Name:        aws.String("message"),
+						Type:        aws.String(models.CustomFieldTypeString),
+						Description: aws.String("The message"),
+					},
+				},
+			},
+		},
+		{ // invalid schemas are skipped
+			CustomLogSchemaSettings: models.CustomLogSchemaSettings{
+				LogType:     aws.String("Custom.Invalid"),
+				Description: aws.String("A custom log type without fields"),
+				Format:      aws.String(models.CustomLogFormatJSON),
+			},
+		},
+	}
+	marshaledResult, err := jsoniter.Marshal(schemas)
+	require.NoError(t, err)
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: marshaledResult}, nil).Once()
+
+	require.NoError(t, registerCustomLogSchemas())
+
+	lpm := registry.AvailableParsers().LookupParser("Custom.Registered")
+	require.Equal(t, "custom_registered", lpm.GlueTableMetadata.TableName())
+	require.NotNil(t, lpm.Parser.Parse(`{"message":"hello"}`))
+	require.NotContains(t, registry.AvailableParsers().Elements(), "Custom.Invalid")
+
+	// the schema has been deleted
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("[]")}, nil).Once()
+	require.NoError(t, registerCustomLogSchemas())
+	require.NotContains(t, registry.AvailableParsers().Elements(), "Custom.Registered")
+	require.Contains(t, registry.AvailableParsers().Elements(), "AWS.CloudTrail")
+	lambdaMock.AssertExpectations(t)
+}
@@ -0,0 +1,131 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+)
+
+// KinesisEventSource is the event source of the records of Lambda events from Kinesis Data Streams
+const KinesisEventSource = "aws:kinesis"
+
+var recordDelimiter = []byte("\n")
+
+// ReadKinesisRecords returns a DataStream for each Kinesis stream the records of the event came from.
+// The data of each record can be compressed and holds one or more log lines. The records are processed in order,
+// the position in the stream is checkpointed by the Lambda event source mapping when the invocation succeeds.
+// Records sent by CloudWatch Logs subscription filters get a DataStream of their own, see ReadCloudWatchLogsEvent().
+// Records that cannot be decoded are logged and skipped, since retrying them would block the shard until they expire.
+func ReadKinesisRecords(records []events.KinesisEventRecord) ([]*common.DataStream, error) {
+	zap.L().Debug("reading data for kinesis records", zap.Int("numRecords", len(records)))
+
+	var result []*common.DataStream
+	streamReaders := make(map[string][]io.Reader) // by stream ARN
+	for i := range records {
+		record := &records[i]
+		reader, err := newKinesisRecordReader(record)
+		if err != nil {
+			logSkippedKinesisRecord(record, err)
+			continue
+		}
+		if isCloudWatchLogsData(reader) {
+			data, err := decodeCloudWatchLogsData(reader)
+			if err != nil {
+				logSkippedKinesisRecord(record, err)
+				continue
+			}
+			dataStream, err := newCloudWatchLogsDataStream(data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read record %s of %s",
+					record.Kinesis.SequenceNumber, record.EventSourceArn)
+			}
+			if dataStream != nil {
+				result = append(result, dataStream)
+			}
+			continue
+		}
+		if _, ok := streamReaders[record.EventSourceArn]; !ok {
+			result = append(result, &common.DataStream{
+				Hints: common.DataStreamHints{
+					Kinesis: &common.KinesisDataStreamHints{
+						StreamARN: record.EventSourceArn,
+					},
+				},
+			})
+		}
+		// records do not necessarily end with a new line
+		streamReaders[record.EventSourceArn] = append(streamReaders[record.EventSourceArn],
+			reader, bytes.NewReader(recordDelimiter))
+	}
+
+	for _, dataStream := range result {
+		if dataStream.Hints.Kinesis != nil {
+			dataStream.Reader = io.MultiReader(streamReaders[dataStream.Hints.Kinesis.StreamARN]...)
+		}
+	}
+	return result, nil
+}
+
+func newKinesisRecordReader(record *events.KinesisEventRecord) (*bufio.Reader, error) {
+	header := record.Kinesis.Data
+	if len(header) > contentTypeHeaderSize {
+		header = header[:contentTypeHeaderSize]
+	}
+	contentType := detectContentType(header)
+	if strings.HasPrefix(contentType, zipContentType) {
+		return nil, errors.New("zip archives are not supported in kinesis records")
+	}
+	reader, err := newStreamReader(contentType, bytes.NewReader(record.Kinesis.Data))
+	if err != nil {
+		return nil, err
+	}
+	return bufio.NewReader(&kinesisRecordReader{record: record, reader: reader}), nil
+}
+
+// kinesisRecordReader ends the data of a record at the first read error (e.g. corrupt compressed data),
+// so that the lines read so far are processed and the rest of the record is skipped
+type kinesisRecordReader struct {
+	record *events.KinesisEventRecord
+	reader io.Reader
+}
+
+func (r *kinesisRecordReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF {
+		logSkippedKinesisRecord(r.record, err)
+		return n, io.EOF
+	}
+	return n, err
+}
+
+func logSkippedKinesisRecord(record *events.KinesisEventRecord, err error) {
+	zap.L().Error("skipping data of kinesis record that cannot be decoded",
+		zap.String("streamArn", record.EventSourceArn),
+		zap.String("sequenceNumber", record.Kinesis.SequenceNumber),
+		zap.Error(err))
+}
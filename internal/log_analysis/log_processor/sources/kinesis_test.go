@@ -0,0 +1,104 @@
+package sources
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStreamArn1 = "arn:aws:kinesis:us-east-1:123456789012:stream/stream1"
+	testStreamArn2 = "arn:aws:kinesis:us-east-1:123456789012:stream/stream2"
+)
+
+func TestReadKinesisRecords(t *testing.T) {
+	records := []events.KinesisEventRecord{
+		newKinesisRecord(testStreamArn1, "1", []byte("line1\n")),
+		newKinesisRecord(testStreamArn2, "2", gzipData(t, "line2")),
+		newKinesisRecord(testStreamArn1, "3", gzipData(t, "line3\nline4")),
+	}
+
+	dataStreams, err := ReadKinesisRecords(records)
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 2)
+
+	assert.Equal(t, testStreamArn1, dataStreams[0].Hints.Kinesis.StreamARN)
+	assert.Nil(t, dataStreams[0].Hints.S3)
+	data, err := ioutil.ReadAll(dataStreams[0].Reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line1\n\nline3\nline4\n", string(data))
+
+	assert.Equal(t, testStreamArn2, dataStreams[1].Hints.Kinesis.StreamARN)
+	data, err = ioutil.ReadAll(dataStreams[1].Reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line2\n", string(data))
+}
+
+func TestReadKinesisRecordsZip(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	addZipFile(t, writer, "file", []byte("data\n"))
+	require.NoError(t, writer.Close())
+
+	// the record is skipped rather than failing the batch
+	dataStreams, err := ReadKinesisRecords([]events.KinesisEventRecord{
+		newKinesisRecord(testStreamArn1, "1", buffer.Bytes()),
+		newKinesisRecord(testStreamArn1, "2", []byte("line2")),
+	})
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 1)
+	data, err := ioutil.ReadAll(dataStreams[0].Reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line2\n", string(data))
+}
+
+func TestReadKinesisRecordsCorrupt(t *testing.T) {
+	compressed := gzipData(t, "line2\nline3\n")
+	records := []events.KinesisEventRecord{
+		newKinesisRecord(testStreamArn1, "1", []byte("line1")),
+		newKinesisRecord(testStreamArn1, "2", compressed[:len(compressed)-4]),       // truncated
+		newKinesisRecord(testStreamArn1, "3", gzipData(t, `{"messageType":"DATA_`)), // not json
+		newKinesisRecord(testStreamArn1, "4", []byte("line4")),
+	}
+
+	dataStreams, err := ReadKinesisRecords(records)
+	require.NoError(t, err)
+	require.Len(t, dataStreams, 1)
+	data, err := ioutil.ReadAll(dataStreams[0].Reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line1\nline2\nline3\n\nline4\n", string(data))
+}
+
+func newKinesisRecord(streamArn, sequenceNumber string, data []byte) events.KinesisEventRecord {
+	return events.KinesisEventRecord{
+		EventSource:    KinesisEventSource,
+		EventSourceArn: streamArn,
+		Kinesis: events.KinesisRecord{
+			Data:           data,
+			SequenceNumber: sequenceNumber,
+		},
+	}
+}
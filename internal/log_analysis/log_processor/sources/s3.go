@@ -20,15 +20,17 @@ package sources
 
 import (
 	"bufio"
-	"compress/gzip"
+	"fmt"
 	"io"
-	"net/http"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
@@ -40,6 +42,12 @@ import (
 const (
 	s3TestEvent                 = "s3:TestEvent"
 	cloudTrailValidationMessage = "CloudTrail validation message."
+
+	// s3ObjectBlockSize is the size of the ranged reads of the objects read at random (zip archives)
+	s3ObjectBlockSize = 1024 * 1024
+	// defaultMaxZipArchiveSizeMB is the size of the largest zip archive that is read if it is not configured
+	defaultMaxZipArchiveSizeMB = 512
+	bytesPerMB                 = 1024 * 1024
 )
 
 // ReadSQSMessages reads incoming messages containing SNS notifications and returns a slice of DataStream items
@@ -102,17 +110,18 @@ func handleNotificationMessage(notification *SnsNotification) (result []*common.
 		return nil, err
 	}
 	for _, s3Object := range s3Objects {
-		var dataStream *common.DataStream
-		dataStream, err = readS3Object(s3Object)
+		var dataStreams []*common.DataStream
+		dataStreams, err = readS3Object(s3Object)
 		if err != nil {
 			return
 		}
-		result = append(result, dataStream)
+		result = append(result, dataStreams...)
 	}
 	return result, err
 }
 
-func readS3Object(s3Object *S3ObjectInfo) (dataStream *common.DataStream, err error) {
+// readS3Object returns the data streams of the S3 object, one for each file if the object is an archive (zip)
+func readS3Object(s3Object *S3ObjectInfo) (dataStreams []*common.DataStream, err error) {
 	operation := common.OpLogManager.Start("readS3Object", common.OpLogS3ServiceDim)
 	defer func() {
 		operation.Stop()
@@ -122,7 +131,7 @@ func readS3Object(s3Object *S3ObjectInfo) (dataStream *common.DataStream, err er
 			zap.String("key", s3Object.S3ObjectKey))
 	}()
 
-	s3Client, err := getS3Client(s3Object)
+	s3Client, source, err := getS3Client(s3Object)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to get S3 client for s3://%s/%s",
 			s3Object.S3Bucket, s3Object.S3ObjectKey)
@@ -140,49 +149,169 @@ func readS3Object(s3Object *S3ObjectInfo) (dataStream *common.DataStream, err er
 		return nil, err
 	}
 
-	bufferedReader := bufio.NewReader(output.Body)
+	object := &s3ObjectReader{
+		client: s3Client,
+		bucket: s3Object.S3Bucket,
+		key:    s3Object.S3ObjectKey,
+		eTag:   output.ETag,
+		size:   aws.Int64Value(output.ContentLength),
+		body:   output.Body,
+	}
+	dataStreams, err = newDataStreams(object, s3Object)
+	if err != nil {
+		return nil, err
+	}
+	for _, dataStream := range dataStreams {
+		dataStream.LogTypes = aws.StringValueSlice(source.LogTypes)
+		dataStream.SourceID = aws.StringValue(source.IntegrationID)
+		dataStream.SourceLabel = aws.StringValue(source.IntegrationLabel)
+	}
+	return dataStreams, nil
+}
+
+// objectReader reads the content of an S3 object as a stream or at random (zip archives)
+type objectReader interface {
+	io.Reader
+	io.ReaderAt
+	Size() int64
+}
+
+// newDataStreams returns the data streams of the uncompressed content of the S3 object
+func newDataStreams(object objectReader, s3Object *S3ObjectInfo) ([]*common.DataStream, error) {
+	bufferedReader := bufio.NewReader(object)
 
 	// We peek into the file header to identify the content type
-	// http.DetectContentType only uses up to the first 512 bytes
-	headerBytes, err := bufferedReader.Peek(512)
+	contentType, err := peekContentType(bufferedReader)
 	if err != nil {
-		if err != bufio.ErrBufferFull && err != io.EOF { // EOF or ErrBufferFull means file is shorter than n
-			err = errors.Wrapf(err, "failed to Peek() in S3 payload for s3://%s/%s",
-				s3Object.S3Bucket, s3Object.S3ObjectKey)
-			return nil, err
-		}
-		err = nil // not really an error
+		return nil, errors.Wrapf(err, "failed to Peek() in S3 payload for s3://%s/%s",
+			s3Object.S3Bucket, s3Object.S3ObjectKey)
 	}
-	contentType := http.DetectContentType(headerBytes)
-
-	var streamReader io.Reader
 
+	var streamReaders []io.Reader
 	// Checking for prefix because the returned type can have also charset used
-	if strings.HasPrefix(contentType, "text/plain") {
-		// if it's plain text, just return the buffered reader
-		streamReader = bufferedReader
-	} else if strings.HasPrefix(contentType, "application/x-gzip") {
-		var gzipReader *gzip.Reader
-		gzipReader, err = gzip.NewReader(bufferedReader)
+	if strings.HasPrefix(contentType, zipContentType) {
+		if maxSize := maxZipArchiveSize(); object.Size() > maxSize {
+			return nil, errors.Errorf("zip archive s3://%s/%s is %d bytes, larger than the maximum of %d bytes",
+				s3Object.S3Bucket, s3Object.S3ObjectKey, object.Size(), maxSize)
+		}
+		// the files are read at random with ranged reads rather than from the stream
+		if closer, ok := object.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		streamReaders, err = newZipReaders(object, object.Size())
 		if err != nil {
-			err = errors.Wrapf(err, "failed to created gzip reader for s3://%s/%s",
+			return nil, errors.Wrapf(err, "failed to read zip archive s3://%s/%s",
+				s3Object.S3Bucket, s3Object.S3ObjectKey)
+		}
+	} else {
+		var streamReader io.Reader
+		streamReader, err = newStreamReader(contentType, bufferedReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create reader for s3://%s/%s",
 				s3Object.S3Bucket, s3Object.S3ObjectKey)
-			return nil, err
 		}
-		streamReader = gzipReader
+		streamReaders = []io.Reader{streamReader}
 	}
 
-	dataStream = &common.DataStream{
-		Reader: streamReader,
-		Hints: common.DataStreamHints{
-			S3: &common.S3DataStreamHints{
-				Bucket:      s3Object.S3Bucket,
-				Key:         s3Object.S3ObjectKey,
-				ContentType: contentType,
+	dataStreams := make([]*common.DataStream, len(streamReaders))
+	for i, streamReader := range streamReaders {
+		dataStreams[i] = &common.DataStream{
+			Reader: streamReader,
+			Hints: common.DataStreamHints{
+				S3: &common.S3DataStreamHints{
+					Bucket:      s3Object.S3Bucket,
+					Key:         s3Object.S3ObjectKey,
+					ContentType: contentType,
+				},
 			},
-		},
+		}
+	}
+	return dataStreams, nil
+}
+
+// maxZipArchiveSize returns the size in bytes of the largest zip archive that is read
+func maxZipArchiveSize() int64 {
+	maxSizeMB, _ := strconv.Atoi(os.Getenv("MAX_ZIP_ARCHIVE_SIZE_MB"))
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxZipArchiveSizeMB
 	}
-	return dataStream, err
+	return int64(maxSizeMB) * bytesPerMB
+}
+
+// s3ObjectReader reads an S3 object from the body of GetObject or at random with ranged reads.
+// The last block read at random is kept since the files of zip archives are read sequentially in small chunks.
+type s3ObjectReader struct {
+	client s3iface.S3API
+	bucket string
+	key    string
+	eTag   *string // the ranged reads fail if the object changes
+	size   int64
+	body   io.ReadCloser
+
+	block       []byte
+	blockOffset int64
+}
+
+func (r *s3ObjectReader) Read(p []byte) (int, error) {
+	return r.body.Read(p)
+}
+
+func (r *s3ObjectReader) Close() error {
+	return r.body.Close()
+}
+
+func (r *s3ObjectReader) Size() int64 {
+	return r.size
+}
+
+func (r *s3ObjectReader) ReadAt(p []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n := 0
+	for n < len(p) {
+		position := offset + int64(n)
+		if position >= r.size {
+			return n, io.EOF
+		}
+		if position < r.blockOffset || position >= r.blockOffset+int64(len(r.block)) {
+			if err := r.readBlock(position); err != nil {
+				return n, err
+			}
+		}
+		n += copy(p[n:], r.block[position-r.blockOffset:])
+	}
+	return n, nil
+}
+
+// readBlock reads the block of the object starting at offset
+func (r *s3ObjectReader) readBlock(offset int64) error {
+	end := offset + s3ObjectBlockSize
+	if end > r.size {
+		end = r.size
+	}
+	output, err := r.client.GetObject(&s3.GetObjectInput{
+		Bucket:  &r.bucket,
+		Key:     &r.key,
+		IfMatch: r.eTag,
+		Range:   aws.String(fmt.Sprintf("bytes=%d-%d", offset, end-1)),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "GetObject() failed for s3://%s/%s", r.bucket, r.key)
+	}
+	defer output.Body.Close()
+
+	block := r.block
+	if int64(cap(block)) < end-offset {
+		block = make([]byte, end-offset)
+	}
+	block = block[:end-offset]
+	if _, err = io.ReadFull(output.Body, block); err != nil {
+		return errors.Wrapf(err, "failed to read s3://%s/%s", r.bucket, r.key)
+	}
+	r.block = block
+	r.blockOffset = offset
+	return nil
 }
 
 // ParseNotification parses a message received
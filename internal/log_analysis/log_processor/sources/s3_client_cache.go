@@ -92,20 +92,20 @@ func init() {
 }
 
 // getS3Client Fetches S3 client with permissions to read data from the account
-// that contains the event
-func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, error) {
-	roleArn, err := getRoleArn(s3Object)
+// that contains the event. It also returns the source integration the object belongs to.
+func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, *models.SourceIntegration, error) {
+	source, err := getSourceInfo(s3Object)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to fetch the appropriate role arn to retrieve S3 object %#v", s3Object)
+		return nil, nil, errors.Wrapf(err, "failed to fetch the appropriate role arn to retrieve S3 object %#v", s3Object)
 	}
 
-	if roleArn == nil {
-		return nil, errors.Errorf("there is no source configured for S3 object %#v", s3Object)
+	if source == nil {
+		return nil, nil, errors.Errorf("there is no source configured for S3 object %#v", s3Object)
 	}
 
-	awsCreds := getAwsCredentials(*roleArn)
+	awsCreds := getAwsCredentials(*source.LogProcessingRole)
 	if awsCreds == nil {
-		return nil, errors.Errorf("failed to fetch credentials for assumed role to read %#v", s3Object)
+		return nil, nil, errors.Errorf("failed to fetch credentials for assumed role to read %#v", s3Object)
 	}
 
 	bucketRegion, ok := bucketCache.Get(s3Object.S3Bucket)
@@ -113,7 +113,7 @@ func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, error) {
 		zap.L().Debug("bucket region was not cached, fetching it", zap.String("bucket", s3Object.S3Bucket))
 		bucketRegion, err = getBucketRegion(s3Object.S3Bucket, awsCreds)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		bucketCache.Add(s3Object.S3Bucket, bucketRegion)
 	}
@@ -122,7 +122,7 @@ func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, error) {
 
 	bucketRegionString := bucketRegion.(string)
 	cacheKey := s3ClientCacheKey{
-		roleArn:   *roleArn,
+		roleArn:   *source.LogProcessingRole,
 		awsRegion: bucketRegionString,
 	}
 
@@ -133,7 +133,7 @@ func getS3Client(s3Object *S3ObjectInfo) (s3iface.S3API, error) {
 		client = newS3ClientFunc(aws.String(bucketRegionString), awsCreds)
 		s3ClientCache.Add(cacheKey, client)
 	}
-	return client.(s3iface.S3API), nil
+	return client.(s3iface.S3API), source, nil
 }
 
 func getBucketRegion(s3Bucket string, awsCreds *credentials.Credentials) (string, error) {
@@ -162,38 +162,49 @@ func getAwsCredentials(roleArn string) *credentials.Credentials {
 	})
 }
 
-// Returns the appropriate role arn for a given S3 object
-// It will return error if it encountered an issue retrieving the role.
-// It will return nil result if role for such object doesn't exist.
-func getRoleArn(s3Object *S3ObjectInfo) (*string, error) {
+// Returns the source integration for a given S3 object
+// It will return error if it encountered an issue retrieving the integrations.
+// It will return nil result if no source is configured for such object.
+func getSourceInfo(s3Object *S3ObjectInfo) (*models.SourceIntegration, error) {
+	sources, err := getSources()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, integration := range sources {
+		if aws.StringValue(integration.S3Bucket) == s3Object.S3Bucket {
+			if integration.S3Prefix == nil { // no prefix configured
+				return integration, nil
+			}
+			if strings.HasPrefix(s3Object.S3ObjectKey, aws.StringValue(integration.S3Prefix)) {
+				return integration, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Returns the integrations of all types (the S3 and CloudWatch Logs sources are looked up in the same cache)
+func getSources() ([]*models.SourceIntegration, error) {
 	now := time.Now() // No need to be UTC. We care about relative time
 	if sourceCache.cacheUpdateTime.Add(sourceCacheDuration).Before(now) {
 		// we need to update the cache
 		input := &models.LambdaInput{
-			ListIntegrations: &models.ListIntegrationsInput{
-				IntegrationType: aws.String(models.IntegrationTypeAWS3),
-			},
+			ListIntegrations: &models.ListIntegrationsInput{},
 		}
 		var output []*models.SourceIntegration
 		err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output)
 		if err != nil {
 			return nil, err
 		}
+		// refresh the custom log types together with the sources that may use them
+		if err := registerCustomLogSchemas(); err != nil {
+			return nil, err
+		}
 		sourceCache.cacheUpdateTime = now
 		sourceCache.sources = output
 	}
-
-	for _, integration := range sourceCache.sources {
-		if aws.StringValue(integration.S3Bucket) == s3Object.S3Bucket {
-			if integration.S3Prefix == nil { // no prefix configured
-				return integration.LogProcessingRole, nil
-			}
-			if strings.HasPrefix(s3Object.S3ObjectKey, aws.StringValue(integration.S3Prefix)) {
-				return integration.LogProcessingRole, nil
-			}
-		}
-	}
-	return nil, nil
+	return sourceCache.sources, nil
 }
 
 func getNewS3Client(region *string, creds *credentials.Credentials) (result s3iface.S3API) {
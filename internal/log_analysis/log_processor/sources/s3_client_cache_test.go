@@ -46,6 +46,10 @@ var (
 			LogProcessingRole: aws.String("arn:aws:iam::123456789012:role/PantherLogProcessingRole-suffix"),
 		},
 	}
+
+	noCustomLogSchemasOutput = &lambda.InvokeOutput{
+		Payload: []byte("[]"),
+	}
 )
 
 func TestGetS3Client(t *testing.T) {
@@ -68,6 +72,7 @@ func TestGetS3Client(t *testing.T) {
 	expectedGetBucketLocationInput := &s3.GetBucketLocationInput{Bucket: aws.String("test-bucket")}
 
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(noCustomLogSchemasOutput, nil).Once()
 	s3Mock.On("GetBucketLocation", expectedGetBucketLocationInput).Return(
 		&s3.GetBucketLocationOutput{LocationConstraint: aws.String("us-west-2")}, nil).Once()
 
@@ -75,14 +80,16 @@ func TestGetS3Client(t *testing.T) {
 		S3Bucket:    "test-bucket",
 		S3ObjectKey: "prefix/key",
 	}
-	result, err := getS3Client(s3Object)
+	result, source, err := getS3Client(s3Object)
 	require.NoError(t, err)
 	require.NotNil(t, result)
+	require.Equal(t, integration, source)
 
 	// Subsequent calls should use cache
-	result, err = getS3Client(s3Object)
+	result, source, err = getS3Client(s3Object)
 	require.NoError(t, err)
 	require.NotNil(t, result)
+	require.Equal(t, integration, source)
 
 	s3Mock.AssertExpectations(t)
 	lambdaMock.AssertExpectations(t)
@@ -106,6 +113,7 @@ func TestGetS3ClientUnknownBucket(t *testing.T) {
 	}
 
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(noCustomLogSchemasOutput, nil).Once()
 
 	newCredentialsFunc =
 		func(c client.ConfigProvider, roleARN string, options ...func(*stscreds.AssumeRoleProvider)) *credentials.Credentials {
@@ -117,9 +125,10 @@ func TestGetS3ClientUnknownBucket(t *testing.T) {
 		S3ObjectKey: "prefix/key",
 	}
 
-	result, err := getS3Client(s3Object)
+	result, source, err := getS3Client(s3Object)
 	require.Error(t, err)
 	require.Nil(t, result)
+	require.Nil(t, source)
 
 	s3Mock.AssertExpectations(t)
 	lambdaMock.AssertExpectations(t)
@@ -151,6 +160,7 @@ func TestGetS3ClientSourceNoPrefix(t *testing.T) {
 	}
 
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(noCustomLogSchemasOutput, nil).Once()
 
 	newCredentialsFunc =
 		func(c client.ConfigProvider, roleARN string, options ...func(*stscreds.AssumeRoleProvider)) *credentials.Credentials {
@@ -162,9 +172,10 @@ func TestGetS3ClientSourceNoPrefix(t *testing.T) {
 		S3ObjectKey: "test",
 	}
 
-	result, err := getS3Client(s3Object)
+	result, source, err := getS3Client(s3Object)
 	require.NoError(t, err)
 	require.NotNil(t, result)
+	require.Equal(t, integration, source)
 
 	s3Mock.AssertExpectations(t)
 	lambdaMock.AssertExpectations(t)
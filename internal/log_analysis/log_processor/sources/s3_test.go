@@ -19,9 +19,17 @@ package sources
  */
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 func TestParseCloudTrailNotification(t *testing.T) {
@@ -84,3 +92,43 @@ func TestParseUnknownMessage(t *testing.T) {
 	_, err := ParseNotification(notification)
 	require.Error(t, err)
 }
+
+func TestS3ObjectReaderReadAt(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), s3ObjectBlockSize/4) // 2.5 blocks
+	s3Mock := &testutils.S3Mock{}
+	getRange := func(rangeHeader string, start, end int) {
+		s3Mock.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return *input.Range == rangeHeader && *input.IfMatch == "etag"
+		})).Return(&s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data[start:end]))}, nil).Once()
+	}
+	object := &s3ObjectReader{
+		client: s3Mock,
+		bucket: "bucket",
+		key:    "key",
+		eTag:   aws.String("etag"),
+		size:   int64(len(data)),
+	}
+
+	// a read spanning two blocks
+	getRange("bytes=10-1048585", 10, s3ObjectBlockSize+10)
+	getRange("bytes=1048586-2097161", s3ObjectBlockSize+10, 2*s3ObjectBlockSize+10)
+	p := make([]byte, s3ObjectBlockSize+10)
+	n, err := object.ReadAt(p, 10)
+	require.NoError(t, err)
+	require.Equal(t, len(p), n)
+	require.Equal(t, data[10:len(p)+10], p)
+
+	// a read of the block read last is not requested again
+	n, err = object.ReadAt(p[:10], s3ObjectBlockSize+20)
+	require.NoError(t, err)
+	require.Equal(t, 10, n)
+	require.Equal(t, data[s3ObjectBlockSize+20:s3ObjectBlockSize+30], p[:10])
+
+	// the last block is shorter
+	getRange("bytes=2621430-2621439", len(data)-10, len(data))
+	n, err = object.ReadAt(p[:20], int64(len(data)-10))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 10, n)
+	require.Equal(t, data[len(data)-10:], p[:10])
+	s3Mock.AssertExpectations(t)
+}
@@ -30,6 +30,9 @@ class EventMatch:
     severity: str
     event: Dict[str, Any]
     title: Optional[str] = None
+    alert_threshold: int = 1
+    renotify_event_count: int = 0
+    renotify_on_severity_escalation: bool = False
 
 
 @dataclass
@@ -38,6 +38,12 @@ _ALERT_EVENT_COUNT = 'eventCount'
 _ALERT_SEVERITY_ATTR_NAME = 'severity'
 _ALERT_LOG_TYPES = 'logTypes'
 _ALERT_TITLE = 'title'
+_ALERT_THRESHOLD = 'alertThreshold'
+_ALERT_RENOTIFY_EVENT_COUNT = 'renotifyEventCount'
+_ALERT_RENOTIFY_ON_SEVERITY_ESCALATION = 'renotifyOnSeverityEscalation'
+
+# Severities from the lowest to the highest
+_SEVERITIES = ['INFO', 'LOW', 'MEDIUM', 'HIGH', 'CRITICAL']
 
 
 # pylint: disable=too-many-instance-attributes
@@ -53,6 +59,9 @@ class MatchingGroupInfo:
     num_matches: int
     title: Optional[str]
     processing_time: datetime
+    alert_threshold: int = 1
+    renotify_event_count: int = 0
+    renotify_on_severity_escalation: bool = False
 
 
 def _generate_dedup_key(rule_id: str, dedup: str) -> str:
@@ -84,7 +93,7 @@ def _update_get_conditional(group_info: MatchingGroupInfo) -> AlertInfo:
     2. This rule with the same dedup string has fired before, but after the dedup period has expired
     """
     condition_expression = '(#1 < :1) OR (attribute_not_exists(#2))'
-    update_expression = 'ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10, #11=:11'
+    update_expression = 'ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10, #11=:11, #13=:13, #14=:14, #15=:15'
 
     if group_info.title:
         update_expression += ', #12=:12'
@@ -100,6 +109,9 @@ def _update_get_conditional(group_info: MatchingGroupInfo) -> AlertInfo:
         '#9': _ALERT_SEVERITY_ATTR_NAME,
         '#10': _ALERT_LOG_TYPES,
         '#11': _RULE_VERSION_ATTR_NAME,
+        '#13': _ALERT_THRESHOLD,
+        '#14': _ALERT_RENOTIFY_EVENT_COUNT,
+        '#15': _ALERT_RENOTIFY_ON_SEVERITY_ESCALATION,
     }
 
     if group_info.title:
@@ -138,6 +150,15 @@ def _update_get_conditional(group_info: MatchingGroupInfo) -> AlertInfo:
         ':11': {
             'S': group_info.rule_version
         },
+        ':13': {
+            'N': '{}'.format(group_info.alert_threshold)
+        },
+        ':14': {
+            'N': '{}'.format(group_info.renotify_event_count)
+        },
+        ':15': {
+            'BOOL': group_info.renotify_on_severity_escalation
+        },
     }
 
     if group_info.title:
@@ -164,6 +185,8 @@ def _update_get(group_info: MatchingGroupInfo) -> AlertInfo:
     """Updates the following attributes in DDB:
     1. Alert event account - it adds the new events to existing
     2. Alert Update Time - it sets it to given time
+    3. Alert settings - they are set to the ones of the rule
+    4. Alert severity - it is the highest severity of the rule seen during the dedup period, see _escalate_severity
     """
 
     response = _DDB_CLIENT.update_item(
@@ -171,12 +194,15 @@ def _update_get(group_info: MatchingGroupInfo) -> AlertInfo:
         Key={_PARTITION_KEY_NAME: {
             'S': _generate_dedup_key(group_info.rule_id, group_info.dedup)
         }},
-        # Setting proper value to alertUpdateTime. Increase event count
-        UpdateExpression='SET #1=:1\nADD #2 :2, #3 :3',
+        # Setting proper value to alertUpdateTime and settings. Increase event count
+        UpdateExpression='SET #1=:1, #4=:4, #5=:5, #6=:6\nADD #2 :2, #3 :3',
         ExpressionAttributeNames={
             '#1': _ALERT_UPDATE_TIME_ATTR_NAME,
             '#2': _ALERT_EVENT_COUNT,
-            '#3': _ALERT_LOG_TYPES
+            '#3': _ALERT_LOG_TYPES,
+            '#4': _ALERT_THRESHOLD,
+            '#5': _ALERT_RENOTIFY_EVENT_COUNT,
+            '#6': _ALERT_RENOTIFY_ON_SEVERITY_ESCALATION
         },
         ExpressionAttributeValues={
             ':1': {
@@ -188,13 +214,70 @@ def _update_get(group_info: MatchingGroupInfo) -> AlertInfo:
             ':3': {
                 'SS': [group_info.log_type]
             },
+            ':4': {
+                'N': '{}'.format(group_info.alert_threshold)
+            },
+            ':5': {
+                'N': '{}'.format(group_info.renotify_event_count)
+            },
+            ':6': {
+                'BOOL': group_info.renotify_on_severity_escalation
+            },
         },
         ReturnValues='ALL_NEW'
     )
     alert_count = response['Attributes'][_ALERT_COUNT_ATTR_NAME]['N']
     alert_creation_time = response['Attributes'][_ALERT_CREATION_TIME_ATTR_NAME]['N']
+    alert_severity = response['Attributes'][_ALERT_SEVERITY_ATTR_NAME]['S']
+    if _severity_rank(group_info.severity) > _severity_rank(alert_severity):
+        _escalate_severity(group_info, alert_count)
     return AlertInfo(
         alert_id=_generate_alert_id(group_info.rule_id, group_info.dedup, alert_count),
         alert_creation_time=datetime.utcfromtimestamp(int(alert_creation_time)),
         alert_update_time=group_info.processing_time
     )
+
+
+def _severity_rank(severity: str) -> int:
+    return _SEVERITIES.index(severity) if severity in _SEVERITIES else -1
+
+
+def _escalate_severity(group_info: MatchingGroupInfo, alert_count: str) -> None:
+    """Raises the severity of the alert to the one of the rule.
+
+    The severity of an alert escalates when its rule matches events with a higher severity than the alert during the
+    dedup period, it never decreases. The alert forwarder sends an update of the alert when its severity escalates.
+    The update is conditional so that a concurrent escalation to a higher severity or a new alert are not overwritten.
+    """
+    # the severity of the rule and the higher ones
+    escalated_severities = _SEVERITIES[_severity_rank(group_info.severity):]
+    expression_attribute_values = {
+        ':1': {
+            'S': group_info.severity
+        },
+        ':2': {
+            'N': alert_count
+        },
+    }
+    escalated_placeholders = []
+    for i, severity in enumerate(escalated_severities):
+        placeholder = ':s{}'.format(i)
+        escalated_placeholders.append(placeholder)
+        expression_attribute_values[placeholder] = {'S': severity}
+    try:
+        _DDB_CLIENT.update_item(
+            TableName=_DDB_TABLE_NAME,
+            Key={_PARTITION_KEY_NAME: {
+                'S': _generate_dedup_key(group_info.rule_id, group_info.dedup)
+            }},
+            UpdateExpression='SET #1=:1',
+            ConditionExpression='#2 = :2 AND NOT (#1 IN ({}))'.format(', '.join(escalated_placeholders)),
+            ExpressionAttributeNames={
+                '#1': _ALERT_SEVERITY_ATTR_NAME,
+                '#2': _ALERT_COUNT_ATTR_NAME,
+            },
+            ExpressionAttributeValues=expression_attribute_values
+        )
+    except _DDB_CLIENT.exceptions.ConditionalCheckFailedException:
+        # The alert already has an equal or higher severity or it is no longer the current alert
+        pass
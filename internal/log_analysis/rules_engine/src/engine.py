@@ -60,7 +60,10 @@ class Engine:
                     dedup_period_mins=rule.rule_dedup_period_mins,
                     event=event,
                     severity=rule.rule_severity,
-                    title=result.title
+                    title=result.title,
+                    alert_threshold=rule.rule_alert_threshold,
+                    renotify_event_count=rule.rule_renotify_event_count,
+                    renotify_on_severity_escalation=rule.rule_renotify_on_severity_escalation
                 )
                 matched.append(match)
 
@@ -19,7 +19,7 @@ import json
 from gzip import GzipFile
 from io import TextIOWrapper
 from timeit import default_timer
-from typing import Any, Dict, List, Optional
+from typing import Any, Dict, Iterator, List, Optional
 
 import boto3
 
@@ -27,12 +27,16 @@ from .engine import Engine
 from .analysis_api import AnalysisAPIClient
 from .logging import get_logger
 from .output import MatchedEventsBuffer
+from .parquet_reader import read_events
 from .rule import Rule
 
 _S3_CLIENT = boto3.client('s3')
 _LOGGER = get_logger()
 _RULES_ENGINE = Engine(AnalysisAPIClient())
 
+# The suffix of log data stored as Parquet, JSON log data is gzipped
+_PARQUET_SUFFIX = '.parquet'
+
 
 def lambda_handler(event: Dict[str, Any], unused_context: Any) -> Optional[Dict[str, Any]]:
     """Entry point for the Lambda"""
@@ -99,26 +103,20 @@ def log_analysis(event: Dict[str, Any]) -> None:
 
     start = default_timer()
 
-    # Dictionary containing mapping from log type to list of TextIOWrapper's
-    log_type_to_data: Dict[str, List[TextIOWrapper]] = collections.defaultdict(list)
+    # Dictionary containing mapping from log type to list of event iterators
+    log_type_to_data: Dict[str, List[Iterator[Dict[str, Any]]]] = collections.defaultdict(list)
     for record in event['Records']:
         record_body = json.loads(record['body'])
         bucket = record_body['s3Bucket']
         object_key = record_body['s3ObjectKey']
         _LOGGER.debug("loading object from S3, bucket [%s], key [%s]", bucket, object_key)
-        log_type_to_data[record_body['id']].append(_load_contents(bucket, object_key))
+        log_type_to_data[record_body['id']].append(_load_events(bucket, object_key))
 
     matches = 0
     output_buffer = MatchedEventsBuffer()
     for log_type, data_streams in log_type_to_data.items():
         for data_stream in data_streams:
-            for data in data_stream:
-                try:  # Bad json data can cause exceptions to be thrown. Best effort: log and continue
-                    json_data = json.loads(data)
-                except Exception as err:  # pylint: disable=broad-except
-                    _LOGGER.error("data is not valid JSON %s", err)  # do not log data!
-                    continue
-
+            for json_data in data_stream:
                 for analysis_result in _RULES_ENGINE.analyze(log_type, json_data):
                     matches += 1
                     output_buffer.add_event(analysis_result)
@@ -127,9 +125,17 @@ def log_analysis(event: Dict[str, Any]) -> None:
     _LOGGER.info("Matched %d events in %s seconds", matches, end - start)
 
 
-# Returns a TextIOWrapper for the S3 data. This makes sure that we don't have to keep all
-# contents of S3 object in memory
-def _load_contents(bucket: str, key: str) -> TextIOWrapper:
+# Returns an iterator of the events in the S3 data. JSON data is streamed so that we don't have to keep all
+# contents of S3 object in memory, Parquet files (which are compressed) are read in batches
+def _load_events(bucket: str, key: str) -> Iterator[Dict[str, Any]]:
     response = _S3_CLIENT.get_object(Bucket=bucket, Key=key)
+    if key.endswith(_PARQUET_SUFFIX):
+        yield from read_events(response['Body'].read())
+        return
+
     gzipped = GzipFile(None, 'rb', fileobj=response['Body'])
-    return TextIOWrapper(gzipped)  # type: ignore
+    for data in TextIOWrapper(gzipped):  # type: ignore
+        try:  # Bad json data can cause exceptions to be thrown. Best effort: log and continue
+            yield json.loads(data)
+        except Exception as err:  # pylint: disable=broad-except
+            _LOGGER.error("data is not valid JSON %s", err)  # do not log data!
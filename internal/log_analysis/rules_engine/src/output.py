@@ -114,7 +114,7 @@ class MatchedEventsBuffer:
 
 
 def _write_to_s3(time: datetime, key: OutputGroupingKey, events: List[EventMatch]) -> None:
-    # 'severity', 'version', 'title', 'dedup_period' of a rule might differ if the rule was modified
+    # 'severity', 'version', 'title', 'dedup_period', alert settings of a rule might differ if the rule was modified
     # while the rules engine was running. We pick the first encountered set of values.
     group_info = MatchingGroupInfo(
         rule_id=key.rule_id,
@@ -125,7 +125,10 @@ def _write_to_s3(time: datetime, key: OutputGroupingKey, events: List[EventMatch
         severity=events[0].severity,
         num_matches=len(events),
         title=events[0].title,
-        processing_time=time
+        processing_time=time,
+        alert_threshold=events[0].alert_threshold,
+        renotify_event_count=events[0].renotify_event_count,
+        renotify_on_severity_escalation=events[0].renotify_on_severity_escalation
     )
     alert_info = update_get_alert_info(group_info)
     data_stream = BytesIO()
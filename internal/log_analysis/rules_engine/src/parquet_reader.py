@@ -0,0 +1,82 @@
+# Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+"""Reads the events of log data stored as Parquet the way they are stored as JSON"""
+
+import json
+from datetime import datetime
+from io import BytesIO
+from typing import Any, Dict, Iterator, Set
+
+# The log processor lists the fields that the Parquet types do not describe in the file metadata.
+# Fields are paths of names joined by '.', with '[]' for array elements and '{}' for map values.
+_RAW_JSON_FIELDS_KEY = b'panther.rawJSONFields'
+_MAP_FIELDS_KEY = b'panther.mapFields'
+
+# The timestamp layout of the JSON log data (nanoseconds), Parquet timestamps are read with microsecond precision
+_TIMESTAMP_FORMAT = '%Y-%m-%d %H:%M:%S.%f'
+
+_BATCH_SIZE = 1000
+
+
+def read_events(data: bytes) -> Iterator[Dict[str, Any]]:
+    """Returns the events of a Parquet file"""
+    # pyarrow is imported lazily, it is only needed (and slow to import) when log data is stored as Parquet
+    import pyarrow.parquet as pq  # pylint: disable=import-outside-toplevel
+
+    # the INT96 timestamps are nanoseconds, which Python datetimes do not support
+    parquet_file = pq.ParquetFile(BytesIO(data), coerce_int96_timestamp_unit='us')
+    metadata = parquet_file.schema_arrow.metadata or {}
+    raw_json_fields = set(json.loads(metadata.get(_RAW_JSON_FIELDS_KEY, b'[]')))
+    map_fields = set(json.loads(metadata.get(_MAP_FIELDS_KEY, b'[]')))
+
+    for batch in parquet_file.iter_batches(batch_size=_BATCH_SIZE):
+        columns = batch.to_pydict()
+        for i in range(batch.num_rows):
+            yield to_event({name: values[i] for name, values in columns.items()}, raw_json_fields, map_fields)
+
+
+def to_event(row: Dict[str, Any], raw_json_fields: Set[str], map_fields: Set[str]) -> Dict[str, Any]:
+    """Converts a row read by pyarrow to the event as it is in JSON"""
+    return _to_struct(row, '', raw_json_fields, map_fields)
+
+
+def _to_struct(value: Dict[str, Any], field: str, raw_json_fields: Set[str], map_fields: Set[str]) -> Dict[str, Any]:
+    # fields are omitted from JSON when they are empty
+    return {
+        name: _to_json_value(field_value, field + '.' + name if field else name, raw_json_fields, map_fields)
+        for name, field_value in value.items()
+        if field_value is not None
+    }
+
+
+def _to_json_value(value: Any, field: str, raw_json_fields: Set[str], map_fields: Set[str]) -> Any:
+    if value is None:
+        return None
+    if field in map_fields:  # maps are read as lists of (key, value)
+        return {key: _to_json_value(entry, field + '{}', raw_json_fields, map_fields) for key, entry in value}
+    if isinstance(value, dict):
+        return _to_struct(value, field, raw_json_fields, map_fields)
+    if isinstance(value, list):
+        return [_to_json_value(element, field + '[]', raw_json_fields, map_fields) for element in value]
+    if isinstance(value, datetime):
+        return value.strftime(_TIMESTAMP_FORMAT) + '000'
+    if field in raw_json_fields:
+        # JSON documents are stored as JSON, except strings which are stored as is
+        try:
+            return json.loads(value)
+        except ValueError:
+            return value
+    return value
@@ -20,7 +20,7 @@ import tempfile
 from dataclasses import dataclass
 from importlib import util as import_util
 from pathlib import Path
-from typing import Any, Dict, Optional, Callable
+from typing import Any, Dict, List, Optional, Callable
 
 from .logging import get_logger
 
@@ -41,6 +41,9 @@ DEFAULT_RULE_VERSION = 'default'
 
 DEFAULT_RULE_DEDUP_PERIOD_MINS = 60
 
+# By default, an alert is sent as soon as an event matches it
+DEFAULT_ALERT_THRESHOLD = 1
+
 
 @dataclass
 class RuleResult:
@@ -66,6 +69,8 @@ class Rule:
                 severity: The severity of the rule
                 (Optional) version: The version of the rule
                 (Optional) dedup_period_mins: The period during which the events will be deduplicated
+                (Optional) alertSettings: The event fields grouping the events into alerts, the number of events
+                    before an alert is sent and when updates of the alert are sent
         """
         if not ('id' in config) or not isinstance(config['id'], str):
             raise AssertionError('Field "id" of type str is required field')
@@ -89,6 +94,12 @@ class Rule:
         else:
             self.rule_dedup_period_mins = config['dedupPeriodMinutes']
 
+        alert_settings = config.get('alertSettings') or {}
+        self.rule_dedup_fields: List[str] = alert_settings.get('dedupFields') or []
+        self.rule_alert_threshold: int = alert_settings.get('threshold') or DEFAULT_ALERT_THRESHOLD
+        self.rule_renotify_event_count: int = alert_settings.get('renotifyEventCount') or 0
+        self.rule_renotify_on_severity_escalation: bool = alert_settings.get('renotifyOnSeverityEscalation') or False
+
         self._store_rule()
         self._module = self._import_rule_as_module()
 
@@ -123,13 +134,18 @@ class Rule:
 
     def _get_dedup(self, event: Dict[str, Any]) -> str:
         if not self._has_dedup:
-            # If no dedup function defined, return default dedup string
-            return self._default_dedup_string
-        try:
-            dedup_string = self._run_command(self._module.dedup, event, str)
-        except Exception as err:  # pylint: disable=broad-except
-            self.logger.warning('dedup method raised exception. Defaulting dedup string to "%s". Exception: %s', self.rule_id, err)
-            return self._default_dedup_string
+            if self.rule_dedup_fields:
+                # If no dedup function defined, group the events by the values of the dedup fields
+                dedup_string = _dedup_from_fields(event, self.rule_dedup_fields)
+            else:
+                # If no dedup function nor dedup fields defined, return default dedup string
+                return self._default_dedup_string
+        else:
+            try:
+                dedup_string = self._run_command(self._module.dedup, event, str)
+            except Exception as err:  # pylint: disable=broad-except
+                self.logger.warning('dedup method raised exception. Defaulting dedup string to "%s". Exception: %s', self.rule_id, err)
+                return self._default_dedup_string
 
         if dedup_string:
             if len(dedup_string) > MAX_DEDUP_STRING_SIZE:
@@ -205,6 +221,17 @@ class Rule:
         return result
 
 
+def _dedup_from_fields(event: Dict[str, Any], fields: List[str]) -> str:
+    """Returns the dedup string of the values of the event fields, nested fields are separated by dots"""
+    values = []
+    for field in fields:
+        value: Any = event
+        for key in field.split('.'):
+            value = value.get(key) if isinstance(value, dict) else None
+        values.append('{}={}'.format(field, '' if value is None else value))
+    return ','.join(values)
+
+
 def _rule_id_to_path(rule_id: str) -> str:
     """Method returns the file path where the rule will be stored"""
     safe_id = ''.join(x if _allowed_char(x) else '_' for x in rule_id)
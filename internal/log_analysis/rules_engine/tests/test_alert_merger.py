@@ -0,0 +1,105 @@
+# Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import os
+from datetime import datetime
+from unittest import TestCase, mock
+
+import boto3
+
+from . import mock_to_return, DDB_MOCK
+
+with mock.patch.dict(os.environ, {'ALERTS_DEDUP_TABLE': 'table_name'}), \
+     mock.patch.object(boto3, 'client', side_effect=mock_to_return) as mock_boto:
+    from ..src.alert_merger import MatchingGroupInfo, update_get_alert_info
+
+
+class ConditionalCheckFailedException(Exception):
+    pass
+
+
+def _group_info(severity: str) -> MatchingGroupInfo:
+    return MatchingGroupInfo(
+        rule_id='rule_id',
+        rule_version='rule_version',
+        log_type='log_type',
+        dedup='dedup',
+        dedup_period_mins=100,
+        severity=severity,
+        num_matches=1,
+        title=None,
+        processing_time=datetime.utcfromtimestamp(1000)
+    )
+
+
+def _merged_alert(severity: str) -> dict:
+    return {'Attributes': {'alertCount': {'N': '1'}, 'alertCreationTime': {'N': '900'}, 'severity': {'S': severity}}}
+
+
+class TestAlertMerger(TestCase):
+
+    def setUp(self) -> None:
+        DDB_MOCK.reset_mock(side_effect=True)
+        DDB_MOCK.exceptions.ConditionalCheckFailedException = ConditionalCheckFailedException
+
+    def tearDown(self) -> None:
+        # the mock is shared with the other tests
+        DDB_MOCK.reset_mock(side_effect=True)
+
+    def test_merge_keeps_higher_severity(self) -> None:
+        # the alert exists, so the events are merged into it
+        DDB_MOCK.update_item.side_effect = [ConditionalCheckFailedException(), _merged_alert('HIGH')]
+
+        update_get_alert_info(_group_info('LOW'))
+
+        self.assertEqual(DDB_MOCK.update_item.call_count, 2)
+        merge = DDB_MOCK.update_item.call_args_list[1][1]
+        self.assertNotIn('severity', merge['ExpressionAttributeNames'].values())
+
+    def test_merge_escalates_severity(self) -> None:
+        DDB_MOCK.update_item.side_effect = [ConditionalCheckFailedException(), _merged_alert('LOW'), {}]
+
+        update_get_alert_info(_group_info('HIGH'))
+
+        self.assertEqual(DDB_MOCK.update_item.call_count, 3)
+        escalation = DDB_MOCK.update_item.call_args_list[2][1]
+        self.assertEqual(escalation['UpdateExpression'], 'SET #1=:1')
+        self.assertEqual(escalation['ConditionExpression'], '#2 = :2 AND NOT (#1 IN (:s0, :s1))')
+        self.assertEqual(
+            escalation['ExpressionAttributeValues'], {
+                ':1': {
+                    'S': 'HIGH'
+                },
+                ':2': {
+                    'N': '1'
+                },
+                ':s0': {
+                    'S': 'HIGH'
+                },
+                ':s1': {
+                    'S': 'CRITICAL'
+                },
+            }
+        )
+
+    def test_merge_escalation_lost_to_higher_severity(self) -> None:
+        # a concurrent merge escalated the alert further
+        DDB_MOCK.update_item.side_effect = [ConditionalCheckFailedException(), _merged_alert('LOW'), ConditionalCheckFailedException()]
+
+        alert_info = update_get_alert_info(_group_info('MEDIUM'))
+
+        self.assertEqual(DDB_MOCK.update_item.call_count, 3)
+        self.assertEqual(alert_info.alert_creation_time, datetime.utcfromtimestamp(900))
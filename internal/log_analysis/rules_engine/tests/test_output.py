@@ -70,7 +70,10 @@ class TestMatchedEventsBuffer(TestCase):
                 '#8': 'eventCount',
                 '#9': 'severity',
                 '#10': 'logTypes',
-                '#11': 'ruleVersion'
+                '#11': 'ruleVersion',
+                '#13': 'alertThreshold',
+                '#14': 'renotifyEventCount',
+                '#15': 'renotifyOnSeverityEscalation'
             },
             ExpressionAttributeValues={
                 ':1': {
@@ -102,6 +105,15 @@ class TestMatchedEventsBuffer(TestCase):
                 },
                 ':11': {
                     'S': 'rule_version'
+                },
+                ':13': {
+                    'N': '1'
+                },
+                ':14': {
+                    'N': '0'
+                },
+                ':15': {
+                    'BOOL': False
                 }
             },
             Key={
@@ -112,7 +124,7 @@ class TestMatchedEventsBuffer(TestCase):
             },
             ReturnValues='ALL_NEW',
             TableName='table_name',
-            UpdateExpression='ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10, #11=:11'
+            UpdateExpression='ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10, #11=:11, #13=:13, #14=:14, #15=:15'
         )
 
         S3_MOCK.put_object.assert_called_once_with(Body=mock.ANY, Bucket='s3_bucket', ContentType='gzip', Key=mock.ANY)
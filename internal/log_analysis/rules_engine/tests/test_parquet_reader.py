@@ -0,0 +1,63 @@
+# Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+from datetime import datetime
+from unittest import TestCase
+
+from ..src.parquet_reader import to_event
+
+
+class TestToEvent(TestCase):
+
+    def test_primitives(self) -> None:
+        row = {'name': 'a', 'count': 1, 'ratio': 1.5, 'flag': True, 'missing': None}
+        self.assertEqual({'name': 'a', 'count': 1, 'ratio': 1.5, 'flag': True}, to_event(row, set(), set()))
+
+    def test_timestamps(self) -> None:
+        row = {'p_event_time': datetime(2020, 1, 1, 0, 1, 1, 123456)}
+        self.assertEqual({'p_event_time': '2020-01-01 00:01:01.123456000'}, to_event(row, set(), set()))
+
+    def test_raw_json(self) -> None:
+        row = {'requestParameters': '{"bucketName":"b","keys":[1,2]}', 'document': 'text', 'name': '{"a":1}'}
+        expected = {'requestParameters': {'bucketName': 'b', 'keys': [1, 2]}, 'document': 'text', 'name': '{"a":1}'}
+        self.assertEqual(expected, to_event(row, {'requestParameters', 'document'}, set()))
+
+    def test_nested(self) -> None:
+        row = {
+            'labels': [('k1', 'v1'), ('k2', None)],
+            'empty': [],
+            'resources': [{
+                'arn': 'arn',
+                'type': None,
+                'details': [('d', '{"a":true}')],
+            }],
+        }
+        expected = {
+            'labels': {
+                'k1': 'v1',
+                'k2': None
+            },
+            'empty': {},
+            'resources': [{
+                'arn': 'arn',
+                'details': {
+                    'd': {
+                        'a': True
+                    }
+                },
+            }],
+        }
+        self.assertEqual(expected, to_event(row, {'resources[].details{}'}, {'labels', 'empty', 'resources[].details'}))
@@ -54,6 +54,25 @@ class TestRule(TestCase):
 
         self.assertEqual(60, rule.rule_dedup_period_mins)
 
+    def test_rule_default_alert_settings(self) -> None:
+        rule_body = 'def rule(event):\n\treturn True'
+        rule = Rule({'id': 'test_rule_default_alert_settings', 'body': rule_body, 'severity': 'INFO'})
+
+        self.assertEqual([], rule.rule_dedup_fields)
+        self.assertEqual(1, rule.rule_alert_threshold)
+        self.assertEqual(0, rule.rule_renotify_event_count)
+        self.assertFalse(rule.rule_renotify_on_severity_escalation)
+
+    def test_rule_alert_settings(self) -> None:
+        rule_body = 'def rule(event):\n\treturn True'
+        alert_settings = {'dedupFields': ['user'], 'threshold': 5, 'renotifyEventCount': 100, 'renotifyOnSeverityEscalation': True}
+        rule = Rule({'id': 'test_rule_alert_settings', 'body': rule_body, 'severity': 'INFO', 'alertSettings': alert_settings})
+
+        self.assertEqual(['user'], rule.rule_dedup_fields)
+        self.assertEqual(5, rule.rule_alert_threshold)
+        self.assertEqual(100, rule.rule_renotify_event_count)
+        self.assertTrue(rule.rule_renotify_on_severity_escalation)
+
     def test_create_rule_missing_method(self) -> None:
         exception = False
         rule_body = 'def another_method(event):\n\treturn False'
@@ -182,3 +201,26 @@ class TestRule(TestCase):
 
         expected_result = RuleResult(matched=True, dedup_string='defaultDedupString:test_rule_title_returns_empty_string')
         self.assertEqual(rule.run({}), expected_result)
+
+    def test_rule_with_dedup_fields(self) -> None:
+        rule_body = 'def rule(event):\n\treturn True'
+        alert_settings = {'dedupFields': ['user', 'source.ip', 'missing']}
+        rule = Rule({'id': 'test_rule_with_dedup_fields', 'body': rule_body, 'severity': 'INFO', 'alertSettings': alert_settings})
+
+        expected_rule = RuleResult(matched=True, dedup_string='user=alice,source.ip=1.1.1.1,missing=')
+        self.assertEqual(rule.run({'user': 'alice', 'source': {'ip': '1.1.1.1'}}), expected_rule)
+
+    def test_rule_dedup_function_overrides_dedup_fields(self) -> None:
+        rule_body = 'def rule(event):\n\treturn True\ndef dedup(event):\n\treturn "testdedup"'
+        alert_settings = {'dedupFields': ['user']}
+        rule = Rule(
+            {
+                'id': 'test_rule_dedup_function_overrides_dedup_fields',
+                'body': rule_body,
+                'severity': 'INFO',
+                'alertSettings': alert_settings
+            }
+        )
+
+        expected_rule = RuleResult(matched=True, dedup_string='testdedup')
+        self.assertEqual(rule.run({'user': 'alice'}), expected_rule)
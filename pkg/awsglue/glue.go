@@ -46,6 +46,23 @@ const (
 
 	ViewsDatabaseName        = "panther_views"
 	ViewsDatabaseDescription = "Holds views useful for querying Panther data"
+
+	// Formats of the data files written by Panther
+	JSONDataFormat    = "json"    // gzipped JSON lines
+	ParquetDataFormat = "parquet" // snappy compressed Parquet
+
+	jsonInputFormat     = "org.apache.hadoop.mapred.TextInputFormat"
+	jsonOutputFormat    = "org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat"
+	jsonSerDe           = "org.openx.data.jsonserde.JsonSerDe"
+	parquetInputFormat  = "org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat"
+	parquetOutputFormat = "org.apache.hadoop.hive.ql.io.parquet.MapredParquetOutputFormat"
+	parquetSerDe        = "org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe"
+)
+
+var (
+	// LatePartitionTime is the time of the partition holding the events that arrived after the late-arrival window
+	// (their p_event_time is kept), so that they do not create partitions far in the past.
+	LatePartitionTime = time.Unix(0, 0).UTC()
 )
 
 type PartitionKey struct {
@@ -200,7 +217,7 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 						if hasData, err := gm.partitionHasData(s3Client, update, tableOutput); err != nil {
 							failed = err
 						} else if hasData {
-							if err = gm.createPartition(glueClient, update, tableOutput); err != nil {
+							if err = gm.createPartition(glueClient, update, tableOutput.Table.StorageDescriptor); err != nil {
 								failed = err
 							}
 						}
@@ -208,9 +225,11 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 					continue
 				}
 
-				// leave _everything_ the same except the schema, and the serde info
+				// leave _everything_ the same except the schema, and the serde info (keeping the format of the partition's data)
+				partitionFormat := getDataFormat(getPartitionOutput.Partition.StorageDescriptor)
 				getPartitionOutput.Partition.StorageDescriptor.Columns = columns
-				getPartitionOutput.Partition.StorageDescriptor.SerdeInfo = tableOutput.Table.StorageDescriptor.SerdeInfo
+				getPartitionOutput.Partition.StorageDescriptor.SerdeInfo = partitionStorageDescriptor(
+					tableOutput.Table.StorageDescriptor, partitionFormat).SerdeInfo
 				values := gm.partitionValues(update)
 				partitionInput := &glue.PartitionInput{
 					Values:            values,
@@ -232,6 +251,11 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 		}()
 	}
 
+	// the partition of late events is outside of the time range
+	if startDate.After(LatePartitionTime) {
+		updateChan <- LatePartitionTime
+	}
+
 	// loop over each partition updating
 	for timeBin := startDate; !timeBin.After(endDay); timeBin = gm.Timebin().Next(timeBin) {
 		updateChan <- timeBin
@@ -244,6 +268,16 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 }
 
 func (gm *GlueTableMetadata) CreateJSONPartition(client glueiface.GlueAPI, t time.Time) error {
+	return gm.createFormatPartition(client, t, JSONDataFormat)
+}
+
+func (gm *GlueTableMetadata) CreateParquetPartition(client glueiface.GlueAPI, t time.Time) error {
+	return gm.createFormatPartition(client, t, ParquetDataFormat)
+}
+
+// createFormatPartition creates a partition holding data in dataFormat, the table is expected to have the same format
+// but when the output format of Panther is changed, partitions with data in the previous format are still created.
+func (gm *GlueTableMetadata) createFormatPartition(client glueiface.GlueAPI, t time.Time, dataFormat string) error {
 	// inherit StorageDescriptor from table
 	tableInput := &glue.GetTableInput{
 		DatabaseName: aws.String(gm.databaseName),
@@ -254,27 +288,58 @@ func (gm *GlueTableMetadata) CreateJSONPartition(client glueiface.GlueAPI, t tim
 		return err
 	}
 
-	// ensure this is a JSON table, use Contains() because there are multiple json serdes
-	if !strings.Contains(*tableOutput.Table.StorageDescriptor.SerdeInfo.SerializationLibrary, "json") {
-		return errors.Errorf("not a JSON table: %#v", *tableOutput.Table.StorageDescriptor)
+	return gm.createPartition(client, t, partitionStorageDescriptor(tableOutput.Table.StorageDescriptor, dataFormat))
+}
+
+// GetPartitionDataFormat returns the format of the data in the partition of t, creating the partition for data in
+// dataFormat if it does not exist. All data of a partition must be written in its format since a partition has a
+// single SerDe, so the output format of Panther only changes at partition boundaries.
+func (gm *GlueTableMetadata) GetPartitionDataFormat(client glueiface.GlueAPI, t time.Time,
+	dataFormat string) (string, error) {
+
+	getPartitionInput := &glue.GetPartitionInput{
+		DatabaseName:    aws.String(gm.databaseName),
+		TableName:       aws.String(gm.tableName),
+		PartitionValues: gm.partitionValues(t),
+	}
+	getPartitionOutput, err := client.GetPartition(getPartitionInput)
+	if err == nil {
+		return getDataFormat(getPartitionOutput.Partition.StorageDescriptor), nil
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != glue.ErrCodeEntityNotFoundException {
+		return "", err
+	}
+
+	if err = gm.createFormatPartition(client, t, dataFormat); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == glue.ErrCodeEntityNotFoundException {
+			// the table does not exist yet (e.g. a new custom log type), it is created with the partition of the data
+			return dataFormat, nil
+		}
+		return "", err
 	}
 
-	return gm.createPartition(client, t, tableOutput)
+	// read it back since another writer may have created the partition first in another format
+	getPartitionOutput, err = client.GetPartition(getPartitionInput)
+	if err != nil {
+		return "", err
+	}
+	return getDataFormat(getPartitionOutput.Partition.StorageDescriptor), nil
 }
 
-func (gm *GlueTableMetadata) createPartition(client glueiface.GlueAPI, t time.Time, tableOutput *glue.GetTableOutput) error {
-	location, err := url.Parse(*tableOutput.Table.StorageDescriptor.Location)
+func (gm *GlueTableMetadata) createPartition(client glueiface.GlueAPI, t time.Time, tableStorageDescriptor *glue.StorageDescriptor) error {
+	location, err := url.Parse(*tableStorageDescriptor.Location)
 	if err != nil {
 		return errors.Wrapf(err, "Cannot parse table %s.%s s3 path: %s",
 			gm.DatabaseName(), gm.TableName(),
-			*tableOutput.Table.StorageDescriptor.Location)
+			*tableStorageDescriptor.Location)
 	}
 
-	tableOutput.Table.StorageDescriptor.Location = aws.String("s3://" + location.Host + "/" + gm.GetPartitionPrefix(t))
+	storageDescriptor := *tableStorageDescriptor // copy, do not modify the table
+	storageDescriptor.Location = aws.String("s3://" + location.Host + "/" + gm.GetPartitionPrefix(t))
 
 	partitionInput := &glue.PartitionInput{
 		Values:            gm.partitionValues(t),
-		StorageDescriptor: tableOutput.Table.StorageDescriptor,
+		StorageDescriptor: &storageDescriptor,
 	}
 	input := &glue.CreatePartitionInput{
 		DatabaseName:   aws.String(gm.databaseName),
@@ -290,6 +355,52 @@ func (gm *GlueTableMetadata) createPartition(client glueiface.GlueAPI, t time.Ti
 	return nil
 }
 
+// getDataFormat returns the format of the data described by the storage descriptor
+func getDataFormat(storageDescriptor *glue.StorageDescriptor) string {
+	// use Contains() because there are multiple json serdes
+	if storageDescriptor.SerdeInfo != nil &&
+		strings.Contains(strings.ToLower(aws.StringValue(storageDescriptor.SerdeInfo.SerializationLibrary)), ParquetDataFormat) {
+
+		return ParquetDataFormat
+	}
+	return JSONDataFormat
+}
+
+// partitionStorageDescriptor returns the storage descriptor of the table for data in dataFormat
+func partitionStorageDescriptor(tableStorageDescriptor *glue.StorageDescriptor, dataFormat string) *glue.StorageDescriptor {
+	storageDescriptor := *tableStorageDescriptor // copy, do not modify the table
+	if getDataFormat(tableStorageDescriptor) == dataFormat {
+		return &storageDescriptor
+	}
+
+	switch dataFormat {
+	case ParquetDataFormat:
+		storageDescriptor.InputFormat = aws.String(parquetInputFormat)
+		storageDescriptor.OutputFormat = aws.String(parquetOutputFormat)
+		storageDescriptor.SerdeInfo = &glue.SerDeInfo{
+			SerializationLibrary: aws.String(parquetSerDe),
+			Parameters: map[string]*string{
+				"serialization.format": aws.String("1"),
+			},
+		}
+	default:
+		parameters := map[string]*string{
+			"serialization.format": aws.String("1"),
+			"case.insensitive":     aws.String("false"), // columns may have the same name but different casing
+		}
+		for _, column := range storageDescriptor.Columns { // required when columns are case sensitive
+			parameters["mapping."+strings.ToLower(*column.Name)] = column.Name
+		}
+		storageDescriptor.InputFormat = aws.String(jsonInputFormat)
+		storageDescriptor.OutputFormat = aws.String(jsonOutputFormat)
+		storageDescriptor.SerdeInfo = &glue.SerDeInfo{
+			SerializationLibrary: aws.String(jsonSerDe),
+			Parameters:           parameters,
+		}
+	}
+	return &storageDescriptor
+}
+
 func (gm *GlueTableMetadata) deletePartition(client glueiface.GlueAPI, t time.Time) (output *glue.DeletePartitionOutput, err error) {
 	input := &glue.DeletePartitionInput{
 		DatabaseName:    aws.String(gm.databaseName),
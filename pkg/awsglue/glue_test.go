@@ -114,6 +114,26 @@ func TestCreateJSONPartition(t *testing.T) {
 	glueClient.AssertExpectations(t)
 }
 
+func TestCreateJSONPartitionParquetTable(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	// JSON data written before the table was switched to Parquet keeps the JSON SerDe
+	parquetStorageDescriptor := partitionStorageDescriptor(testStorageDescriptor, ParquetDataFormat)
+	glueClient := &mockGlue{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{
+		Table: &glue.TableData{StorageDescriptor: parquetStorageDescriptor},
+	}, nil).Once()
+	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, nil).Once()
+	assert.NoError(t, gm.CreateJSONPartition(glueClient, refTime))
+	glueClient.AssertExpectations(t)
+
+	storageDescriptor := glueClient.Calls[1].Arguments.Get(0).(*glue.CreatePartitionInput).PartitionInput.StorageDescriptor
+	assert.Equal(t, jsonSerDe, *storageDescriptor.SerdeInfo.SerializationLibrary)
+	assert.Equal(t, "col", *storageDescriptor.SerdeInfo.Parameters["mapping.col"])
+	assert.Equal(t, "s3://testbucket/logs/test_logs/year=2020/month=01/day=03/hour=01/", *storageDescriptor.Location)
+	assert.Equal(t, parquetSerDe, *parquetStorageDescriptor.SerdeInfo.SerializationLibrary) // table is not modified
+}
+
 func TestCreateJSONPartitionPartitionExists(t *testing.T) {
 	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
 
@@ -149,12 +169,83 @@ func TestCreateJSONPartitionNonAWSError(t *testing.T) {
 	glueClient.AssertExpectations(t)
 }
 
+func TestGetPartitionDataFormat(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	// the existing partition keeps its format
+	glueClient := &mockGlue{}
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, nil).Once()
+	dataFormat, err := gm.GetPartitionDataFormat(glueClient, refTime, ParquetDataFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, JSONDataFormat, dataFormat)
+	glueClient.AssertExpectations(t)
+}
+
+func TestGetPartitionDataFormatCreatesPartition(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	parquetPartitionOutput := &glue.GetPartitionOutput{
+		Partition: &glue.Partition{
+			StorageDescriptor: partitionStorageDescriptor(testStorageDescriptor, ParquetDataFormat),
+		},
+	}
+	glueClient := &mockGlue{}
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Once()
+	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, nil).Once()
+	glueClient.On("GetPartition", mock.Anything).Return(parquetPartitionOutput, nil).Once()
+	dataFormat, err := gm.GetPartitionDataFormat(glueClient, refTime, ParquetDataFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, ParquetDataFormat, dataFormat)
+	glueClient.AssertExpectations(t)
+
+	storageDescriptor := glueClient.Calls[2].Arguments.Get(0).(*glue.CreatePartitionInput).PartitionInput.StorageDescriptor
+	assert.Equal(t, parquetSerDe, *storageDescriptor.SerdeInfo.SerializationLibrary)
+}
+
+func TestGetPartitionDataFormatCreatedConcurrently(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	// another writer created the partition in its format first
+	glueClient := &mockGlue{}
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Once()
+	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, entityExistsError).Once()
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, nil).Once()
+	dataFormat, err := gm.GetPartitionDataFormat(glueClient, refTime, ParquetDataFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, JSONDataFormat, dataFormat)
+	glueClient.AssertExpectations(t)
+}
+
+func TestGetPartitionDataFormatNoTable(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	glueClient := &mockGlue{}
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Once()
+	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, entityNotFoundError).Once()
+	dataFormat, err := gm.GetPartitionDataFormat(glueClient, refTime, ParquetDataFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, ParquetDataFormat, dataFormat)
+	glueClient.AssertExpectations(t)
+}
+
+func TestGetPartitionDataFormatError(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	glueClient := &mockGlue{}
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, otherAWSError).Once()
+	_, err := gm.GetPartitionDataFormat(glueClient, refTime, ParquetDataFormat)
+	assert.Equal(t, otherAWSError, err)
+	glueClient.AssertExpectations(t)
+}
+
 func TestSyncPartitions(t *testing.T) {
 	var startDate time.Time // default unset
 	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
 
 	syncGetTableOutput := *testGetTableOutput
-	syncGetTableOutput.Table.CreateTime = aws.Time(time.Now().UTC())     // this should cause 24 updates
+	syncGetTableOutput.Table.CreateTime = aws.Time(time.Now().UTC())     // this should cause 24 updates + the late partition
 	syncGetTableOutput.Table.StorageDescriptor.Columns = []*glue.Column{ // this should be copied to partitions
 		{
 			Name: aws.String("updatedCol"),
@@ -164,20 +255,25 @@ func TestSyncPartitions(t *testing.T) {
 
 	glueClient := &mockGlue{}
 	glueClient.On("GetTable", mock.Anything).Return(&syncGetTableOutput, nil).Once()
-	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, nil).Times(24)
-	glueClient.On("UpdatePartition", mock.Anything).Return(testUpdatePartitionOutput, nil).Times(24)
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, nil).Times(25)
+	glueClient.On("UpdatePartition", mock.Anything).Return(testUpdatePartitionOutput, nil).Times(25)
 	s3Client := &mockS3{}
 	err := gm.SyncPartitions(glueClient, s3Client, startDate)
 	assert.NoError(t, err)
 	glueClient.AssertExpectations(t)
 
 	// check that schema was updated
+	updatedLatePartition := false
 	for _, updateCall := range glueClient.Calls {
 		switch updateInput := updateCall.Arguments.Get(0).(type) {
 		case *glue.UpdatePartitionInput:
 			assert.Equal(t, syncGetTableOutput.Table.StorageDescriptor.Columns, updateInput.PartitionInput.StorageDescriptor.Columns)
+			if aws.StringValue(updateInput.PartitionValueList[0]) == "1970" {
+				updatedLatePartition = true
+			}
 		}
 	}
+	assert.True(t, updatedLatePartition)
 }
 
 func TestSyncPartitionsPartitionDoesntExistAndNoData(t *testing.T) {
@@ -187,12 +283,12 @@ func TestSyncPartitionsPartitionDoesntExistAndNoData(t *testing.T) {
 	// test not exists error in GetPartition (should not fail)
 	glueClient := &mockGlue{}
 	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
-	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Times(24)
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Times(25)
 	s3Client := &mockS3{}
 	page := &s3.ListObjectsV2Output{
 		Contents: []*s3.Object{}, // no objects
 	}
-	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Times(24) // no data found in S3
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Times(25) // no data found in S3
 	// no partitions should be created
 	err := gm.SyncPartitions(glueClient, s3Client, startDate)
 	assert.NoError(t, err)
@@ -207,7 +303,7 @@ func TestSyncPartitionsPartitionDoesntExistAndHasData(t *testing.T) {
 	// test not exists error in GetPartition (should not fail)
 	glueClient := &mockGlue{}
 	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
-	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Times(24)
+	glueClient.On("GetPartition", mock.Anything).Return(testGetPartitionOutput, entityNotFoundError).Times(25)
 	s3Client := &mockS3{}
 	page := &s3.ListObjectsV2Output{
 		Contents: []*s3.Object{
@@ -216,8 +312,8 @@ func TestSyncPartitionsPartitionDoesntExistAndHasData(t *testing.T) {
 			},
 		},
 	}
-	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Times(24)      // some data found in S3
-	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, nil).Times(24) // should create partitions
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Times(25)      // some data found in S3
+	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, nil).Times(25) // should create partitions
 	err := gm.SyncPartitions(glueClient, s3Client, startDate)
 	assert.NoError(t, err)
 	glueClient.AssertExpectations(t)
@@ -38,8 +38,8 @@ type GluePartition struct {
 	databaseName     string
 	tableName        string
 	s3Bucket         string
-	dataFormat       string    // Can currently be only "json"
-	compression      string    // Can only be "gzip" currently
+	dataFormat       string    // JSONDataFormat or ParquetDataFormat
+	compression      string    // "gzip" for json, "snappy" for parquet
 	hour             time.Time // the hour this partition corresponds to
 	partitionColumns []PartitionColumnInfo
 }
@@ -103,20 +103,29 @@ type PartitionColumnInfo struct {
 
 // Creates a new partition in Glue using the client provided.
 func (gp *GluePartition) CreatePartition(client glueiface.GlueAPI) error {
-	return NewGlueTableMetadata(gp.datatype, gp.tableName, "", GlueTableHourly, nil).CreateJSONPartition(client, gp.hour)
+	tableMetadata := NewGlueTableMetadata(gp.datatype, gp.tableName, "", GlueTableHourly, nil)
+	if gp.dataFormat == ParquetDataFormat {
+		return tableMetadata.CreateParquetPartition(client, gp.hour)
+	}
+	return tableMetadata.CreateJSONPartition(client, gp.hour)
 }
 
 // Gets the partition from S3bucket and S3 object key info.
 // The s3Object key is expected to be in the the format
-// `{logs,rules}/{table_name}/year=d{4}/month=d{2}/[day=d{2}/][hour=d{2}/]/{S+}.json.gz` otherwise an error is returned.
+// `{logs,rules}/{table_name}/year=d{4}/month=d{2}/[day=d{2}/][hour=d{2}/]/{S+}.{json.gz,parquet}` otherwise an error is returned.
 func GetPartitionFromS3(s3Bucket, s3ObjectKey string) (*GluePartition, error) {
 	partition := &GluePartition{s3Bucket: s3Bucket}
 
-	if !strings.HasSuffix(s3ObjectKey, ".json.gz") {
-		return nil, errors.New("currently only GZIP json is supported")
+	switch {
+	case strings.HasSuffix(s3ObjectKey, ".json.gz"):
+		partition.compression = "gzip"
+		partition.dataFormat = JSONDataFormat
+	case strings.HasSuffix(s3ObjectKey, ".parquet"):
+		partition.compression = "snappy"
+		partition.dataFormat = ParquetDataFormat
+	default:
+		return nil, errors.New("currently only GZIP json and Parquet are supported")
 	}
-	partition.compression = "gzip"
-	partition.dataFormat = "json"
 
 	s3Keys := strings.Split(s3ObjectKey, "/")
 	if len(s3Keys) < 4 {
@@ -112,11 +112,39 @@ func TestCreatePartitionMisingYearPartition(t *testing.T) {
 }
 
 func TestCreatePartitionUknownFormat(t *testing.T) {
-	s3ObjectKey := "rules/table/year=2020/month=02/day=26/hour=15/rule_id=Rule.Id/item.parquet"
+	s3ObjectKey := "rules/table/year=2020/month=02/day=26/hour=15/rule_id=Rule.Id/item.csv"
 	_, err := GetPartitionFromS3("bucket", s3ObjectKey)
 	require.Error(t, err)
 }
 
+func TestCreatePartitionFromS3Parquet(t *testing.T) {
+	s3ObjectKey := "logs/table/year=2020/month=02/day=26/hour=15/item.parquet"
+	partition, err := GetPartitionFromS3("bucket", s3ObjectKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, LogProcessingDatabaseName, partition.GetDatabase())
+	assert.Equal(t, "table", partition.GetTable())
+	assert.Equal(t, "parquet", partition.GetDataFormat())
+	assert.Equal(t, "snappy", partition.GetCompression())
+	assert.Equal(t, "s3://bucket/logs/table/year=2020/month=02/day=26/hour=15/", partition.GetPartitionLocation())
+}
+
+func TestCreatePartitionParquet(t *testing.T) {
+	s3ObjectKey := "logs/table/year=2020/month=02/day=26/hour=15/item.parquet"
+	partition, err := GetPartitionFromS3("bucket", s3ObjectKey)
+	require.NoError(t, err)
+
+	mockClient := &mockGlue{}
+	mockClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	mockClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{}, nil).Once()
+
+	assert.NoError(t, partition.CreatePartition(mockClient))
+	mockClient.AssertExpectations(t)
+	input := mockClient.Calls[1].Arguments.Get(0).(*glue.CreatePartitionInput)
+	assert.Equal(t, parquetSerDe, *input.PartitionInput.StorageDescriptor.SerdeInfo.SerializationLibrary)
+	assert.Equal(t, parquetInputFormat, *input.PartitionInput.StorageDescriptor.InputFormat)
+}
+
 func TestCreatePartitionLog(t *testing.T) {
 	s3ObjectKey := "logs/table/year=2020/month=02/day=26/hour=15/rule_id=Rule.Id/item.json.gz"
 	partition, err := GetPartitionFromS3("bucket", s3ObjectKey)
@@ -29,6 +29,9 @@ const (
 	lambdaErrorsMetricFilterName = "errors"
 	lambdaWarnsMetricFilterName  = "warns"
 	lambdaMemoryMetricFilterName = "memory"
+
+	logProcessorLambdaName                 = "panther-log-processor"
+	logProcessorLateEventsMetricFilterName = "late-events"
 )
 
 type MetricFilter struct {
@@ -70,6 +73,12 @@ func NewLambdaMemoryMetricFilter(lambdaName string) *MetricFilter {
 		`[ report_label="REPORT", ..., label="Used:", max_memory_used_value, unit="MB" ]`, `$max_memory_used_value`)
 }
 
+// The events stored in the late partition because they arrived after the late-arrival window
+func NewLogProcessorLateEventsMetricFilter() *MetricFilter {
+	return NewLambdaMetricFilter(logProcessorLambdaName, logProcessorLateEventsMetricFilterName,
+		`{ $.lateEventCount > 0 }`, `$.lateEventCount`)
+}
+
 func NewLambdaMetricFilter(lambdaName, metricName, filterPattern, metricValue string) *MetricFilter {
 	return &MetricFilter{
 		Type: "AWS::Logs::MetricFilter",
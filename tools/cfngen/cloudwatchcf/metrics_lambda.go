@@ -24,11 +24,15 @@ func generateLambdaMetricFilters(resource map[interface{}]interface{}) []*Metric
 
 	switch runtime {
 	case "go1.x":
-		return []*MetricFilter{
+		metricFilters := []*MetricFilter{
 			NewGoLambdaErrorMetricFilter(lambdaName),
 			NewGoLambdaWarnMetricFilter(lambdaName),
 			NewLambdaMemoryMetricFilter(lambdaName),
 		}
+		if lambdaName == logProcessorLambdaName {
+			metricFilters = append(metricFilters, NewLogProcessorLateEventsMetricFilter())
+		}
+		return metricFilters
 	case "python3.7":
 		return []*MetricFilter{
 			NewPythonLambdaErrorMetricFilter(lambdaName),
@@ -23,6 +23,8 @@ package gluecf
 import (
 	"reflect"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
@@ -57,6 +59,10 @@ var (
 			From: reflect.TypeOf(timestamp.FluentdTimestamp{}),
 			To:   GlueTimestampType,
 		},
+		{
+			From: reflect.TypeOf(timestamp.SuricataTimestamp{}),
+			To:   GlueTimestampType,
+		},
 		{
 			From: reflect.TypeOf(parsers.PantherAnyString{}),
 			To:   "array<string>",
@@ -96,8 +102,8 @@ var (
 	}
 )
 
-// Output CloudFormation for all 'tables'
-func GenerateTables(tables []*awsglue.GlueTableMetadata) (cf []byte, err error) {
+// Output CloudFormation for all 'tables', the log data tables have data in logDataFormat (rule matches are always JSON)
+func GenerateTables(tables []*awsglue.GlueTableMetadata, logDataFormat string) (cf []byte, err error) {
 	const bucketParam = "ProcessedDataBucket"
 	parameters := make(map[string]interface{})
 	parameters[bucketParam] = &cfngen.Parameter{
@@ -130,37 +136,87 @@ func GenerateTables(tables []*awsglue.GlueTableMetadata) (cf []byte, err error)
 		},
 	}
 
-	addTable := func(t *awsglue.GlueTableMetadata, extraColumns ...Column) {
+	addTable := func(t *awsglue.GlueTableMetadata, dataFormat string, extraColumns ...Column) {
 		location := cfngen.Sub{Sub: "s3://${" + bucketParam + "}/" + t.Prefix()}
-
-		columns := InferJSONColumns(t.EventStruct(), GlueMappings...)
-		columns = append(columns, extraColumns...)
-
-		// NOTE: currently all sources are JSONL (could add a type to LogParserMetadata struct if we need more types)
-		resources[cfngen.SanitizeResourceName(t.DatabaseName()+t.TableName())] = NewJSONLTable(&NewTableInput{
-			CatalogID:     CatalogIDRef,
-			DatabaseName:  cfngen.Ref{Ref: cfngen.SanitizeResourceName(t.DatabaseName())},
-			Name:          t.TableName(),
-			Description:   t.Description(),
-			Location:      location,
-			Columns:       columns,
-			PartitionKeys: getPartitionKeys(t),
-		})
+		databaseName := cfngen.Ref{Ref: cfngen.SanitizeResourceName(t.DatabaseName())}
+		resources[cfngen.SanitizeResourceName(t.DatabaseName()+t.TableName())] = newTable(
+			t, CatalogIDRef, databaseName, location, dataFormat, extraColumns...)
 	}
 
 	// add tables for all parsers, and matching tables for rule matches
 	for _, table := range tables {
-		addTable(table)
+		addTable(table, logDataFormat)
 		ruleTable := awsglue.NewGlueTableMetadata(
 			models.RuleData, table.LogType(), table.Description(), awsglue.GlueTableHourly, table.EventStruct())
 		// add a matching table for rule matches, add the columns that the rules engine appends
-		addTable(ruleTable, RuleMatchColumns...)
+		addTable(ruleTable, awsglue.JSONDataFormat, RuleMatchColumns...)
 	}
 
 	// generate CF using cfngen
 	return cfngen.NewTemplate("Panther Glue Resources", parameters, resources, outputs).CloudFormation()
 }
 
+// NewGlueTableInput returns the Glue API input to create (or update) the table of 't' in the bucket at runtime,
+// used for tables that are not known at deployment time (e.g. custom log types). The table is the same as GenerateTables would output.
+func NewGlueTableInput(t *awsglue.GlueTableMetadata, bucket, dataFormat string, extraColumns ...Column) *glue.TableInput {
+	location := "s3://" + bucket + "/" + t.Prefix()
+	table := newTable(t, nil, t.DatabaseName(), location, dataFormat, extraColumns...).Properties.TableInput
+
+	serdeParameters := make(map[string]*string, len(table.StorageDescriptor.SerdeInfo.Parameters))
+	for key, value := range table.StorageDescriptor.SerdeInfo.Parameters {
+		serdeParameters[key] = aws.String(value.(string))
+	}
+	return &glue.TableInput{
+		Name:        aws.String(t.TableName()),
+		Description: aws.String(t.Description()),
+		TableType:   aws.String(table.TableType),
+		StorageDescriptor: &glue.StorageDescriptor{
+			InputFormat:  aws.String(table.StorageDescriptor.InputFormat),
+			OutputFormat: aws.String(table.StorageDescriptor.OutputFormat),
+			Location:     aws.String(location),
+			SerdeInfo: &glue.SerDeInfo{
+				SerializationLibrary: aws.String(table.StorageDescriptor.SerdeInfo.SerializationLibrary),
+				Parameters:           serdeParameters,
+			},
+			Columns: glueColumns(table.StorageDescriptor.Columns),
+		},
+		PartitionKeys: glueColumns(table.PartitionKeys),
+	}
+}
+
+// newTable returns the table of 't' for data in dataFormat with the columns inferred from the event struct followed by extraColumns
+func newTable(t *awsglue.GlueTableMetadata, catalogID, databaseName, location interface{}, dataFormat string,
+	extraColumns ...Column) *Table {
+
+	columns := InferJSONColumns(t.EventStruct(), GlueMappings...)
+	columns = append(columns, extraColumns...)
+
+	input := &NewTableInput{
+		CatalogID:     catalogID,
+		DatabaseName:  databaseName,
+		Name:          t.TableName(),
+		Description:   t.Description(),
+		Location:      location,
+		Columns:       columns,
+		PartitionKeys: getPartitionKeys(t),
+	}
+	if dataFormat == awsglue.ParquetDataFormat {
+		return NewParquetTable(input)
+	}
+	return NewJSONLTable(input)
+}
+
+func glueColumns(columns []Column) (glueColumns []*glue.Column) {
+	for _, column := range columns {
+		glueColumns = append(glueColumns, &glue.Column{
+			Name:    aws.String(column.Name),
+			Type:    aws.String(column.Type),
+			Comment: aws.String(column.Comment),
+		})
+	}
+	return glueColumns
+}
+
 func getPartitionKeys(t *awsglue.GlueTableMetadata) (partitions []Column) {
 	for _, partition := range t.PartitionKeys() {
 		partitions = append(partitions, Column{
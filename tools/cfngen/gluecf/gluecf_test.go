@@ -46,7 +46,7 @@ func TestTablesCloudFormation(t *testing.T) {
 	table := awsglue.NewGlueTableMetadata(models.LogData, "Log.Type", "dummy", awsglue.GlueTableHourly, &dummyParserEvent{})
 	tables := []*awsglue.GlueTableMetadata{table}
 
-	cf, err := GenerateTables(tables)
+	cf, err := GenerateTables(tables, awsglue.JSONDataFormat)
 	require.NoError(t, err)
 
 	const expectedFile = "testdata/gluecf.json.cf"
@@ -57,3 +57,35 @@ func TestTablesCloudFormation(t *testing.T) {
 	require.NoError(t, err)
 	assert.JSONEq(t, string(expected), string(cf))
 }
+
+func TestNewGlueTableInput(t *testing.T) {
+	table := awsglue.NewGlueTableMetadata(models.RuleData, "Log.Type", "dummy", awsglue.GlueTableHourly, &dummyParserEvent{})
+
+	input := NewGlueTableInput(table, "bucket", awsglue.JSONDataFormat, RuleMatchColumns...)
+	assert.Equal(t, "log_type", *input.Name)
+	assert.Equal(t, "dummy", *input.Description)
+	assert.Equal(t, "EXTERNAL_TABLE", *input.TableType)
+	assert.Equal(t, "s3://bucket/rules/log_type/", *input.StorageDescriptor.Location)
+	assert.Equal(t, "org.openx.data.jsonserde.JsonSerDe", *input.StorageDescriptor.SerdeInfo.SerializationLibrary)
+	assert.Equal(t, "FirstName", *input.StorageDescriptor.SerdeInfo.Parameters["mapping.firstname"])
+
+	var columnNames []string
+	for _, column := range input.StorageDescriptor.Columns {
+		columnNames = append(columnNames, *column.Name)
+	}
+	assert.Equal(t, []string{"FirstName", "LastName", "DOB", "Anniversary",
+		"p_rule_id", "p_alert_id", "p_alert_creation_time", "p_alert_update_time"}, columnNames)
+	assert.Equal(t, "timestamp", *input.StorageDescriptor.Columns[2].Type)
+	assert.Len(t, input.PartitionKeys, 4)
+}
+
+func TestNewGlueTableInputParquet(t *testing.T) {
+	table := awsglue.NewGlueTableMetadata(models.LogData, "Log.Type", "dummy", awsglue.GlueTableHourly, &dummyParserEvent{})
+
+	input := NewGlueTableInput(table, "bucket", awsglue.ParquetDataFormat)
+	assert.Equal(t, "s3://bucket/logs/log_type/", *input.StorageDescriptor.Location)
+	assert.Equal(t, "org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe",
+		*input.StorageDescriptor.SerdeInfo.SerializationLibrary)
+	assert.Equal(t, "org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat", *input.StorageDescriptor.InputFormat)
+	assert.Len(t, input.StorageDescriptor.Columns, 4)
+}
@@ -35,10 +35,16 @@ type PantherConfig struct {
 }
 
 type Infra struct {
-	BaseLayerVersionArns         string   `yaml:"BaseLayerVersionArns"`
-	LogProcessorLambdaMemorySize int      `yaml:"LogProcessorLambdaMemorySize"`
-	PipLayer                     []string `yaml:"PipLayer"`
-	PythonLayerVersionArn        string   `yaml:"PythonLayerVersionArn"`
+	BaseLayerVersionArns               string   `yaml:"BaseLayerVersionArns"`
+	LogProcessorGeoIPLayerVersionArn   string   `yaml:"LogProcessorGeoIPLayerVersionArn"`
+	LogProcessorKinesisStreamArns      []string `yaml:"LogProcessorKinesisStreamArns"`
+	LogProcessorLambdaMemorySize       int      `yaml:"LogProcessorLambdaMemorySize"`
+	LogProcessorLateArrivalWindowHours int      `yaml:"LogProcessorLateArrivalWindowHours"`
+	LogProcessorMaxZipArchiveSizeMB    int      `yaml:"LogProcessorMaxZipArchiveSizeMB"`
+	LogProcessorOutputFormat           string   `yaml:"LogProcessorOutputFormat"`
+	LogProcessorSyslogTimezone         string   `yaml:"LogProcessorSyslogTimezone"`
+	PipLayer                           []string `yaml:"PipLayer"`
+	PythonLayerVersionArn              string   `yaml:"PythonLayerVersionArn"`
 }
 
 type Monitoring struct {
@@ -45,9 +45,14 @@ func generateGlueTables() error {
 	}
 	defer glueCfFile.Close()
 
+	settings, err := config.Settings()
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %v", config.Filepath, err)
+	}
+
 	tableResources := registry.AvailableTables()
 	logger.Debugf("deploy: cfngen: loaded %d glue tables", len(tableResources))
-	cf, err := gluecf.GenerateTables(tableResources)
+	cf, err := gluecf.GenerateTables(tableResources, settings.Infra.LogProcessorOutputFormat)
 	if err != nil {
 		return fmt.Errorf("failed to generate Glue Data Catalog CloudFormation template: %v", err)
 	}
@@ -83,10 +83,16 @@ const (
 	layerSourceDir   = "out/pip/analysis/python"
 	layerZipfile     = "out/layer.zip"
 	layerS3ObjectKey = "layers/python-analysis.zip"
+	// The Python version of the analysis Lambda functions, native libraries are downloaded for it
+	layerPythonVersion = "3.7"
 
 	mageUserID = "00000000-0000-4000-8000-000000000000" // used to indicate mage made the call, must be a valid uuid4!
 )
 
+// Natively compiled libraries always added to the default Python layer, downloaded for the Lambda platform.
+// The rules engine uses pyarrow to read log data stored as Parquet (see LogProcessorOutputFormat).
+var layerNativeLibs = []string{"pyarrow==6.0.1"}
+
 // Not all AWS services are available in every region. In particular, Panther will currently NOT work in:
 //     n. california, us-gov, china, paris, stockholm, brazil, osaka, or bahrain
 // These regions are missing combinations of AppSync, Cognito, Athena, and/or Glue.
@@ -261,7 +267,7 @@ func uploadLayer(awsSession *session.Session, libs []string, bucket, key string)
 	head, err := s3Client.HeadObject(&s3.HeadObjectInput{Bucket: &bucket, Key: &key})
 
 	sort.Strings(libs)
-	libString := strings.Join(libs, ",")
+	libString := strings.Join(append(libs, layerNativeLibs...), ",")
 	if err == nil && aws.StringValue(head.Metadata["Libs"]) == libString {
 		logger.Debugf("deploy: s3://%s/%s exists and is up to date", bucket, key)
 		return *head.VersionId
@@ -279,6 +285,13 @@ func uploadLayer(awsSession *session.Session, libs []string, bucket, key string)
 	if err := sh.Run("pip3", args...); err != nil {
 		logger.Fatalf("failed to download pip libraries: %v", err)
 	}
+	// native libraries must be built for Lambda, not for the platform running the deploy
+	args = append([]string{"install", "-t", layerSourceDir, "--platform", "manylinux2014_x86_64",
+		"--implementation", "cp", "--python-version", layerPythonVersion, "--only-binary=:all:", "--upgrade"},
+		layerNativeLibs...)
+	if err := sh.Run("pip3", args...); err != nil {
+		logger.Fatalf("failed to download native pip libraries: %v", err)
+	}
 
 	// The package structure needs to be:
 	//
@@ -366,6 +379,7 @@ func deployMainStacks(awsSession *session.Session, settings *config.PantherConfi
 			"ComplianceApiId":        outputs["ComplianceApiId"],
 			"OutputsKeyId":           outputs["OutputsEncryptionKeyId"],
 			"SqsKeyId":               outputs["QueueEncryptionKeyId"],
+			"ThreatIntelBucket":      outputs["ThreatIntelBucket"],
 			"UserPoolId":             outputs["UserPoolId"],
 
 			"CloudWatchLogRetentionDays": strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
@@ -399,13 +413,20 @@ func deployMainStacks(awsSession *session.Session, settings *config.PantherConfi
 			"ProcessedDataTopicArn": outputs["ProcessedDataTopicArn"],
 			"PythonLayerVersionArn": outputs["PythonLayerVersionArn"],
 			"SqsKeyId":              outputs["QueueEncryptionKeyId"],
-
-			"CloudWatchLogRetentionDays":   strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
-			"Debug":                        strconv.FormatBool(settings.Monitoring.Debug),
-			"LayerVersionArns":             settings.Infra.BaseLayerVersionArns,
-			"LogProcessorLambdaMemorySize": strconv.Itoa(settings.Infra.LogProcessorLambdaMemorySize),
-			"TracingMode":                  settings.Monitoring.TracingMode,
+			"ThreatIntelBucket":     outputs["ThreatIntelBucket"],
+
+			"CloudWatchLogRetentionDays":         strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
+			"Debug":                              strconv.FormatBool(settings.Monitoring.Debug),
+			"LayerVersionArns":                   settings.Infra.BaseLayerVersionArns,
+			"LogProcessorGeoIPLayerVersionArn":   settings.Infra.LogProcessorGeoIPLayerVersionArn,
+			"LogProcessorLambdaMemorySize":       strconv.Itoa(settings.Infra.LogProcessorLambdaMemorySize),
+			"LogProcessorLateArrivalWindowHours": strconv.Itoa(settings.Infra.LogProcessorLateArrivalWindowHours),
+			"LogProcessorMaxZipArchiveSizeMB":    strconv.Itoa(settings.Infra.LogProcessorMaxZipArchiveSizeMB),
+			"LogProcessorOutputFormat":           settings.Infra.LogProcessorOutputFormat,
+			"LogProcessorSyslogTimezone":         settings.Infra.LogProcessorSyslogTimezone,
+			"TracingMode":                        settings.Monitoring.TracingMode,
 		})
+		deployKinesisSources(awsSession, settings, accountID)
 		result <- logAnalysisStack
 	}(finishedStacks)
 
@@ -0,0 +1,125 @@
+package mage
+
+/**
+ * Panther is a scalable, powerful, cloud-native SIEM written in Golang/React.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+
+	"github.com/panther-labs/panther/tools/config"
+)
+
+const (
+	logProcessorFunction = "panther-log-processor"
+
+	// The log processor is invoked when this many records are buffered or the batching window expires
+	kinesisBatchSize     = 1000
+	kinesisBatchingDelay = 30 // seconds
+
+	// A failed batch is split in two and retried this many times, then its records are skipped and described in
+	// the failure queue (defined in the log analysis template) so that they do not block the shard until they expire
+	kinesisMaxRetryAttempts = 5
+	kinesisFailureQueue     = "panther-log-processor-kinesis-dlq"
+)
+
+// Create (or delete) the event source mappings of the Kinesis streams the log processor reads.
+//
+// The number of streams is not fixed so the mappings cannot be defined in the log analysis template.
+// The SQS mapping of the log processor is managed by CloudFormation and left untouched.
+func deployKinesisSources(awsSession *session.Session, settings *config.PantherConfig, accountID string) {
+	client := lambda.New(awsSession)
+	failureQueueArn := fmt.Sprintf("arn:aws:sqs:%s:%s:%s", *awsSession.Config.Region, accountID, kinesisFailureQueue)
+	destinationConfig := &lambda.DestinationConfig{
+		OnFailure: &lambda.OnFailure{Destination: aws.String(failureQueueArn)},
+	}
+
+	// Find the existing mappings by stream ARN
+	existing := make(map[string]string)
+	err := client.ListEventSourceMappingsPages(
+		&lambda.ListEventSourceMappingsInput{FunctionName: aws.String(logProcessorFunction)},
+		func(page *lambda.ListEventSourceMappingsOutput, _ bool) bool {
+			for _, mapping := range page.EventSourceMappings {
+				if isKinesisStreamArn(*mapping.EventSourceArn) {
+					existing[*mapping.EventSourceArn] = *mapping.UUID
+				}
+			}
+			return true
+		})
+	if err != nil {
+		logger.Fatalf("failed to list event source mappings of %s: %v", logProcessorFunction, err)
+	}
+
+	configured := make(map[string]bool)
+	for _, streamArn := range settings.Infra.LogProcessorKinesisStreamArns {
+		configured[streamArn] = true
+		if uuid, ok := existing[streamArn]; ok {
+			// keep the settings of mappings created by previous versions up to date
+			_, err := client.UpdateEventSourceMapping(&lambda.UpdateEventSourceMappingInput{
+				BatchSize:                      aws.Int64(kinesisBatchSize),
+				BisectBatchOnFunctionError:     aws.Bool(true),
+				DestinationConfig:              destinationConfig,
+				FunctionName:                   aws.String(logProcessorFunction),
+				MaximumBatchingWindowInSeconds: aws.Int64(kinesisBatchingDelay),
+				MaximumRetryAttempts:           aws.Int64(kinesisMaxRetryAttempts),
+				UUID:                           aws.String(uuid),
+			})
+			if err != nil {
+				logger.Fatalf("failed to update kinesis stream %s of %s: %v", streamArn, logProcessorFunction, err)
+			}
+			continue
+		}
+
+		logger.Infof("deploy: adding kinesis stream %s to %s", streamArn, logProcessorFunction)
+		_, err := client.CreateEventSourceMapping(&lambda.CreateEventSourceMappingInput{
+			BatchSize:                      aws.Int64(kinesisBatchSize),
+			BisectBatchOnFunctionError:     aws.Bool(true),
+			DestinationConfig:              destinationConfig,
+			EventSourceArn:                 aws.String(streamArn),
+			FunctionName:                   aws.String(logProcessorFunction),
+			MaximumBatchingWindowInSeconds: aws.Int64(kinesisBatchingDelay),
+			MaximumRetryAttempts:           aws.Int64(kinesisMaxRetryAttempts),
+			StartingPosition:               aws.String(lambda.EventSourcePositionLatest),
+		})
+		if err != nil {
+			logger.Fatalf("failed to add kinesis stream %s to %s: %v", streamArn, logProcessorFunction, err)
+		}
+	}
+
+	for streamArn, uuid := range existing {
+		if configured[streamArn] {
+			continue
+		}
+
+		logger.Infof("deploy: removing kinesis stream %s from %s", streamArn, logProcessorFunction)
+		_, err := client.DeleteEventSourceMapping(&lambda.DeleteEventSourceMappingInput{UUID: aws.String(uuid)})
+		if err != nil {
+			logger.Fatalf("failed to remove kinesis stream %s from %s: %v", streamArn, logProcessorFunction, err)
+		}
+	}
+}
+
+// e.g. arn:aws:kinesis:us-east-1:111122223333:stream/my-logs
+func isKinesisStreamArn(arn string) bool {
+	parts := strings.SplitN(arn, ":", 6)
+	return len(parts) == 6 && parts[2] == "kinesis" && strings.HasPrefix(parts[5], "stream/")
+}
@@ -110,6 +110,9 @@ func logDocs() {
 	logCategories := make(map[string][]string) // category -> logTypes
 	for _, table := range tables {
 		logType := table.LogType()
+		if registry.AvailableParsers().LookupParser(logType).Parser == nil { // not a supported log (e.g. unclassified log lines)
+			continue
+		}
 		categoryType := strings.Split(logType, ".")
 		if len(categoryType) != 2 {
 			logger.Fatalf("unexpected logType format: %s", logType)
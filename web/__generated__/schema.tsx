@@ -160,6 +160,21 @@ export type CreateOrModifyRuleInput = {
   tests?: Maybe<Array<Maybe<PolicyUnitTestInput>>>;
 };
 
+export type CustomWebhookConfig = {
+  __typename?: 'CustomWebhookConfig';
+  webhookURL: Scalars['String'];
+  headers?: Maybe<Array<WebhookHeader>>;
+  bodyTemplate?: Maybe<Scalars['String']>;
+  signingSecret?: Maybe<Scalars['String']>;
+};
+
+export type CustomWebhookConfigInput = {
+  webhookURL: Scalars['String'];
+  headers?: Maybe<Array<WebhookHeaderInput>>;
+  bodyTemplate?: Maybe<Scalars['String']>;
+  signingSecret?: Maybe<Scalars['String']>;
+};
+
 export type DeletePolicyInput = {
   policies?: Maybe<Array<Maybe<DeletePolicyInputItem>>>;
 };
@@ -193,6 +208,7 @@ export type DestinationConfig = {
   opsgenie?: Maybe<OpsgenieConfig>;
   msTeams?: Maybe<MsTeamsConfig>;
   asana?: Maybe<AsanaConfig>;
+  customWebhook?: Maybe<CustomWebhookConfig>;
 };
 
 export type DestinationConfigInput = {
@@ -205,6 +221,7 @@ export type DestinationConfigInput = {
   opsgenie?: Maybe<OpsgenieConfigInput>;
   msTeams?: Maybe<MsTeamsConfigInput>;
   asana?: Maybe<AsanaConfigInput>;
+  customWebhook?: Maybe<CustomWebhookConfigInput>;
 };
 
 export type DestinationInput = {
@@ -225,6 +242,7 @@ export enum DestinationTypeEnum {
   Sns = 'sns',
   Sqs = 'sqs',
   Asana = 'asana',
+  Customwebhook = 'customwebhook',
 }
 
 export type GeneralSettings = {
@@ -980,6 +998,17 @@ export type User = {
   status: Scalars['String'];
 };
 
+export type WebhookHeader = {
+  __typename?: 'WebhookHeader';
+  name: Scalars['String'];
+  value: Scalars['String'];
+};
+
+export type WebhookHeaderInput = {
+  name: Scalars['String'];
+  value: Scalars['String'];
+};
+
 export type ResolverTypeWrapper<T> = Promise<T> | T;
 
 export type StitchingResolver<TResult, TParent, TContext, TArgs> = {
@@ -1089,6 +1118,8 @@ export type ResolversTypes = {
   OpsgenieConfig: ResolverTypeWrapper<OpsgenieConfig>;
   MsTeamsConfig: ResolverTypeWrapper<MsTeamsConfig>;
   AsanaConfig: ResolverTypeWrapper<AsanaConfig>;
+  CustomWebhookConfig: ResolverTypeWrapper<CustomWebhookConfig>;
+  WebhookHeader: ResolverTypeWrapper<WebhookHeader>;
   SeverityEnum: SeverityEnum;
   GeneralSettings: ResolverTypeWrapper<GeneralSettings>;
   Boolean: ResolverTypeWrapper<Scalars['Boolean']>;
@@ -1148,6 +1179,8 @@ export type ResolversTypes = {
   OpsgenieConfigInput: OpsgenieConfigInput;
   MsTeamsConfigInput: MsTeamsConfigInput;
   AsanaConfigInput: AsanaConfigInput;
+  CustomWebhookConfigInput: CustomWebhookConfigInput;
+  WebhookHeaderInput: WebhookHeaderInput;
   AddComplianceIntegrationInput: AddComplianceIntegrationInput;
   AddLogIntegrationInput: AddLogIntegrationInput;
   CreateOrModifyPolicyInput: CreateOrModifyPolicyInput;
@@ -1197,6 +1230,8 @@ export type ResolversParentTypes = {
   OpsgenieConfig: OpsgenieConfig;
   MsTeamsConfig: MsTeamsConfig;
   AsanaConfig: AsanaConfig;
+  CustomWebhookConfig: CustomWebhookConfig;
+  WebhookHeader: WebhookHeader;
   SeverityEnum: SeverityEnum;
   GeneralSettings: GeneralSettings;
   Boolean: Scalars['Boolean'];
@@ -1256,6 +1291,8 @@ export type ResolversParentTypes = {
   OpsgenieConfigInput: OpsgenieConfigInput;
   MsTeamsConfigInput: MsTeamsConfigInput;
   AsanaConfigInput: AsanaConfigInput;
+  CustomWebhookConfigInput: CustomWebhookConfigInput;
+  WebhookHeaderInput: WebhookHeaderInput;
   AddComplianceIntegrationInput: AddComplianceIntegrationInput;
   AddLogIntegrationInput: AddLogIntegrationInput;
   CreateOrModifyPolicyInput: CreateOrModifyPolicyInput;
@@ -1406,6 +1443,17 @@ export type ComplianceStatusCountsResolvers<
   __isTypeOf?: isTypeOfResolverFn<ParentType>;
 };
 
+export type CustomWebhookConfigResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['CustomWebhookConfig'] = ResolversParentTypes['CustomWebhookConfig']
+> = {
+  webhookURL?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  headers?: Resolver<Maybe<Array<ResolversTypes['WebhookHeader']>>, ParentType, ContextType>;
+  bodyTemplate?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  signingSecret?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: isTypeOfResolverFn<ParentType>;
+};
+
 export type DestinationResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['Destination'] = ResolversParentTypes['Destination']
@@ -1440,6 +1488,7 @@ export type DestinationConfigResolvers<
   opsgenie?: Resolver<Maybe<ResolversTypes['OpsgenieConfig']>, ParentType, ContextType>;
   msTeams?: Resolver<Maybe<ResolversTypes['MsTeamsConfig']>, ParentType, ContextType>;
   asana?: Resolver<Maybe<ResolversTypes['AsanaConfig']>, ParentType, ContextType>;
+  customWebhook?: Resolver<Maybe<ResolversTypes['CustomWebhookConfig']>, ParentType, ContextType>;
   __isTypeOf?: isTypeOfResolverFn<ParentType>;
 };
 
@@ -2144,6 +2193,15 @@ export type UserResolvers<
   __isTypeOf?: isTypeOfResolverFn<ParentType>;
 };
 
+export type WebhookHeaderResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['WebhookHeader'] = ResolversParentTypes['WebhookHeader']
+> = {
+  name?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  value?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  __isTypeOf?: isTypeOfResolverFn<ParentType>;
+};
+
 export type Resolvers<ContextType = any> = {
   ActiveSuppressCount?: ActiveSuppressCountResolvers<ContextType>;
   AlertDetails?: AlertDetailsResolvers<ContextType>;
@@ -2157,6 +2215,7 @@ export type Resolvers<ContextType = any> = {
   ComplianceIntegrationHealth?: ComplianceIntegrationHealthResolvers<ContextType>;
   ComplianceItem?: ComplianceItemResolvers<ContextType>;
   ComplianceStatusCounts?: ComplianceStatusCountsResolvers<ContextType>;
+  CustomWebhookConfig?: CustomWebhookConfigResolvers<ContextType>;
   Destination?: DestinationResolvers<ContextType>;
   DestinationConfig?: DestinationConfigResolvers<ContextType>;
   GeneralSettings?: GeneralSettingsResolvers<ContextType>;
@@ -2195,6 +2254,7 @@ export type Resolvers<ContextType = any> = {
   TestPolicyResponse?: TestPolicyResponseResolvers<ContextType>;
   UploadPoliciesResponse?: UploadPoliciesResponseResolvers<ContextType>;
   User?: UserResolvers<ContextType>;
+  WebhookHeader?: WebhookHeaderResolvers<ContextType>;
 };
 
 /**
@@ -79,20 +79,40 @@ export const RESOURCE_TYPES = [
 export const LOG_TYPES = [
   'AWS.ALB',
   'AWS.AuroraMySQLAudit',
+  'AWS.CloudFront',
   'AWS.CloudTrail',
   'Fluentd.Syslog3164',
   'Fluentd.Syslog5424',
+  'AWS.ELBClassic',
   'AWS.GuardDuty',
+  'AWS.Route53Resolver',
   'AWS.S3ServerAccess',
+  'AWS.SecurityHub',
   'AWS.VPCFlow',
+  'AWS.WAF',
+  'GSuite.Reports',
+  'Kubernetes.Audit',
+  'Kubernetes.EKSAuthenticator',
   'Nginx.Access',
+  'Okta.SystemLog',
   'Osquery.Batch',
   'Osquery.Differential',
   'Osquery.Snapshot',
   'Osquery.Status',
   'OSSEC.EventInfo',
+  'Suricata.Alert',
+  'Suricata.DNS',
+  'Suricata.FileInfo',
+  'Suricata.Flow',
+  'Suricata.HTTP',
+  'Suricata.TLS',
   'Syslog.RFC3164',
   'Syslog.RFC5424',
+  'Zeek.Conn',
+  'Zeek.DNS',
+  'Zeek.Files',
+  'Zeek.HTTP',
+  'Zeek.SSL',
 ] as const;
 
 export const SEVERITY_COLOR_MAP: { [key in SeverityEnum]: BadgeProps['color'] } = {